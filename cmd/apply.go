@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/notify"
+	"github.com/nov11/nacos-cli/internal/project"
+	"github.com/nov11/nacos-cli/internal/recyclebin"
+	"github.com/nov11/nacos-cli/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyDir          string
+	applyStateFile    string
+	applyStateBackend string
+	applyDryRun       bool
+	applyPrefer       string
+	applyPrune        bool
+	applyForce        bool
+	applyNoRecycle    bool
+	applyProject      string
+	applyTakeOwner    bool
+	applyChangeFile   string
+	applyApprovals    int
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a directory of configurations to Nacos",
+	Long:  help.Apply.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		if applyChangeFile != "" {
+			applyPlan(applyChangeFile, applyApprovals)
+			return
+		}
+		if fileConfig != nil && fileConfig.RequireApprovals > 0 {
+			checkError(fmt.Errorf("the profile requires %d approval(s): use plan, approve and apply --change", fileConfig.RequireApprovals))
+		}
+		if applyPrefer != diff.PreferNone && applyPrefer != diff.PreferLocal && applyPrefer != diff.PreferRemote {
+			checkError(fmt.Errorf("invalid --prefer %q (expected local or remote)", applyPrefer))
+		}
+		proj, err := project.Load(applyDir, applyProject)
+		checkError(err)
+		if applyStateBackend != "" {
+			proj.State.Type = applyStateBackend
+		}
+		if applyStateFile != "" {
+			path, err := filepath.Abs(applyStateFile)
+			checkError(err)
+			proj.State.Type = state.BackendLocal
+			proj.State.Path = path
+		}
+
+		entries, err := configtree.Walk(applyDir)
+		checkError(err)
+
+		nacosClient := newNacosClient()
+		backend, err := state.Open(proj.State, nacosClient, applyDir)
+		checkError(err)
+		st, err := backend.Load(nacosClient.Namespace)
+		checkError(err)
+
+		// Configs applied before but removed locally
+		localKeys := make(map[string]bool)
+		for _, entry := range entries {
+			localKeys[entry.Key()] = true
+		}
+		var removed []string
+		for key := range st.Entries {
+			if !localKeys[key] {
+				removed = append(removed, key)
+			}
+		}
+		sort.Strings(removed)
+
+		if budget := changeBudget(fileConfig); budget > 0 && !applyDryRun {
+			checkError(checkChangeBudget(budget, nacosClient.Namespace, applyChanges(nacosClient, st, entries, removed, proj.Name)))
+		}
+		// Check ownership and ask before anything is published, so declining
+		// leaves the server untouched
+		var prune []string
+		var bin recyclebin.Bin
+		protected := make(map[string]error)
+		if applyPrune && !applyDryRun && len(removed) > 0 {
+			for _, key := range removed {
+				group, dataID := configtree.SplitKey(key)
+				if err := checkOwnership(nacosClient, group, dataID, proj.Name); err != nil {
+					protected[key] = err
+					continue
+				}
+				prune = append(prune, key)
+			}
+			if len(prune) > 0 && !applyNoRecycle {
+				bin, err = openRecycleBin(nacosClient)
+				checkError(err)
+			}
+			if len(prune) > 0 {
+				err := confirmByTyping(fmt.Sprintf("delete %d configuration(s) from namespace %s", len(prune), nacosClient.Namespace), nacosClient.Namespace, applyForce)
+				checkError(err)
+			}
+		}
+
+		if applyDryRun {
+			fmt.Printf("Plan for namespace %s (dry run):\n", nacosClient.Namespace)
+		} else {
+			fmt.Printf("Applying %d configuration(s) to namespace %s...\n", len(entries), nacosClient.Namespace)
+		}
+
+		counts := make(map[string]int)
+		failed := false
+		var changes []notify.Item
+		for _, entry := range entries {
+			action, err := applyEntry(nacosClient, st, entry, proj.Name)
+			counts[action]++
+			changes = notifyItem(changes, action, entry.Key(), err)
+			if err != nil {
+				failed = true
+				fmt.Printf("  %-10s %s: %v\n", action, entry.Key(), err)
+				continue
+			}
+			fmt.Printf("  %-10s %s\n", action, entry.Key())
+		}
+
+		if len(removed) > 0 {
+			if !applyPrune {
+				fmt.Printf("\n%d configuration(s) were removed locally, use --prune to delete them from Nacos\n", len(removed))
+			} else if applyDryRun {
+				for _, key := range removed {
+					fmt.Printf("  %-10s %s\n", "prune", key)
+				}
+			} else {
+				for _, key := range removed {
+					if err := protected[key]; err != nil {
+						failed = true
+						changes = notifyItem(changes, "protected", key, err)
+						fmt.Printf("  %-10s %s: %v\n", "protected", key, err)
+					}
+				}
+				refs := make([]configRef, len(prune))
+				for i, key := range prune {
+					group, dataID := configtree.SplitKey(key)
+					refs[i] = configRef{Group: group, DataID: dataID}
+				}
+				errs := deleteConfigs(nacosClient, bin, refs, 1)
+				for i, key := range prune {
+					if err := errs[i]; err != nil {
+						failed = true
+						changes = notifyItem(changes, "error", key, err)
+						fmt.Printf("  %-10s %s: %v\n", "error", key, err)
+						continue
+					}
+					delete(st.Entries, key)
+					counts["prune"]++
+					changes = notifyItem(changes, "prune", key, nil)
+					fmt.Printf("  %-10s %s\n", "prune", key)
+				}
+			}
+		}
+
+		if !applyDryRun {
+			saveErr := backend.Save(st)
+			notifyRun("apply", nacosClient, counts, changes, failed || counts["conflict"] > 0 || saveErr != nil)
+			if saveErr != nil {
+				if errors.Is(saveErr, state.ErrConcurrentUpdate) {
+					saveErr = fmt.Errorf("%w; the configurations were applied, run apply again to record them", saveErr)
+				}
+				checkError(saveErr)
+			}
+		}
+
+		fmt.Printf("\nCreated: %d, Updated: %d, Merged: %d, Adopted: %d, Unchanged: %d, Pruned: %d, Conflicts: %d\n",
+			counts["create"], counts["update"], counts["merge"], counts["adopt"], counts["unchanged"], counts["prune"], counts["conflict"])
+		if bin != nil && counts["prune"] > 0 {
+			fmt.Printf("Pruned configurations were kept in the recycle bin %s, use config-restore-deleted to restore\n", bin.Describe())
+		}
+		if counts["protected"] > 0 {
+			fmt.Println("Configurations owned by other projects were skipped; use --take-ownership to manage them")
+		}
+		if counts["conflict"] > 0 {
+			fmt.Println("Conflicts were written to the local files with <<<<<<< markers; resolve them and run apply again")
+		}
+		if failed || counts["conflict"] > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// applyChanges returns how many configurations apply is about to change,
+// running the entries as a dry run first. Prunes count with --prune.
+func applyChanges(nacosClient *client.NacosClient, st *state.State, entries []configtree.Entry, removed []string, owner string) int {
+	applyDryRun = true
+	defer func() { applyDryRun = false }()
+	changes := 0
+	for _, entry := range entries {
+		switch action, _ := applyEntry(nacosClient, st, entry, owner); action {
+		case "create", "update", "merge", "adopt":
+			changes++
+		}
+	}
+	if applyPrune {
+		changes += len(removed)
+	}
+	return changes
+}
+
+// applyEntry applies one local file and returns the action taken.
+// When the server changed since the last apply, local and server edits are
+// merged three-way against the last applied content recorded in the state.
+// Configurations owned by another project are left alone unless --take-ownership is set.
+func applyEntry(nacosClient *client.NacosClient, st *state.State, entry configtree.Entry, owner string) (string, error) {
+	data, err := os.ReadFile(entry.Path)
+	if err != nil {
+		return "error", err
+	}
+	local := string(data)
+	localMD5 := listener.CalculateMD5(local)
+
+	var remote string
+	var tags []string
+	detail, err := nacosClient.GetConfigDetail(entry.DataID, entry.Group)
+	if err != nil && !errors.Is(err, client.ErrConfigNotFound) {
+		return "error", err
+	}
+	if detail != nil {
+		remote = detail.Content
+		tags = detail.Tags()
+	}
+	if current := project.Owner(tags); current != "" && current != owner && !applyTakeOwner {
+		return "protected", fmt.Errorf("owned by project %q", current)
+	}
+	opts := client.PublishOptions{Tags: project.WithOwner(tags, owner)}
+	previous := st.Entries[entry.Key()]
+
+	// Not on the server yet (or deleted there since the last apply)
+	if detail == nil {
+		if applyDryRun {
+			return "create", nil
+		}
+		if err := nacosClient.PublishConfigIdempotent(entry.DataID, entry.Group, local, opts, publishRetries); err != nil {
+			return "error", err
+		}
+		st.Record(entry.Key(), local, localMD5)
+		return "create", nil
+	}
+
+	remoteMD5 := listener.CalculateMD5(remote)
+	if remoteMD5 == localMD5 {
+		action := "unchanged"
+		if project.Owner(tags) != owner {
+			// Same content, but not labelled as ours yet
+			action = "adopt"
+			if !applyDryRun {
+				if err := nacosClient.PublishConfigIdempotent(entry.DataID, entry.Group, local, opts, publishRetries); err != nil {
+					return "error", err
+				}
+			}
+		}
+		if !applyDryRun {
+			st.Record(entry.Key(), local, localMD5)
+		}
+		return action, nil
+	}
+
+	// Server unchanged since the last apply (or never applied): local wins
+	if previous == nil || previous.MD5 == remoteMD5 {
+		if applyDryRun {
+			return "update", nil
+		}
+		if err := nacosClient.PublishConfigIdempotent(entry.DataID, entry.Group, local, opts, publishRetries); err != nil {
+			return "error", err
+		}
+		st.Record(entry.Key(), local, localMD5)
+		return "update", nil
+	}
+
+	// Both sides changed: three-way merge
+	merged := diff.Merge3(previous.Content, local, remote, applyPrefer)
+	if merged.Conflicts > 0 {
+		if !applyDryRun {
+			if err := os.WriteFile(entry.Path, []byte(merged.Content), 0644); err != nil {
+				return "error", err
+			}
+		}
+		return "conflict", nil
+	}
+	if applyDryRun {
+		return "merge", nil
+	}
+	if err := nacosClient.PublishConfigIdempotent(entry.DataID, entry.Group, merged.Content, opts, publishRetries); err != nil {
+		return "error", err
+	}
+	if err := os.WriteFile(entry.Path, []byte(merged.Content), 0644); err != nil {
+		return "error", err
+	}
+	st.Record(entry.Key(), merged.Content, listener.CalculateMD5(merged.Content))
+	return "merge", nil
+}
+
+// checkOwnership fails when a configuration is owned by a project other than owner
+func checkOwnership(nacosClient *client.NacosClient, group, dataID, owner string) error {
+	if applyTakeOwner {
+		return nil
+	}
+	detail, err := nacosClient.GetConfigDetail(dataID, group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if current := project.Owner(detail.Tags()); current != "" && current != owner {
+		return fmt.Errorf("owned by project %q", current)
+	}
+	return nil
+}
+
+func init() {
+	applyCmd.Flags().StringVarP(&applyDir, "dir", "d", ".", "Directory laid out as <group>/<dataId>")
+	applyCmd.Flags().StringVar(&applyStateFile, "state-file", "", "Local state file path (default: <dir>/"+state.DefaultFileName+")")
+	applyCmd.Flags().StringVar(&applyStateBackend, "state-backend", "", "State backend: local, nacos or s3 (default: from "+project.FileName+", else local)")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Show what would change without publishing")
+	applyCmd.Flags().StringVar(&applyPrefer, "prefer", "", "Resolve merge conflicts automatically: local or remote")
+	applyCmd.Flags().BoolVar(&applyPrune, "prune", false, "Delete configurations that were applied before but removed locally")
+	applyCmd.Flags().BoolVar(&applyForce, "force", false, "Skip the confirmation prompt for --prune")
+	applyCmd.Flags().BoolVar(&applyNoRecycle, "no-recycle-bin", false, "Prune without keeping a copy in the recycle bin")
+	applyCmd.Flags().StringVar(&applyProject, "project", "", "Project name recorded as owner on applied configs (default: name in "+project.FileName+", else directory name)")
+	applyCmd.Flags().BoolVar(&applyTakeOwner, "take-ownership", false, "Modify and prune configurations owned by other projects, taking them over")
+	applyCmd.Flags().StringVar(&applyChangeFile, "change", "", "Apply a change file written by plan instead of the directory")
+	applyCmd.Flags().IntVar(&applyApprovals, "require-approvals", 0, "With --change, the number of valid approvals required (at least requireApprovals of the profile)")
+	rootCmd.AddCommand(applyCmd)
+}
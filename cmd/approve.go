@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/approval"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	approveKey      string
+	approveReviewer string
+	approveDiff     bool
+	approveYes      bool
+
+	keygenOutput string
+	keygenForce  bool
+)
+
+var approveCmd = &cobra.Command{
+	Use:   "approve changeFile",
+	Short: "Sign a change file written by plan as a reviewer",
+	Long:  help.Approve.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+		plan, err := approval.Load(path)
+		checkError(err)
+		keyPath, err := approvalKeyPath(approveKey)
+		checkError(err)
+		key, err := approval.LoadPrivateKey(keyPath)
+		checkError(err)
+		reviewer := approveReviewer
+		if reviewer == "" {
+			reviewer = currentUserName()
+		}
+
+		fmt.Printf("Change by %s at %s for %s, namespace %s:\n",
+			plan.Author, plan.CreatedAt, plan.Server, namespaceLabel(plan.Namespace))
+		for _, change := range plan.Changes {
+			fmt.Printf("  %-10s %s\n", change.Action, configtree.Key(change.Group, change.DataID))
+		}
+		for _, a := range plan.Approvals {
+			fmt.Printf("Approved by %s at %s\n", a.Reviewer, a.ApprovedAt)
+		}
+		fmt.Println()
+
+		if approveDiff {
+			printPlanDiff(newNacosClient().WithNamespace(plan.Namespace), plan, resolveDiffMode(cmd))
+		}
+
+		if !approveYes && !isInteractive() {
+			checkError(fmt.Errorf("refusing to approve without confirmation in non-interactive mode (use --yes)"))
+		}
+		if !confirmYesNo(fmt.Sprintf("Approve %d change(s) as %s?", len(plan.Changes), reviewer), approveYes) {
+			fmt.Println("Cancelled")
+			return
+		}
+		plan.Approve(reviewer, key, time.Now())
+		checkError(plan.Save(path))
+		fmt.Printf("Approved as %s (%d approval(s))\n", reviewer, len(plan.Approvals))
+	},
+}
+
+// printPlanDiff shows each change against the current server content in a
+// diff mode
+func printPlanDiff(nacosClient *client.NacosClient, plan *approval.Plan, mode string) {
+	for _, change := range plan.Changes {
+		key := configtree.Key(change.Group, change.DataID)
+		current, err := nacosClient.GetConfig(change.DataID, change.Group)
+		if err != nil && !errors.Is(err, client.ErrConfigNotFound) {
+			checkError(err)
+		}
+		output, err := diff.Diff(mode,
+			diff.Document{Name: key + " (server)", Content: current, Format: change.DataID},
+			diff.Document{Name: key + " (change)", Content: change.Content, Format: change.DataID},
+			diff.Options{Color: useColor()})
+		checkError(err)
+		if output == "" {
+			fmt.Printf("%s: no differences with the server\n", key)
+			continue
+		}
+		fmt.Print(output)
+	}
+	fmt.Println()
+}
+
+var approvalKeygenCmd = &cobra.Command{
+	Use:   "approval-keygen",
+	Short: "Create the signing key used by approve",
+	Long:  help.ApprovalKeygen.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := approvalKeyPath(keygenOutput)
+		checkError(err)
+		if _, err := os.Stat(path); err == nil && !keygenForce {
+			checkError(fmt.Errorf("%s already exists (use --force to replace it)", path))
+		}
+		private, public, err := approval.GenerateKey()
+		checkError(err)
+		checkError(os.MkdirAll(filepath.Dir(path), 0700))
+		checkError(os.WriteFile(path, []byte(private+"\n"), 0600))
+
+		fmt.Printf("Signing key written to %s\n\n", path)
+		fmt.Println("Add the public key to the approvers of the profiles that trust you:")
+		fmt.Println()
+		fmt.Println("approvers:")
+		fmt.Printf("  %s: %s\n", currentUserName(), public)
+	},
+}
+
+// approvalKeyPath returns the signing key path, ~/.nacos-cli/approval.key by default
+func approvalKeyPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nacos-cli", "approval.key"), nil
+}
+
+func init() {
+	approveCmd.Flags().StringVar(&approveKey, "key", "", "Signing key written by approval-keygen (default: ~/.nacos-cli/approval.key)")
+	approveCmd.Flags().StringVar(&approveReviewer, "reviewer", "", "Reviewer name, as listed in the approvers of the profile (default: current user)")
+	approveCmd.Flags().BoolVar(&approveDiff, "diff", false, "Show each change against the current server content")
+	addDiffModeFlag(approveCmd)
+	approveCmd.Flags().BoolVarP(&approveYes, "yes", "y", false, "Approve without asking for confirmation")
+	rootCmd.AddCommand(approveCmd)
+
+	approvalKeygenCmd.Flags().StringVarP(&keygenOutput, "output", "o", "", "Signing key file (default: ~/.nacos-cli/approval.key)")
+	approvalKeygenCmd.Flags().BoolVar(&keygenForce, "force", false, "Replace an existing key")
+	rootCmd.AddCommand(approvalKeygenCmd)
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	authExportOutput string
+	authImportDryRun bool
+)
+
+// rbacFile is the RBAC definition written by auth-export and read by
+// auth-import. Passwords are never part of it.
+type rbacFile struct {
+	Users []string   `yaml:"users"`
+	Roles []rbacRole `yaml:"roles"`
+}
+
+// rbacRole is a role with the users bound to it and its permissions
+type rbacRole struct {
+	Role        string           `yaml:"role"`
+	Users       []string         `yaml:"users"`
+	Permissions []rbacPermission `yaml:"permissions,omitempty"`
+}
+
+// rbacPermission grants an action (r, w or rw) on a namespaceId:group:resource
+type rbacPermission struct {
+	Resource string `yaml:"resource"`
+	Action   string `yaml:"action"`
+}
+
+var authExportCmd = &cobra.Command{
+	Use:   "auth-export",
+	Short: "Export users, roles and permissions to a YAML file",
+	Long:  help.AuthExport.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		nacosClient := newNacosClient()
+		rbac, err := exportRBAC(nacosClient)
+		checkError(err)
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		checkError(enc.Encode(rbac))
+		checkError(enc.Close())
+
+		if authExportOutput == "" || authExportOutput == "-" {
+			fmt.Print(buf.String())
+			return
+		}
+		checkError(os.WriteFile(authExportOutput, buf.Bytes(), 0644))
+		permissions := 0
+		for _, r := range rbac.Roles {
+			permissions += len(r.Permissions)
+		}
+		fmt.Printf("Exported %d user(s), %d role(s) and %d permission(s) to %s\n",
+			len(rbac.Users), len(rbac.Roles), permissions, authExportOutput)
+	},
+}
+
+var authImportCmd = &cobra.Command{
+	Use:   "auth-import file",
+	Short: "Create the users, roles and permissions of a YAML file that are missing",
+	Long:  help.AuthImport.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := os.ReadFile(args[0])
+		checkError(err)
+		var rbac rbacFile
+		if err := yaml.Unmarshal(data, &rbac); err != nil {
+			checkError(fmt.Errorf("parse %s: %w", args[0], err))
+		}
+		checkError(validateRBAC(&rbac))
+		created, err := importRBAC(newNacosClient(), &rbac, authImportDryRun)
+		checkError(err)
+		printCreateSummary(created, authImportDryRun)
+	},
+}
+
+// exportRBAC reads every user, role binding and permission of the server
+func exportRBAC(nacosClient *client.NacosClient) (*rbacFile, error) {
+	users, err := nacosClient.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := nacosClient.ListRoles("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	rbac := &rbacFile{}
+	for _, u := range users {
+		rbac.Users = append(rbac.Users, u.Username)
+	}
+	sort.Strings(rbac.Users)
+
+	roles := make(map[string]*rbacRole)
+	for _, b := range bindings {
+		r, ok := roles[b.Role]
+		if !ok {
+			r = &rbacRole{Role: b.Role}
+			roles[b.Role] = r
+		}
+		r.Users = append(r.Users, b.Username)
+	}
+	names := make([]string, 0, len(roles))
+	for name := range roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r := roles[name]
+		sort.Strings(r.Users)
+		permissions, err := nacosClient.ListPermissions(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range permissions {
+			if p.Role == name {
+				r.Permissions = append(r.Permissions, rbacPermission{Resource: p.Resource, Action: p.Action})
+			}
+		}
+		sort.Slice(r.Permissions, func(i, j int) bool {
+			if r.Permissions[i].Resource != r.Permissions[j].Resource {
+				return r.Permissions[i].Resource < r.Permissions[j].Resource
+			}
+			return r.Permissions[i].Action < r.Permissions[j].Action
+		})
+		rbac.Roles = append(rbac.Roles, *r)
+	}
+	return rbac, nil
+}
+
+// validateRBAC checks an RBAC file before anything is created
+func validateRBAC(rbac *rbacFile) error {
+	users := make(map[string]bool)
+	for _, u := range rbac.Users {
+		if u == "" {
+			return fmt.Errorf("users: empty user name")
+		}
+		users[u] = true
+	}
+	for _, r := range rbac.Roles {
+		if r.Role == "" {
+			return fmt.Errorf("roles: a role has no name")
+		}
+		if len(r.Users) == 0 {
+			return fmt.Errorf("role %s: a role exists only bound to a user, list at least one in users", r.Role)
+		}
+		for _, u := range r.Users {
+			if !users[u] {
+				return fmt.Errorf("role %s: user %s is not in users", r.Role, u)
+			}
+		}
+		for _, p := range r.Permissions {
+			switch p.Action {
+			case client.ActionRead, client.ActionWrite, client.ActionReadWrite:
+			default:
+				return fmt.Errorf("role %s: invalid action %q on %s (expected r, w or rw)", r.Role, p.Action, p.Resource)
+			}
+			if p.Resource == "" {
+				return fmt.Errorf("role %s: a permission has no resource", r.Role)
+			}
+		}
+	}
+	return nil
+}
+
+// importRBAC creates what the server lacks, leaving existing users (and their
+// passwords), bindings and permissions alone and removing nothing. New users
+// get a generated password, printed once. It returns the number of things
+// created, or to create with dryRun.
+func importRBAC(nacosClient *client.NacosClient, rbac *rbacFile, dryRun bool) (int, error) {
+	verb := "created"
+	if dryRun {
+		verb = "create"
+	}
+	created := 0
+
+	for _, username := range rbac.Users {
+		user, err := nacosClient.GetUser(username)
+		if err != nil {
+			return created, err
+		}
+		if user != nil {
+			fmt.Printf("  %-8s user %s\n", "exists", username)
+			continue
+		}
+		created++
+		if dryRun {
+			fmt.Printf("  %-8s user %s\n", verb, username)
+			continue
+		}
+		password, err := randomPassword()
+		if err != nil {
+			return created, err
+		}
+		if err := nacosClient.CreateUser(username, password); err != nil {
+			return created, err
+		}
+		fmt.Printf("  %-8s user %s, password %s (shown only once)\n", verb, username, password)
+	}
+
+	for _, r := range rbac.Roles {
+		bindings, err := nacosClient.ListRoles(r.Role, "")
+		if err != nil {
+			return created, err
+		}
+		bound := make(map[string]bool)
+		for _, b := range bindings {
+			if b.Role == r.Role {
+				bound[b.Username] = true
+			}
+		}
+		for _, username := range r.Users {
+			if bound[username] {
+				fmt.Printf("  %-8s role %s of %s\n", "exists", r.Role, username)
+				continue
+			}
+			created++
+			if !dryRun {
+				if err := nacosClient.CreateRole(r.Role, username); err != nil {
+					return created, err
+				}
+			}
+			fmt.Printf("  %-8s role %s of %s\n", verb, r.Role, username)
+		}
+
+		permissions, err := nacosClient.ListPermissions(r.Role)
+		if err != nil {
+			return created, err
+		}
+		granted := make(map[rbacPermission]bool)
+		for _, p := range permissions {
+			if p.Role == r.Role {
+				granted[rbacPermission{Resource: p.Resource, Action: p.Action}] = true
+			}
+		}
+		for _, p := range r.Permissions {
+			if granted[p] {
+				fmt.Printf("  %-8s permission %s of %s on %s\n", "exists", p.Action, r.Role, p.Resource)
+				continue
+			}
+			created++
+			if !dryRun {
+				if err := nacosClient.CreatePermission(r.Role, p.Resource, p.Action); err != nil {
+					return created, err
+				}
+			}
+			fmt.Printf("  %-8s permission %s of %s on %s\n", verb, p.Action, r.Role, p.Resource)
+		}
+	}
+
+	return created, nil
+}
+
+// printCreateSummary ends the output of the commands creating what is missing
+func printCreateSummary(created int, dryRun bool) {
+	if dryRun {
+		fmt.Printf("\nDry run: %d to create, nothing changed\n", created)
+	} else {
+		fmt.Printf("\nCreated: %d\n", created)
+	}
+}
+
+func init() {
+	authExportCmd.Flags().StringVarP(&authExportOutput, "output", "o", "", "File to write the definitions to (default: stdout)")
+	authImportCmd.Flags().BoolVar(&authImportDryRun, "dry-run", false, "Show what would be created without changing anything")
+	rootCmd.AddCommand(authExportCmd)
+	rootCmd.AddCommand(authImportCmd)
+}
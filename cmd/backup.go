@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/backup"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/lock"
+	"github.com/nov11/nacos-cli/internal/selector"
+	"github.com/nov11/nacos-cli/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput      string
+	exportConcurrency int
+
+	scheduleCron          string
+	scheduleDest          string
+	scheduleKeep          int
+	scheduleAllNamespaces bool
+	scheduleRunNow        bool
+	scheduleMetricsAddr   string
+	storageOptions        storage.Options
+)
+
+var exportConfigCmd = &cobra.Command{
+	Use:   "config-export",
+	Short: "Export all configurations of a namespace to a zip archive",
+	Long:  help.ConfigExport.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		nacosClient := newNacosClient()
+		output := exportOutput
+		if output == "" {
+			output = backup.ArchiveName(nacosClient.Namespace, time.Now())
+		}
+
+		archive, err := exportNamespace(nacosClient, parseSelector(), exportConcurrency)
+		checkError(err)
+		w, err := storage.Create(output, storageOptions)
+		checkError(err)
+		if err := archive.Write(w); err != nil {
+			w.Close()
+			checkError(err)
+		}
+		checkError(w.Close())
+		fmt.Printf("Exported %d configuration(s) from namespace %s to %s\n",
+			len(archive.Manifest.Entries), nacosClient.Namespace, output)
+	},
+}
+
+var scheduleBackupCmd = &cobra.Command{
+	Use:   "backup-schedule",
+	Short: "Run as a daemon exporting namespaces on a cron schedule, with retention",
+	Long:  help.BackupSchedule.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if scheduleCron == "" || scheduleDest == "" {
+			checkError(fmt.Errorf("--cron and --dest are required"))
+		}
+		schedule, err := backup.ParseCron(scheduleCron)
+		checkError(err)
+		dest, err := storage.Open(scheduleDest, storageOptions)
+		checkError(err)
+		nacosClient := newNacosClient()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		metrics := &backupMetrics{}
+		if scheduleMetricsAddr != "" {
+			go func() {
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", metrics)
+				if err := http.ListenAndServe(scheduleMetricsAddr, mux); err != nil {
+					checkError(fmt.Errorf("metrics server: %w", err))
+				}
+			}()
+			logBackup("Serving metrics on http://%s/metrics", scheduleMetricsAddr)
+		}
+
+		logBackup("Backing up to %s on schedule %q, keeping %d backup(s) per namespace", dest.Describe(), scheduleCron, scheduleKeep)
+		if scheduleRunNow {
+			runBackup(nacosClient, dest, metrics)
+		}
+		for {
+			next := schedule.Next(time.Now())
+			if next.IsZero() {
+				checkError(fmt.Errorf("the schedule %q never fires", scheduleCron))
+			}
+			logBackup("Next backup at %s", next.Format("2006-01-02 15:04:05"))
+			sleepCtx(ctx, time.Until(next))
+			if ctx.Err() != nil {
+				logBackup("Stopped")
+				return
+			}
+			runBackup(nacosClient, dest, metrics)
+		}
+	},
+}
+
+// exportNamespace reads the configurations of the client's namespace, those
+// sel matches when not nil, into an archive. The advisory locks of config-edit
+// are left out.
+func exportNamespace(nacosClient *client.NacosClient, sel *selector.Selector, concurrency int) (*backup.Archive, error) {
+	items, err := nacosClient.ListAllConfigs("", "")
+	if err != nil {
+		return nil, err
+	}
+	if items, err = selectConfigs(nacosClient, sel, items, concurrency); err != nil {
+		return nil, err
+	}
+	var refs []configRef
+	var types []string
+	for _, item := range items {
+		if item.GroupName == lock.Group {
+			continue
+		}
+		if !configtree.ValidName(item.GroupName) || !configtree.ValidName(item.DataID) {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s/%s: not usable as an archive path\n", item.GroupName, item.DataID)
+			continue
+		}
+		refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+		types = append(types, item.Type)
+	}
+
+	contents, errs := fetchConfigs(nacosClient, refs, concurrency)
+	archive := &backup.Archive{
+		Manifest: configtree.Manifest{
+			Server:    nacosClient.ServerAddr,
+			Namespace: nacosClient.Namespace,
+			PulledAt:  time.Now(),
+			Selector:  sel.String(),
+		},
+		Contents: make(map[string]string),
+	}
+	for i, ref := range refs {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("export %s: %w", configtree.Key(ref.Group, ref.DataID), errs[i])
+		}
+		key := configtree.Key(ref.Group, ref.DataID)
+		archive.Contents[key] = contents[i]
+		archive.Manifest.Entries = append(archive.Manifest.Entries, configtree.ManifestEntry{
+			Group:  ref.Group,
+			DataID: ref.DataID,
+			MD5:    listener.CalculateMD5(contents[i]),
+			Type:   types[i],
+		})
+	}
+	return archive, nil
+}
+
+// runBackup exports every scheduled namespace, uploads it and applies retention
+func runBackup(nacosClient *client.NacosClient, dest storage.Store, metrics *backupMetrics) {
+	namespaces := []string{nacosClient.Namespace}
+	if scheduleAllNamespaces {
+		list, err := nacosClient.ListNamespaces()
+		if err != nil {
+			logBackup("Error: list namespaces: %v", err)
+			metrics.record("", 0, 0, 0, err)
+			return
+		}
+		namespaces = namespaces[:0]
+		for _, ns := range list {
+			namespaces = append(namespaces, ns.Namespace)
+		}
+	}
+
+	for _, ns := range namespaces {
+		start := time.Now()
+		nsClient := nacosClient.WithNamespace(ns)
+		configs, size, err := backupNamespace(nsClient, dest, start)
+		metrics.record(ns, configs, size, time.Since(start), err)
+		if err != nil {
+			logBackup("Error: back up namespace %s: %v", ns, err)
+			continue
+		}
+		logBackup("Backed up %d configuration(s) of namespace %s (%d bytes) in %s",
+			configs, ns, size, time.Since(start).Round(time.Millisecond))
+
+		deleted, err := backup.Prune(dest, ns, scheduleKeep)
+		if err != nil {
+			logBackup("Warning: retention for namespace %s: %v", ns, err)
+		}
+		for _, name := range deleted {
+			logBackup("Deleted old backup %s", name)
+		}
+	}
+}
+
+func backupNamespace(nsClient *client.NacosClient, dest storage.Store, at time.Time) (int, int, error) {
+	archive, err := exportNamespace(nsClient, nil, 8)
+	if err != nil {
+		return 0, 0, err
+	}
+	w, err := dest.Create(backup.ArchiveName(nsClient.Namespace, at))
+	if err != nil {
+		return 0, 0, err
+	}
+	counter := &countingWriter{w: w}
+	if err := archive.Write(counter); err != nil {
+		w.Close()
+		return 0, 0, err
+	}
+	if err := w.Close(); err != nil {
+		return 0, 0, err
+	}
+	return len(archive.Manifest.Entries), counter.n, nil
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+func logBackup(format string, args ...interface{}) {
+	fmt.Printf("%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+}
+
+// backupMetrics exposes the outcome of scheduled backups in the Prometheus text format
+type backupMetrics struct {
+	mu          sync.Mutex
+	namespaces  map[string]*namespaceBackupMetrics
+	runs        int
+	failures    int
+	lastSuccess time.Time
+}
+
+type namespaceBackupMetrics struct {
+	lastSuccess  time.Time
+	lastFailure  time.Time
+	lastDuration time.Duration
+	lastSize     int
+	lastConfigs  int
+}
+
+func (m *backupMetrics) record(namespace string, configs, size int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs++
+	if err != nil {
+		m.failures++
+	} else {
+		m.lastSuccess = time.Now()
+	}
+	if namespace == "" {
+		return
+	}
+	if m.namespaces == nil {
+		m.namespaces = make(map[string]*namespaceBackupMetrics)
+	}
+	ns, ok := m.namespaces[namespace]
+	if !ok {
+		ns = &namespaceBackupMetrics{}
+		m.namespaces[namespace] = ns
+	}
+	if err != nil {
+		ns.lastFailure = time.Now()
+		return
+	}
+	ns.lastSuccess = time.Now()
+	ns.lastDuration = duration
+	ns.lastSize = size
+	ns.lastConfigs = configs
+}
+
+func (m *backupMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.namespaces))
+	for name := range m.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP nacos_cli_backup_runs_total Namespace backups attempted.\n# TYPE nacos_cli_backup_runs_total counter\n")
+	fmt.Fprintf(w, "nacos_cli_backup_runs_total %d\n", m.runs)
+	fmt.Fprintf(w, "# HELP nacos_cli_backup_failures_total Namespace backups that failed.\n# TYPE nacos_cli_backup_failures_total counter\n")
+	fmt.Fprintf(w, "nacos_cli_backup_failures_total %d\n", m.failures)
+	fmt.Fprintf(w, "# HELP nacos_cli_backup_last_success_timestamp_seconds Time of the last successful backup.\n# TYPE nacos_cli_backup_last_success_timestamp_seconds gauge\n")
+	fmt.Fprintf(w, "nacos_cli_backup_last_success_timestamp_seconds %d\n", unixOrZero(m.lastSuccess))
+	for _, name := range names {
+		ns := m.namespaces[name]
+		fmt.Fprintf(w, "nacos_cli_backup_last_success_timestamp_seconds{namespace=%q} %d\n", name, unixOrZero(ns.lastSuccess))
+	}
+	fmt.Fprintf(w, "# HELP nacos_cli_backup_last_failure_timestamp_seconds Time of the last failed backup.\n# TYPE nacos_cli_backup_last_failure_timestamp_seconds gauge\n")
+	for _, name := range names {
+		ns := m.namespaces[name]
+		fmt.Fprintf(w, "nacos_cli_backup_last_failure_timestamp_seconds{namespace=%q} %d\n", name, unixOrZero(ns.lastFailure))
+	}
+	fmt.Fprintf(w, "# HELP nacos_cli_backup_last_duration_seconds Duration of the last successful backup.\n# TYPE nacos_cli_backup_last_duration_seconds gauge\n")
+	for _, name := range names {
+		ns := m.namespaces[name]
+		fmt.Fprintf(w, "nacos_cli_backup_last_duration_seconds{namespace=%q} %.3f\n", name, ns.lastDuration.Seconds())
+	}
+	fmt.Fprintf(w, "# HELP nacos_cli_backup_last_size_bytes Size of the last successful backup archive.\n# TYPE nacos_cli_backup_last_size_bytes gauge\n")
+	for _, name := range names {
+		ns := m.namespaces[name]
+		fmt.Fprintf(w, "nacos_cli_backup_last_size_bytes{namespace=%q} %d\n", name, ns.lastSize)
+	}
+	fmt.Fprintf(w, "# HELP nacos_cli_backup_last_configs Configurations in the last successful backup.\n# TYPE nacos_cli_backup_last_configs gauge\n")
+	for _, name := range names {
+		ns := m.namespaces[name]
+		fmt.Fprintf(w, "nacos_cli_backup_last_configs{namespace=%q} %d\n", name, ns.lastConfigs)
+	}
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func init() {
+	exportConfigCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Archive file or s3://, oss://, gs:// URL (default: <namespace>-<time>.zip)")
+	exportConfigCmd.Flags().IntVar(&exportConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addSelectorFlag(exportConfigCmd)
+	addStorageFlags(exportConfigCmd)
+	rootCmd.AddCommand(exportConfigCmd)
+
+	scheduleBackupCmd.Flags().StringVar(&scheduleCron, "cron", "", "Cron schedule in local time, e.g. '0 2 * * *' or @daily")
+	scheduleBackupCmd.Flags().StringVar(&scheduleDest, "dest", "", "Directory or s3://, oss://, gs://bucket/prefix to store the archives in")
+	scheduleBackupCmd.Flags().IntVar(&scheduleKeep, "keep", 30, "Backups to keep per namespace, 0 to keep all")
+	scheduleBackupCmd.Flags().BoolVar(&scheduleAllNamespaces, "all-namespaces", false, "Back up every namespace instead of the current one")
+	scheduleBackupCmd.Flags().BoolVar(&scheduleRunNow, "run-now", false, "Take a backup at startup too")
+	scheduleBackupCmd.Flags().StringVar(&scheduleMetricsAddr, "metrics-addr", "", "Serve Prometheus metrics on this address, e.g. :9108")
+	addStorageFlags(scheduleBackupCmd)
+	rootCmd.AddCommand(scheduleBackupCmd)
+}
+
+// addStorageFlags adds the object storage flags of commands reading or writing archives
+func addStorageFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&storageOptions.Endpoint, "storage-endpoint", "", "Object storage endpoint, e.g. a MinIO server (default: from the URL scheme)")
+	cmd.Flags().StringVar(&storageOptions.Region, "storage-region", "", "Object storage region (default: us-east-1 for s3, cn-hangzhou for oss)")
+	cmd.Flags().BoolVar(&storageOptions.PathStyle, "storage-path-style", false, "Use path-style bucket URLs (MinIO)")
+}
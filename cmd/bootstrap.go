@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var bootstrapDryRun bool
+
+// clusterFile declares the day-0 state of a cluster: namespaces with their
+// capacity, groups and seed configurations, and the users, roles and
+// permissions of auth-export
+type clusterFile struct {
+	Namespaces []clusterNamespace `yaml:"namespaces"`
+	rbacFile   `yaml:",inline"`
+}
+
+// clusterNamespace is a namespace to create; public always exists and is only filled
+type clusterNamespace struct {
+	ID       string           `yaml:"id"`
+	Name     string           `yaml:"name"`
+	Desc     string           `yaml:"desc"`
+	Capacity *clusterCapacity `yaml:"capacity"`
+	Groups   []clusterGroup   `yaml:"groups"`
+}
+
+// clusterGroup is a group with its capacity and seed configurations
+type clusterGroup struct {
+	Name     string           `yaml:"name"`
+	Capacity *clusterCapacity `yaml:"capacity"`
+	Configs  []seedConfig     `yaml:"configs"`
+}
+
+// clusterCapacity limits the configurations of a namespace or group; limits
+// left out are not changed
+type clusterCapacity struct {
+	Quota        int `yaml:"quota"`        // maximum number of configurations
+	MaxSize      int `yaml:"maxSize"`      // maximum size of a configuration in bytes
+	MaxAggrCount int `yaml:"maxAggrCount"` // maximum number of aggregated sub-configurations
+	MaxAggrSize  int `yaml:"maxAggrSize"`  // maximum size of a sub-configuration in bytes
+}
+
+// seedConfig is published only when the configuration does not exist, with
+// content given inline or read from a file relative to the cluster file
+type seedConfig struct {
+	DataID  string `yaml:"dataId"`
+	Type    string `yaml:"type"`
+	Content string `yaml:"content"`
+	File    string `yaml:"file"`
+}
+
+var bootstrapCmd = &cobra.Command{
+	Use:   "bootstrap cluster.yaml",
+	Short: "Provision namespaces, RBAC, seed configurations and capacity from one file",
+	Long:  help.Bootstrap.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := os.ReadFile(args[0])
+		checkError(err)
+		var cluster clusterFile
+		if err := yaml.Unmarshal(data, &cluster); err != nil {
+			checkError(fmt.Errorf("parse %s: %w", args[0], err))
+		}
+		checkError(loadSeedConfigs(&cluster, filepath.Dir(args[0])))
+		checkError(validateRBAC(&cluster.rbacFile))
+
+		nacosClient := newNacosClient()
+		verb := "created"
+		if bootstrapDryRun {
+			verb = "create"
+		}
+		created := 0
+		for _, ns := range cluster.Namespaces {
+			n, err := bootstrapClusterNamespace(nacosClient, ns, verb)
+			checkError(err)
+			created += n
+		}
+		n, err := importRBAC(nacosClient, &cluster.rbacFile, bootstrapDryRun)
+		checkError(err)
+		printCreateSummary(created+n, bootstrapDryRun)
+	},
+}
+
+// loadSeedConfigs checks the file and reads the seed configurations given as
+// files, before anything is created
+func loadSeedConfigs(cluster *clusterFile, dir string) error {
+	seen := make(map[string]bool)
+	for _, ns := range cluster.Namespaces {
+		if ns.ID == "" {
+			return fmt.Errorf("namespaces: a namespace has no id")
+		}
+		if seen[ns.ID] {
+			return fmt.Errorf("namespace %s is declared twice", ns.ID)
+		}
+		seen[ns.ID] = true
+		for _, g := range ns.Groups {
+			if !configtree.ValidName(g.Name) {
+				return fmt.Errorf("namespace %s: invalid group name %q", ns.ID, g.Name)
+			}
+			for i := range g.Configs {
+				c := &g.Configs[i]
+				if !configtree.ValidName(c.DataID) {
+					return fmt.Errorf("namespace %s, group %s: invalid dataId %q", ns.ID, g.Name, c.DataID)
+				}
+				if c.File != "" {
+					if c.Content != "" {
+						return fmt.Errorf("%s/%s: give content or file, not both", g.Name, c.DataID)
+					}
+					path := c.File
+					if !filepath.IsAbs(path) {
+						path = filepath.Join(dir, path)
+					}
+					data, err := os.ReadFile(path)
+					if err != nil {
+						return fmt.Errorf("%s/%s: %w", g.Name, c.DataID, err)
+					}
+					c.Content = string(data)
+				}
+				if c.Content == "" {
+					return fmt.Errorf("%s/%s: seed configuration without content", g.Name, c.DataID)
+				}
+				if c.Type == "" {
+					c.Type = configtree.TypeOf(c.DataID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// bootstrapClusterNamespace creates a namespace when it is missing, sets the
+// capacity it declares and publishes the seed configurations that do not exist.
+// Existing configurations are never overwritten, so the file can be applied
+// again. It returns the number of things created or changed.
+func bootstrapClusterNamespace(nacosClient *client.NacosClient, ns clusterNamespace, verb string) (int, error) {
+	created := 0
+	if ns.ID != "public" {
+		existing, err := nacosClient.GetNamespace(ns.ID)
+		if err != nil {
+			return created, err
+		}
+		if existing != nil {
+			fmt.Printf("  %-8s namespace %s\n", "exists", ns.ID)
+		} else {
+			name := ns.Name
+			if name == "" {
+				name = ns.ID
+			}
+			if !bootstrapDryRun {
+				if err := nacosClient.CreateNamespace(ns.ID, name, ns.Desc); err != nil {
+					return created, err
+				}
+			}
+			fmt.Printf("  %-8s namespace %s\n", verb, ns.ID)
+			created++
+		}
+	}
+	changed, err := bootstrapCapacity(nacosClient, ns.ID, "", ns.Capacity)
+	if err != nil {
+		return created, err
+	}
+	if changed {
+		created++
+	}
+
+	nsClient := nacosClient.WithNamespace(ns.ID)
+	for _, g := range ns.Groups {
+		changed, err := bootstrapCapacity(nacosClient, ns.ID, g.Name, g.Capacity)
+		if err != nil {
+			return created, err
+		}
+		if changed {
+			created++
+		}
+		for _, c := range g.Configs {
+			key := ns.ID + ":" + configtree.Key(g.Name, c.DataID)
+			_, err := nsClient.GetConfig(c.DataID, g.Name)
+			if err == nil {
+				fmt.Printf("  %-8s config %s\n", "exists", key)
+				continue
+			}
+			if !errors.Is(err, client.ErrConfigNotFound) {
+				return created, fmt.Errorf("get %s: %w", key, err)
+			}
+			if !bootstrapDryRun {
+				if err := nsClient.PublishConfigWithOptions(c.DataID, g.Name, c.Content, client.PublishOptions{Type: c.Type}); err != nil {
+					return created, fmt.Errorf("publish %s: %w", key, err)
+				}
+			}
+			fmt.Printf("  %-8s config %s\n", verb, key)
+			created++
+		}
+	}
+	return created, nil
+}
+
+// bootstrapCapacity sets the capacity of a namespace or group when it differs
+// from the declared one, reporting whether it did; limits left out of the file
+// are not changed
+func bootstrapCapacity(nacosClient *client.NacosClient, namespaceID, group string, want *clusterCapacity) (bool, error) {
+	if want == nil {
+		return false, nil
+	}
+	what := "capacity of namespace " + namespaceID
+	if group != "" {
+		what = "capacity of " + namespaceID + ":" + group
+	}
+	current, err := nacosClient.GetCapacity(namespaceID, group)
+	if err != nil {
+		return false, err
+	}
+	if current != nil &&
+		(want.Quota == 0 || want.Quota == current.Quota) &&
+		(want.MaxSize == 0 || want.MaxSize == current.MaxSize) &&
+		(want.MaxAggrCount == 0 || want.MaxAggrCount == current.MaxAggrCount) &&
+		(want.MaxAggrSize == 0 || want.MaxAggrSize == current.MaxAggrSize) {
+		fmt.Printf("  %-8s %s\n", "exists", what)
+		return false, nil
+	}
+	if !bootstrapDryRun {
+		limits := client.Capacity{Quota: want.Quota, MaxSize: want.MaxSize, MaxAggrCount: want.MaxAggrCount, MaxAggrSize: want.MaxAggrSize}
+		if err := nacosClient.UpdateCapacity(namespaceID, group, limits); err != nil {
+			return false, err
+		}
+	}
+	fmt.Printf("  %-8s %s\n", "set", what)
+	return true, nil
+}
+
+func init() {
+	bootstrapCmd.Flags().BoolVar(&bootstrapDryRun, "dry-run", false, "Show what would be created without changing anything")
+	rootCmd.AddCommand(bootstrapCmd)
+}
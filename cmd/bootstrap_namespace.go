@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bootstrapName     string
+	bootstrapDesc     string
+	bootstrapUser     string
+	bootstrapPassword string
+	bootstrapRole     string
+	bootstrapGrant    string
+)
+
+var bootstrapNamespaceCmd = &cobra.Command{
+	Use:   "bootstrap-namespace namespaceId",
+	Short: "Create a namespace with a user, a role and a permission scoped to it",
+	Long:  help.BootstrapNamespace.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := args[0]
+		if id == "public" {
+			checkError(fmt.Errorf("the public namespace cannot be bootstrapped"))
+		}
+		if bootstrapUser == "" {
+			checkError(fmt.Errorf("--create-user is required"))
+		}
+		switch bootstrapGrant {
+		case client.ActionRead, client.ActionWrite, client.ActionReadWrite:
+		default:
+			checkError(fmt.Errorf("invalid --grant %q (expected r, w or rw)", bootstrapGrant))
+		}
+		role := bootstrapRole
+		if role == "" {
+			role = id + "-" + bootstrapGrant
+		}
+		name := bootstrapName
+		if name == "" {
+			name = id
+		}
+		nacosClient := newNacosClient()
+
+		existing, err := nacosClient.GetNamespace(id)
+		checkError(err)
+		if existing != nil {
+			fmt.Printf("  %-8s namespace %s\n", "exists", id)
+		} else {
+			checkError(nacosClient.CreateNamespace(id, name, bootstrapDesc))
+			fmt.Printf("  %-8s namespace %s\n", "created", id)
+		}
+
+		user, err := nacosClient.GetUser(bootstrapUser)
+		checkError(err)
+		if user != nil {
+			fmt.Printf("  %-8s user %s (password unchanged)\n", "exists", bootstrapUser)
+		} else {
+			password := bootstrapPassword
+			if password == "" {
+				password, err = randomPassword()
+				checkError(err)
+			}
+			checkError(nacosClient.CreateUser(bootstrapUser, password))
+			if bootstrapPassword == "" {
+				fmt.Printf("  %-8s user %s, password %s (shown only once)\n", "created", bootstrapUser, password)
+			} else {
+				fmt.Printf("  %-8s user %s\n", "created", bootstrapUser)
+			}
+		}
+
+		bindings, err := nacosClient.ListRoles(role, bootstrapUser)
+		checkError(err)
+		bound := false
+		for _, b := range bindings {
+			bound = bound || (b.Role == role && b.Username == bootstrapUser)
+		}
+		if bound {
+			fmt.Printf("  %-8s role %s of %s\n", "exists", role, bootstrapUser)
+		} else {
+			checkError(nacosClient.CreateRole(role, bootstrapUser))
+			fmt.Printf("  %-8s role %s of %s\n", "created", role, bootstrapUser)
+		}
+
+		resource := client.NamespaceResource(id)
+		permissions, err := nacosClient.ListPermissions(role)
+		checkError(err)
+		granted := false
+		for _, p := range permissions {
+			granted = granted || (p.Resource == resource && p.Action == bootstrapGrant)
+		}
+		if granted {
+			fmt.Printf("  %-8s permission %s on %s\n", "exists", bootstrapGrant, resource)
+		} else {
+			checkError(nacosClient.CreatePermission(role, resource, bootstrapGrant))
+			fmt.Printf("  %-8s permission %s on %s\n", "created", bootstrapGrant, resource)
+		}
+
+		fmt.Printf("\nNamespace %s is ready for %s\n", id, bootstrapUser)
+	},
+}
+
+// randomPassword returns a password for a new service account
+func randomPassword() (string, error) {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func init() {
+	bootstrapNamespaceCmd.Flags().StringVar(&bootstrapName, "name", "", "Namespace name (default: the namespace ID)")
+	bootstrapNamespaceCmd.Flags().StringVar(&bootstrapDesc, "desc", "", "Namespace description")
+	bootstrapNamespaceCmd.Flags().StringVar(&bootstrapUser, "create-user", "", "Service account user to create, or reuse when it exists (required)")
+	bootstrapNamespaceCmd.Flags().StringVar(&bootstrapPassword, "user-password", "", "Password of the new user (default: generated and printed once)")
+	bootstrapNamespaceCmd.Flags().StringVar(&bootstrapRole, "role", "", "Role bound to the user (default: <namespaceId>-<grant>)")
+	bootstrapNamespaceCmd.Flags().StringVar(&bootstrapGrant, "grant", client.ActionReadWrite, "Access of the role to the namespace: r, w or rw")
+	rootCmd.AddCommand(bootstrapNamespaceCmd)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/yamlutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	catGroup       string
+	catMerge       bool
+	catConcurrency int
+)
+
+var catConfigCmd = &cobra.Command{
+	Use:   "config-cat [dataId...]",
+	Short: "Print several configurations, fetched concurrently",
+	Long:  help.ConfigCat.FormatForCLI("nacos-cli"),
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		group := resolveGroup(cmd, catGroup, "DEFAULT_GROUP")
+		nacosClient := newNacosClient()
+
+		refs := make([]configRef, len(args))
+		for i, dataID := range args {
+			refs[i] = configRef{Group: group, DataID: dataID}
+		}
+		contents, errs := fetchConfigs(nacosClient, refs, catConcurrency)
+
+		failed := false
+		for i, err := range errs {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s (%s): %v\n", args[i], group, err)
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+
+		startPager()
+		if catMerge {
+			merged, err := yamlutil.MergeDocuments(contents)
+			checkError(err)
+			fmt.Print(merged)
+			return
+		}
+		for i, content := range contents {
+			fmt.Printf("--- # %s\n", args[i])
+			fmt.Print(content)
+			if !strings.HasSuffix(content, "\n") {
+				fmt.Println()
+			}
+		}
+	},
+}
+
+func init() {
+	catConfigCmd.Flags().StringVar(&catGroup, "group", "", "Configuration group name or alias (default: DEFAULT_GROUP)")
+	catConfigCmd.Flags().BoolVar(&catMerge, "merge", false, "Deep-merge the YAML/JSON documents into one, later ones overriding earlier ones")
+	catConfigCmd.Flags().IntVar(&catConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	rootCmd.AddCommand(catConfigCmd)
+}
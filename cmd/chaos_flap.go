@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/terminal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	chaosService  string
+	chaosGroup    string
+	chaosAddr     string
+	chaosCluster  string
+	chaosMetadata []string
+	chaosPeriod   time.Duration
+	chaosDuration time.Duration
+)
+
+// chaosBeatInterval is how often a registered synthetic instance sends its
+// heartbeats, well within the 15s after which the server marks it unhealthy
+const chaosBeatInterval = 5 * time.Second
+
+var chaosFlapCmd = &cobra.Command{
+	Use:   "chaos-flap",
+	Short: "Register and deregister a synthetic instance periodically to exercise client failover",
+	Long:  help.ChaosFlap.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if chaosService == "" {
+			fmt.Fprintf(os.Stderr, "Error: --service is required\n")
+			os.Exit(1)
+		}
+		if chaosPeriod <= 0 || chaosDuration <= 0 {
+			checkError(fmt.Errorf("--period and --duration must be positive"))
+		}
+		inst, err := parseInstanceAddr(chaosAddr)
+		checkError(err)
+		inst.ClusterName = chaosCluster
+		inst.Weight = 1
+		inst.Enabled, inst.Healthy, inst.Ephemeral = true, true, true
+		inst.Metadata, err = parseMetadata(chaosMetadata)
+		checkError(err)
+		if inst.Metadata == nil {
+			inst.Metadata = make(map[string]string)
+		}
+		// Lets consumers and operators tell the synthetic instance apart
+		inst.Metadata["chaos"] = "flap"
+		chaosGroup = resolveGroup(cmd, chaosGroup, "DEFAULT_GROUP")
+
+		nacosClient := newNacosClient()
+		checkError(checkTestTarget(cmd.Name(), nacosClient.Namespace))
+
+		ctx, cancel := context.WithTimeout(context.Background(), chaosDuration)
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		name := fmt.Sprintf("%s@@%s %s:%d", chaosGroup, chaosService, inst.IP, inst.Port)
+		fmt.Fprintf(os.Stderr, "Flapping %s in namespace %s every %s for %s (Ctrl+C to stop)...\n",
+			name, namespaceLabel(nacosClient.Namespace), chaosPeriod, chaosDuration)
+
+		// Each period the instance is up for the first half and down for the second
+		flaps := 0
+		for ctx.Err() == nil {
+			if err := nacosClient.RegisterInstance(chaosService, chaosGroup, inst); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Warning: %v\n", time.Now().Format("15:04:05"), err)
+			} else {
+				fmt.Printf("%s up    %s\n", time.Now().Format("15:04:05"), name)
+			}
+			keepAlive(ctx, nacosClient, inst, chaosPeriod/2)
+			if ctx.Err() != nil {
+				break
+			}
+			if err := nacosClient.DeregisterInstance(chaosService, chaosGroup, inst); err != nil {
+				fmt.Fprintf(os.Stderr, "%s Warning: %v\n", time.Now().Format("15:04:05"), err)
+			} else {
+				fmt.Printf("%s down  %s\n", time.Now().Format("15:04:05"), name)
+			}
+			flaps++
+			sleepCtx(ctx, chaosPeriod-chaosPeriod/2)
+		}
+
+		// Never leave the synthetic instance behind
+		err = nacosClient.DeregisterInstance(chaosService, chaosGroup, inst)
+		if err != nil && !errors.Is(err, client.ErrInstanceNotFound) {
+			checkError(fmt.Errorf("failed to deregister %s: %w", name, err))
+		}
+		fmt.Fprintf(os.Stderr, "Stopped after %d flap(s), %s deregistered\n", flaps, name)
+	},
+}
+
+// checkTestTarget refuses to run test tooling through a protected profile or
+// against what looks like production, whatever the overrides
+func checkTestTarget(command, namespaceID string) error {
+	if fileConfig != nil && fileConfig.Protected {
+		return fmt.Errorf("%s does not run with a protected profile", command)
+	}
+	if terminal.IsProduction(profile) || terminal.IsProduction(namespaceID) {
+		return fmt.Errorf("%s does not run against production (profile %q, namespace %s)", command, profile, namespaceLabel(namespaceID))
+	}
+	return nil
+}
+
+// keepAlive sends the heartbeats of an ephemeral instance for d
+func keepAlive(ctx context.Context, nacosClient *client.NacosClient, inst client.Instance, d time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	for sleepCtx(ctx, min(chaosBeatInterval, d)); ctx.Err() == nil; sleepCtx(ctx, chaosBeatInterval) {
+		if _, err := nacosClient.SendBeat(chaosService, chaosGroup, inst); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Warning: %v\n", time.Now().Format("15:04:05"), err)
+		}
+	}
+}
+
+func init() {
+	chaosFlapCmd.Flags().StringVar(&chaosService, "service", "", "Service name")
+	chaosFlapCmd.Flags().StringVar(&chaosGroup, "group", "", "Service group (default: DEFAULT_GROUP)")
+	chaosFlapCmd.Flags().StringVar(&chaosAddr, "ip", "192.0.2.1:8080", "ip:port of the synthetic instance, an unroutable test address by default")
+	chaosFlapCmd.Flags().StringVar(&chaosCluster, "cluster", "DEFAULT", "Cluster of the synthetic instance")
+	chaosFlapCmd.Flags().StringArrayVar(&chaosMetadata, "metadata", nil, "Metadata of the synthetic instance as key=value (repeatable)")
+	chaosFlapCmd.Flags().DurationVar(&chaosPeriod, "period", 10*time.Second, "Time of one up and down cycle")
+	chaosFlapCmd.Flags().DurationVar(&chaosDuration, "duration", 5*time.Minute, "How long to flap")
+	rootCmd.AddCommand(chaosFlapCmd)
+}
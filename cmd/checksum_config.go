@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/selector"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checksumOutput      string
+	checksumAlgorithm   string
+	checksumVerify      string
+	checksumIgnoreExtra bool
+	checksumConcurrency int
+)
+
+// checksumFile is a checksum manifest: "<hash>  <group>/<dataId>" lines in
+// the format of sha256sum, after "# key: value" header comments
+type checksumFile struct {
+	Namespace string
+	Algorithm string
+	Selector  string
+	Generated string
+	Sums      map[string]string // group/dataId -> hash
+}
+
+var checksumConfigCmd = &cobra.Command{
+	Use:   "config-checksum",
+	Short: "Write or verify a checksum manifest of every configuration in a namespace",
+	Long:  help.ConfigChecksum.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		nacosClient := newNacosClient()
+		if checksumVerify != "" {
+			verifyChecksums(cmd, nacosClient)
+			return
+		}
+
+		if checksumAlgorithm != "sha256" && checksumAlgorithm != "md5" {
+			checkError(fmt.Errorf("unknown --algorithm %q (expected sha256 or md5)", checksumAlgorithm))
+		}
+		sel := parseSelector()
+		sums, err := checksumNamespace(nacosClient, sel, checksumAlgorithm)
+		checkError(err)
+
+		var out io.Writer = os.Stdout
+		if checksumOutput != "" && checksumOutput != "-" {
+			f, err := os.Create(checksumOutput)
+			checkError(err)
+			defer f.Close()
+			out = f
+		}
+		w := bufio.NewWriter(out)
+		fmt.Fprintf(w, "# namespace: %s\n", nacosClient.Namespace)
+		fmt.Fprintf(w, "# algorithm: %s\n", checksumAlgorithm)
+		if sel != nil {
+			fmt.Fprintf(w, "# selector: %s\n", sel.String())
+		}
+		fmt.Fprintf(w, "# generated: %s\n", time.Now().UTC().Format(time.RFC3339))
+		keys := make([]string, 0, len(sums))
+		for key := range sums {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(w, "%s  %s\n", sums[key], key)
+		}
+		checkError(w.Flush())
+		if out != os.Stdout {
+			fmt.Printf("Wrote %d checksum(s) of namespace %s to %s\n", len(keys), namespaceLabel(nacosClient.Namespace), checksumOutput)
+		}
+	},
+}
+
+// checksumNamespace hashes the content of every configuration of the
+// namespace of the client, those sel matches when not nil. nacos-cli
+// bookkeeping groups are left out.
+func checksumNamespace(nacosClient *client.NacosClient, sel *selector.Selector, algorithm string) (map[string]string, error) {
+	items, err := nacosClient.ListAllConfigs("", "")
+	if err != nil {
+		return nil, err
+	}
+	if items, err = selectConfigs(nacosClient, sel, items, checksumConcurrency); err != nil {
+		return nil, err
+	}
+	var refs []configRef
+	for _, item := range items {
+		if !isInternalGroup(item.GroupName) {
+			refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+		}
+	}
+	contents, errs := fetchConfigs(nacosClient, refs, checksumConcurrency)
+	sums := make(map[string]string, len(refs))
+	for i, ref := range refs {
+		key := configtree.Key(ref.Group, ref.DataID)
+		if errors.Is(errs[i], client.ErrConfigNotFound) {
+			// Deleted since it was listed
+			continue
+		}
+		if errs[i] != nil {
+			return nil, fmt.Errorf("fetch %s: %w", key, errs[i])
+		}
+		sums[key] = contentChecksum(algorithm, contents[i])
+	}
+	return sums, nil
+}
+
+// contentChecksum returns the hex digest of a configuration content
+func contentChecksum(algorithm, content string) string {
+	if algorithm == "md5" {
+		sum := md5.Sum([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadChecksumFile reads a checksum manifest; without an algorithm header it
+// is inferred from the length of the hashes
+func loadChecksumFile(path string) (*checksumFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	file := &checksumFile{Sums: make(map[string]string)}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			key, value, _ := strings.Cut(comment, ":")
+			value = strings.TrimSpace(value)
+			switch strings.TrimSpace(key) {
+			case "namespace":
+				file.Namespace = value
+			case "algorithm":
+				file.Algorithm = value
+			case "selector":
+				file.Selector = value
+			case "generated":
+				file.Generated = value
+			}
+			continue
+		}
+		hash, key, ok := strings.Cut(line, "  ")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("%s:%d: expected \"<hash>  <group>/<dataId>\"", path, n)
+		}
+		if file.Algorithm == "" {
+			switch len(hash) {
+			case md5.Size * 2:
+				file.Algorithm = "md5"
+			case sha256.Size * 2:
+				file.Algorithm = "sha256"
+			}
+		}
+		file.Sums[strings.TrimSpace(key)] = strings.ToLower(hash)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if file.Algorithm != "sha256" && file.Algorithm != "md5" {
+		return nil, fmt.Errorf("%s: unknown checksum algorithm %q", path, file.Algorithm)
+	}
+	return file, nil
+}
+
+// verifyChecksums compares the namespace with a checksum manifest in both
+// directions and exits with status 1 on any difference
+func verifyChecksums(cmd *cobra.Command, nacosClient *client.NacosClient) {
+	file, err := loadChecksumFile(checksumVerify)
+	checkError(err)
+	if !cmd.Flags().Changed("namespace") && file.Namespace != "" {
+		nacosClient = nacosClient.WithNamespace(file.Namespace)
+	}
+	sel := parseSelector()
+	if sel == nil && file.Selector != "" {
+		// The manifest holds the configurations it was generated with
+		sel, err = selector.Parse(file.Selector)
+		checkError(err)
+	}
+	current, err := checksumNamespace(nacosClient, sel, file.Algorithm)
+	checkError(err)
+
+	fmt.Printf("Verifying %s against namespace %s\n", checksumVerify, namespaceLabel(nacosClient.Namespace))
+	if file.Generated != "" {
+		fmt.Printf("Checksums taken at %s\n", file.Generated)
+	}
+	counts := make(map[string]int)
+	report := func(status, key, detail string) {
+		counts[status]++
+		if status != "ok" {
+			fmt.Printf("  %-8s %s: %s\n", status, key, detail)
+		}
+	}
+	keys := make([]string, 0, len(file.Sums))
+	for key := range file.Sums {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		sum, ok := current[key]
+		switch {
+		case !ok:
+			report("missing", key, "not on the server")
+		case sum != file.Sums[key]:
+			report("changed", key, fmt.Sprintf("%s %s, expected %s", file.Algorithm, sum, file.Sums[key]))
+		default:
+			report("ok", key, "")
+		}
+	}
+	keys = keys[:0]
+	for key := range current {
+		if _, ok := file.Sums[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		report("extra", key, "on the server but not in the checksums")
+	}
+
+	fmt.Printf("\nOK: %d, Changed: %d, Missing: %d, Extra: %d\n", counts["ok"], counts["changed"], counts["missing"], counts["extra"])
+	failures := counts["changed"] + counts["missing"]
+	if !checksumIgnoreExtra {
+		failures += counts["extra"]
+	}
+	if failures > 0 {
+		fmt.Println("FAIL")
+		os.Exit(1)
+	}
+	fmt.Println("PASS")
+}
+
+func init() {
+	checksumConfigCmd.Flags().StringVarP(&checksumOutput, "output", "o", "", "File to write the checksums to (default: stdout)")
+	checksumConfigCmd.Flags().StringVar(&checksumAlgorithm, "algorithm", "sha256", "Checksum algorithm: sha256 or md5")
+	checksumConfigCmd.Flags().StringVar(&checksumVerify, "verify", "", "Compare the namespace with a checksum file instead of writing one")
+	checksumConfigCmd.Flags().BoolVar(&checksumIgnoreExtra, "ignore-extra", false, "With --verify, pass even if the server has configurations the file lacks")
+	checksumConfigCmd.Flags().IntVar(&checksumConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addSelectorFlag(checksumConfigCmd)
+	rootCmd.AddCommand(checksumConfigCmd)
+}
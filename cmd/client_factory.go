@@ -0,0 +1,403 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/config"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/recording"
+	"github.com/spf13/cobra"
+)
+
+// sessionTransport records, replays or logs the requests of all clients, nil otherwise
+var sessionTransport http.RoundTripper
+
+// requestID identifies the requests of the run in the server logs
+var requestID string
+
+// resolveGlobalFlags loads the config file and profile, then fills unset global
+// flags with priority: command line > per-command default > config file > default
+func resolveGlobalFlags(cmd *cobra.Command) {
+	hookCommand = cmd.Name()
+
+	// Load configuration from file if specified
+	if configFile != "" {
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to load config file: %v\n", err)
+		} else {
+			fileConfig = cfg
+		}
+	}
+
+	// Apply the selected profile on top of the top-level settings
+	if profile != "" {
+		if fileConfig == nil {
+			checkError(fmt.Errorf("--profile %s requires a config file (--config)", profile))
+		}
+		cfg, err := fileConfig.WithProfile(profile)
+		checkError(err)
+		fileConfig = cfg
+	}
+
+	// Server address: --server has highest priority
+	if serverAddr == "" {
+		// Try to build from --host and --port
+		if host != "" {
+			if port > 0 {
+				serverAddr = fmt.Sprintf("%s:%d", host, port)
+			} else if strings.Contains(host, ":") {
+				// Host already contains port
+				serverAddr = host
+			} else {
+				// Use default port 8848
+				serverAddr = fmt.Sprintf("%s:8848", host)
+			}
+		} else if fileConfig != nil {
+			// Use from config file
+			serverAddr = fileConfig.GetServerAddr()
+		}
+	}
+
+	// Namespace: command line > per-command default > config file > default (empty)
+	if namespace == "" && fileConfig != nil {
+		if defaults, ok := fileConfig.Commands[cmd.Name()]; ok && defaults.Namespace != "" {
+			namespace = defaults.Namespace
+		} else if fileConfig.Namespace != "" {
+			namespace = fileConfig.Namespace
+		}
+	}
+
+	// AccessKey / SecretKey: command line > config file（AuthType=aliyun 时使用）
+	if accessKey == "" && fileConfig != nil {
+		accessKey = fileConfig.AccessKey
+	}
+	if secretKey == "" && fileConfig != nil {
+		secretKey = fileConfig.SecretKey
+	}
+
+	// AuthType: command line > config file > aliyun when AK/SK are given > nacos
+	if authType == "" {
+		if fileConfig != nil && fileConfig.AuthType != "" {
+			authType = fileConfig.AuthType
+		} else if accessKey != "" && secretKey != "" {
+			authType = client.AuthTypeAliyun
+		} else {
+			authType = client.AuthTypeNacos
+		}
+	}
+
+	if fileConfig != nil && fileConfig.CompensateClockSkew {
+		compensateClockSkew = true
+	}
+
+	// Username: command line > config file > default
+	if username == "" {
+		if fileConfig != nil && fileConfig.Username != "" {
+			username = fileConfig.Username
+		} else {
+			username = "nacos"
+		}
+	}
+
+	// Password: command line > config file > default
+	if password == "" {
+		if fileConfig != nil && fileConfig.Password != "" {
+			password = fileConfig.Password
+		} else {
+			password = "nacos"
+		}
+	}
+
+	// Token provider: --token / --token-command > config file
+	if token != "" {
+		tokenConfig = &config.TokenProviderConfig{Type: "static", Token: token}
+	} else if tokenCommand != "" {
+		tokenConfig = &config.TokenProviderConfig{Type: "command", Command: tokenCommand}
+	} else if fileConfig != nil {
+		tokenConfig = fileConfig.TokenProvider
+	}
+
+	// Impersonation: --as needs a profile that obtains tokens for other users
+	if asUser != "" {
+		if fileConfig == nil || fileConfig.Impersonation == nil {
+			checkError(fmt.Errorf("--as requires a profile with impersonation settings"))
+		}
+		if token != "" || tokenCommand != "" {
+			checkError(fmt.Errorf("--as cannot be combined with --token or --token-command"))
+		}
+		fmt.Fprintf(os.Stderr, "Acting as %s\n", asUser)
+	}
+
+	// Connection pool: config file > defaults
+	if fileConfig != nil && fileConfig.Transport != nil {
+		opts, err := transportOptions(fileConfig.Transport)
+		checkError(err)
+		client.SetTransportOptions(opts)
+	}
+
+	// Record/replay: wrap the transport of every client of the run
+	if recordFile != "" && replayFile != "" {
+		checkError(fmt.Errorf("--record and --replay cannot be used together"))
+	}
+	if replayFile != "" {
+		replayer, err := recording.LoadReplayer(replayFile)
+		checkError(err)
+		sessionTransport = replayer
+	} else if recordFile != "" {
+		sessionTransport = recording.NewRecorder(recordFile, client.SharedTransport(), os.Args[1:])
+	}
+	if debugRequests {
+		base := sessionTransport
+		if base == nil {
+			base = client.SharedTransport()
+		}
+		sessionTransport = recording.NewDebugger(base, os.Stderr)
+	}
+	if showTimings {
+		base := sessionTransport
+		if base == nil {
+			base = client.SharedTransport()
+		}
+		sessionTimer = recording.NewTimer(base)
+		sessionTransport = sessionTimer
+	}
+
+	// Request ID: one per invocation, sent with every request
+	if requestID == "" {
+		requestID = os.Getenv("NACOS_CLI_REQUEST_ID")
+	}
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+
+	// Output format, color and pager: command line > config file > defaults
+	applyOutputDefault(cmd)
+	resolveColorMode()
+	if fileConfig != nil && fileConfig.DiffMode != "" {
+		_, err := diff.Get(fileConfig.DiffMode)
+		checkError(err)
+	}
+
+	// Set default server address if still empty
+	if serverAddr == "" {
+		serverAddr = "127.0.0.1:8848"
+	}
+
+	// Guard rails: refuse operations the profile does not allow
+	guardCommand(cmd)
+}
+
+// newNacosClient creates a Nacos client from the resolved global flags.
+// It is the single construction point used by all subcommands and the terminal.
+func newNacosClient() *client.NacosClient {
+	if authType == client.AuthTypeAliyun && (accessKey == "" || secretKey == "") {
+		checkError(fmt.Errorf("auth type aliyun requires --access-key and --secret-key"))
+	}
+	opts := []client.Option{client.InNamespace(namespace)}
+	if asUser != "" {
+		provider, err := impersonationProvider(fileConfig.Impersonation, asUser)
+		checkError(err)
+		opts = append(opts, client.WithTokenProvider(provider))
+	} else if tokenConfig != nil && tokenConfig.Type != "" {
+		provider, err := newTokenProvider(tokenConfig)
+		checkError(err)
+		opts = append(opts, client.WithTokenProvider(provider))
+	} else {
+		opts = append(opts,
+			client.WithAuthType(authType),
+			client.WithAuth(username, password),
+			client.WithAccessKey(accessKey, secretKey))
+		if fileConfig != nil && fileConfig.Signer != "" {
+			signer, err := client.NewSigner(fileConfig.Signer, accessKey, secretKey)
+			checkError(err)
+			opts = append(opts, client.WithSigner(signer))
+		}
+	}
+	if compensateClockSkew {
+		opts = append(opts, client.WithClockSkewCompensation())
+	}
+	hook, err := prePublishHook(fileConfig, profile, serverAddr, username)
+	checkError(err)
+	if hook != nil {
+		opts = append(opts, client.WithPublishHook(hook))
+	}
+	c := client.NewClient(serverAddr, sessionOptions(opts...)...)
+	if fileConfig != nil {
+		c.Cache = configCache(fileConfig.CacheDir)
+	}
+	return c
+}
+
+// clientForConfig creates a client from a config file alone, with the same
+// defaults as the global flags; profileName names it to pre-publish hooks
+func clientForConfig(cfg *config.Config, profileName string) (*client.NacosClient, error) {
+	addr := cfg.GetServerAddr()
+	if addr == "" {
+		addr = "127.0.0.1:8848"
+	}
+	if cfg.TokenProvider != nil && cfg.TokenProvider.Type != "" {
+		provider, err := newTokenProvider(cfg.TokenProvider)
+		if err != nil {
+			return nil, err
+		}
+		opts := []client.Option{client.InNamespace(cfg.Namespace), client.WithTokenProvider(provider)}
+		hook, err := prePublishHook(cfg, profileName, addr, "")
+		if err != nil {
+			return nil, err
+		}
+		if hook != nil {
+			opts = append(opts, client.WithPublishHook(hook))
+		}
+		c := client.NewClient(addr, sessionOptions(opts...)...)
+		c.Cache = configCache(cfg.CacheDir)
+		return c, nil
+	}
+	auth := cfg.AuthType
+	if auth == "" {
+		auth = client.AuthTypeNacos
+		if cfg.AccessKey != "" && cfg.SecretKey != "" {
+			auth = client.AuthTypeAliyun
+		}
+	}
+	if auth == client.AuthTypeAliyun && (cfg.AccessKey == "" || cfg.SecretKey == "") {
+		return nil, fmt.Errorf("auth type aliyun requires accessKey and secretKey")
+	}
+	user, pass := cfg.Username, cfg.Password
+	if user == "" {
+		user = "nacos"
+	}
+	if pass == "" {
+		pass = "nacos"
+	}
+	opts := []client.Option{
+		client.InNamespace(cfg.Namespace),
+		client.WithAuthType(auth),
+		client.WithAuth(user, pass),
+		client.WithAccessKey(cfg.AccessKey, cfg.SecretKey),
+	}
+	if cfg.Signer != "" {
+		signer, err := client.NewSigner(cfg.Signer, cfg.AccessKey, cfg.SecretKey)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.WithSigner(signer))
+	}
+	if cfg.CompensateClockSkew {
+		opts = append(opts, client.WithClockSkewCompensation())
+	}
+	hook, err := prePublishHook(cfg, profileName, addr, user)
+	if err != nil {
+		return nil, err
+	}
+	if hook != nil {
+		opts = append(opts, client.WithPublishHook(hook))
+	}
+	c := client.NewClient(addr, sessionOptions(opts...)...)
+	c.Cache = configCache(cfg.CacheDir)
+	return c, nil
+}
+
+// sessionOptions adds the --record, --replay or --debug transport, the request
+// ID and the --ticket and --message of the run to client options
+func sessionOptions(opts ...client.Option) []client.Option {
+	if requestID != "" {
+		opts = append(opts, client.WithRequestID(requestID))
+	}
+	if sessionTransport != nil {
+		opts = append(opts, client.WithTransport(sessionTransport))
+	}
+	if ticket != "" {
+		opts = append(opts, client.WithTicket(ticket))
+	}
+	if changeMessage != "" {
+		opts = append(opts, client.WithMessage(changeMessage))
+	}
+	return opts
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// configCache returns the content cache in dir, nil when dir is empty
+func configCache(dir string) client.ConfigCache {
+	if dir == "" {
+		return nil
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, dir[1:])
+		}
+	}
+	return &client.DirCache{Dir: dir}
+}
+
+// transportOptions converts the transport section of a config file
+func transportOptions(cfg *config.TransportConfig) (client.TransportOptions, error) {
+	opts := client.TransportOptions{
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		DisableHTTP2:        cfg.DisableHTTP2,
+	}
+	if cfg.IdleTimeout != "" {
+		d, err := time.ParseDuration(cfg.IdleTimeout)
+		if err != nil {
+			return opts, fmt.Errorf("invalid transport.idleTimeout %q: %w", cfg.IdleTimeout, err)
+		}
+		opts.IdleConnTimeout = d
+	}
+	return opts, nil
+}
+
+// impersonationProvider returns the provider of tokens for another user
+func impersonationProvider(cfg *config.ImpersonationConfig, user string) (client.TokenProvider, error) {
+	switch {
+	case cfg.Command != "":
+		return &client.CommandTokenProvider{Command: cfg.Command, Env: []string{"NACOS_CLI_AS_USER=" + user}}, nil
+	case cfg.TokenSecretKey != "":
+		ttl := 30 * time.Minute
+		if cfg.TokenTTL != "" {
+			d, err := time.ParseDuration(cfg.TokenTTL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid impersonation.tokenTtl %q: %w", cfg.TokenTTL, err)
+			}
+			ttl = d
+		}
+		return &client.MintedTokenProvider{Username: user, SecretKey: cfg.TokenSecretKey, TTL: ttl}, nil
+	default:
+		return nil, fmt.Errorf("impersonation requires tokenSecretKey or command")
+	}
+}
+
+// newTokenProvider builds a token provider from its configuration
+func newTokenProvider(cfg *config.TokenProviderConfig) (client.TokenProvider, error) {
+	switch cfg.Type {
+	case "static":
+		return &client.StaticTokenProvider{AccessToken: cfg.Token}, nil
+	case "command":
+		return &client.CommandTokenProvider{Command: cfg.Command}, nil
+	case "oidc":
+		return &client.OIDCDeviceTokenProvider{
+			DeviceAuthURL: cfg.DeviceAuthURL,
+			TokenURL:      cfg.TokenURL,
+			ClientID:      cfg.ClientID,
+			Scope:         cfg.Scope,
+			UseIDToken:    cfg.UseIDToken,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown token provider type: %s (expected static, command or oidc)", cfg.Type)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	clientStatsProbes int
+	clientStatsOutput string
+)
+
+var clientStatsCmd = &cobra.Command{
+	Use:   "cluster-client-stats",
+	Short: "Show the client's view of each server node: latency, errors and health",
+	Long:  help.ClusterClientStats.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(clientStatsOutput)
+		nacosClient := newNacosClient()
+		for i := 0; i < clientStatsProbes; i++ {
+			// Failures are what the stats are about
+			nacosClient.Ping()
+		}
+		stats := nacosClient.NodeStats()
+		if clientStatsOutput == "json" {
+			printJSON(stats)
+			return
+		}
+
+		preferred := nacosClient.PreferredNode()
+		fmt.Println("═══════════════════════════════════════════════════════════════════════════")
+		fmt.Printf("%-2s%-28s %-9s %-9s %-8s %-10s %s\n", "", "Node", "Health", "Requests", "Errors", "Latency", "Last error")
+		fmt.Println("───────────────────────────────────────────────────────────────────────────")
+		for _, s := range stats {
+			mark, health := "", "healthy"
+			if s.Node == preferred && s.Healthy {
+				mark = "*"
+			}
+			if !s.Healthy {
+				health = "failing"
+			}
+			latency := "-"
+			if s.Latency > 0 {
+				latency = s.Latency.Round(100 * time.Microsecond).String()
+			}
+			lastError := ""
+			if s.LastError != "" {
+				lastError = s.LastErrorAt.Format("15:04:05") + " " + s.LastError
+			}
+			fmt.Printf("%-2s%-28s %-9s %-9d %-8s %-10s %s\n", mark, s.Node, health, s.Requests,
+				fmt.Sprintf("%.0f%%", s.ErrorRate()*100), latency, lastError)
+		}
+		fmt.Println("\n* preferred for reads: the healthy node with the lowest latency")
+	},
+}
+
+func init() {
+	clientStatsCmd.Flags().IntVar(&clientStatsProbes, "probes", 5, "Lightweight reads to send to the server before reporting")
+	clientStatsCmd.Flags().StringVarP(&clientStatsOutput, "output", "o", "table", "Output format: table or json")
+	rootCmd.AddCommand(clientStatsCmd)
+}
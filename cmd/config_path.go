@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/yamlutil"
+	"gopkg.in/yaml.v3"
+)
+
+// parseConfigDocument parses a YAML or JSON configuration for --path
+func parseConfigDocument(dataID, contentType, content string) (*yaml.Node, bool, error) {
+	ext := strings.TrimPrefix(filepath.Ext(dataID), ".")
+	switch {
+	case contentType == "properties" || ext == "properties":
+		return nil, false, fmt.Errorf("--path supports YAML and JSON configurations, %s is properties", dataID)
+	case contentType == "xml" || ext == "xml":
+		return nil, false, fmt.Errorf("--path supports YAML and JSON configurations, %s is XML", dataID)
+	}
+	isJSON := contentType == "json" || ext == "json"
+	if contentType == "" && ext != "yaml" && ext != "yml" {
+		trimmed := strings.TrimSpace(content)
+		isJSON = strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+	}
+	doc, err := yamlutil.Parse(content)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %w", dataID, err)
+	}
+	return doc, isJSON, nil
+}
+
+// formatPathValue prints a scalar as is and anything else in the format of the
+// configuration
+func formatPathValue(node *yaml.Node, isJSON bool) (string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, nil
+	}
+	if isJSON {
+		out, err := yamlutil.EncodeJSON(node)
+		return strings.TrimSuffix(out, "\n"), err
+	}
+	out, err := yamlutil.Encode(node)
+	return strings.TrimSuffix(out, "\n"), err
+}
+
+// setConfigAtPath changes one value of a configuration. Only the text of the
+// value is replaced when it is a single-line scalar, so comments and formatting
+// are kept; other changes re-encode the document and are refused when that
+// would reformat it.
+func setConfigAtPath(nacosClient *client.NacosClient, dataID, group, path, value string) error {
+	newValue := yamlutil.ParseValue(value)
+	return rewriteConfig(nacosClient, dataID, group, fmt.Sprintf("%s = %s", path, value), "", false,
+		func(detail *client.ConfigDetail) (string, error) {
+			doc, isJSON, err := parseConfigDocument(dataID, detail.Type, detail.Content)
+			if err != nil {
+				return "", err
+			}
+			encode := documentEncoder(isJSON)
+			before, err := encode(doc)
+			if err != nil {
+				return "", fmt.Errorf("failed to encode %s: %w", dataID, err)
+			}
+			target, err := yamlutil.Lookup(doc, path)
+			if err != nil {
+				return "", err
+			}
+			if target != nil && target.Kind == yaml.ScalarNode && newValue.Tag == "!!str" && newValue.Style == 0 {
+				// Keep the quotes of the replaced string
+				newValue.Style = target.Style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle)
+			}
+			if err := yamlutil.SetPath(doc, path, newValue); err != nil {
+				return "", err
+			}
+			content, err := encode(doc)
+			if err != nil {
+				return "", fmt.Errorf("failed to encode %s: %w", dataID, err)
+			}
+			if target != nil {
+				if edited, ok := yamlutil.ReplaceScalar(detail.Content, target, newValue, isJSON); ok {
+					// Use the edit only if it means what setting the path means
+					if check, _, err := parseConfigDocument(dataID, detail.Type, edited); err == nil {
+						if out, err := encode(check); err == nil && out == content {
+							return edited, nil
+						}
+					}
+				}
+			}
+			if before != detail.Content {
+				return "", fmt.Errorf("cannot set %s in place: %w", path, reformatError(dataID, group))
+			}
+			return content, nil
+		})
+}
+
+// documentEncoder returns the encoder of a configuration parsed by parseConfigDocument
+func documentEncoder(isJSON bool) func(*yaml.Node) (string, error) {
+	if isJSON {
+		return yamlutil.EncodeJSON
+	}
+	return yamlutil.Encode
+}
+
+// modifyConfig changes a YAML or JSON configuration with a read-modify-write
+// that fails rather than overwrite a concurrent change; desc, when set, is
+// recorded with the revision, and with dryRun it only shows the diff. The
+// document is re-encoded, so it is refused unless it is formatted as the
+// encoder writes it.
+func modifyConfig(nacosClient *client.NacosClient, dataID, group, change, desc string, dryRun bool,
+	modify func(doc *yaml.Node) (*yaml.Node, error)) error {
+	return rewriteConfig(nacosClient, dataID, group, change, desc, dryRun, func(detail *client.ConfigDetail) (string, error) {
+		doc, isJSON, err := parseConfigDocument(dataID, detail.Type, detail.Content)
+		if err != nil {
+			return "", err
+		}
+		encode := documentEncoder(isJSON)
+		before, err := encode(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode %s: %w", dataID, err)
+		}
+		if before != detail.Content {
+			return "", reformatError(dataID, group)
+		}
+		doc, err = modify(doc)
+		if err != nil {
+			return "", err
+		}
+		content, err := encode(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode %s: %w", dataID, err)
+		}
+		return content, nil
+	})
+}
+
+// reformatError refuses to publish a re-encoded configuration whose comments,
+// quoting or indentation would change
+func reformatError(dataID, group string) error {
+	return fmt.Errorf("re-encoding %s (%s) would change its formatting or drop comments, edit it with config-edit instead", dataID, group)
+}
+
+// rewriteConfig publishes the content edit returns for a configuration,
+// compare-and-swap against the content it was given
+func rewriteConfig(nacosClient *client.NacosClient, dataID, group, change, desc string, dryRun bool,
+	edit func(detail *client.ConfigDetail) (string, error)) error {
+	detail, err := nacosClient.GetConfigDetail(dataID, group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		return fmt.Errorf("configuration %s (%s) not found, publish it first", dataID, group)
+	}
+	if err != nil {
+		return err
+	}
+	content, err := edit(detail)
+	if err != nil {
+		return err
+	}
+	if content == detail.Content {
+		fmt.Printf("%s (%s) is unchanged by %s, nothing to publish\n", dataID, group, change)
+		return nil
+	}
+	if dryRun {
+		out, err := diff.Diff("line",
+			diff.Document{Name: fmt.Sprintf("%s/%s (current)", group, dataID), Content: detail.Content},
+			diff.Document{Name: fmt.Sprintf("%s/%s (patched)", group, dataID), Content: content},
+			diff.Options{Color: useColor()})
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+		return nil
+	}
+
+	fmt.Printf("Publishing config: %s (%s), %s...\n", dataID, group, change)
+	opts := client.PublishOptions{CasMd5: listener.CalculateMD5(detail.Content), Type: detail.Type, Tags: detail.Tags(), Desc: desc}
+	if err := nacosClient.PublishConfigWithOptions(dataID, group, content, opts); err != nil {
+		if errors.Is(err, client.ErrCasMismatch) {
+			return fmt.Errorf("%s (%s) changed while it was being updated, run again: %w", dataID, group, err)
+		}
+		return err
+	}
+	fmt.Println("Configuration published successfully")
+	return nil
+}
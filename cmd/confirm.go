@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// confirmByTyping asks the user to retype the resource name before a destructive
+// operation, like GitHub repository deletion. It succeeds immediately when force
+// is set, and refuses to proceed when stdin is not interactive.
+func confirmByTyping(action, resourceName string, force bool) error {
+	if force {
+		return nil
+	}
+	if !isInteractive() {
+		return fmt.Errorf("refusing to %s without confirmation in non-interactive mode (use --force)", action)
+	}
+
+	fmt.Fprintf(os.Stderr, "\033[31mWarning:\033[0m this will %s.\n", action)
+	fmt.Fprintf(os.Stderr, "Type \033[1m%s\033[0m to confirm: ", resourceName)
+
+	answer, err := readLine()
+	if err != nil {
+		return fmt.Errorf("read confirmation: %w", err)
+	}
+	if answer != resourceName {
+		return fmt.Errorf("confirmation did not match %q, aborted", resourceName)
+	}
+	return nil
+}
+
+// confirmYesNo asks a y/N question. It returns true immediately when assumeYes is set
+// and false when stdin is not interactive.
+func confirmYesNo(question string, assumeYes bool) bool {
+	if assumeYes {
+		return true
+	}
+	if !isInteractive() {
+		return false
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, err := readLine()
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes"
+}
+
+// isInteractive reports whether stdin is a terminal
+func isInteractive() bool {
+	return isTerminal(os.Stdin)
+}
+
+// isTerminal reports whether the file is a character device (a terminal)
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// readLine reads a single trimmed line from stdin
+func readLine() (string, error) {
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
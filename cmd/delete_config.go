@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/recyclebin"
+	"github.com/nov11/nacos-cli/internal/selector"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteGroup       string
+	deletePattern     string
+	deleteYes         bool
+	deleteConcurrency int
+	deleteNoRecycle   bool
+)
+
+var deleteConfigCmd = &cobra.Command{
+	Use:   "config-delete [dataId...]",
+	Short: "Delete configurations by data ID or pattern, after a preview",
+	Long:  help.ConfigDelete.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		matching := deletePattern != "" || selectorExpr != ""
+		if (len(args) == 0) == !matching {
+			checkError(fmt.Errorf("give either data IDs or --pattern/--selector"))
+		}
+		group := deleteGroup
+		if group == "" && deletePattern == "" && selectorExpr != "" {
+			// The selector usually names the groups
+			group = "*"
+		}
+		if group != "*" {
+			group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+		} else if !matching {
+			checkError(fmt.Errorf("--group * requires --pattern or --selector"))
+		}
+		sel := parseSelector()
+		nacosClient := newNacosClient()
+		var bin recyclebin.Bin
+		if !deleteNoRecycle {
+			var err error
+			bin, err = openRecycleBin(nacosClient)
+			checkError(err)
+		}
+
+		refs, missing, err := deleteTargets(nacosClient, args, group, sel)
+		checkError(err)
+		for _, ref := range missing {
+			fmt.Printf("  %-8s %s\n", "not found", configtree.Key(ref.Group, ref.DataID))
+		}
+		if len(refs) == 0 {
+			fmt.Println("No matching configurations")
+			return
+		}
+
+		fmt.Printf("The following %d configuration(s) in namespace %s will be deleted:\n", len(refs), nacosClient.Namespace)
+		for _, ref := range refs {
+			fmt.Printf("  %s\n", configtree.Key(ref.Group, ref.DataID))
+		}
+		fmt.Println()
+		checkError(checkChangeBudget(changeBudget(fileConfig), nacosClient.Namespace, len(refs)))
+		if !deleteYes && !isInteractive() {
+			checkError(fmt.Errorf("refusing to delete without confirmation in non-interactive mode (use --yes)"))
+		}
+		checkError(confirmByTyping(fmt.Sprintf("delete %d configuration(s)", len(refs)), nacosClient.Namespace, deleteYes))
+
+		errs := deleteConfigs(nacosClient, bin, refs, deleteConcurrency)
+		failed := 0
+		for i, ref := range refs {
+			if errs[i] != nil {
+				failed++
+				fmt.Printf("  %-8s %s: %v\n", "error", configtree.Key(ref.Group, ref.DataID), errs[i])
+				continue
+			}
+			fmt.Printf("  %-8s %s\n", "deleted", configtree.Key(ref.Group, ref.DataID))
+		}
+		fmt.Printf("\nDeleted: %d, Failed: %d", len(refs)-failed, failed)
+		if len(missing) > 0 {
+			fmt.Printf(", Not found: %d", len(missing))
+		}
+		fmt.Println()
+		if bin != nil && failed < len(refs) {
+			fmt.Printf("Kept in the recycle bin %s, use config-restore-deleted to restore\n", bin.Describe())
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// deleteTargets resolves the data IDs, or --pattern and the selector, to
+// existing configurations. Data IDs given explicitly that do not exist are
+// returned as missing.
+func deleteTargets(nacosClient *client.NacosClient, dataIDs []string, group string, sel *selector.Selector) (refs, missing []configRef, err error) {
+	if len(dataIDs) > 0 {
+		for _, dataID := range dataIDs {
+			ref := configRef{Group: group, DataID: dataID}
+			_, err := nacosClient.GetConfig(dataID, group)
+			if errors.Is(err, client.ErrConfigNotFound) {
+				missing = append(missing, ref)
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			refs = append(refs, ref)
+		}
+		return refs, missing, nil
+	}
+
+	listGroup := group
+	if listGroup == "*" {
+		listGroup = ""
+	}
+	items, err := nacosClient.ListAllConfigs(deletePattern, listGroup)
+	if err != nil {
+		return nil, nil, err
+	}
+	if items, err = selectConfigs(nacosClient, sel, items, deleteConcurrency); err != nil {
+		return nil, nil, err
+	}
+	for _, item := range items {
+		if group == "*" && isInternalGroup(item.GroupName) {
+			continue
+		}
+		refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+	}
+	return refs, nil, nil
+}
+
+// deleteConfigs deletes configurations concurrently, with at most concurrency
+// requests in flight, first keeping each one in bin unless it is nil. A
+// configuration that cannot be kept is not deleted. Errors are in the order of refs.
+func deleteConfigs(nacosClient *client.NacosClient, bin recyclebin.Bin, refs []configRef, concurrency int) []error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, len(refs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		go func(i int, ref configRef) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if bin != nil {
+				if err := keepDeleted(nacosClient, bin, ref); err != nil {
+					errs[i] = err
+					return
+				}
+			}
+			errs[i] = nacosClient.DeleteConfig(ref.DataID, ref.Group)
+		}(i, ref)
+	}
+	wg.Wait()
+	return errs
+}
+
+func init() {
+	deleteConfigCmd.Flags().StringVar(&deleteGroup, "group", "", "Configuration group name or alias, * for all groups with --pattern (default: DEFAULT_GROUP, all groups with --selector only)")
+	deleteConfigCmd.Flags().StringVar(&deletePattern, "pattern", "", "Delete the configurations whose data ID matches (supports wildcard *)")
+	deleteConfigCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Delete without asking for confirmation")
+	deleteConfigCmd.Flags().BoolVar(&deleteNoRecycle, "no-recycle-bin", false, "Delete without keeping a copy in the recycle bin")
+	deleteConfigCmd.Flags().IntVar(&deleteConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addSelectorFlag(deleteConfigCmd)
+	rootCmd.AddCommand(deleteConfigCmd)
+}
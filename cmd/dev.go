@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devDir      string
+	devDelete   bool
+	devDebounce time.Duration
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Watch a directory tree and publish local edits immediately",
+	Long:  help.Dev.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := filepath.Abs(devDir)
+		checkError(err)
+		entries, err := configtree.Walk(dir)
+		checkError(err)
+
+		nacosClient := newNacosClient()
+
+		watcher, err := fsnotify.NewWatcher()
+		checkError(err)
+		defer watcher.Close()
+		checkError(watcher.Add(dir))
+		groups, err := os.ReadDir(dir)
+		checkError(err)
+		for _, g := range groups {
+			if g.IsDir() && !skipDevName(g.Name()) {
+				checkError(watcher.Add(filepath.Join(dir, g.Name())))
+			}
+		}
+
+		// Initial sync, so the namespace matches the directory before watching
+		fmt.Printf("Syncing %d file(s) to namespace %s...\n", len(entries), nacosClient.Namespace)
+		refs := make([]configRef, len(entries))
+		for i, entry := range entries {
+			refs[i] = configRef{Group: entry.Group, DataID: entry.DataID}
+		}
+		remotes, errs := fetchConfigs(nacosClient, refs, 8)
+		published := make(map[string]string) // key -> MD5 on the server
+		for i, entry := range entries {
+			action, err := pushEntry(nacosClient, entry, remotes[i], errs[i])
+			if action != "unchanged" {
+				printDevResult(action, entry.Key(), err)
+			}
+			if err == nil {
+				if data, rerr := os.ReadFile(entry.Path); rerr == nil {
+					published[entry.Key()] = listener.CalculateMD5(string(data))
+				}
+			}
+		}
+		fmt.Printf("\nWatching %s for changes (Ctrl+C to stop)...\n", dir)
+
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+		// Editors emit several events per save; publish once the file is quiet
+		if devDebounce < 10*time.Millisecond {
+			devDebounce = 10 * time.Millisecond
+		}
+		pending := make(map[string]time.Time)
+		ticker := time.NewTicker(devDebounce / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sigCh:
+				fmt.Println("\nStopped")
+				return
+			case err := <-watcher.Errors:
+				fmt.Fprintf(os.Stderr, "Warning: watch error: %v\n", err)
+			case event := <-watcher.Events:
+				rel, err := filepath.Rel(dir, event.Name)
+				if err != nil {
+					continue
+				}
+				parts := strings.Split(rel, string(filepath.Separator))
+				if skipDevName(parts[len(parts)-1]) {
+					continue
+				}
+				if len(parts) == 1 {
+					// New group directory
+					if event.Op&fsnotify.Create != 0 {
+						if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+							if err := watcher.Add(event.Name); err != nil {
+								fmt.Fprintf(os.Stderr, "Warning: watch %s: %v\n", rel, err)
+							}
+						}
+					}
+					continue
+				}
+				if len(parts) == 2 {
+					pending[event.Name] = time.Now()
+				}
+			case now := <-ticker.C:
+				for path, at := range pending {
+					if now.Sub(at) < devDebounce {
+						continue
+					}
+					delete(pending, path)
+					devPublish(nacosClient, dir, path, published)
+				}
+			}
+		}
+	},
+}
+
+// devPublish publishes (or deletes, with --delete) the configuration of a changed file
+func devPublish(nacosClient *client.NacosClient, dir, path string, published map[string]string) {
+	rel, _ := filepath.Rel(dir, path)
+	group, dataID := filepath.Split(rel)
+	group = filepath.Clean(group)
+	key := configtree.Key(group, dataID)
+	stamp := time.Now().Format("15:04:05")
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if _, ok := published[key]; !ok || !devDelete {
+			return
+		}
+		if err := nacosClient.DeleteConfig(dataID, group); err != nil {
+			printDevResult("error", stamp+" "+key, err)
+			return
+		}
+		delete(published, key)
+		printDevResult("delete", stamp+" "+key, nil)
+		return
+	}
+	if err != nil {
+		printDevResult("error", stamp+" "+key, err)
+		return
+	}
+
+	content := string(data)
+	md5 := listener.CalculateMD5(content)
+	if published[key] == md5 {
+		return
+	}
+	action := "update"
+	if _, ok := published[key]; !ok {
+		action = "create"
+	}
+	opts := client.PublishOptions{Type: configtree.TypeOf(dataID)}
+	if err := nacosClient.PublishConfigWithOptions(dataID, group, content, opts); err != nil {
+		printDevResult("error", stamp+" "+key, err)
+		return
+	}
+	published[key] = md5
+	printDevResult(action, stamp+" "+key, nil)
+}
+
+// skipDevName reports whether a file is hidden or an editor backup/swap file
+func skipDevName(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") ||
+		strings.HasSuffix(name, ".swp") || strings.HasSuffix(name, ".tmp")
+}
+
+func printDevResult(action, what string, err error) {
+	if err != nil {
+		fmt.Printf("  %-10s %s: %v\n", action, what, err)
+		return
+	}
+	fmt.Printf("  %-10s %s\n", action, what)
+}
+
+func init() {
+	devCmd.Flags().StringVarP(&devDir, "dir", "d", ".", "Directory laid out as <group>/<dataId>")
+	devCmd.Flags().BoolVar(&devDelete, "delete", false, "Delete configurations whose files are removed")
+	devCmd.Flags().DurationVar(&devDebounce, "debounce", 300*time.Millisecond, "Wait for a file to be quiet this long before publishing")
+	rootCmd.AddCommand(devCmd)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffConfigFile string
+	diffMode       string
+	diffExitCode   bool
+)
+
+var diffConfigCmd = &cobra.Command{
+	Use:   "config-diff [dataId] [group]",
+	Short: "Compare a configuration with a local file",
+	Long:  help.ConfigDiff.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID := args[0]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+
+		if diffConfigFile == "" {
+			fmt.Fprintf(os.Stderr, "Error: --file is required\n")
+			os.Exit(1)
+		}
+		engine, err := diff.Get(resolveDiffMode(cmd))
+		checkError(err)
+
+		local, err := os.ReadFile(diffConfigFile)
+		checkError(err)
+
+		nacosClient := newNacosClient()
+		remote, err := nacosClient.GetConfig(dataID, group)
+		checkError(err)
+
+		output, err := engine.Diff(
+			diff.Document{Name: fmt.Sprintf("%s/%s (server)", group, dataID), Content: remote, Format: dataID},
+			diff.Document{Name: diffConfigFile, Content: string(local), Format: dataID},
+			diff.Options{Color: useColor()},
+		)
+		checkError(err)
+
+		startPager()
+		if output == "" {
+			fmt.Println("No differences")
+			return
+		}
+		fmt.Print(output)
+		if diffExitCode {
+			stopPager()
+			os.Exit(1)
+		}
+	},
+}
+
+// addDiffModeFlag adds --diff-mode to a command showing diffs
+func addDiffModeFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&diffMode, "diff-mode", "line", "Diff mode: line, word, or semantic (default: diffMode of the profile, else line)")
+}
+
+// resolveDiffMode returns --diff-mode, else the diffMode of the profile, else
+// line, after checking that the mode exists
+func resolveDiffMode(cmd *cobra.Command) string {
+	mode := diffMode
+	if !cmd.Flags().Changed("diff-mode") && fileConfig != nil && fileConfig.DiffMode != "" {
+		mode = fileConfig.DiffMode
+	}
+	_, err := diff.Get(mode)
+	checkError(err)
+	return mode
+}
+
+func init() {
+	diffConfigCmd.Flags().StringVarP(&diffConfigFile, "file", "f", "", "Local file to compare with the server content")
+	addDiffModeFlag(diffConfigCmd)
+	diffConfigCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Exit with code 1 when there are differences")
+	rootCmd.AddCommand(diffConfigCmd)
+}
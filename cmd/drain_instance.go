@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	drainIP            string
+	drainMode          string
+	drainGrace         time.Duration
+	drainAllNamespaces bool
+	drainDryRun        bool
+	drainYes           bool
+	drainConcurrency   int
+)
+
+// hostInstance is an instance registered from the drained host
+type hostInstance struct {
+	client    *client.NacosClient
+	Namespace string
+	Group     string
+	Service   string
+	Instance  client.Instance
+}
+
+func (h hostInstance) String() string {
+	return fmt.Sprintf("%s/%s@@%s %s:%d (cluster %s)", namespaceLabel(h.Namespace), h.Group, h.Service,
+		h.Instance.IP, h.Instance.Port, h.Instance.ClusterName)
+}
+
+var drainInstanceCmd = &cobra.Command{
+	Use:   "instance-drain",
+	Short: "Drain and deregister every service instance registered from a host",
+	Long:  help.InstanceDrain.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if drainIP == "" {
+			fmt.Fprintf(os.Stderr, "Error: --ip is required\n")
+			os.Exit(1)
+		}
+		ip, port := drainIP, 0
+		if host, p, err := net.SplitHostPort(drainIP); err == nil {
+			if port, err = strconv.Atoi(p); err != nil {
+				checkError(fmt.Errorf("invalid --ip %q: %w", drainIP, err))
+			}
+			ip = host
+		}
+		if drainMode != "disable" && drainMode != "weight" {
+			checkError(fmt.Errorf("invalid --mode %q (expected disable or weight)", drainMode))
+		}
+
+		nacosClient := newNacosClient()
+		namespaces := []string{nacosClient.Namespace}
+		if drainAllNamespaces {
+			list, err := nacosClient.ListNamespaces()
+			checkError(err)
+			namespaces = namespaces[:0]
+			for _, ns := range list {
+				namespaces = append(namespaces, ns.Namespace)
+			}
+		}
+		var targets []hostInstance
+		counts := make(map[string]int)
+		for _, ns := range namespaces {
+			found, err := findHostInstances(nacosClient.WithNamespace(ns), ip, port, drainConcurrency)
+			checkError(err)
+			counts[ns] = len(found)
+			targets = append(targets, found...)
+		}
+		if len(targets) == 0 {
+			fmt.Printf("No instances are registered from %s\n", drainIP)
+			return
+		}
+
+		fmt.Printf("The following %d instance(s) registered from %s will be drained (%s) and deregistered:\n", len(targets), drainIP, drainMode)
+		for _, t := range targets {
+			fmt.Printf("  %s\n", t)
+		}
+		fmt.Println()
+		if drainDryRun {
+			fmt.Println("Dry run, nothing changed")
+			return
+		}
+		for _, ns := range namespaces {
+			checkError(checkChangeBudget(changeBudget(fileConfig), ns, counts[ns]))
+		}
+		if !drainYes && !isInteractive() {
+			checkError(fmt.Errorf("refusing to drain without confirmation in non-interactive mode (use --yes)"))
+		}
+		checkError(confirmByTyping(fmt.Sprintf("drain and deregister %d instance(s)", len(targets)), drainIP, drainYes))
+
+		drained := runOnInstances(targets, drainConcurrency, "drained", func(t hostInstance) error {
+			inst := t.Instance
+			if drainMode == "weight" {
+				inst.Weight = 0
+			} else {
+				inst.Enabled = false
+			}
+			return t.client.UpdateInstance(t.Service, t.Group, inst)
+		})
+		if len(drained) > 0 && drainGrace > 0 {
+			fmt.Printf("\nWaiting %s for clients to stop sending traffic...\n\n", drainGrace)
+			time.Sleep(drainGrace)
+		}
+		deregistered := runOnInstances(drained, drainConcurrency, "removed", func(t hostInstance) error {
+			return t.client.DeregisterInstance(t.Service, t.Group, t.Instance)
+		})
+		fmt.Printf("\nDeregistered: %d, Failed: %d\n", len(deregistered), len(targets)-len(deregistered))
+		if len(deregistered) < len(targets) {
+			os.Exit(1)
+		}
+	},
+}
+
+// findHostInstances returns the instances of every service of the namespace
+// registered from ip, and port unless it is 0
+func findHostInstances(nacosClient *client.NacosClient, ip string, port, concurrency int) ([]hostInstance, error) {
+	services, err := nacosClient.ListAllServices("", "")
+	if err != nil {
+		return nil, err
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	found := make([][]hostInstance, len(services))
+	errs := make([]error, len(services))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, svc := range services {
+		wg.Add(1)
+		go func(i int, svc client.Service) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			instances, err := nacosClient.ListInstances(svc.Name, svc.GroupName, "")
+			if err != nil {
+				errs[i] = fmt.Errorf("%s@@%s: %w", svc.GroupName, svc.Name, err)
+				return
+			}
+			for _, inst := range instances {
+				if inst.IP == ip && (port == 0 || inst.Port == port) {
+					found[i] = append(found[i], hostInstance{nacosClient, nacosClient.Namespace, svc.GroupName, svc.Name, inst})
+				}
+			}
+		}(i, svc)
+	}
+	wg.Wait()
+	var all []hostInstance
+	for i := range services {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		all = append(all, found[i]...)
+	}
+	return all, nil
+}
+
+// runOnInstances applies change to the instances concurrently, printing the
+// outcome of each, and returns those it succeeded on in their order
+func runOnInstances(targets []hostInstance, concurrency int, done string, change func(hostInstance) error) []hostInstance {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, len(targets))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t hostInstance) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = change(t)
+		}(i, t)
+	}
+	wg.Wait()
+	var succeeded []hostInstance
+	for i, t := range targets {
+		if errs[i] != nil {
+			fmt.Printf("  %-8s %s: %v\n", "error", t, errs[i])
+			continue
+		}
+		fmt.Printf("  %-8s %s\n", done, t)
+		succeeded = append(succeeded, t)
+	}
+	return succeeded
+}
+
+func init() {
+	drainInstanceCmd.Flags().StringVar(&drainIP, "ip", "", "IP of the host to drain, or ip:port to drain the instances on one port")
+	drainInstanceCmd.Flags().StringVar(&drainMode, "mode", "disable", "How to drain: disable the instances, or set their weight to 0")
+	drainInstanceCmd.Flags().DurationVar(&drainGrace, "grace", 30*time.Second, "Time to wait between draining and deregistering")
+	drainInstanceCmd.Flags().BoolVar(&drainAllNamespaces, "all-namespaces", false, "Drain the instances of every namespace instead of the current one")
+	drainInstanceCmd.Flags().BoolVar(&drainDryRun, "dry-run", false, "List the instances without changing them")
+	drainInstanceCmd.Flags().BoolVarP(&drainYes, "yes", "y", false, "Drain without asking for confirmation")
+	drainInstanceCmd.Flags().IntVar(&drainConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	rootCmd.AddCommand(drainInstanceCmd)
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/lock"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editLockTTL time.Duration
+	editNoLock  bool
+)
+
+var editConfigCmd = &cobra.Command{
+	Use:   "config-edit [dataId] [group]",
+	Short: "Edit a configuration in $EDITOR and publish it",
+	Long:  help.ConfigEdit.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID := args[0]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+		mode := resolveDiffMode(cmd)
+
+		nacosClient := newNacosClient()
+
+		var l *lock.Lock
+		if !editNoLock {
+			var err error
+			l, err = lock.Acquire(nacosClient, group, dataID, lock.DefaultHolder(), editLockTTL)
+			checkError(err)
+		}
+
+		err := editConfig(nacosClient, dataID, group, mode)
+		if l != nil {
+			if rerr := l.Release(); rerr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", rerr)
+			}
+		}
+		checkError(err)
+	},
+}
+
+// editConfig opens the configuration in the editor and publishes the result,
+// previewing the edit as a diff in mode. The publish is conditional on the
+// content fetched, so edits made without the lock are not overwritten.
+func editConfig(nacosClient *client.NacosClient, dataID, group, mode string) error {
+	original, err := nacosClient.GetConfig(dataID, group)
+	if err != nil && !errors.Is(err, client.ErrConfigNotFound) {
+		return err
+	}
+
+	tempDir, err := os.MkdirTemp("", "nacos-cli-edit-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	// Keep the data ID as file name so that editors pick the right syntax
+	path := filepath.Join(tempDir, filepath.Base(dataID))
+	if err := os.WriteFile(path, []byte(original), 0600); err != nil {
+		return err
+	}
+
+	if err := runEditor(path); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	edited := string(data)
+	if edited == original {
+		fmt.Println("No changes")
+		return nil
+	}
+	if strings.TrimSpace(edited) == "" {
+		return fmt.Errorf("edited content is empty, not publishing")
+	}
+
+	preview, err := diff.Diff(mode,
+		diff.Document{Name: fmt.Sprintf("%s/%s (server)", group, dataID), Content: original, Format: dataID},
+		diff.Document{Name: fmt.Sprintf("%s/%s (edited)", group, dataID), Content: edited, Format: dataID},
+		diff.Options{Color: useColor()})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: cannot show the %s diff: %v\n", mode, err)
+	}
+	fmt.Print(preview)
+
+	opts := client.PublishOptions{}
+	if original != "" {
+		opts.CasMd5 = listener.CalculateMD5(original)
+	}
+	fmt.Printf("Publishing config: %s (%s)...\n", dataID, group)
+	if err := nacosClient.PublishConfigWithOptions(dataID, group, edited, opts); err != nil {
+		if errors.Is(err, client.ErrCasMismatch) {
+			saved := filepath.Join(os.TempDir(), fmt.Sprintf("%s.%d", filepath.Base(dataID), time.Now().Unix()))
+			if werr := os.WriteFile(saved, data, 0600); werr == nil {
+				return fmt.Errorf("%w while editing; your version was saved to %s", client.ErrCasMismatch, saved)
+			}
+		}
+		return err
+	}
+	fmt.Println("Configuration published successfully")
+	return nil
+}
+
+// runEditor runs $VISUAL or $EDITOR (default vi, or notepad on Windows) on path
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// The editor variable may carry arguments, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", editor, err)
+	}
+	return nil
+}
+
+func init() {
+	editConfigCmd.Flags().DurationVar(&editLockTTL, "lock-ttl", lock.DefaultTTL, "Lock lifetime without renewal (renewed while editing)")
+	editConfigCmd.Flags().BoolVar(&editNoLock, "no-lock", false, "Edit without taking the advisory lock")
+	addDiffModeFlag(editConfigCmd)
+	rootCmd.AddCommand(editConfigCmd)
+}
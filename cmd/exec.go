@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configenv"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/supervisor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execDataIDs       []string
+	execGroup         string
+	execPrefix        string
+	execAsFile        bool
+	execResolveRefs   bool
+	execWatch         bool
+	execRestartSignal string
+	execMaxRestarts   int
+	execBackoff       time.Duration
+	execMaxBackoff    time.Duration
+	execPollTimeout   time.Duration
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec --data-id <dataId> [--data-id <dataId>...] -- <command> [args...]",
+	Short: "Run a command with configurations injected as environment variables",
+	Long:  help.Exec.FormatForCLI("nacos-cli"),
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(execDataIDs) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: at least one --data-id is required\n")
+			os.Exit(1)
+		}
+		if !execAsFile {
+			for _, dataID := range execDataIDs {
+				if !configenv.IsSupported(dataID) {
+					checkError(fmt.Errorf("cannot convert %s to environment variables (supported: .properties, .env, .yaml, .yml, .json); use --as-file", dataID))
+				}
+			}
+		}
+
+		execGroup = resolveGroup(cmd, execGroup, "DEFAULT_GROUP")
+
+		var reloadSignal os.Signal
+		if execRestartSignal != "restart" {
+			sig, err := supervisor.ParseSignal(execRestartSignal)
+			checkError(err)
+			reloadSignal = sig
+			if !execAsFile {
+				fmt.Fprintf(os.Stderr, "Warning: environment variables of a running process cannot change, use --as-file with --restart-signal %s\n", execRestartSignal)
+			}
+		}
+
+		nacosClient := newNacosClient()
+
+		tempDir := ""
+		if execAsFile {
+			dir, err := os.MkdirTemp("", "nacos-cli-exec-")
+			checkError(err)
+			tempDir = dir
+		}
+
+		env, md5s, err := buildExecEnv(nacosClient, tempDir)
+		if err != nil {
+			os.RemoveAll(tempDir)
+			checkError(err)
+		}
+
+		var exitCode int
+		if execWatch {
+			exitCode = superviseChild(nacosClient, args, env, md5s, tempDir, reloadSignal)
+		} else {
+			exitCode = runChild(args, env)
+		}
+		if tempDir != "" {
+			os.RemoveAll(tempDir)
+		}
+		os.Exit(exitCode)
+	},
+}
+
+// buildExecEnv fetches the configured data IDs and converts them to environment variables.
+// When tempDir is set, each configuration is written to a file there and referenced by
+// a NACOS_CONFIG_<DATAID> variable instead. It also returns the MD5 of each config.
+func buildExecEnv(nacosClient *client.NacosClient, tempDir string) (map[string]string, map[string]string, error) {
+	env := make(map[string]string)
+	md5s := make(map[string]string)
+	for _, dataID := range execDataIDs {
+		content, err := nacosClient.GetConfig(dataID, execGroup)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetch %s (%s): %w", dataID, execGroup, err)
+		}
+		md5s[dataID] = listener.CalculateMD5(content)
+		if execResolveRefs {
+			if content, err = resolveConfigRefs(nacosClient, dataID, execGroup, content); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if tempDir != "" {
+			filePath := filepath.Join(tempDir, filepath.Base(dataID))
+			if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+				return nil, nil, fmt.Errorf("write %s: %w", filePath, err)
+			}
+			env[configenv.EnvName("NACOS_CONFIG_", dataID)] = filePath
+			continue
+		}
+
+		vars, err := configenv.ToEnv(dataID, content, execPrefix)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w (use --as-file to pass it as a file)", err)
+		}
+		for key, value := range vars {
+			env[key] = value
+		}
+	}
+	return env, md5s, nil
+}
+
+// runChild runs the command with the extra environment, forwarding signals,
+// and returns its exit code
+func runChild(args []string, env map[string]string) int {
+	child := exec.Command(args[0], args[1:]...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	child.Env = append(os.Environ(), configenv.Environ(env)...)
+
+	if err := child.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: start %s: %v\n", args[0], err)
+		return 127
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		for sig := range sigCh {
+			child.Process.Signal(sig)
+		}
+	}()
+
+	if err := child.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// superviseChild runs the command under a supervisor that reacts to config changes:
+// the child is restarted with a fresh environment, or signaled after its files are rewritten
+func superviseChild(nacosClient *client.NacosClient, args []string, env, md5s map[string]string, tempDir string, reloadSignal os.Signal) int {
+	var items []listener.ConfigItem
+	for _, dataID := range execDataIDs {
+		items = append(items, listener.ConfigItem{
+			DataID: dataID,
+			Group:  execGroup,
+			Tenant: nacosClient.Namespace,
+			MD5:    md5s[dataID],
+		})
+	}
+
+	changes := make(chan struct{}, 1)
+	handler := func(dataID, group, tenant string) error {
+		if reloadSignal != nil && tempDir != "" {
+			// Rewrite the files before signaling so the child reloads the new content
+			if _, _, err := buildExecEnv(nacosClient, tempDir); err != nil {
+				return err
+			}
+		}
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+
+	configListener := listener.NewConfigListener(nacosClient.ServerAddr, nacosClient.Username, nacosClient.Password)
+	configListener.SetAccessToken(nacosClient.AccessToken())
+	configListener.SetTransport(nacosClient.Transport())
+	configListener.SetPollTimeout(execPollTimeout)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go configListener.StartListening(items, handler, stopCh)
+
+	// The initial environment is already fetched, later starts fetch it again
+	initial := configenv.Environ(env)
+	sup := &supervisor.Supervisor{
+		Args: args,
+		Prepare: func() ([]string, error) {
+			if initial != nil {
+				prepared := initial
+				initial = nil
+				return prepared, nil
+			}
+			fresh, _, err := buildExecEnv(nacosClient, tempDir)
+			if err != nil {
+				return nil, err
+			}
+			return configenv.Environ(fresh), nil
+		},
+		ReloadSignal: reloadSignal,
+		MaxRestarts:  execMaxRestarts,
+		Backoff:      execBackoff,
+		MaxBackoff:   execMaxBackoff,
+		StopTimeout:  10 * time.Second,
+	}
+	return sup.Run(changes)
+}
+
+func init() {
+	execCmd.Flags().SetInterspersed(false)
+	execCmd.Flags().StringArrayVar(&execDataIDs, "data-id", nil, "Configuration data ID to inject (repeatable)")
+	execCmd.Flags().StringVar(&execGroup, "group", "", "Configuration group (default: DEFAULT_GROUP)")
+	execCmd.Flags().StringVar(&execPrefix, "prefix", "", "Prefix for generated variable names (e.g. APP_)")
+	execCmd.Flags().BoolVar(&execAsFile, "as-file", false, "Write configs to temp files referenced by NACOS_CONFIG_<DATAID> instead")
+	execCmd.Flags().BoolVar(&execResolveRefs, "resolve-refs", false, "Replace ${nacos:[GROUP/]dataId[:key]} references before injecting")
+	execCmd.Flags().BoolVar(&execWatch, "watch", false, "Supervise the command and react to config changes")
+	execCmd.Flags().StringVar(&execRestartSignal, "restart-signal", "restart", "On change: 'restart' or a signal to send (e.g. SIGHUP)")
+	execCmd.Flags().IntVar(&execMaxRestarts, "max-restarts", 5, "Crash restarts allowed with --watch (-1 for unlimited)")
+	execCmd.Flags().DurationVar(&execBackoff, "restart-backoff", time.Second, "Initial delay before restarting a crashed command")
+	execCmd.Flags().DurationVar(&execMaxBackoff, "max-backoff", 30*time.Second, "Maximum delay between crash restarts")
+	execCmd.Flags().DurationVar(&execPollTimeout, "poll-timeout", listener.DefaultPollTimeout, "How long the server may hold a long poll open with --watch")
+	rootCmd.AddCommand(execCmd)
+}
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	flagConfig  string
+	flagGroup   string
+	flagOutput  string
+	flagRollout int
+	flagDryRun  bool
+)
+
+// featureFlag is a flag of the flags config:
+//
+//	flags:
+//	  newCheckout:
+//	    enabled: true
+//	    rollout: 25        # optional percentage
+//	    description: New checkout flow
+//	  darkMode: false      # shorthand for enabled
+type featureFlag struct {
+	Name        string `json:"name"`
+	Enabled     bool   `json:"enabled"`
+	Rollout     *int   `json:"rollout,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+var flagListCmd = &cobra.Command{
+	Use:   "flag-list",
+	Short: "List the feature flags of a flags configuration",
+	Long:  help.FlagList.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(flagOutput)
+		resolveFlagsConfig(cmd)
+		nacosClient := newNacosClient()
+		detail, err := nacosClient.GetConfigDetail(flagConfig, flagGroup)
+		if errors.Is(err, client.ErrConfigNotFound) {
+			checkError(fmt.Errorf("flags configuration %s (%s) not found", flagConfig, flagGroup))
+		}
+		checkError(err)
+		doc, _, err := parseConfigDocument(flagConfig, detail.Type, detail.Content)
+		checkError(err)
+		_, flags, err := parseFeatureFlags(doc)
+		checkError(err)
+		sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+
+		if flagOutput == "json" {
+			printJSON(flags)
+			return
+		}
+		fmt.Printf("%s (%s): %d flag(s)\n", flagConfig, flagGroup, len(flags))
+		if len(flags) == 0 {
+			return
+		}
+		fmt.Println("═══════════════════════════════════════════════════════════════")
+		fmt.Printf("%-30s %-9s %-8s %s\n", "Flag", "Enabled", "Rollout", "Description")
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		for _, f := range flags {
+			rollout := "-"
+			if f.Rollout != nil {
+				rollout = strconv.Itoa(*f.Rollout) + "%"
+			}
+			fmt.Printf("%-30s %-9t %-8s %s\n", f.Name, f.Enabled, rollout, f.Description)
+		}
+	},
+}
+
+var flagEnableCmd = &cobra.Command{
+	Use:   "flag-enable NAME",
+	Short: "Enable a feature flag, optionally for a percentage of users",
+	Long:  help.FlagEnable.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var rollout *int
+		if cmd.Flags().Changed("rollout") {
+			if flagRollout < 0 || flagRollout > 100 {
+				checkError(fmt.Errorf("--rollout must be between 0 and 100"))
+			}
+			rollout = &flagRollout
+		}
+		setFeatureFlag(cmd, args[0], true, rollout)
+	},
+}
+
+var flagDisableCmd = &cobra.Command{
+	Use:   "flag-disable NAME",
+	Short: "Disable a feature flag",
+	Long:  help.FlagDisable.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setFeatureFlag(cmd, args[0], false, nil)
+	},
+}
+
+// setFeatureFlag changes one flag with a compare-and-swap publish, recording
+// the change as the description of the revision
+func setFeatureFlag(cmd *cobra.Command, name string, enabled bool, rollout *int) {
+	resolveFlagsConfig(cmd)
+	change := "disable flag " + name
+	if enabled {
+		change = "enable flag " + name
+		if rollout != nil {
+			change += fmt.Sprintf(" for %d%%", *rollout)
+		}
+	}
+	desc := change
+	if changeMessage != "" {
+		desc += ": " + changeMessage
+	}
+	checkError(modifyConfig(newNacosClient(), flagConfig, flagGroup, change, desc, flagDryRun,
+		func(doc *yaml.Node) (*yaml.Node, error) {
+			flagsNode, flags, err := parseFeatureFlags(doc)
+			if err != nil {
+				return nil, err
+			}
+			found := false
+			for _, f := range flags {
+				found = found || f.Name == name
+			}
+			if !found {
+				// Refuse rather than add a flag no code reads, e.g. a typo
+				return nil, fmt.Errorf("flag %q is not defined in %s, add it to the configuration first", name, flagConfig)
+			}
+			setFlagNode(flagsNode, name, enabled, rollout)
+			return doc, nil
+		}))
+}
+
+// resolveFlagsConfig picks the flags configuration: --data-id, else the
+// flagsConfig of the profile, else flags.yaml
+func resolveFlagsConfig(cmd *cobra.Command) {
+	if flagConfig == "" && fileConfig != nil && fileConfig.FlagsConfig != "" {
+		flagConfig = fileConfig.FlagsConfig
+		if strings.Contains(flagConfig, "/") {
+			group, dataID := configtree.SplitKey(flagConfig)
+			flagConfig = dataID
+			if flagGroup == "" {
+				flagGroup = group
+			}
+		}
+	}
+	if flagConfig == "" {
+		flagConfig = "flags.yaml"
+	}
+	flagGroup = resolveGroup(cmd, flagGroup, "DEFAULT_GROUP")
+}
+
+// parseFeatureFlags validates the flags mapping of a flags configuration
+func parseFeatureFlags(doc *yaml.Node) (*yaml.Node, []featureFlag, error) {
+	root := doc
+	if root.Kind == yaml.DocumentNode {
+		root = root.Content[0]
+	}
+	flagsNode := mappingValue(root, "flags")
+	if flagsNode == nil {
+		return nil, nil, fmt.Errorf("no flags mapping at the top of %s", flagConfig)
+	}
+	if flagsNode.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("line %d: flags must be a mapping of flag names", flagsNode.Line)
+	}
+	var flags []featureFlag
+	for i := 0; i+1 < len(flagsNode.Content); i += 2 {
+		name, value := flagsNode.Content[i].Value, flagsNode.Content[i+1]
+		f := featureFlag{Name: name}
+		switch value.Kind {
+		case yaml.ScalarNode:
+			if value.ShortTag() != "!!bool" {
+				return nil, nil, fmt.Errorf("line %d: flag %s must be true, false or a mapping, not %q", value.Line, name, value.Value)
+			}
+			if err := value.Decode(&f.Enabled); err != nil {
+				return nil, nil, err
+			}
+		case yaml.MappingNode:
+			enabled := mappingValue(value, "enabled")
+			if enabled == nil || enabled.ShortTag() != "!!bool" {
+				return nil, nil, fmt.Errorf("line %d: flag %s needs enabled: true or false", value.Line, name)
+			}
+			if err := enabled.Decode(&f.Enabled); err != nil {
+				return nil, nil, err
+			}
+			if rollout := mappingValue(value, "rollout"); rollout != nil {
+				n, err := strconv.Atoi(rollout.Value)
+				if rollout.ShortTag() != "!!int" || err != nil || n < 0 || n > 100 {
+					return nil, nil, fmt.Errorf("line %d: rollout of flag %s must be a percentage from 0 to 100", rollout.Line, name)
+				}
+				f.Rollout = &n
+			}
+			if description := mappingValue(value, "description"); description != nil {
+				if description.Kind != yaml.ScalarNode {
+					return nil, nil, fmt.Errorf("line %d: description of flag %s must be text", description.Line, name)
+				}
+				f.Description = description.Value
+			}
+		default:
+			return nil, nil, fmt.Errorf("line %d: flag %s must be true, false or a mapping", value.Line, name)
+		}
+		flags = append(flags, f)
+	}
+	return flagsNode, flags, nil
+}
+
+// setFlagNode changes a flag in place, keeping its other attributes and
+// comments; a shorthand flag becomes a mapping only to hold a rollout
+func setFlagNode(flagsNode *yaml.Node, name string, enabled bool, rollout *int) {
+	for i := 0; i+1 < len(flagsNode.Content); i += 2 {
+		if flagsNode.Content[i].Value != name {
+			continue
+		}
+		value := flagsNode.Content[i+1]
+		if value.Kind == yaml.ScalarNode {
+			if rollout == nil {
+				value.Value = strconv.FormatBool(enabled)
+				return
+			}
+			value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "enabled"},
+				{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(enabled)},
+			}}
+			flagsNode.Content[i+1] = value
+		}
+		mappingValue(value, "enabled").Value = strconv.FormatBool(enabled)
+		if rollout != nil {
+			if r := mappingValue(value, "rollout"); r != nil {
+				r.Value, r.Tag = strconv.Itoa(*rollout), "!!int"
+			} else {
+				value.Content = append(value.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "rollout"},
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(*rollout)})
+			}
+		}
+		return
+	}
+}
+
+// mappingValue returns the value of a key in a mapping node, nil if absent
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{flagListCmd, flagEnableCmd, flagDisableCmd} {
+		c.Flags().StringVar(&flagConfig, "data-id", "", "Data ID of the flags configuration (default: flagsConfig of the profile, else flags.yaml)")
+		c.Flags().StringVar(&flagGroup, "group", "", "Group of the flags configuration (default: DEFAULT_GROUP)")
+		rootCmd.AddCommand(c)
+	}
+	flagListCmd.Flags().StringVarP(&flagOutput, "output", "o", "table", "Output format: table or json")
+	flagEnableCmd.Flags().IntVar(&flagRollout, "rollout", 0, "Enable for this percentage of users (0-100)")
+	for _, c := range []*cobra.Command{flagEnableCmd, flagDisableCmd} {
+		c.Flags().BoolVar(&flagDryRun, "dry-run", false, "Show the diff without publishing")
+	}
+}
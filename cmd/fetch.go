@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"sync"
+
+	"github.com/nov11/nacos-cli/internal/client"
+)
+
+// publishRetries is how often the batch commands retry a publish that failed
+// in transit, once the server shows it did not land
+const publishRetries = 2
+
+// configRef identifies a configuration
+type configRef struct {
+	Group  string
+	DataID string
+}
+
+// fetchConfigs gets configurations concurrently, with at most concurrency
+// requests in flight. Results are in the order of refs.
+func fetchConfigs(nacosClient *client.NacosClient, refs []configRef, concurrency int) ([]string, []error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	contents := make([]string, len(refs))
+	errs := make([]error, len(refs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		go func(i int, ref configRef) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			contents[i], errs[i] = nacosClient.GetConfig(ref.DataID, ref.Group)
+		}(i, ref)
+	}
+	wg.Wait()
+	return contents, errs
+}
+
+// fetchConfigDetails gets the content and metadata of configurations
+// concurrently. Results are in the order of refs.
+func fetchConfigDetails(nacosClient *client.NacosClient, refs []configRef, concurrency int) ([]*client.ConfigDetail, []error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	details := make([]*client.ConfigDetail, len(refs))
+	errs := make([]error, len(refs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		go func(i int, ref configRef) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			details[i], errs[i] = nacosClient.GetConfigDetail(ref.DataID, ref.Group)
+		}(i, ref)
+	}
+	wg.Wait()
+	return details, errs
+}
+
+// fillConfigDetails completes list items with the metadata only returned by the
+// detail API (tags, modify time, MD5), fetching concurrently
+func fillConfigDetails(nacosClient *client.NacosClient, items []client.Config, concurrency int) []error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range items {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			item := &items[i]
+			group := item.GroupName
+			if group == "" {
+				group = item.Group
+			}
+			detail, err := nacosClient.GetConfigDetail(item.DataID, group)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			item.Tags = detail.ConfigTags
+			item.Md5 = detail.Md5
+			item.ModifyTime = detail.ModifyTime
+			item.CreateTime = detail.CreateTime
+			if item.AppName == "" {
+				item.AppName = detail.AppName
+			}
+			if item.Type == "" {
+				item.Type = detail.Type
+			}
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// fetchListeners gets the server MD5 and the listening clients of
+// configurations concurrently. Results are in the order of refs.
+func fetchListeners(nacosClient *client.NacosClient, refs []configRef, concurrency int) ([]string, [][]listenerState, []error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	md5s := make([]string, len(refs))
+	states := make([][]listenerState, len(refs))
+	errs := make([]error, len(refs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		go func(i int, ref configRef) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			md5s[i], states[i], errs[i] = configListeners(nacosClient, ref.DataID, ref.Group)
+		}(i, ref)
+	}
+	wg.Wait()
+	return md5s, states, errs
+}
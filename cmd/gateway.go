@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+var (
+	routeID         string
+	routeURI        string
+	routeOrder      int
+	routePaths      []string
+	routePredicates []string
+	routeFilters    []string
+	routeReplace    bool
+)
+
+var gatewayRouteListCmd = &cobra.Command{
+	Use:   "gateway-route-list dataId [group]",
+	Short: "List and validate the Spring Cloud Gateway routes of a configuration",
+	Long:  help.GatewayRouteList.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID, group := ruleArgs(cmd, args, "DEFAULT_GROUP")
+		content, err := getRuleContent(newNacosClient(), dataID, group)
+		checkError(err)
+		routes, err := rules.ParseRoutes(content)
+		checkError(err)
+
+		fmt.Printf("%d route(s) in %s (%s)\n", len(routes), dataID, group)
+		if len(routes) == 0 {
+			return
+		}
+		fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+		fmt.Printf("%-20s %-30s %-6s %s\n", "ID", "URI", "Order", "Predicates / Filters")
+		fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+		for _, r := range routes {
+			fmt.Printf("%-20s %-30s %-6d %s\n", r.ID, r.URI, r.Order, joinDefinitions(r.Predicates))
+			if len(r.Filters) > 0 {
+				fmt.Printf("%-58s %s\n", "", joinDefinitions(r.Filters))
+			}
+		}
+	},
+}
+
+var gatewayRouteAddCmd = &cobra.Command{
+	Use:   "gateway-route-add dataId [group]",
+	Short: "Add (or replace) a Spring Cloud Gateway route",
+	Long:  help.GatewayRouteAdd.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID, group := ruleArgs(cmd, args, "DEFAULT_GROUP")
+		if routeID == "" || routeURI == "" {
+			checkError(fmt.Errorf("--id and --uri are required"))
+		}
+
+		route := rules.RouteDefinition{ID: routeID, URI: routeURI, Order: routeOrder, Filters: []rules.Definition{}}
+		for _, p := range routePaths {
+			route.Predicates = append(route.Predicates, rules.Definition{Name: "Path", Args: map[string]string{"_genkey_0": p}})
+		}
+		for _, s := range routePredicates {
+			d, err := rules.ParseShortcut(s)
+			checkError(err)
+			route.Predicates = append(route.Predicates, d)
+		}
+		for _, s := range routeFilters {
+			d, err := rules.ParseShortcut(s)
+			checkError(err)
+			route.Filters = append(route.Filters, d)
+		}
+
+		nacosClient := newNacosClient()
+		content, err := getRuleContent(nacosClient, dataID, group)
+		checkError(err)
+		routes, err := rules.ParseRoutes(content)
+		checkError(err)
+
+		action := "Added"
+		if i := rules.FindRoute(routes, routeID); i >= 0 {
+			if !routeReplace {
+				checkError(fmt.Errorf("route %s already exists (use --replace)", routeID))
+			}
+			routes[i] = route
+			action = "Replaced"
+		} else {
+			routes = append(routes, route)
+		}
+		checkError(rules.ValidateRoutes(routes))
+
+		checkError(publishRules(nacosClient, dataID, group, content, routes))
+		fmt.Printf("%s route %s in %s (%s)\n", action, routeID, dataID, group)
+	},
+}
+
+func joinDefinitions(defs []rules.Definition) string {
+	s := make([]string, len(defs))
+	for i, d := range defs {
+		s[i] = d.String()
+	}
+	return strings.Join(s, " ")
+}
+
+func init() {
+	f := gatewayRouteAddCmd.Flags()
+	f.StringVar(&routeID, "id", "", "Route ID (required)")
+	f.StringVar(&routeURI, "uri", "", "Route URI, e.g. lb://order-service (required)")
+	f.IntVar(&routeOrder, "order", 0, "Route order")
+	f.StringArrayVar(&routePaths, "path", nil, "Path predicate pattern (repeatable)")
+	f.StringArrayVar(&routePredicates, "predicate", nil, "Predicate in shortcut notation, e.g. Method=GET,POST (repeatable)")
+	f.StringArrayVar(&routeFilters, "filter", nil, "Filter in shortcut notation, e.g. StripPrefix=1 (repeatable)")
+	f.BoolVar(&routeReplace, "replace", false, "Replace an existing route with the same ID")
+	rootCmd.AddCommand(gatewayRouteListCmd)
+	rootCmd.AddCommand(gatewayRouteAddCmd)
+}
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/recyclebin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gcGroup         string
+	gcUnmodifiedFor string
+	gcExcludeTags   []string
+	gcReportPath    string
+	gcDelete        bool
+	gcDryRun        bool
+	gcYes           bool
+	gcNoRecycle     bool
+	gcConcurrency   int
+	gcOutput        string
+)
+
+// gcConfig is a configuration collected by gc and what gc did with it
+type gcConfig struct {
+	DataID string    `json:"dataId"`
+	Group  string    `json:"group"`
+	Reason string    `json:"reason"` // expired or stale
+	Since  time.Time `json:"since"`  // when the configuration expired or was last modified
+	Action string    `json:"action"` // report, delete, deleted or error
+	Error  string    `json:"error,omitempty"`
+}
+
+// gcReport lists the stale configurations gc reported in a namespace and
+// group, by key with their last modification in milliseconds
+type gcReport struct {
+	CreatedAt     time.Time        `json:"createdAt"`
+	UnmodifiedFor string           `json:"unmodifiedFor"`
+	Configs       map[string]int64 `json:"configs"`
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete expired temporary configurations, or stale ones after a report",
+	Long:  help.GC.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(gcOutput)
+		unmodifiedFor, err := parseAge(gcUnmodifiedFor)
+		checkError(err)
+		stale := gcUnmodifiedFor != ""
+		if stale && unmodifiedFor == 0 {
+			checkError(fmt.Errorf("--unmodified-for must be positive"))
+		}
+		if gcDelete && !stale {
+			checkError(fmt.Errorf("--delete goes with --unmodified-for; expired temporary configurations are deleted without it"))
+		}
+		group := resolveGroup(cmd, gcGroup, "")
+		nacosClient := newNacosClient()
+		now := time.Now()
+
+		var found []gcConfig
+		if stale {
+			found, err = findStaleConfigs(nacosClient, group, now.Add(-unmodifiedFor), gcConcurrency)
+		} else {
+			found, err = findExpiredConfigs(nacosClient, group, now, gcConcurrency)
+		}
+		checkError(err)
+
+		// Stale configurations are only deleted once a previous run reported
+		// them, and only when they were not modified since
+		var reports map[string]gcReport
+		reportPath := gcReportPath
+		scope := nacosClient.ServerAddr + "/" + namespaceLabel(nacosClient.Namespace) + "/" + group
+		if stale {
+			if reportPath == "" {
+				reportPath, err = defaultGCReportsPath()
+				checkError(err)
+			}
+			reports, err = loadGCReports(reportPath)
+			checkError(err)
+			report, reported := reports[scope]
+			if gcDelete && !reported {
+				checkError(fmt.Errorf("no report of stale configurations for namespace %s in %s; run gc --unmodified-for %s without --delete to review them first",
+					namespaceLabel(nacosClient.Namespace), reportPath, gcUnmodifiedFor))
+			}
+			for i, c := range found {
+				modified, ok := report.Configs[configtree.Key(c.Group, c.DataID)]
+				found[i].Action = "report"
+				if gcDelete && ok && modified == c.Since.UnixMilli() {
+					found[i].Action = "delete"
+				}
+			}
+		}
+
+		toDelete := 0
+		for _, c := range found {
+			if c.Action == "delete" {
+				toDelete++
+			}
+		}
+		if toDelete > 0 && !gcDryRun {
+			checkError(checkChangeBudget(changeBudget(fileConfig), nacosClient.Namespace, toDelete))
+			if !gcYes && !isInteractive() {
+				checkError(fmt.Errorf("refusing to delete without confirmation in non-interactive mode (use --yes)"))
+			}
+			if !gcYes && gcOutput == "table" {
+				printGCConfigs(found)
+				fmt.Println()
+			}
+			checkError(confirmByTyping(fmt.Sprintf("delete %d configuration(s)", toDelete), namespaceLabel(nacosClient.Namespace), gcYes))
+			checkError(deleteGCConfigs(nacosClient, found))
+		}
+		if stale && !gcDryRun {
+			// What was not deleted makes the report the next --delete run acts on
+			report := gcReport{CreatedAt: now, UnmodifiedFor: gcUnmodifiedFor, Configs: make(map[string]int64)}
+			for _, c := range found {
+				if c.Action == "report" || c.Action == "error" {
+					report.Configs[configtree.Key(c.Group, c.DataID)] = c.Since.UnixMilli()
+				}
+			}
+			delete(reports, scope)
+			if len(report.Configs) > 0 {
+				reports[scope] = report
+			}
+			checkError(saveGCReports(reportPath, reports))
+		}
+
+		failed, reported := 0, 0
+		for _, c := range found {
+			switch c.Action {
+			case "error":
+				failed++
+			case "report":
+				reported++
+			}
+		}
+		if gcOutput == "json" {
+			printJSON(struct {
+				Namespace string     `json:"namespace"`
+				DryRun    bool       `json:"dryRun"`
+				Configs   []gcConfig `json:"configs"`
+			}{namespaceLabel(nacosClient.Namespace), gcDryRun, found})
+		} else {
+			what := "expired"
+			if stale {
+				what = "stale"
+			}
+			fmt.Printf("Namespace %s: %d %s configuration(s)\n", namespaceLabel(nacosClient.Namespace), len(found), what)
+			if len(found) > 0 {
+				printGCConfigs(found)
+			}
+			switch {
+			case gcDryRun && toDelete > 0:
+				fmt.Printf("\nDry run: %d configuration(s) would be deleted\n", toDelete)
+			case toDelete > 0:
+				fmt.Printf("\nDeleted: %d, Failed: %d\n", toDelete-failed, failed)
+			}
+			if reported > 0 && !gcDryRun {
+				again := "nacos-cli gc --unmodified-for " + gcUnmodifiedFor
+				if group != "" {
+					again += " --group " + group
+				}
+				for _, tag := range gcExcludeTags {
+					again += " --exclude-tag " + tag
+				}
+				fmt.Printf("\nReported %d configuration(s) in %s; after reviewing them, delete them with:\n  %s --delete\n",
+					reported, reportPath, again)
+			}
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// findExpiredConfigs returns the temporary configurations of a group, of every
+// group when empty, that expired before now. The tags, where config-set --ttl
+// records the expiry, only come with the details of each configuration.
+func findExpiredConfigs(nacosClient *client.NacosClient, group string, now time.Time, concurrency int) ([]gcConfig, error) {
+	items, err := nacosClient.ListAllConfigsByTags("", group, []string{client.TemporaryTag})
+	if err != nil {
+		return nil, err
+	}
+	expiries := make([]time.Time, len(items))
+	_, firstErr := runParallel(len(items), concurrency, func(i int) error {
+		detail, err := nacosClient.GetConfigDetail(items[i].DataID, items[i].GroupName)
+		if errors.Is(err, client.ErrConfigNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !excludedByTag(detail.Tags()) {
+			expiries[i], _ = client.TagsExpiry(detail.Tags())
+		}
+		return nil
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	var found []gcConfig
+	for i, item := range items {
+		if expiries[i].IsZero() || expiries[i].After(now) {
+			continue
+		}
+		found = append(found, gcConfig{DataID: item.DataID, Group: item.GroupName, Reason: "expired", Since: expiries[i], Action: "delete"})
+	}
+	return found, nil
+}
+
+// findStaleConfigs returns the configurations of a group, of every group when
+// empty, last modified before cutoff. Only the candidates' details are read,
+// for the tags excluding them.
+func findStaleConfigs(nacosClient *client.NacosClient, group string, cutoff time.Time, concurrency int) ([]gcConfig, error) {
+	items, err := nacosClient.ListAllConfigs("", group)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []client.Config
+	for _, item := range items {
+		if isInternalGroup(item.GroupName) || item.ModifyTime == 0 || !time.UnixMilli(item.ModifyTime).Before(cutoff) {
+			continue
+		}
+		candidates = append(candidates, item)
+	}
+	excluded := make([]bool, len(candidates))
+	if len(gcExcludeTags) > 0 {
+		_, firstErr := runParallel(len(candidates), concurrency, func(i int) error {
+			detail, err := nacosClient.GetConfigDetail(candidates[i].DataID, candidates[i].GroupName)
+			if errors.Is(err, client.ErrConfigNotFound) {
+				excluded[i] = true
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			excluded[i] = excludedByTag(detail.Tags())
+			return nil
+		})
+		if firstErr != nil {
+			return nil, firstErr
+		}
+	}
+	var found []gcConfig
+	for i, item := range candidates {
+		if excluded[i] {
+			continue
+		}
+		found = append(found, gcConfig{DataID: item.DataID, Group: item.GroupName, Reason: "stale", Since: time.UnixMilli(item.ModifyTime)})
+	}
+	return found, nil
+}
+
+// excludedByTag reports whether the tags have one of --exclude-tag
+func excludedByTag(tags []string) bool {
+	for _, tag := range gcExcludeTags {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteGCConfigs deletes the configurations to delete, into the recycle bin
+// unless --no-recycle-bin, and records the outcome of each one
+func deleteGCConfigs(nacosClient *client.NacosClient, found []gcConfig) error {
+	var bin recyclebin.Bin
+	if !gcNoRecycle {
+		var err error
+		if bin, err = openRecycleBin(nacosClient); err != nil {
+			return err
+		}
+	}
+	var refs []configRef
+	var indexes []int
+	for i, c := range found {
+		if c.Action == "delete" {
+			refs = append(refs, configRef{Group: c.Group, DataID: c.DataID})
+			indexes = append(indexes, i)
+		}
+	}
+	for i, err := range deleteConfigs(nacosClient, bin, refs, gcConcurrency) {
+		c := &found[indexes[i]]
+		c.Action = "deleted"
+		if err != nil {
+			c.Action, c.Error = "error", err.Error()
+		}
+	}
+	return nil
+}
+
+func printGCConfigs(found []gcConfig) {
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%-36s %-20s %-10s %-17s %s\n", "Data ID", "Group", "Reason", "Since", "Action")
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+	for _, c := range found {
+		action := c.Action
+		if c.Error != "" {
+			action += ": " + c.Error
+		}
+		fmt.Printf("%-36s %-20s %-10s %-17s %s\n", c.DataID, c.Group, c.Reason, c.Since.Local().Format("2006-01-02 15:04"), action)
+	}
+}
+
+// defaultGCReportsPath returns ~/.nacos-cli/gc-reports.json
+func defaultGCReportsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".nacos-cli", "gc-reports.json"), nil
+}
+
+// loadGCReports reads the reports of stale configurations, keyed by
+// server/namespace/group
+func loadGCReports(path string) (map[string]gcReport, error) {
+	reports := make(map[string]gcReport)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return reports, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &reports); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return reports, nil
+}
+
+func saveGCReports(path string, reports map[string]gcReport) error {
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func init() {
+	gcCmd.Flags().StringVar(&gcGroup, "group", "", "Only collect the configurations of this group (default: every group)")
+	gcCmd.Flags().StringVar(&gcUnmodifiedFor, "unmodified-for", "", "Collect the configurations not modified for this long, e.g. 90d, instead of the expired ones")
+	gcCmd.Flags().StringArrayVar(&gcExcludeTags, "exclude-tag", nil, "Never collect the configurations with this tag (repeatable)")
+	gcCmd.Flags().StringVar(&gcReportPath, "report", "", "File of the reported stale configurations (default: ~/.nacos-cli/gc-reports.json)")
+	gcCmd.Flags().BoolVar(&gcDelete, "delete", false, "Delete the stale configurations of the previous report that were not modified since")
+	gcCmd.Flags().BoolVar(&gcDryRun, "dry-run", false, "Report what would be deleted without deleting it")
+	gcCmd.Flags().BoolVarP(&gcYes, "yes", "y", false, "Delete without asking for confirmation")
+	gcCmd.Flags().BoolVar(&gcNoRecycle, "no-recycle-bin", false, "Delete without keeping the configurations in the recycle bin")
+	gcCmd.Flags().IntVar(&gcConcurrency, "concurrency", 8, "Parallel requests")
+	gcCmd.Flags().StringVarP(&gcOutput, "output", "o", "table", "Output format: table or json")
+	rootCmd.AddCommand(gcCmd)
+}
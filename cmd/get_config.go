@@ -4,21 +4,56 @@ import (
 	"fmt"
 
 	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configref"
 	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/yamlutil"
 	"github.com/spf13/cobra"
 )
 
+var (
+	getConfigPath        string
+	getConfigResolveRefs bool
+)
+
 var getConfigCmd = &cobra.Command{
 	Use:   "config-get [dataId] [group]",
 	Short: "Get a specific configuration",
 	Long:  help.ConfigGet.FormatForCLI("nacos-cli"),
-	Args:  cobra.ExactArgs(2),
+	Args:  cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		dataID := args[0]
-		group := args[1]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
 
 		// Create Nacos client
-		nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
+		nacosClient := newNacosClient()
+
+		if getConfigPath != "" {
+			// Just the value, for scripts
+			content, err := nacosClient.GetConfig(dataID, group)
+			checkError(err)
+			if content == "" {
+				checkError(fmt.Errorf("configuration %s (%s) not found", dataID, group))
+			}
+			if getConfigResolveRefs {
+				content, err = resolveConfigRefs(nacosClient, dataID, group, content)
+				checkError(err)
+			}
+			doc, isJSON, err := parseConfigDocument(dataID, "", content)
+			checkError(err)
+			node, err := yamlutil.Lookup(doc, getConfigPath)
+			checkError(err)
+			if node == nil {
+				checkError(fmt.Errorf("%s has no value at %s", dataID, getConfigPath))
+			}
+			value, err := formatPathValue(node, isJSON)
+			checkError(err)
+			fmt.Println(value)
+			return
+		}
 
 		// Get config
 		fmt.Printf("Fetching config: %s (%s)...\n\n", dataID, group)
@@ -29,8 +64,13 @@ var getConfigCmd = &cobra.Command{
 			fmt.Println("Configuration not found")
 			return
 		}
+		if getConfigResolveRefs {
+			content, err = resolveConfigRefs(nacosClient, dataID, group, content)
+			checkError(err)
+		}
 
 		// Display content
+		startPager()
 		fmt.Println("═══════════════════════════════════════")
 		fmt.Printf("Data ID: %s\n", dataID)
 		fmt.Printf("Group: %s\n", group)
@@ -40,5 +80,13 @@ var getConfigCmd = &cobra.Command{
 }
 
 func init() {
+	getConfigCmd.Flags().StringVar(&getConfigPath, "path", "", "Print only the value at this path of a YAML or JSON configuration, e.g. spring.datasource.url")
+	getConfigCmd.Flags().BoolVar(&getConfigResolveRefs, "resolve-refs", false, "Replace ${nacos:[GROUP/]dataId[:key]} references with the referenced configurations")
 	rootCmd.AddCommand(getConfigCmd)
 }
+
+// resolveConfigRefs replaces the references of a configuration to others,
+// recursively
+func resolveConfigRefs(nacosClient *client.NacosClient, dataID, group, content string) (string, error) {
+	return configref.NewResolver(nacosClient.GetConfig).Resolve(dataID, group, content)
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/nov11/nacos-cli/internal/client"
 	"github.com/nov11/nacos-cli/internal/help"
 	"github.com/nov11/nacos-cli/internal/skill"
 	"github.com/spf13/cobra"
@@ -31,7 +30,7 @@ var getSkillCmd = &cobra.Command{
 		}
 
 		// Create Nacos client
-		nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
+		nacosClient := newNacosClient()
 
 		// Create skill service
 		skillService := skill.NewSkillService(nacosClient)
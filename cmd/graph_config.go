@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configref"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphFormat      string
+	graphDataID      string
+	graphGroup       string
+	graphConcurrency int
+)
+
+// graphEdge is a dependency of a configuration on another, by group/dataId
+type graphEdge struct {
+	From, To, Kind string
+}
+
+var graphConfigCmd = &cobra.Command{
+	Use:   "config-graph",
+	Short: "Draw the dependencies between configurations as a DOT or Mermaid graph",
+	Long:  help.ConfigGraph.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if graphFormat != "dot" && graphFormat != "mermaid" {
+			checkError(fmt.Errorf("unknown --format %q (expected dot or mermaid)", graphFormat))
+		}
+		nacosClient := newNacosClient()
+		edges, existing, err := configGraph(nacosClient)
+		checkError(err)
+		if graphDataID != "" {
+			target := configtree.Key(resolveGroup(cmd, graphGroup, "DEFAULT_GROUP"), graphDataID)
+			edges = dependentsOf(edges, target)
+			if len(edges) == 0 {
+				fmt.Printf("No configuration depends on %s\n", target)
+				return
+			}
+		}
+		if graphFormat == "mermaid" {
+			printMermaidGraph(edges, existing)
+		} else {
+			printDotGraph(edges, existing)
+		}
+	},
+}
+
+// configGraph scans every configuration of the namespace, those --selector
+// matches, for the configurations it depends on. existing holds the keys of
+// the configurations on the server.
+func configGraph(nacosClient *client.NacosClient) ([]graphEdge, map[string]bool, error) {
+	items, err := nacosClient.ListAllConfigs("", "")
+	if err != nil {
+		return nil, nil, err
+	}
+	existing := make(map[string]bool, len(items))
+	for _, item := range items {
+		existing[configtree.Key(item.GroupName, item.DataID)] = true
+	}
+	if items, err = selectConfigs(nacosClient, parseSelector(), items, graphConcurrency); err != nil {
+		return nil, nil, err
+	}
+	var refs []configRef
+	for _, item := range items {
+		if !isInternalGroup(item.GroupName) {
+			refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+		}
+	}
+	contents, errs := fetchConfigs(nacosClient, refs, graphConcurrency)
+	var edges []graphEdge
+	for i, ref := range refs {
+		if errors.Is(errs[i], client.ErrConfigNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, nil, fmt.Errorf("fetch %s: %w", configtree.Key(ref.Group, ref.DataID), errs[i])
+		}
+		from := configtree.Key(ref.Group, ref.DataID)
+		for _, dep := range configref.Scan(ref.DataID, ref.Group, contents[i]) {
+			edges = append(edges, graphEdge{From: from, To: configtree.Key(dep.Group, dep.DataID), Kind: dep.Kind})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Kind < edges[j].Kind
+	})
+	return edges, existing, nil
+}
+
+// dependentsOf keeps the edges leading to target, directly or through other
+// configurations: what a change of target can break
+func dependentsOf(edges []graphEdge, target string) []graphEdge {
+	reached := map[string]bool{target: true}
+	for changed := true; changed; {
+		changed = false
+		for _, e := range edges {
+			if reached[e.To] && !reached[e.From] {
+				reached[e.From] = true
+				changed = true
+			}
+		}
+	}
+	var kept []graphEdge
+	for _, e := range edges {
+		if reached[e.To] && reached[e.From] {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// graphNodes returns the configurations of edges, sorted
+func graphNodes(edges []graphEdge) []string {
+	seen := make(map[string]bool)
+	var nodes []string
+	for _, e := range edges {
+		for _, n := range []string{e.From, e.To} {
+			if !seen[n] {
+				seen[n] = true
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// printDotGraph prints a Graphviz digraph; missing configurations are dashed
+func printDotGraph(edges []graphEdge, existing map[string]bool) {
+	fmt.Println("digraph nacos {")
+	fmt.Println("  rankdir=LR;")
+	fmt.Println("  node [shape=box];")
+	for _, n := range graphNodes(edges) {
+		if !existing[n] {
+			fmt.Printf("  %q [style=dashed];\n", n)
+		}
+	}
+	for _, e := range edges {
+		fmt.Printf("  %q -> %q [label=%q];\n", e.From, e.To, e.Kind)
+	}
+	fmt.Println("}")
+}
+
+// printMermaidGraph prints a Mermaid flowchart; missing configurations are
+// dashed
+func printMermaidGraph(edges []graphEdge, existing map[string]bool) {
+	ids := make(map[string]string)
+	fmt.Println("graph LR")
+	var missing []string
+	for i, n := range graphNodes(edges) {
+		ids[n] = fmt.Sprintf("n%d", i)
+		fmt.Printf("  %s[\"%s\"]\n", ids[n], strings.ReplaceAll(n, `"`, "#quot;"))
+		if !existing[n] {
+			missing = append(missing, ids[n])
+		}
+	}
+	for _, e := range edges {
+		fmt.Printf("  %s -->|%s| %s\n", ids[e.From], e.Kind, ids[e.To])
+	}
+	if len(missing) > 0 {
+		fmt.Println("  classDef missing stroke-dasharray: 5 5")
+		fmt.Printf("  class %s missing\n", strings.Join(missing, ","))
+	}
+}
+
+func init() {
+	graphConfigCmd.Flags().StringVar(&graphFormat, "format", "dot", "Graph format: dot or mermaid")
+	graphConfigCmd.Flags().StringVar(&graphDataID, "data-id", "", "Show only the configurations depending on this one, directly or indirectly")
+	graphConfigCmd.Flags().StringVar(&graphGroup, "group", "", "Group of --data-id (default: DEFAULT_GROUP)")
+	graphConfigCmd.Flags().IntVar(&graphConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addSelectorFlag(graphConfigCmd)
+	rootCmd.AddCommand(graphConfigCmd)
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/audit"
+	"github.com/nov11/nacos-cli/internal/backup"
+	"github.com/nov11/nacos-cli/internal/config"
+	"github.com/nov11/nacos-cli/internal/recording"
+	"github.com/spf13/cobra"
+)
+
+// iKnowWhatIAmDoing overrides the guard rails of protected profiles and
+// overrideReason the change windows; ticket and changeMessage describe the
+// changes. maxChanges and confirmOverBudget set and lift the change budget of
+// batch commands.
+var (
+	iKnowWhatIAmDoing bool
+	overrideReason    string
+	ticket            string
+	changeMessage     string
+	maxChanges        int
+	confirmOverBudget bool
+)
+
+// commandOperations lists the operations of the commands changing the server;
+// every other command only reads
+var commandOperations = map[string][]string{
+	"apply":                  {config.OperationPublish},
+	"auth-import":            {config.OperationPublish},
+	"bootstrap":              {config.OperationPublish},
+	"bootstrap-namespace":    {config.OperationPublish},
+	"chaos-flap":             {config.OperationPublish, config.OperationDelete},
+	"config-delete":          {config.OperationDelete},
+	"config-edit":            {config.OperationPublish},
+	"config-import":          {config.OperationPublish},
+	"config-mv":              {config.OperationPublish, config.OperationDelete},
+	"config-new":             {config.OperationPublish},
+	"config-push":            {config.OperationPublish},
+	"config-restore-deleted": {config.OperationPublish},
+	"config-revert-last":     {config.OperationPublish},
+	"config-patch":           {config.OperationPublish},
+	"config-rollback":        {config.OperationPublish},
+	"config-set":             {config.OperationPublish},
+	"config-tag-add":         {config.OperationPublish},
+	"config-tag-rm":          {config.OperationPublish},
+	"dev":                    {config.OperationPublish},
+	"flag-disable":           {config.OperationPublish},
+	"flag-enable":            {config.OperationPublish},
+	"gateway-route-add":      {config.OperationPublish},
+	"gc":                     {config.OperationDelete},
+	"instance-drain":         {config.OperationPublish, config.OperationDelete},
+	"instance-heartbeat":     {config.OperationPublish},
+	"loadgen":                {config.OperationPublish},
+	"loadgen-cleanup":        {config.OperationDelete},
+	"mcp-registry-publish":   {config.OperationPublish},
+	"namespace-create":       {config.OperationPublish},
+	"namespace-delete":       {config.OperationDelete},
+	"namespace-update":       {config.OperationPublish},
+	"sandbox-create":         {config.OperationPublish},
+	"sandbox-destroy":        {config.OperationDelete},
+	"sentinel-flow-set":      {config.OperationPublish},
+	"service-import":         {config.OperationPublish},
+	"service-prune":          {config.OperationDelete},
+	"skill-upload":           {config.OperationPublish},
+}
+
+// commandOperationsOf returns the operations of a command with its flags
+func commandOperationsOf(cmd *cobra.Command) []string {
+	// manifest-import writes to the server only to save a nacos state
+	if cmd.Name() == "manifest-import" && manifestImportSavesToNacos() {
+		return []string{config.OperationPublish}
+	}
+	operations, ok := commandOperations[cmd.Name()]
+	if !ok {
+		return []string{config.OperationRead}
+	}
+	if (cmd.Name() == "apply" && applyPrune) || (cmd.Name() == "dev" && devDelete) {
+		operations = append([]string{config.OperationDelete}, operations...)
+	}
+	// gc only reports with --dry-run, and --unmodified-for without --delete
+	if cmd.Name() == "gc" && (gcDryRun || (gcUnmodifiedFor != "" && !gcDelete)) {
+		return []string{config.OperationRead}
+	}
+	return operations
+}
+
+// guardCommand stops the command before it reaches the server when the profile
+// does not allow its operations or it runs outside the change windows, then
+// records the change in the audit log
+func guardCommand(cmd *cobra.Command) {
+	operations := commandOperationsOf(cmd)
+	overrides, err := checkGuardRails(fileConfig, profile, namespace, time.Now(), operations...)
+	checkError(err)
+	checkError(recordChange(fileConfig, profile, serverAddr, namespace, os.Args[1:], operations, overrides))
+}
+
+// checkGuardRails returns an error when the settings of a profile do not allow
+// one of the operations, or a change outside its change windows. It returns the
+// guard rails overridden by --i-know-what-i-am-doing and --override.
+func checkGuardRails(cfg *config.Config, profileName, namespaceID string, now time.Time, operations ...string) ([]string, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	for _, allowed := range cfg.AllowedOperations {
+		switch allowed {
+		case config.OperationRead, config.OperationPublish, config.OperationDelete:
+		default:
+			return nil, fmt.Errorf("invalid allowed operation %q (expected %s, %s or %s)",
+				allowed, config.OperationRead, config.OperationPublish, config.OperationDelete)
+		}
+	}
+	where := "the config file"
+	if profileName != "" {
+		where = "profile " + profileName
+	}
+	if namespaceID == "" {
+		namespaceID = "public"
+	}
+
+	var overrides []string
+	for _, operation := range operations {
+		if cfg.AllowsOperation(operation) {
+			continue
+		}
+		if !iKnowWhatIAmDoing {
+			return nil, fmt.Errorf("%s does not allow %s operations (namespace %s); pass --i-know-what-i-am-doing to override",
+				where, operation, namespaceID)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %s does not allow %s operations (namespace %s), overridden by --i-know-what-i-am-doing\n",
+			where, operation, namespaceID)
+		overrides = append(overrides, "allowedOperations:"+operation)
+	}
+
+	if !isChange(operations) {
+		return overrides, nil
+	}
+	if cfg.RequireTicket && ticket == "" {
+		return nil, fmt.Errorf("%s requires a ticket for changes; pass --ticket <id>", where)
+	}
+	if cfg.TicketPattern != "" && ticket != "" {
+		pattern, err := regexp.Compile("^(?:" + cfg.TicketPattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticketPattern: %w", err)
+		}
+		if !pattern.MatchString(ticket) {
+			return nil, fmt.Errorf("ticket %q does not match the ticket pattern %s of %s", ticket, cfg.TicketPattern, where)
+		}
+	}
+	if len(cfg.ChangeWindows) == 0 {
+		return overrides, nil
+	}
+	open, next, err := inChangeWindow(cfg.ChangeWindows, now)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return overrides, nil
+	}
+	closed := fmt.Sprintf("%s allows changes only in its change windows", where)
+	if !next.IsZero() {
+		closed += fmt.Sprintf(" (next opens %s)", next.Format("2006-01-02 15:04 MST"))
+	}
+	if overrideReason == "" {
+		return nil, fmt.Errorf("%s; pass --override <reason> to change anyway", closed)
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %s, overridden: %s\n", closed, overrideReason)
+	return append(overrides, "changeWindows"), nil
+}
+
+// changeBudget returns how many changes a batch command may make in one
+// namespace: --max-changes, else the maxChanges of the profile, 0 for no limit
+func changeBudget(cfg *config.Config) int {
+	if maxChanges > 0 || cfg == nil {
+		return maxChanges
+	}
+	return cfg.MaxChanges
+}
+
+// checkChangeBudget fails when a batch command is about to make more changes
+// in a namespace than its budget allows, unless --confirm-over-budget is given
+func checkChangeBudget(budget int, namespaceID string, changes int) error {
+	if budget <= 0 || changes <= budget {
+		return nil
+	}
+	exceeded := fmt.Sprintf("%d change(s) in namespace %s exceed the budget of %d", changes, namespaceLabel(namespaceID), budget)
+	if !confirmOverBudget {
+		return fmt.Errorf("%s; pass --confirm-over-budget to change anyway", exceeded)
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %s, confirmed by --confirm-over-budget\n", exceeded)
+	return nil
+}
+
+// isChange reports whether the operations change the server
+func isChange(operations []string) bool {
+	for _, operation := range operations {
+		if operation != config.OperationRead {
+			return true
+		}
+	}
+	return false
+}
+
+// inChangeWindow reports whether one of the cron expressions matches the minute
+// of now, and otherwise when the next window opens (zero when never)
+func inChangeWindow(windows []string, now time.Time) (bool, time.Time, error) {
+	var next time.Time
+	for _, window := range windows {
+		schedule, err := backup.ParseCron(window)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid change window: %w", err)
+		}
+		if schedule.Matches(now) {
+			return true, time.Time{}, nil
+		}
+		if t := schedule.Next(now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return false, next, nil
+}
+
+// recordChange appends a change, or any command run with --as, to the audit
+// log of the profile. Without an auditLog setting only changes with a ticket,
+// overridden guard rails and impersonation are recorded, in the default log.
+func recordChange(cfg *config.Config, profileName, server, namespaceID string, command, operations, overrides []string) error {
+	if !isChange(operations) && asUser == "" {
+		return nil
+	}
+	path := ""
+	if cfg != nil {
+		path = cfg.AuditLog
+	}
+	if path == "" {
+		if len(overrides) == 0 && ticket == "" && asUser == "" {
+			return nil
+		}
+		var err error
+		if path, err = audit.DefaultPath(); err != nil {
+			return err
+		}
+	}
+	if namespaceID == "" {
+		namespaceID = "public"
+	}
+	hostname, _ := os.Hostname()
+	var changes []string
+	for _, operation := range operations {
+		if operation != config.OperationRead {
+			changes = append(changes, operation)
+		}
+	}
+	if len(changes) == 0 {
+		changes = []string{config.OperationRead}
+	}
+	return audit.Append(path, audit.Entry{
+		Time:       time.Now(),
+		User:       currentUserName(),
+		As:         asUser,
+		Host:       hostname,
+		Profile:    profileName,
+		Server:     server,
+		Namespace:  namespaceID,
+		Command:    recording.RedactArgs(command),
+		Operations: changes,
+		Ticket:     ticket,
+		RequestID:  requestID,
+		Message:    changeMessage,
+		Overrides:  overrides,
+		Reason:     overrideReason,
+	})
+}
+
+// terminalGuard checks the operations of terminal commands against a profile
+// and records them in its audit log
+func terminalGuard(cfg *config.Config, profileName, server string) func(operation, namespaceID, command string) error {
+	return func(operation, namespaceID, command string) error {
+		overrides, err := checkGuardRails(cfg, profileName, namespaceID, time.Now(), operation)
+		if err != nil {
+			return err
+		}
+		return recordChange(cfg, profileName, server, namespaceID, strings.Fields(command), []string{operation}, overrides)
+	}
+}
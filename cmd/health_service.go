@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serviceHealthName       string
+	serviceHealthGroup      string
+	serviceHealthCluster    string
+	serviceHealthMinHealthy int
+	serviceHealthWatch      bool
+	serviceHealthTimeout    time.Duration
+	serviceHealthInterval   time.Duration
+)
+
+var healthServiceCmd = &cobra.Command{
+	Use:   "service-health",
+	Short: "Check that a service has enough healthy instances, optionally over a window",
+	Long:  help.ServiceHealth.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if serviceHealthName == "" {
+			fmt.Fprintf(os.Stderr, "Error: --name is required\n")
+			os.Exit(1)
+		}
+		if serviceHealthMinHealthy < 0 {
+			checkError(fmt.Errorf("--min-healthy must not be negative"))
+		}
+		if serviceHealthWatch && serviceHealthInterval <= 0 {
+			checkError(fmt.Errorf("--interval must be positive"))
+		}
+		serviceHealthGroup = resolveGroup(cmd, serviceHealthGroup, "DEFAULT_GROUP")
+
+		nacosClient := newNacosClient()
+		service := serviceHealthGroup + "@@" + serviceHealthName
+		var deadline time.Time
+		if serviceHealthWatch && serviceHealthTimeout > 0 {
+			deadline = time.Now().Add(serviceHealthTimeout)
+		}
+		for {
+			healthy, total, err := countHealthy(nacosClient, serviceHealthName, serviceHealthGroup, serviceHealthCluster)
+			checkError(err)
+			line := fmt.Sprintf("%s %d/%d instance(s) healthy (minimum %d)", service, healthy, total, serviceHealthMinHealthy)
+			if serviceHealthWatch {
+				line = time.Now().Format("15:04:05") + " " + line
+			}
+			if healthy < serviceHealthMinHealthy {
+				fmt.Printf("FAIL: %s\n", line)
+				os.Exit(1)
+			}
+			if !serviceHealthWatch {
+				fmt.Printf("OK: %s\n", line)
+				return
+			}
+			fmt.Println(line)
+
+			wait := serviceHealthInterval
+			if !deadline.IsZero() {
+				left := time.Until(deadline)
+				if left <= 0 {
+					fmt.Printf("OK: %s stayed at or above %d healthy instance(s) for %s\n", service, serviceHealthMinHealthy, serviceHealthTimeout)
+					return
+				}
+				wait = min(wait, left)
+			}
+			time.Sleep(wait)
+		}
+	},
+}
+
+// countHealthy returns how many instances of a service serve traffic, out of
+// how many are registered
+func countHealthy(nacosClient *client.NacosClient, name, group, cluster string) (int, int, error) {
+	instances, err := nacosClient.ListInstances(name, group, cluster)
+	if err != nil {
+		return 0, 0, err
+	}
+	healthy := 0
+	for _, inst := range instances {
+		if inst.ServesTraffic() {
+			healthy++
+		}
+	}
+	return healthy, len(instances), nil
+}
+
+func init() {
+	healthServiceCmd.Flags().StringVar(&serviceHealthName, "name", "", "Service name")
+	healthServiceCmd.Flags().StringVar(&serviceHealthGroup, "group", "", "Service group (default: DEFAULT_GROUP)")
+	healthServiceCmd.Flags().StringVar(&serviceHealthCluster, "cluster", "", "Only count the instances of this cluster")
+	healthServiceCmd.Flags().IntVar(&serviceHealthMinHealthy, "min-healthy", 1, "Minimum number of healthy instances")
+	healthServiceCmd.Flags().BoolVar(&serviceHealthWatch, "watch", false, "Keep checking until --timeout elapses, failing as soon as the service drops below --min-healthy")
+	healthServiceCmd.Flags().DurationVar(&serviceHealthTimeout, "timeout", 0, "Length of the --watch window (default: until interrupted)")
+	healthServiceCmd.Flags().DurationVar(&serviceHealthInterval, "interval", 5*time.Second, "Time between checks with --watch")
+	rootCmd.AddCommand(healthServiceCmd)
+}
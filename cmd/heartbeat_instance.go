@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	heartbeatService      string
+	heartbeatGroup        string
+	heartbeatAddr         string
+	heartbeatCluster      string
+	heartbeatWeight       float64
+	heartbeatMetadata     []string
+	heartbeatInterval     time.Duration
+	heartbeatDuration     time.Duration
+	heartbeatNoDeregister bool
+)
+
+var heartbeatInstanceCmd = &cobra.Command{
+	Use:   "instance-heartbeat",
+	Short: "Register an ephemeral instance and keep it alive with heartbeats",
+	Long:  help.InstanceHeartbeat.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if heartbeatService == "" || heartbeatAddr == "" {
+			fmt.Fprintf(os.Stderr, "Error: --service and --ip are required\n")
+			os.Exit(1)
+		}
+		inst, err := parseInstanceAddr(heartbeatAddr)
+		checkError(err)
+		inst.ClusterName = heartbeatCluster
+		inst.Weight = heartbeatWeight
+		inst.Enabled, inst.Healthy, inst.Ephemeral = true, true, true
+		inst.Metadata, err = parseMetadata(heartbeatMetadata)
+		checkError(err)
+		if heartbeatInterval <= 0 {
+			checkError(fmt.Errorf("--interval must be positive"))
+		}
+		heartbeatGroup = resolveGroup(cmd, heartbeatGroup, "DEFAULT_GROUP")
+
+		nacosClient := newNacosClient()
+		name := fmt.Sprintf("%s@@%s %s:%d", heartbeatGroup, heartbeatService, inst.IP, inst.Port)
+		checkError(nacosClient.RegisterInstance(heartbeatService, heartbeatGroup, inst))
+		fmt.Fprintf(os.Stderr, "Registered %s in namespace %s, sending heartbeats every %s (Ctrl+C to stop)...\n",
+			name, namespaceLabel(nacosClient.Namespace), heartbeatInterval)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if heartbeatDuration > 0 {
+			ctx, cancel = context.WithTimeout(ctx, heartbeatDuration)
+			defer cancel()
+		}
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		beats := 0
+		for sleepCtx(ctx, heartbeatInterval); ctx.Err() == nil; sleepCtx(ctx, heartbeatInterval) {
+			_, err := nacosClient.SendBeat(heartbeatService, heartbeatGroup, inst)
+			if errors.Is(err, client.ErrInstanceNotFound) {
+				// Expired, e.g. after missed beats or a server restart
+				fmt.Fprintf(os.Stderr, "%s instance expired, registering again\n", time.Now().Format("15:04:05"))
+				err = nacosClient.RegisterInstance(heartbeatService, heartbeatGroup, inst)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s Warning: %v\n", time.Now().Format("15:04:05"), err)
+				continue
+			}
+			beats++
+		}
+
+		fmt.Fprintf(os.Stderr, "Sent %d heartbeat(s)\n", beats)
+		if heartbeatNoDeregister {
+			return
+		}
+		checkError(nacosClient.DeregisterInstance(heartbeatService, heartbeatGroup, inst))
+		fmt.Fprintf(os.Stderr, "Deregistered %s\n", name)
+	},
+}
+
+// parseInstanceAddr parses the ip:port of an instance
+func parseInstanceAddr(addr string) (client.Instance, error) {
+	host, p, err := net.SplitHostPort(addr)
+	if err != nil {
+		return client.Instance{}, fmt.Errorf("invalid instance address %q (expected ip:port): %w", addr, err)
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil || port < 1 || port > 65535 {
+		return client.Instance{}, fmt.Errorf("invalid port in instance address %q", addr)
+	}
+	return client.Instance{IP: host, Port: port}, nil
+}
+
+// parseMetadata parses key=value metadata flags
+func parseMetadata(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	metadata := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid metadata %q (expected key=value)", pair)
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
+
+func init() {
+	heartbeatInstanceCmd.Flags().StringVar(&heartbeatService, "service", "", "Service name")
+	heartbeatInstanceCmd.Flags().StringVar(&heartbeatGroup, "group", "", "Service group (default: DEFAULT_GROUP)")
+	heartbeatInstanceCmd.Flags().StringVar(&heartbeatAddr, "ip", "", "ip:port of the instance")
+	heartbeatInstanceCmd.Flags().StringVar(&heartbeatCluster, "cluster", "DEFAULT", "Cluster of the instance")
+	heartbeatInstanceCmd.Flags().Float64Var(&heartbeatWeight, "weight", 1, "Weight of the instance")
+	heartbeatInstanceCmd.Flags().StringArrayVar(&heartbeatMetadata, "metadata", nil, "Metadata of the instance as key=value (repeatable)")
+	heartbeatInstanceCmd.Flags().DurationVar(&heartbeatInterval, "interval", 5*time.Second, "Time between heartbeats")
+	heartbeatInstanceCmd.Flags().DurationVar(&heartbeatDuration, "duration", 0, "Stop after this long (default: until interrupted)")
+	heartbeatInstanceCmd.Flags().BoolVar(&heartbeatNoDeregister, "no-deregister", false, "Leave the instance registered on exit, to expire on its own")
+	rootCmd.AddCommand(heartbeatInstanceCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyLimit    int
+	historyRevision int64
+	historyOutput   string
+)
+
+// historyOpNames spells out the op types of history revisions
+var historyOpNames = map[string]string{"I": "create", "U": "update", "D": "delete"}
+
+var historyConfigCmd = &cobra.Command{
+	Use:   "config-history dataId [group]",
+	Short: "List the revisions of a configuration or print one of them",
+	Long:  help.ConfigHistory.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID := args[0]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+		checkOutputFormat(historyOutput)
+		nacosClient := newNacosClient()
+
+		if historyRevision > 0 {
+			rev, err := nacosClient.GetConfigHistory(dataID, group, historyRevision)
+			if err != nil {
+				checkError(fmt.Errorf("get revision %d: %w", historyRevision, err))
+			}
+			if historyOutput == "json" {
+				printJSON(rev)
+				return
+			}
+			fmt.Print(rev.Content)
+			return
+		}
+
+		var revisions []client.ConfigHistory
+		total := 0
+		if historyLimit > 0 {
+			page, err := nacosClient.ListConfigHistory(dataID, group, 1, historyLimit)
+			checkError(err)
+			revisions, total = page.PageItems, page.TotalCount
+		} else {
+			var err error
+			revisions, err = nacosClient.ListAllConfigHistory(dataID, group)
+			checkError(err)
+			total = len(revisions)
+		}
+		if historyOutput == "json" {
+			printJSON(revisions)
+			return
+		}
+		if len(revisions) == 0 {
+			fmt.Printf("No history for %s/%s\n", group, dataID)
+			return
+		}
+
+		fmt.Printf("History of %s/%s in namespace %s (%d of %d revisions)\n", group, dataID, namespaceLabel(nacosClient.Namespace), len(revisions), total)
+		fmt.Println("═══════════════════════════════════════════════════════════════════════════════════════════════════════")
+		fmt.Printf("%-10s %-7s %-16s %-16s %-19s %-10s %s\n", "Revision", "Op", "Operator", "Source IP", "Modified", "MD5", "Description")
+		fmt.Println("───────────────────────────────────────────────────────────────────────────────────────────────────────")
+		for _, r := range revisions {
+			op := historyOpNames[r.OpType]
+			if op == "" {
+				op = r.OpType
+			}
+			modified := "-"
+			if t := r.ModifyTime; t > 0 {
+				modified = time.UnixMilli(t).Format("2006-01-02 15:04:05")
+			} else if r.CreateTime > 0 {
+				modified = time.UnixMilli(r.CreateTime).Format("2006-01-02 15:04:05")
+			}
+			md5 := r.Md5
+			if len(md5) > 8 {
+				md5 = md5[:8]
+			}
+			fmt.Printf("%-10d %-7s %-16s %-16s %-19s %-10s %s\n", r.ID, op, r.SrcUser, r.SrcIP, modified, md5, r.Desc)
+		}
+		fmt.Printf("\nShow a revision with --revision <id>, restore it with config-rollback %s %s --revision <id>\n", dataID, group)
+	},
+}
+
+func init() {
+	historyConfigCmd.Flags().IntVar(&historyLimit, "limit", 20, "Number of most recent revisions to list (0 for all)")
+	historyConfigCmd.Flags().Int64Var(&historyRevision, "revision", 0, "Print the content of this revision instead of listing")
+	historyConfigCmd.Flags().StringVarP(&historyOutput, "output", "o", "table", "Output format: table or json")
+	rootCmd.AddCommand(historyConfigCmd)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/spf13/cobra"
+)
+
+var (
+	impactDataID string
+	impactGroup  string
+	impactFile   string
+	impactOutput string
+)
+
+// impactReport lists the clients that a publish of new content would notify
+type impactReport struct {
+	Namespace   string          `json:"namespace"`
+	Group       string          `json:"group"`
+	DataID      string          `json:"dataId"`
+	Exists      bool            `json:"exists"`
+	CurrentMD5  string          `json:"currentMd5,omitempty"`
+	NewMD5      string          `json:"newMd5,omitempty"`
+	Unchanged   bool            `json:"unchanged"`
+	Subscribers []listenerState `json:"subscribers"`
+}
+
+var impactConfigCmd = &cobra.Command{
+	Use:   "config-impact",
+	Short: "List the clients that publishing a configuration would notify",
+	Long:  help.ConfigImpact.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if impactDataID == "" {
+			fmt.Fprintf(os.Stderr, "Error: --data-id is required\n")
+			os.Exit(1)
+		}
+		checkOutputFormat(impactOutput)
+		impactGroup = resolveGroup(cmd, impactGroup, "DEFAULT_GROUP")
+
+		var newContent *string
+		if impactFile != "" {
+			data, err := os.ReadFile(impactFile)
+			checkError(err)
+			content := string(data)
+			newContent = &content
+		}
+
+		nacosClient := newNacosClient()
+		report := &impactReport{Namespace: nacosClient.Namespace, Group: impactGroup, DataID: impactDataID}
+		var err error
+		report.CurrentMD5, report.Subscribers, err = configListeners(nacosClient, impactDataID, impactGroup)
+		checkError(err)
+		report.Exists = report.CurrentMD5 != ""
+		if newContent != nil {
+			report.NewMD5 = listener.CalculateMD5(*newContent)
+			report.Unchanged = report.Exists && report.NewMD5 == report.CurrentMD5
+		}
+
+		if impactOutput == "json" {
+			printJSON(report)
+			return
+		}
+		printImpactReport(report)
+	},
+}
+
+func printImpactReport(report *impactReport) {
+	fmt.Printf("Configuration: %s/%s (namespace %s)\n", report.Group, report.DataID, report.Namespace)
+	if report.Exists {
+		fmt.Printf("Current MD5:   %s\n", report.CurrentMD5)
+	} else {
+		fmt.Println("Current MD5:   (does not exist yet)")
+	}
+	if report.NewMD5 != "" {
+		fmt.Printf("New MD5:       %s\n", report.NewMD5)
+	}
+	fmt.Println()
+
+	if len(report.Subscribers) == 0 {
+		fmt.Println("No clients are listening to this configuration")
+		return
+	}
+	outdated := printListenerTable(report.Subscribers)
+	fmt.Println()
+
+	if report.Unchanged {
+		fmt.Printf("%d client(s) listening; the file matches the server content, publishing it notifies no one\n", len(report.Subscribers))
+		return
+	}
+	fmt.Printf("%d client(s) listening will receive the change", len(report.Subscribers))
+	if outdated > 0 {
+		fmt.Printf(" (%d do not have the current content yet)", outdated)
+	}
+	fmt.Println()
+}
+
+func init() {
+	impactConfigCmd.Flags().StringVar(&impactDataID, "data-id", "", "Configuration data ID")
+	impactConfigCmd.Flags().StringVar(&impactGroup, "group", "", "Configuration group (default: DEFAULT_GROUP)")
+	impactConfigCmd.Flags().StringVarP(&impactFile, "file", "f", "", "New content to compare with the server content")
+	impactConfigCmd.Flags().StringVarP(&impactOutput, "output", "o", "table", "Output format: table or json")
+	rootCmd.AddCommand(impactConfigCmd)
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/nov11/nacos-cli/internal/backup"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importToNamespace string
+	importRewrite     []string
+	importRewriteGrp  []string
+	importMapping     string
+	importOnConflict  string
+	importDryRun      bool
+	importConcurrency int
+)
+
+// importItem is an archived configuration and where it is imported to
+type importItem struct {
+	source  string // group/dataId in the archive
+	target  configRef
+	content string
+	typ     string
+	action  string // create, overwrite, skip or error
+	err     error
+}
+
+var importConfigCmd = &cobra.Command{
+	Use:   "config-import archive",
+	Short: "Import a config-export archive, optionally into another namespace with renames",
+	Long:  help.ConfigImport.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if importOnConflict != "abort" && importOnConflict != "skip" && importOnConflict != "overwrite" {
+			checkError(fmt.Errorf("--on-conflict must be abort, skip or overwrite"))
+		}
+		dataIDRules, err := backup.ParseRewriteRules(importRewrite)
+		checkError(err)
+		groupRules, err := backup.ParseRewriteRules(importRewriteGrp)
+		checkError(err)
+		var mapping *backup.Mapping
+		if importMapping != "" {
+			mapping, err = backup.LoadMapping(importMapping)
+			checkError(err)
+		}
+
+		r, err := storage.OpenFile(args[0], storageOptions)
+		checkError(err)
+		archive, err := backup.Load(r)
+		r.Close()
+		checkError(err)
+
+		nacosClient := newNacosClient()
+		targetNamespace := nacosClient.Namespace
+		if mapped := mapping.Namespace(archive.Manifest.Namespace); mapped != archive.Manifest.Namespace {
+			targetNamespace = mapped
+		}
+		if importToNamespace != "" {
+			targetNamespace = importToNamespace
+		}
+		if targetNamespace != "public" {
+			ns, err := nacosClient.GetNamespace(targetNamespace)
+			checkError(err)
+			if ns == nil {
+				checkError(fmt.Errorf("target namespace %s does not exist", targetNamespace))
+			}
+		}
+		targetClient := nacosClient.WithNamespace(targetNamespace)
+
+		// Bookkeeping such as apply state belongs to the archived namespace
+		skipInternal := targetNamespace != archive.Manifest.Namespace
+		items, err := planImport(archive, mapping, dataIDRules, groupRules, skipInternal)
+		checkError(err)
+		checkImportConflicts(targetClient, items)
+
+		conflicts := 0
+		for _, item := range items {
+			if item.action == "overwrite" {
+				conflicts++
+			}
+		}
+		if conflicts > 0 && importOnConflict == "abort" {
+			for _, item := range items {
+				if item.action == "overwrite" {
+					fmt.Fprintf(os.Stderr, "  exists   %s\n", configtree.Key(item.target.Group, item.target.DataID))
+				}
+			}
+			checkError(fmt.Errorf("%d configuration(s) already exist in namespace %s (use --on-conflict skip or overwrite)", conflicts, targetNamespace))
+		}
+		if importOnConflict == "skip" {
+			for _, item := range items {
+				if item.action == "overwrite" {
+					item.action = "skip"
+				}
+			}
+		}
+
+		if !importDryRun {
+			changes := 0
+			for _, item := range items {
+				if item.action != "skip" {
+					changes++
+				}
+			}
+			checkError(checkChangeBudget(changeBudget(fileConfig), targetNamespace, changes))
+		}
+		if importDryRun {
+			fmt.Printf("Plan for importing %s (namespace %s) into namespace %s (dry run):\n", args[0], archive.Manifest.Namespace, targetNamespace)
+		} else {
+			fmt.Printf("Importing %d configuration(s) from %s (namespace %s) into namespace %s...\n",
+				len(items), args[0], archive.Manifest.Namespace, targetNamespace)
+			publishImport(targetClient, items, importConcurrency)
+		}
+
+		counts := make(map[string]int)
+		for _, item := range items {
+			counts[item.action]++
+			target := configtree.Key(item.target.Group, item.target.DataID)
+			if target != item.source {
+				target = item.source + " -> " + target
+			}
+			if item.err != nil {
+				fmt.Printf("  %-10s %s: %v\n", item.action, target, item.err)
+				continue
+			}
+			fmt.Printf("  %-10s %s\n", item.action, target)
+		}
+		fmt.Printf("\nCreated: %d, Overwritten: %d, Skipped: %d, Failed: %d\n",
+			counts["create"], counts["overwrite"], counts["skip"], counts["error"])
+		if counts["error"] > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// planImport applies the mapping, then the rewrite rules, and rejects invalid
+// or colliding targets
+func planImport(archive *backup.Archive, mapping *backup.Mapping, dataIDRules, groupRules []backup.RewriteRule, skipInternal bool) ([]*importItem, error) {
+	types := make(map[string]string)
+	for _, entry := range archive.Manifest.Entries {
+		types[configtree.Key(entry.Group, entry.DataID)] = entry.Type
+	}
+	keys := make([]string, 0, len(archive.Contents))
+	for key := range archive.Contents {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var items []*importItem
+	sources := make(map[string]string)
+	for _, key := range keys {
+		group, dataID := configtree.SplitKey(key)
+		if skipInternal && isInternalGroup(group) {
+			continue
+		}
+		target := configRef{
+			Group:  backup.Rewrite(groupRules, mapping.Group(group)),
+			DataID: backup.Rewrite(dataIDRules, mapping.DataID(dataID)),
+		}
+		targetKey := configtree.Key(target.Group, target.DataID)
+		if !configtree.ValidName(target.Group) || !configtree.ValidName(target.DataID) {
+			return nil, fmt.Errorf("%s is rewritten to the invalid name %s", key, targetKey)
+		}
+		if other, ok := sources[targetKey]; ok {
+			return nil, fmt.Errorf("%s and %s are both rewritten to %s", other, key, targetKey)
+		}
+		sources[targetKey] = key
+		typ := types[key]
+		if typ == "" {
+			typ = configtree.TypeOf(target.DataID)
+		}
+		items = append(items, &importItem{source: key, target: target, content: archive.Contents[key], typ: typ, action: "create"})
+	}
+	return items, nil
+}
+
+// checkImportConflicts marks the items whose target already exists as overwrites
+func checkImportConflicts(targetClient *client.NacosClient, items []*importItem) {
+	refs := make([]configRef, len(items))
+	for i, item := range items {
+		refs[i] = item.target
+	}
+	_, errs := fetchConfigs(targetClient, refs, importConcurrency)
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			items[i].action = "overwrite"
+		case !errors.Is(err, client.ErrConfigNotFound):
+			items[i].action = "error"
+			items[i].err = err
+		}
+	}
+}
+
+// publishImport publishes the items to create or overwrite concurrently
+func publishImport(targetClient *client.NacosClient, items []*importItem, concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		if item.action != "create" && item.action != "overwrite" {
+			continue
+		}
+		wg.Add(1)
+		go func(item *importItem) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			opts := client.PublishOptions{Type: item.typ}
+			if err := targetClient.PublishConfigIdempotent(item.target.DataID, item.target.Group, item.content, opts, publishRetries); err != nil {
+				item.action = "error"
+				item.err = err
+			}
+		}(item)
+	}
+	wg.Wait()
+}
+
+func init() {
+	importConfigCmd.Flags().StringVar(&importToNamespace, "to-namespace", "", "Namespace to import into (default: the current namespace)")
+	importConfigCmd.Flags().StringArrayVar(&importRewrite, "rewrite", nil, "Rename data IDs: '<regex> => <replacement>', e.g. 'prod-(.*).yaml => dr-$1.yaml' (repeatable)")
+	importConfigCmd.Flags().StringArrayVar(&importRewriteGrp, "rewrite-group", nil, "Rename groups: '<regex> => <replacement>' (repeatable)")
+	importConfigCmd.Flags().StringVar(&importMapping, "mapping", "", "Mapping file renaming the namespace, groups and data IDs, applied before --rewrite")
+	importConfigCmd.Flags().StringVar(&importOnConflict, "on-conflict", "abort", "When a configuration exists: abort, skip or overwrite")
+	importConfigCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without publishing")
+	importConfigCmd.Flags().IntVar(&importConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addStorageFlags(importConfigCmd)
+	rootCmd.AddCommand(importConfigCmd)
+}
@@ -1,27 +1,119 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/config"
+	"github.com/nov11/nacos-cli/internal/diff"
 	"github.com/nov11/nacos-cli/internal/terminal"
 	"github.com/spf13/cobra"
 )
 
+var interactiveNoRecycle bool
+
 var interactiveCmd = &cobra.Command{
 	Use:   "interactive",
 	Short: "Start interactive terminal mode",
 	Long:  `Start an interactive terminal for managing Nacos configurations and skills`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Create Nacos client
-		nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
+		startTerminal()
+	},
+}
+
+// sessionCache keeps the configurations read in the terminal, so that getting
+// an unchanged configuration again does not download it
+var sessionCache = client.NewMemoryCache()
 
-		// Create and start terminal
-		term := terminal.NewTerminal(nacosClient)
-		if err := term.Start(); err != nil {
-			checkError(err)
+// startTerminal creates the shared client and runs the interactive terminal on it
+func startTerminal() {
+	nacosClient := newNacosClient()
+	if nacosClient.Cache == nil {
+		nacosClient.Cache = sessionCache
+	}
+	term := terminal.NewTerminal(nacosClient)
+	term.Profile = profile
+	term.SwitchProfile = terminalProfile
+	if home, err := os.UserHomeDir(); err == nil {
+		term.SuggestionsFile = filepath.Join(home, ".nacos-cli", "suggestions.json")
+		term.BookmarksFile = filepath.Join(home, ".nacos-cli", "bookmarks.json")
+	}
+	if fileConfig != nil {
+		term.Prompt = fileConfig.Prompt
+		term.PromptColor = fileConfig.PromptColor
+		term.DiffMode = fileConfig.DiffMode
+		term.ResolveGroup = fileConfig.ResolveGroup
+		term.Group = fileConfig.ResolveGroup(fileConfig.Group)
+		term.Guard = terminalGuard(fileConfig, profile, serverAddr)
+	}
+	keep, err := terminalKeepDeleted(fileConfig, nacosClient)
+	checkError(err)
+	term.KeepDeleted = keep
+	if err := term.Start(); err != nil {
+		checkError(err)
+	}
+}
+
+// terminalProfile builds the terminal context for a profile of the config file.
+// The profile replaces the server, namespace and credentials given at startup.
+func terminalProfile(name string) (*terminal.Profile, error) {
+	if configFile == "" {
+		return nil, fmt.Errorf("switching profiles requires a config file (--config)")
+	}
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg, err = cfg.WithProfile(name); err != nil {
+		return nil, err
+	}
+	if cfg.DiffMode != "" {
+		if _, err := diff.Get(cfg.DiffMode); err != nil {
+			return nil, err
 		}
-	},
+	}
+	c, err := clientForConfig(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	if c.Cache == nil {
+		c.Cache = sessionCache
+	}
+	keep, err := terminalKeepDeleted(cfg, c)
+	if err != nil {
+		return nil, err
+	}
+	return &terminal.Profile{
+		Name:         name,
+		Client:       c,
+		Group:        cfg.ResolveGroup(cfg.Group),
+		Prompt:       cfg.Prompt,
+		PromptColor:  cfg.PromptColor,
+		DiffMode:     cfg.DiffMode,
+		ResolveGroup: cfg.ResolveGroup,
+		Guard:        terminalGuard(cfg, name, c.ServerAddr),
+		KeepDeleted:  keep,
+	}, nil
+}
+
+// terminalKeepDeleted keeps the configurations the terminal deletes in the
+// recycle bin of cfg; nil when it is disabled or with --no-recycle-bin
+func terminalKeepDeleted(cfg *config.Config, c *client.NacosClient) (func(dataID, group string) error, error) {
+	if interactiveNoRecycle {
+		return nil, nil
+	}
+	bin, err := openRecycleBinOf(cfg, c)
+	if err != nil || bin == nil {
+		return nil, err
+	}
+	return func(dataID, group string) error {
+		return keepDeleted(c, bin, configRef{Group: group, DataID: dataID})
+	}, nil
 }
 
 func init() {
+	interactiveCmd.Flags().BoolVar(&interactiveNoRecycle, "no-recycle-bin", false, "Delete without keeping a copy in the recycle bin")
 	rootCmd.AddCommand(interactiveCmd)
 }
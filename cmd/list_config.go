@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/nov11/nacos-cli/internal/client"
 	"github.com/nov11/nacos-cli/internal/help"
@@ -9,10 +14,14 @@ import (
 )
 
 var (
-	configListPage   int
-	configListSize   int
-	configListDataID string
-	configListGroup  string
+	configListPage    int
+	configListSize    int
+	configListDataID  string
+	configListGroup   string
+	configListDetail  bool
+	configListOutput  string
+	configListContent string
+	configListTags    []string
 )
 
 var listConfigCmd = &cobra.Command{
@@ -20,13 +29,51 @@ var listConfigCmd = &cobra.Command{
 	Short: "List all configurations",
 	Long:  help.ConfigList.FormatForCLI("nacos-cli"),
 	Run: func(cmd *cobra.Command, args []string) {
+		if configListOutput != "table" && configListOutput != "json" {
+			checkError(fmt.Errorf("invalid --output %q (expected table or json)", configListOutput))
+		}
+		if configListContent != "" && len(configListTags) > 0 {
+			checkError(fmt.Errorf("--content-contains and --tag cannot be combined"))
+		}
+
 		// Create Nacos client
-		nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
+		nacosClient := newNacosClient()
 
 		// List configs
-		configs, err := nacosClient.ListConfigs(configListDataID, configListGroup, "", configListPage, configListSize)
+		group := resolveGroup(cmd, configListGroup, "")
+		var configs *client.ConfigListResponse
+		var err error
+		switch {
+		case configListContent != "":
+			configs, err = searchConfigsByContent(nacosClient, configListDataID, group, configListContent, configListPage, configListSize)
+		case len(configListTags) > 0:
+			configs, err = nacosClient.ListConfigsByTags(configListDataID, group, configListTags, configListPage, configListSize)
+		default:
+			configs, err = nacosClient.ListConfigs(configListDataID, group, "", configListPage, configListSize)
+		}
 		checkError(err)
 
+		if configListDetail {
+			for i, err := range fillConfigDetails(nacosClient, configs.PageItems, 8) {
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", configs.PageItems[i].DataID, err)
+				}
+			}
+		}
+
+		startPager()
+		if configListOutput == "json" {
+			for i := range configs.PageItems {
+				if configs.PageItems[i].GroupName == "" {
+					configs.PageItems[i].GroupName = configs.PageItems[i].Group
+				}
+			}
+			data, err := json.MarshalIndent(configs, "", "  ")
+			checkError(err)
+			fmt.Println(string(data))
+			return
+		}
+
 		// Display results
 		if len(configs.PageItems) == 0 {
 			fmt.Println("No configurations found")
@@ -34,9 +81,15 @@ var listConfigCmd = &cobra.Command{
 		}
 
 		fmt.Printf("Configuration List (Total: %d)\n", configs.TotalCount)
-		fmt.Println("═══════════════════════════════════════════════════════════════")
-		fmt.Printf("%-5s %-30s %-20s %-10s\n", "No.", "Data ID", "Group", "Type")
-		fmt.Println("───────────────────────────────────────────────────────────────")
+		if configListDetail {
+			fmt.Println("═══════════════════════════════════════════════════════════════════════════════════════════════════════")
+			fmt.Printf("%-5s %-30s %-20s %-10s %-19s %-10s %s\n", "No.", "Data ID", "Group", "Type", "Modified", "MD5", "Tags")
+			fmt.Println("───────────────────────────────────────────────────────────────────────────────────────────────────────")
+		} else {
+			fmt.Println("═══════════════════════════════════════════════════════════════")
+			fmt.Printf("%-5s %-30s %-20s %-10s\n", "No.", "Data ID", "Group", "Type")
+			fmt.Println("───────────────────────────────────────────────────────────────")
+		}
 
 		for i, config := range configs.PageItems {
 			groupName := config.GroupName
@@ -53,15 +106,77 @@ var listConfigCmd = &cobra.Command{
 				groupName = groupName[:15] + "..."
 			}
 
-			fmt.Printf("%-5d %-30s %-20s %-10s\n", i+1, dataID, groupName, config.Type)
+			if !configListDetail {
+				fmt.Printf("%-5d %-30s %-20s %-10s\n", i+1, dataID, groupName, config.Type)
+				continue
+			}
+			modified := ""
+			if config.ModifyTime > 0 {
+				modified = time.UnixMilli(config.ModifyTime).Format("2006-01-02 15:04:05")
+			}
+			md5 := config.Md5
+			if len(md5) > 8 {
+				md5 = md5[:8]
+			}
+			fmt.Printf("%-5d %-30s %-20s %-10s %-19s %-10s %s\n", i+1, dataID, groupName, config.Type, modified, md5, config.Tags)
 		}
 	},
 }
 
+// searchConfigsByContent uses the server-side content search, falling back to
+// fetching every matching config and searching client-side when the server
+// rejects the search; other errors are returned
+func searchConfigsByContent(nacosClient *client.NacosClient, dataID, group, keyword string, pageNo, pageSize int) (*client.ConfigListResponse, error) {
+	result, err := nacosClient.SearchConfigsByContent(dataID, group, keyword, pageNo, pageSize)
+	if !errors.Is(err, client.ErrContentSearchUnsupported) {
+		return result, err
+	}
+	fmt.Fprintf(os.Stderr, "Server-side content search unavailable (%v), searching client-side...\n", err)
+
+	items, err := nacosClient.ListAllConfigs(dataID, group)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]configRef, len(items))
+	for i, item := range items {
+		refs[i] = configRef{Group: item.GroupName, DataID: item.DataID}
+	}
+	contents, errs := fetchConfigs(nacosClient, refs, 8)
+	var matched []client.Config
+	for i, item := range items {
+		if errs[i] == nil && strings.Contains(contents[i], keyword) {
+			item.Content = contents[i]
+			matched = append(matched, item)
+		}
+	}
+
+	if pageNo < 1 {
+		pageNo = 1
+	}
+	start := (pageNo - 1) * pageSize
+	end := start + pageSize
+	if start > len(matched) {
+		start = len(matched)
+	}
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return &client.ConfigListResponse{
+		TotalCount:     len(matched),
+		PageNumber:     pageNo,
+		PagesAvailable: (len(matched) + pageSize - 1) / pageSize,
+		PageItems:      matched[start:end],
+	}, nil
+}
+
 func init() {
 	listConfigCmd.Flags().IntVar(&configListPage, "page", 1, "Page number (default: 1)")
 	listConfigCmd.Flags().IntVar(&configListSize, "size", 20, "Page size (default: 20)")
 	listConfigCmd.Flags().StringVar(&configListDataID, "data-id", "", "Filter by data ID (supports wildcard *, e.g. 'resource*')")
 	listConfigCmd.Flags().StringVar(&configListGroup, "group", "", "Filter by group (supports wildcard *, e.g. 'skill_*')")
+	listConfigCmd.Flags().StringVar(&configListContent, "content-contains", "", "Only configs whose content contains this keyword")
+	listConfigCmd.Flags().StringSliceVar(&configListTags, "tag", nil, "Only configs with one of the tags (repeatable or comma separated)")
+	listConfigCmd.Flags().BoolVar(&configListDetail, "detail", false, "Fetch each config's metadata (MD5, modify time, tags)")
+	listConfigCmd.Flags().StringVarP(&configListOutput, "output", "o", "table", "Output format: table or json")
 	rootCmd.AddCommand(listConfigCmd)
 }
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	groupListFilter string
+	groupListOutput string
+)
+
+// groupSummary is a group with its number of configurations
+type groupSummary struct {
+	Group       string `json:"group"`
+	ConfigCount int    `json:"configCount"`
+}
+
+var listGroupCmd = &cobra.Command{
+	Use:   "group-list",
+	Short: "List the groups of a namespace with their config counts",
+	Long:  help.GroupList.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		if groupListOutput != "table" && groupListOutput != "json" {
+			checkError(fmt.Errorf("invalid --output %q (expected table or json)", groupListOutput))
+		}
+
+		nacosClient := newNacosClient()
+
+		// Nacos has no group catalog API; aggregate the config list
+		items, err := nacosClient.ListAllConfigs("", groupListFilter)
+		checkError(err)
+		counts := make(map[string]int)
+		for _, item := range items {
+			counts[item.GroupName]++
+		}
+		groups := make([]groupSummary, 0, len(counts))
+		for group, count := range counts {
+			groups = append(groups, groupSummary{Group: group, ConfigCount: count})
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Group < groups[j].Group })
+
+		if groupListOutput == "json" {
+			data, err := json.MarshalIndent(map[string]interface{}{
+				"namespace":   nacosClient.Namespace,
+				"configCount": len(items),
+				"groups":      groups,
+			}, "", "  ")
+			checkError(err)
+			fmt.Println(string(data))
+			return
+		}
+
+		fmt.Printf("Namespace %s: %d group(s), %d configuration(s)\n", nacosClient.Namespace, len(groups), len(items))
+		if len(groups) == 0 {
+			return
+		}
+		fmt.Println("═══════════════════════════════════════════════════════════════")
+		fmt.Printf("%-5s %-45s %s\n", "No.", "Group", "Configs")
+		fmt.Println("───────────────────────────────────────────────────────────────")
+		for i, g := range groups {
+			fmt.Printf("%-5d %-45s %d\n", i+1, g.Group, g.ConfigCount)
+		}
+	},
+}
+
+func init() {
+	listGroupCmd.Flags().StringVar(&groupListFilter, "group", "", "Filter by group (supports wildcard *)")
+	listGroupCmd.Flags().StringVarP(&groupListOutput, "output", "o", "table", "Output format: table or json")
+	rootCmd.AddCommand(listGroupCmd)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serviceListName          string
+	serviceListGroup         string
+	serviceListUnhealthyOnly bool
+	serviceListOutput        string
+	serviceListConcurrency   int
+)
+
+// serviceSummary is a service of the catalog with its health
+type serviceSummary struct {
+	Name             string  `json:"name"`
+	Group            string  `json:"group"`
+	Instances        int     `json:"instances"`
+	Healthy          int     `json:"healthy"`
+	ProtectThreshold float64 `json:"protectThreshold"`
+	Status           string  `json:"status"`
+}
+
+var listServiceCmd = &cobra.Command{
+	Use:   "service-list",
+	Short: "List the services of a namespace with their instance health",
+	Long:  help.ServiceList.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(serviceListOutput)
+
+		nacosClient := newNacosClient()
+		services, err := nacosClient.ListAllServices(serviceListName, serviceListGroup)
+		checkError(err)
+		if serviceListUnhealthyOnly {
+			unhealthy := services[:0]
+			for _, svc := range services {
+				if svc.HealthyInstanceCount < svc.IPCount {
+					unhealthy = append(unhealthy, svc)
+				}
+			}
+			services = unhealthy
+		}
+		summaries, err := summarizeServices(nacosClient, services, serviceListConcurrency)
+		checkError(err)
+
+		if serviceListOutput == "json" {
+			printJSON(struct {
+				Namespace string           `json:"namespace"`
+				Services  []serviceSummary `json:"services"`
+			}{nacosClient.Namespace, summaries})
+			return
+		}
+		fmt.Printf("Namespace %s: %d service(s)\n", namespaceLabel(nacosClient.Namespace), len(summaries))
+		if len(summaries) == 0 {
+			return
+		}
+		printServiceTable(summaries)
+	},
+}
+
+// summarizeServices reads the protect threshold of each service, which the
+// catalog does not return, concurrently. Results are in the order of services.
+func summarizeServices(nacosClient *client.NacosClient, services []client.Service, concurrency int) ([]serviceSummary, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	summaries := make([]serviceSummary, len(services))
+	errs := make([]error, len(services))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, svc := range services {
+		wg.Add(1)
+		go func(i int, svc client.Service) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			s := serviceSummary{Name: svc.Name, Group: svc.GroupName, Instances: svc.IPCount, Healthy: svc.HealthyInstanceCount}
+			detail, err := nacosClient.GetService(svc.Name, svc.GroupName)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s@@%s: %w", svc.GroupName, svc.Name, err)
+				return
+			}
+			s.ProtectThreshold = detail.ProtectThreshold
+			s.Status = serviceStatus(s)
+			summaries[i] = s
+		}(i, svc)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return summaries, nil
+}
+
+// serviceStatus summarizes the health of a service. A service is protected
+// when the ratio of its healthy instances is at or below the protect
+// threshold: Nacos then returns the unhealthy instances to clients as well.
+func serviceStatus(s serviceSummary) string {
+	switch {
+	case s.Instances == 0:
+		return "empty"
+	case s.ProtectThreshold > 0 && float64(s.Healthy)/float64(s.Instances) <= s.ProtectThreshold:
+		return "protected"
+	case s.Healthy == 0:
+		return "down"
+	case s.Healthy < s.Instances:
+		return "degraded"
+	}
+	return "healthy"
+}
+
+func printServiceTable(summaries []serviceSummary) {
+	color := useColor()
+	fmt.Println("══════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%-32s %-20s %-10s %-8s %-10s %s\n", "Service", "Group", "Instances", "Healthy", "Threshold", "Status")
+	fmt.Println("──────────────────────────────────────────────────────────────────────────────────────")
+	for _, s := range summaries {
+		status := s.Status
+		if color && (status == "protected" || status == "down") {
+			status = "\033[31m" + status + "\033[0m"
+		}
+		fmt.Printf("%-32s %-20s %-10d %-8d %-10.2f %s\n", s.Name, s.Group, s.Instances, s.Healthy, s.ProtectThreshold, status)
+	}
+}
+
+func init() {
+	listServiceCmd.Flags().StringVar(&serviceListName, "name", "", "Filter by service name (substring match)")
+	listServiceCmd.Flags().StringVar(&serviceListGroup, "group", "", "Filter by group (substring match)")
+	listServiceCmd.Flags().BoolVar(&serviceListUnhealthyOnly, "unhealthy-only", false, "Only list services with unhealthy instances")
+	listServiceCmd.Flags().StringVarP(&serviceListOutput, "output", "o", "table", "Output format: table or json")
+	listServiceCmd.Flags().IntVar(&serviceListConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	rootCmd.AddCommand(listServiceCmd)
+}
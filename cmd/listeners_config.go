@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listenersDataID string
+	listenersGroup  string
+	listenersOutput string
+)
+
+// listenerState is a client listening to a configuration, compared with the
+// content on the server
+type listenerState struct {
+	client.ConfigSubscriber
+	Current bool `json:"current"` // the client has the content currently on the server
+}
+
+var listenersConfigCmd = &cobra.Command{
+	Use:   "config-listeners",
+	Short: "List the clients listening to a configuration and the MD5 they have",
+	Long:  help.ConfigListeners.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if listenersDataID == "" {
+			fmt.Fprintf(os.Stderr, "Error: --data-id is required\n")
+			os.Exit(1)
+		}
+		checkOutputFormat(listenersOutput)
+		listenersGroup = resolveGroup(cmd, listenersGroup, "DEFAULT_GROUP")
+
+		nacosClient := newNacosClient()
+		md5, states, err := configListeners(nacosClient, listenersDataID, listenersGroup)
+		checkError(err)
+
+		if listenersOutput == "json" {
+			printJSON(struct {
+				Namespace string          `json:"namespace"`
+				Group     string          `json:"group"`
+				DataID    string          `json:"dataId"`
+				MD5       string          `json:"md5"`
+				Listeners []listenerState `json:"listeners"`
+			}{nacosClient.Namespace, listenersGroup, listenersDataID, md5, states})
+			return
+		}
+		if md5 == "" {
+			md5 = "(does not exist)"
+		}
+		fmt.Printf("Listeners of %s/%s (namespace %s, server MD5 %s)\n\n", listenersGroup, listenersDataID, nacosClient.Namespace, md5)
+		if len(states) == 0 {
+			fmt.Println("No clients are listening to this configuration")
+			return
+		}
+		outdated := printListenerTable(states)
+		fmt.Printf("\nTotal: %d client(s), %d out of date\n", len(states), outdated)
+	},
+}
+
+// configListeners returns the MD5 of the configuration on the server, empty
+// when it does not exist, and the clients listening to it
+func configListeners(nacosClient *client.NacosClient, dataID, group string) (string, []listenerState, error) {
+	md5 := ""
+	content, err := nacosClient.GetConfig(dataID, group)
+	if err == nil {
+		md5 = listener.CalculateMD5(content)
+	} else if !errors.Is(err, client.ErrConfigNotFound) {
+		return "", nil, err
+	}
+	subscribers, err := nacosClient.ListConfigSubscribers(dataID, group)
+	if err != nil {
+		return "", nil, err
+	}
+	states := make([]listenerState, len(subscribers))
+	for i, s := range subscribers {
+		states[i] = listenerState{ConfigSubscriber: s, Current: s.MD5 == md5}
+	}
+	return md5, states, nil
+}
+
+// printListenerTable prints the clients, highlighting those out of date, and
+// returns how many are
+func printListenerTable(states []listenerState) int {
+	color := useColor()
+	fmt.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Printf("%-24s %-34s %s\n", "CLIENT", "MD5", "STATE")
+	fmt.Println("───────────────────────────────────────────────────────────────")
+	outdated := 0
+	for _, s := range states {
+		state := "current"
+		if !s.Current {
+			state = "out of date"
+			if color {
+				state = "\033[31mout of date\033[0m"
+			}
+			outdated++
+		}
+		fmt.Printf("%-24s %-34s %s\n", s.IP, s.MD5, state)
+	}
+	return outdated
+}
+
+func init() {
+	listenersConfigCmd.Flags().StringVar(&listenersDataID, "data-id", "", "Configuration data ID")
+	listenersConfigCmd.Flags().StringVar(&listenersGroup, "group", "", "Configuration group (default: DEFAULT_GROUP)")
+	listenersConfigCmd.Flags().StringVarP(&listenersOutput, "output", "o", "table", "Output format: table or json")
+	rootCmd.AddCommand(listenersConfigCmd)
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+// loadgenMarker is the tag of generated configurations and the metadata key of
+// generated services and instances; configurations also get the tag
+// loadgen-<run>
+const loadgenMarker = "loadgen"
+
+// maxLoadgenInstances is how many instance addresses 198.18.0.0/15, the
+// benchmarking range, provides
+const maxLoadgenInstances = 1<<17 - 2
+
+var (
+	loadgenConfigs     int
+	loadgenSize        string
+	loadgenServices    int
+	loadgenInstances   int
+	loadgenGroup       string
+	loadgenPrefix      string
+	loadgenRun         string
+	loadgenConcurrency int
+
+	loadgenCleanupRun         string
+	loadgenCleanupGroup       string
+	loadgenCleanupDryRun      bool
+	loadgenCleanupYes         bool
+	loadgenCleanupConcurrency int
+)
+
+var loadgenCmd = &cobra.Command{
+	Use:   "loadgen",
+	Short: "Populate a test cluster with synthetic configurations and services",
+	Long:  help.Loadgen.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if loadgenConfigs < 0 || loadgenServices < 0 || loadgenInstances < 0 {
+			checkError(fmt.Errorf("--configs, --services and --instances cannot be negative"))
+		}
+		if loadgenConfigs == 0 && loadgenServices == 0 {
+			checkError(fmt.Errorf("nothing to generate: pass --configs and/or --services"))
+		}
+		if loadgenServices*loadgenInstances > maxLoadgenInstances {
+			checkError(fmt.Errorf("at most %d instances can be generated", maxLoadgenInstances))
+		}
+		size, err := parseSize(loadgenSize)
+		checkError(err)
+		run := loadgenRun
+		if run == "" {
+			run = time.Now().UTC().Format("20060102-150405")
+		}
+		if strings.ContainsAny(run, ", ") {
+			checkError(fmt.Errorf("invalid run %q (cannot contain commas or spaces)", run))
+		}
+		group := resolveGroup(cmd, loadgenGroup, "LOADGEN_GROUP")
+
+		nacosClient := newNacosClient()
+		checkError(checkTestTarget(cmd.Name(), nacosClient.Namespace))
+		changes := loadgenConfigs + loadgenServices*(1+loadgenInstances)
+		checkError(checkChangeBudget(changeBudget(fileConfig), nacosClient.Namespace, changes))
+		fmt.Fprintf(os.Stderr, "Generating run %s in namespace %s, group %s...\n", run, namespaceLabel(nacosClient.Namespace), group)
+
+		tags := []string{loadgenMarker, loadgenMarker + "-" + run}
+		start := time.Now()
+		configsFailed, configErr := runParallel(loadgenConfigs, loadgenConcurrency, func(i int) error {
+			dataID := fmt.Sprintf("%s-%s-%05d.yaml", loadgenPrefix, run, i+1)
+			return nacosClient.PublishConfigWithOptions(dataID, group, loadgenContent(dataID, run, i, size), client.PublishOptions{
+				Tags: tags,
+				Type: "yaml",
+				Desc: "generated by nacos-cli loadgen, run " + run,
+			})
+		})
+		configTime := time.Since(start)
+
+		start = time.Now()
+		servicesFailed, serviceErr := runParallel(loadgenServices, loadgenConcurrency, func(i int) error {
+			name := fmt.Sprintf("%s-%s-svc-%04d", loadgenPrefix, run, i+1)
+			marker := map[string]string{loadgenMarker: run}
+			// Persistent instances stay registered without heartbeats
+			err := nacosClient.CreateService(client.ServiceDetail{ServiceName: name, GroupName: group, Metadata: marker})
+			if err != nil {
+				return err
+			}
+			for j := 0; j < loadgenInstances; j++ {
+				err := nacosClient.RegisterInstance(name, group, client.Instance{
+					IP:          loadgenIP(i*loadgenInstances + j),
+					Port:        8080,
+					Weight:      1,
+					Healthy:     true,
+					Enabled:     true,
+					ClusterName: "DEFAULT",
+					Metadata:    marker,
+				})
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+			}
+			return nil
+		})
+		serviceTime := time.Since(start)
+
+		fmt.Printf("Run %s in namespace %s, group %s\n", run, namespaceLabel(nacosClient.Namespace), group)
+		if loadgenConfigs > 0 {
+			fmt.Printf("  configs:  %d published (%d bytes each) in %s, %.0f/s, %d failed\n",
+				loadgenConfigs-configsFailed, size, configTime.Round(time.Millisecond),
+				float64(loadgenConfigs-configsFailed)/configTime.Seconds(), configsFailed)
+		}
+		if loadgenServices > 0 {
+			fmt.Printf("  services: %d created with %d instance(s) each in %s, %d failed\n",
+				loadgenServices-servicesFailed, loadgenInstances, serviceTime.Round(time.Millisecond), servicesFailed)
+		}
+		for _, err := range []error{configErr, serviceErr} {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "First error: %v\n", err)
+			}
+		}
+		cleanup := "nacos-cli loadgen-cleanup --run " + run
+		if ns := namespaceLabel(nacosClient.Namespace); ns != "public" {
+			cleanup += " -n " + ns
+		}
+		if group != "LOADGEN_GROUP" {
+			cleanup += " --group " + group
+		}
+		fmt.Printf("Remove it with: %s\n", cleanup)
+		if configsFailed > 0 || servicesFailed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var loadgenCleanupCmd = &cobra.Command{
+	Use:   "loadgen-cleanup",
+	Short: "Remove the configurations and services created by loadgen",
+	Long:  help.LoadgenCleanup.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		tag := loadgenMarker
+		if loadgenCleanupRun != "" {
+			tag += "-" + loadgenCleanupRun
+		}
+		group := resolveGroup(cmd, loadgenCleanupGroup, "LOADGEN_GROUP")
+		nacosClient := newNacosClient()
+
+		// Configurations are found by their tag in every group, services by
+		// their metadata in the loadgen group
+		configs, err := nacosClient.ListAllConfigsByTags("", "", []string{tag})
+		checkError(err)
+		services, err := findLoadgenServices(nacosClient, group, loadgenCleanupRun, loadgenCleanupConcurrency)
+		checkError(err)
+
+		fmt.Printf("Namespace %s: %d configuration(s) and %d service(s) generated by %s\n",
+			namespaceLabel(nacosClient.Namespace), len(configs), len(services), tag)
+		if len(configs)+len(services) == 0 || loadgenCleanupDryRun {
+			if loadgenCleanupDryRun && len(configs)+len(services) > 0 {
+				fmt.Println("Dry run, nothing deleted")
+			}
+			return
+		}
+		checkError(checkChangeBudget(changeBudget(fileConfig), nacosClient.Namespace, len(configs)+len(services)))
+		checkError(confirmByTyping(fmt.Sprintf("delete %d configuration(s) and %d service(s)", len(configs), len(services)), tag, loadgenCleanupYes))
+
+		configsFailed, configErr := runParallel(len(configs), loadgenCleanupConcurrency, func(i int) error {
+			return nacosClient.DeleteConfig(configs[i].DataID, configs[i].GroupName)
+		})
+		servicesFailed, serviceErr := runParallel(len(services), loadgenCleanupConcurrency, func(i int) error {
+			name := services[i]
+			instances, err := nacosClient.ListInstances(name, group, "")
+			if err != nil {
+				return err
+			}
+			for _, inst := range instances {
+				if err := nacosClient.DeregisterInstance(name, group, inst); err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+			}
+			return nacosClient.DeleteService(name, group)
+		})
+
+		fmt.Printf("Deleted: %d configuration(s), %d service(s), Failed: %d\n",
+			len(configs)-configsFailed, len(services)-servicesFailed, configsFailed+servicesFailed)
+		for _, err := range []error{configErr, serviceErr} {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "First error: %v\n", err)
+			}
+		}
+		if configsFailed > 0 || servicesFailed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// findLoadgenServices returns the services of a group generated by loadgen,
+// by any run when run is empty
+func findLoadgenServices(nacosClient *client.NacosClient, group, run string, concurrency int) ([]string, error) {
+	services, err := nacosClient.ListAllServices("", group)
+	if err != nil {
+		return nil, err
+	}
+	generated := make([]bool, len(services))
+	_, firstErr := runParallel(len(services), concurrency, func(i int) error {
+		detail, err := nacosClient.GetService(services[i].Name, group)
+		if err != nil {
+			return err
+		}
+		marker, ok := detail.Metadata[loadgenMarker]
+		generated[i] = ok && (run == "" || marker == run)
+		return nil
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	var names []string
+	for i, svc := range services {
+		if generated[i] {
+			names = append(names, svc.Name)
+		}
+	}
+	return names, nil
+}
+
+// runParallel calls fn for 0..n-1 with at most concurrency calls at a time and
+// returns how many failed with the first error
+func runParallel(n, concurrency int, fn func(i int) error) (int, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failed   int
+		firstErr error
+	)
+	sem := make(chan struct{}, max(concurrency, 1))
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(i); err != nil {
+				mu.Lock()
+				failed++
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return failed, firstErr
+}
+
+// loadgenContent generates YAML of about size bytes, different for each
+// configuration but the same across runs
+func loadgenContent(dataID, run string, i, size int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	rnd := rand.New(rand.NewSource(int64(i)))
+	var b strings.Builder
+	fmt.Fprintf(&b, "# generated by nacos-cli loadgen, run %s\napp:\n  name: %s\n  settings:\n", run, dataID)
+	for k := 0; b.Len() < size; k++ {
+		value := make([]byte, 8+rnd.Intn(40))
+		for j := range value {
+			value[j] = letters[rnd.Intn(len(letters))]
+		}
+		fmt.Fprintf(&b, "    key-%04d: %s\n", k, value)
+	}
+	return b.String()
+}
+
+// loadgenIP returns the address of the nth generated instance in 198.18.0.0/15
+func loadgenIP(n int) string {
+	n++
+	return fmt.Sprintf("198.%d.%d.%d", 18+n>>16, n>>8&255, n&255)
+}
+
+// parseSize parses a size in bytes with an optional b, kb or mb suffix
+func parseSize(s string) (int, error) {
+	number, unit := strings.ToLower(s), 1
+	for _, suffix := range []struct {
+		name string
+		unit int
+	}{{"kb", 1 << 10}, {"k", 1 << 10}, {"mb", 1 << 20}, {"m", 1 << 20}, {"b", 1}} {
+		if n, ok := strings.CutSuffix(number, suffix.name); ok {
+			number, unit = n, suffix.unit
+			break
+		}
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(number))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (e.g. 512, 2kb or 1mb)", s)
+	}
+	return n * unit, nil
+}
+
+func init() {
+	loadgenCmd.Flags().IntVar(&loadgenConfigs, "configs", 0, "Configurations to publish")
+	loadgenCmd.Flags().StringVar(&loadgenSize, "size", "2kb", "Size of each configuration, e.g. 512, 2kb or 1mb")
+	loadgenCmd.Flags().IntVar(&loadgenServices, "services", 0, "Services to create")
+	loadgenCmd.Flags().IntVar(&loadgenInstances, "instances", 0, "Persistent instances to register per service")
+	loadgenCmd.Flags().StringVar(&loadgenGroup, "group", "", "Group of the configurations and services (default: LOADGEN_GROUP)")
+	loadgenCmd.Flags().StringVar(&loadgenPrefix, "prefix", "loadgen", "Prefix of the generated names")
+	loadgenCmd.Flags().StringVar(&loadgenRun, "run", "", "Name of the run, used in the names and tags (default: the current time)")
+	loadgenCmd.Flags().IntVar(&loadgenConcurrency, "concurrency", 16, "Parallel requests")
+	rootCmd.AddCommand(loadgenCmd)
+
+	loadgenCleanupCmd.Flags().StringVar(&loadgenCleanupRun, "run", "", "Only remove what this run created (default: every run)")
+	loadgenCleanupCmd.Flags().StringVar(&loadgenCleanupGroup, "group", "", "Group of the generated services (default: LOADGEN_GROUP)")
+	loadgenCleanupCmd.Flags().BoolVar(&loadgenCleanupDryRun, "dry-run", false, "Count what would be removed without deleting it")
+	loadgenCleanupCmd.Flags().BoolVarP(&loadgenCleanupYes, "yes", "y", false, "Delete without asking for confirmation")
+	loadgenCleanupCmd.Flags().IntVar(&loadgenCleanupConcurrency, "concurrency", 16, "Parallel requests")
+	rootCmd.AddCommand(loadgenCleanupCmd)
+}
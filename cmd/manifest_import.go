@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/project"
+	"github.com/nov11/nacos-cli/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	manifestImportDest         string
+	manifestImportProject      string
+	manifestImportStateFile    string
+	manifestImportStateBackend string
+	manifestImportForce        bool
+	manifestImportDryRun       bool
+	manifestImportConcurrency  int
+)
+
+var manifestImportCmd = &cobra.Command{
+	Use:   "manifest-import",
+	Short: "Bring the configurations on the server under apply: write them to a directory and record them in the apply state",
+	Long:  help.ManifestImport.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		proj, err := project.Load(manifestImportDest, manifestImportProject)
+		checkError(err)
+		_, statErr := os.Stat(filepath.Join(manifestImportDest, project.FileName))
+		writeProject := os.IsNotExist(statErr)
+		if manifestImportStateBackend != "" {
+			proj.State.Type = manifestImportStateBackend
+		}
+		if manifestImportStateFile != "" {
+			path, err := filepath.Abs(manifestImportStateFile)
+			checkError(err)
+			proj.State.Type = state.BackendLocal
+			proj.State.Path = path
+		}
+
+		nacosClient := newNacosClient()
+		items, err := nacosClient.ListAllConfigs("", "")
+		checkError(err)
+		sel := parseSelector()
+		items, err = selectConfigs(nacosClient, sel, items, manifestImportConcurrency)
+		checkError(err)
+		var refs []configRef
+		for _, item := range items {
+			if isInternalGroup(item.GroupName) {
+				continue
+			}
+			if !configtree.ValidName(item.GroupName) || !configtree.ValidName(item.DataID) {
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s/%s: not usable as a file path\n", item.GroupName, item.DataID)
+				continue
+			}
+			refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+		}
+
+		backend, err := state.Open(proj.State, nacosClient, manifestImportDest)
+		checkError(err)
+		st, err := backend.Load(nacosClient.Namespace)
+		checkError(err)
+
+		if manifestImportDryRun {
+			fmt.Printf("Import plan for namespace %s into %s (dry run):\n", namespaceLabel(nacosClient.Namespace), manifestImportDest)
+		} else {
+			fmt.Printf("Importing %d configuration(s) from namespace %s into %s...\n", len(refs), namespaceLabel(nacosClient.Namespace), manifestImportDest)
+		}
+		details, errs := fetchConfigDetails(nacosClient, refs, manifestImportConcurrency)
+
+		manifest := &configtree.Manifest{
+			Server:    nacosClient.ServerAddr,
+			Namespace: nacosClient.Namespace,
+			PulledAt:  time.Now(),
+		}
+		if sel != nil {
+			manifest.Selector = sel.String()
+		}
+		counts := make(map[string]int)
+		report := func(action, key, detail string) {
+			counts[action]++
+			if detail != "" {
+				fmt.Printf("  %-9s %s: %s\n", action, key, detail)
+			} else {
+				fmt.Printf("  %-9s %s\n", action, key)
+			}
+		}
+		for i, ref := range refs {
+			key := configtree.Key(ref.Group, ref.DataID)
+			if errs[i] != nil {
+				report("error", key, errs[i].Error())
+				continue
+			}
+			detail := details[i]
+			if owner := project.Owner(detail.Tags()); owner != "" && owner != proj.Name {
+				report("protected", key, fmt.Sprintf("owned by project %q", owner))
+				continue
+			}
+			path := configtree.Path(manifestImportDest, ref.Group, ref.DataID)
+			if local, err := os.ReadFile(path); err == nil && string(local) != detail.Content && !manifestImportForce {
+				report("conflict", key, "a different local file exists, use --force to overwrite it")
+				continue
+			}
+			md5 := listener.CalculateMD5(detail.Content)
+			manifest.Entries = append(manifest.Entries, configtree.ManifestEntry{
+				Group:   ref.Group,
+				DataID:  ref.DataID,
+				MD5:     md5,
+				Type:    detail.Type,
+				Tags:    detail.Tags(),
+				Desc:    detail.Desc,
+				AppName: detail.AppName,
+			})
+			if !manifestImportDryRun {
+				checkError(os.MkdirAll(filepath.Dir(path), 0755))
+				if err := os.WriteFile(path, []byte(detail.Content), 0644); err != nil {
+					report("error", key, err.Error())
+					continue
+				}
+				st.Record(key, detail.Content, md5)
+			}
+			report("import", key, "")
+		}
+
+		if !manifestImportDryRun {
+			checkError(os.MkdirAll(manifestImportDest, 0755))
+			checkError(manifest.Save(manifestImportDest))
+			if writeProject {
+				checkError(saveImportedProject(proj))
+			}
+			checkError(backend.Save(st))
+		}
+
+		fmt.Printf("\nImported: %d, Protected: %d, Conflicts: %d, Failed: %d\n",
+			counts["import"], counts["protected"], counts["conflict"], counts["error"])
+		if !manifestImportDryRun && counts["import"] > 0 {
+			fmt.Printf("State recorded in %s. Run apply --dry-run --dir %s to check: configurations not labelled owner:%s yet show as adopt\n",
+				backend.Describe(), manifestImportDest, proj.Name)
+		}
+		if counts["error"] > 0 || counts["conflict"] > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// saveImportedProject writes the project file of an imported directory, so
+// apply uses the same project name and state backend
+func saveImportedProject(proj *project.Project) error {
+	content := fmt.Sprintf("name: %s\n", proj.Name)
+	if proj.State.Type != "" {
+		content += fmt.Sprintf("state:\n  type: %s\n", proj.State.Type)
+		if proj.State.Path != "" {
+			content += fmt.Sprintf("  path: %s\n", proj.State.Path)
+		}
+	}
+	return os.WriteFile(filepath.Join(manifestImportDest, project.FileName), []byte(content), 0644)
+}
+
+// manifestImportSavesToNacos reports whether manifest-import saves the apply
+// state in Nacos, chosen by --state-backend or the project file
+func manifestImportSavesToNacos() bool {
+	if manifestImportDryRun || manifestImportStateFile != "" {
+		return false
+	}
+	backend := manifestImportStateBackend
+	if backend == "" {
+		proj, err := project.Load(manifestImportDest, manifestImportProject)
+		if err != nil {
+			// The command fails on it before saving anything
+			return false
+		}
+		backend = proj.State.Type
+	}
+	return backend == state.BackendNacos
+}
+
+func init() {
+	manifestImportCmd.Flags().StringVar(&manifestImportDest, "dest", ".", "Directory to write the <group>/<dataId> tree to")
+	manifestImportCmd.Flags().StringVar(&manifestImportProject, "project", "", "Project name apply records as owner (default: name in "+project.FileName+", else directory name)")
+	manifestImportCmd.Flags().StringVar(&manifestImportStateFile, "state-file", "", "Local state file path (default: <dest>/"+state.DefaultFileName+")")
+	manifestImportCmd.Flags().StringVar(&manifestImportStateBackend, "state-backend", "", "State backend: local, nacos or s3 (default: from "+project.FileName+", else local)")
+	manifestImportCmd.Flags().BoolVar(&manifestImportForce, "force", false, "Overwrite local files whose content differs from the server")
+	manifestImportCmd.Flags().BoolVar(&manifestImportDryRun, "dry-run", false, "Show what would be imported without writing anything")
+	manifestImportCmd.Flags().IntVar(&manifestImportConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addSelectorFlag(manifestImportCmd)
+	rootCmd.AddCommand(manifestImportCmd)
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mcpListName     string
+	mcpOutput       string
+	mcpVersion      string
+	mcpServerFile   string
+	mcpToolsFile    string
+	mcpEndpointFile string
+	mcpLatest       bool
+)
+
+var mcpListCmd = &cobra.Command{
+	Use:   "mcp-registry-list",
+	Short: "List the MCP servers registered in Nacos",
+	Long:  help.McpRegistryList.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(mcpOutput)
+		nacosClient := newNacosClient()
+
+		var servers []client.McpServer
+		for pageNo := 1; ; pageNo++ {
+			page, err := nacosClient.ListMcpServers(mcpListName, false, pageNo, 100)
+			checkError(err)
+			servers = append(servers, page.PageItems...)
+			if len(page.PageItems) < 100 || len(servers) >= page.TotalCount {
+				break
+			}
+		}
+
+		if mcpOutput == "json" {
+			printJSON(servers)
+			return
+		}
+		fmt.Printf("%d MCP server(s) in namespace %s\n", len(servers), nacosClient.Namespace)
+		if len(servers) == 0 {
+			return
+		}
+		fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+		fmt.Printf("%-30s %-16s %-10s %-8s %s\n", "Name", "Protocol", "Version", "Enabled", "Description")
+		fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+		for _, s := range servers {
+			fmt.Printf("%-30s %-16s %-10s %-8t %s\n", s.Name, s.Protocol, s.LatestVersion(), s.Enabled, s.Description)
+		}
+	},
+}
+
+var mcpGetCmd = &cobra.Command{
+	Use:   "mcp-registry-get name",
+	Short: "Show an MCP server definition with its tools",
+	Long:  help.McpRegistryGet.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(mcpOutput)
+		detail, err := newNacosClient().GetMcpServer(args[0], mcpVersion)
+		checkError(err)
+
+		if mcpOutput == "json" {
+			printJSON(detail)
+			return
+		}
+		fmt.Println("═══════════════════════════════════════")
+		fmt.Printf("Name: %s\n", detail.Name)
+		fmt.Printf("ID: %s\n", detail.ID)
+		fmt.Printf("Protocol: %s\n", detail.Protocol)
+		fmt.Printf("Version: %s\n", detail.LatestVersion())
+		fmt.Printf("Enabled: %t\n", detail.Enabled)
+		if detail.Description != "" {
+			fmt.Printf("Description: %s\n", detail.Description)
+		}
+		if len(detail.AllVersions) > 0 {
+			versions := ""
+			for i, v := range detail.AllVersions {
+				if i > 0 {
+					versions += ", "
+				}
+				versions += v.Version
+			}
+			fmt.Printf("Versions: %s\n", versions)
+		}
+		fmt.Println("═══════════════════════════════════════")
+		for _, section := range []struct {
+			title string
+			raw   json.RawMessage
+		}{
+			{"Remote server config", detail.RemoteServerConfig},
+			{"Local server config", detail.LocalServerConfig},
+			{"Tools", detail.ToolSpec},
+		} {
+			if len(section.raw) == 0 || string(section.raw) == "null" {
+				continue
+			}
+			fmt.Printf("%s:\n", section.title)
+			printJSON(section.raw)
+		}
+	},
+}
+
+var mcpPublishCmd = &cobra.Command{
+	Use:   "mcp-registry-publish",
+	Short: "Register an MCP server or publish a new version of it",
+	Long:  help.McpRegistryPublish.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if mcpServerFile == "" {
+			checkError(fmt.Errorf("--file is required"))
+		}
+		req := client.McpPublishRequest{Latest: mcpLatest}
+		var server client.McpServer
+		req.Server = readJSONFile(mcpServerFile, &server)
+		if server.Name == "" || server.Protocol == "" {
+			checkError(fmt.Errorf("%s: name and protocol are required", mcpServerFile))
+		}
+		version := server.LatestVersion()
+		if version == "" {
+			checkError(fmt.Errorf("%s: versionDetail.version is required", mcpServerFile))
+		}
+		if mcpToolsFile != "" {
+			req.Tools = readJSONFile(mcpToolsFile, nil)
+		}
+		if mcpEndpointFile != "" {
+			req.Endpoint = readJSONFile(mcpEndpointFile, nil)
+		} else if server.Protocol != "stdio" && len(server.RemoteServerConfig) == 0 {
+			checkError(fmt.Errorf("protocol %s needs an endpoint (--endpoint or remoteServerConfig)", server.Protocol))
+		}
+
+		nacosClient := newNacosClient()
+		existing, err := nacosClient.GetMcpServer(server.Name, "")
+		switch {
+		case errors.Is(err, client.ErrMcpServerNotFound):
+			checkError(nacosClient.CreateMcpServer(req))
+			fmt.Printf("Registered MCP server %s %s\n", server.Name, version)
+		case err != nil:
+			checkError(err)
+		default:
+			action := "Published new version"
+			for _, v := range existing.AllVersions {
+				if v.Version == version {
+					action = "Updated version"
+				}
+			}
+			checkError(nacosClient.UpdateMcpServer(req))
+			fmt.Printf("%s %s of MCP server %s\n", action, version, server.Name)
+		}
+	},
+}
+
+// readJSONFile reads a JSON file, decoding it into v when not nil
+func readJSONFile(path string, v interface{}) json.RawMessage {
+	data, err := os.ReadFile(path)
+	checkError(err)
+	if !json.Valid(data) {
+		checkError(fmt.Errorf("%s: invalid JSON", path))
+	}
+	if v != nil {
+		if err := json.Unmarshal(data, v); err != nil {
+			checkError(fmt.Errorf("%s: %w", path, err))
+		}
+	}
+	return data
+}
+
+func checkOutputFormat(output string) {
+	if output != "table" && output != "json" {
+		checkError(fmt.Errorf("invalid --output %q (expected table or json)", output))
+	}
+}
+
+func printJSON(v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	checkError(err)
+	fmt.Println(string(data))
+}
+
+func init() {
+	mcpListCmd.Flags().StringVar(&mcpListName, "name", "", "Filter by name (substring match)")
+	for _, c := range []*cobra.Command{mcpListCmd, mcpGetCmd} {
+		c.Flags().StringVarP(&mcpOutput, "output", "o", "table", "Output format: table or json")
+	}
+	mcpGetCmd.Flags().StringVar(&mcpVersion, "version", "", "Version to show (default: latest)")
+	mcpPublishCmd.Flags().StringVarP(&mcpServerFile, "file", "f", "", "Server specification JSON file (required)")
+	mcpPublishCmd.Flags().StringVar(&mcpToolsFile, "tools", "", "Tool specification JSON file")
+	mcpPublishCmd.Flags().StringVar(&mcpEndpointFile, "endpoint", "", "Endpoint specification JSON file for remote servers")
+	mcpPublishCmd.Flags().BoolVar(&mcpLatest, "latest", true, "Mark the published version as latest")
+	rootCmd.AddCommand(mcpListCmd)
+	rootCmd.AddCommand(mcpGetCmd)
+	rootCmd.AddCommand(mcpPublishCmd)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/backup"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mvToNamespace string
+	mvToGroup     string
+	mvHistoryFile string
+	mvForce       bool
+	mvYes         bool
+	mvMapping     string
+)
+
+// configLocation identifies a configuration across namespaces
+type configLocation struct {
+	Namespace string `json:"namespace"`
+	Group     string `json:"group"`
+	DataID    string `json:"dataId"`
+}
+
+// historyExport is the audit file written by config-mv. Nacos cannot import
+// history, so this file is the only record of the source's change trail.
+type historyExport struct {
+	Source     configLocation         `json:"source"`
+	Target     configLocation         `json:"target"`
+	ExportedAt time.Time              `json:"exportedAt"`
+	ExportedBy string                 `json:"exportedBy"`
+	Current    *client.ConfigDetail   `json:"current"`
+	History    []client.ConfigHistory `json:"history"` // newest first, with content
+}
+
+var mvConfigCmd = &cobra.Command{
+	Use:   "config-mv dataId [group]",
+	Short: "Move a configuration to another namespace or group, exporting its history",
+	Long:  help.ConfigMove.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID := args[0]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+
+		nacosClient := newNacosClient()
+		src := configLocation{Namespace: nacosClient.Namespace, Group: group, DataID: dataID}
+		dst := configLocation{Namespace: src.Namespace, Group: group, DataID: dataID}
+		if mvMapping != "" {
+			mapping, err := backup.LoadMapping(mvMapping)
+			checkError(err)
+			dst = configLocation{Namespace: mapping.Namespace(src.Namespace), Group: mapping.Group(group), DataID: mapping.DataID(dataID)}
+		}
+		if mvToNamespace != "" {
+			dst.Namespace = mvToNamespace
+		}
+		if mvToGroup != "" {
+			dst.Group = mvToGroup
+		}
+		if dst == src {
+			checkError(fmt.Errorf("--to-namespace, --to-group or --mapping must move the configuration elsewhere"))
+		}
+		if !mvYes && !isInteractive() {
+			checkError(fmt.Errorf("refusing to move without confirmation in non-interactive mode (use --yes)"))
+		}
+		targetClient := nacosClient.WithNamespace(dst.Namespace)
+
+		detail, err := nacosClient.GetConfigDetail(dataID, group)
+		if errors.Is(err, client.ErrConfigNotFound) {
+			checkError(fmt.Errorf("configuration %s/%s not found in namespace %s", group, dataID, src.Namespace))
+		}
+		checkError(err)
+
+		if dst.Namespace != src.Namespace && dst.Namespace != "public" {
+			ns, err := nacosClient.GetNamespace(dst.Namespace)
+			checkError(err)
+			if ns == nil {
+				checkError(fmt.Errorf("target namespace %s does not exist", dst.Namespace))
+			}
+		}
+		if _, err := targetClient.GetConfigDetail(dst.DataID, dst.Group); err == nil {
+			if !mvForce {
+				checkError(fmt.Errorf("%s/%s already exists in namespace %s (use --force to overwrite)", dst.Group, dst.DataID, dst.Namespace))
+			}
+		} else if !errors.Is(err, client.ErrConfigNotFound) {
+			checkError(err)
+		}
+
+		// Export the history before touching anything
+		fmt.Printf("Exporting history of %s/%s...\n", group, dataID)
+		revisions, err := nacosClient.ListAllConfigHistory(dataID, group)
+		checkError(err)
+		for i := range revisions {
+			rev, err := nacosClient.GetConfigHistory(dataID, group, revisions[i].ID)
+			if err != nil {
+				checkError(fmt.Errorf("get revision %d: %w", revisions[i].ID, err))
+			}
+			revisions[i] = *rev
+		}
+		export := historyExport{
+			Source:     src,
+			Target:     dst,
+			ExportedAt: time.Now().UTC(),
+			ExportedBy: currentUserName(),
+			Current:    detail,
+			History:    revisions,
+		}
+		data, err := json.MarshalIndent(export, "", "  ")
+		checkError(err)
+		historyFile := mvHistoryFile
+		if historyFile == "" {
+			historyFile = historyFileName(src)
+		}
+		checkError(os.WriteFile(historyFile, append(data, '\n'), 0600))
+		fmt.Printf("Wrote %d revision(s) to %s\n", len(revisions), historyFile)
+
+		opts := client.PublishOptions{Tags: detail.Tags(), Type: detail.Type}
+		checkError(targetClient.PublishConfigWithOptions(dst.DataID, dst.Group, detail.Content, opts))
+		copied, err := targetClient.GetConfig(dst.DataID, dst.Group)
+		checkError(err)
+		if copied != detail.Content {
+			checkError(fmt.Errorf("content in %s/%s/%s does not match after publishing, source kept", dst.Namespace, dst.Group, dst.DataID))
+		}
+		fmt.Printf("Published %s/%s to namespace %s\n", dst.Group, dst.DataID, dst.Namespace)
+
+		checkError(confirmByTyping(fmt.Sprintf("delete %s/%s from namespace %s", group, dataID, src.Namespace), dataID, mvYes))
+		checkError(nacosClient.DeleteConfig(dataID, group))
+		fmt.Printf("Deleted %s/%s from namespace %s\n", group, dataID, src.Namespace)
+		fmt.Printf("\nNote: Nacos cannot import history; the change trail before the move is kept in %s\n", historyFile)
+	},
+}
+
+// historyFileName returns the default audit file name for a moved configuration
+func historyFileName(loc configLocation) string {
+	name := fmt.Sprintf("%s.%s.%s.history.json", loc.Namespace, loc.Group, loc.DataID)
+	return strings.NewReplacer("/", "_", `\`, "_", ":", "_").Replace(name)
+}
+
+func init() {
+	mvConfigCmd.Flags().StringVar(&mvToNamespace, "to-namespace", "", "Target namespace ID (default: the source namespace)")
+	mvConfigCmd.Flags().StringVar(&mvToGroup, "to-group", "", "Target group (default: the source group)")
+	mvConfigCmd.Flags().StringVar(&mvHistoryFile, "history-file", "", "Audit file for the exported history (default: <namespace>.<group>.<dataId>.history.json)")
+	mvConfigCmd.Flags().StringVar(&mvMapping, "mapping", "", "Mapping file giving the target namespace, group and data ID (overridden by --to-namespace and --to-group)")
+	mvConfigCmd.Flags().BoolVar(&mvForce, "force", false, "Overwrite an existing target")
+	mvConfigCmd.Flags().BoolVarP(&mvYes, "yes", "y", false, "Delete the source without asking for confirmation")
+	rootCmd.AddCommand(mvConfigCmd)
+}
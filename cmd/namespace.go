@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/recyclebin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	namespaceOutput string
+	namespaceName   string
+	namespaceDesc   string
+	namespaceForce  bool
+	namespacePurge  bool
+
+	namespaceNoRecycle   bool
+	namespaceConcurrency int
+)
+
+// namespaceTypeNames spells out the types of namespaces
+var namespaceTypeNames = map[int]string{0: "global", 1: "private", 2: "custom"}
+
+var namespaceListCmd = &cobra.Command{
+	Use:   "namespace-list",
+	Short: "List the namespaces of the server",
+	Long:  help.NamespaceList.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(namespaceOutput)
+		nacosClient := newNacosClient()
+		namespaces, err := nacosClient.ListNamespaces()
+		checkError(err)
+		if namespaceOutput == "json" {
+			printJSON(namespaces)
+			return
+		}
+
+		fmt.Println("═══════════════════════════════════════════════════════════════════════════════════════")
+		fmt.Printf("%-38s %-24s %-8s %-7s %s\n", "ID", "Name", "Configs", "Quota", "Description")
+		fmt.Println("───────────────────────────────────────────────────────────────────────────────────────")
+		for _, ns := range namespaces {
+			id := ns.Namespace
+			if id == "" {
+				id = "public"
+			}
+			fmt.Printf("%-38s %-24s %-8d %-7d %s\n", id, ns.NamespaceShowName, ns.ConfigCount, ns.Quota, ns.NamespaceDesc)
+		}
+		fmt.Printf("\nTotal: %d namespace(s)\n", len(namespaces))
+	},
+}
+
+var namespaceDescribeCmd = &cobra.Command{
+	Use:   "namespace-describe namespaceId",
+	Short: "Show the details of a namespace",
+	Long:  help.NamespaceDescribe.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(namespaceOutput)
+		nacosClient := newNacosClient()
+		ns := mustGetNamespace(nacosClient, args[0])
+		if namespaceOutput == "json" {
+			printJSON(ns)
+			return
+		}
+		typ := namespaceTypeNames[ns.Type]
+		if typ == "" {
+			typ = fmt.Sprintf("%d", ns.Type)
+		}
+		fmt.Printf("%-15s %s\n", "ID:", args[0])
+		fmt.Printf("%-15s %s\n", "Name:", ns.NamespaceShowName)
+		fmt.Printf("%-15s %s\n", "Description:", ns.NamespaceDesc)
+		fmt.Printf("%-15s %s\n", "Type:", typ)
+		fmt.Printf("%-15s %d\n", "Configs:", ns.ConfigCount)
+		fmt.Printf("%-15s %d\n", "Quota:", ns.Quota)
+	},
+}
+
+var namespaceCreateCmd = &cobra.Command{
+	Use:   "namespace-create namespaceId",
+	Short: "Create a namespace",
+	Long:  help.NamespaceCreate.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := args[0]
+		name := namespaceName
+		if name == "" {
+			name = id
+		}
+		nacosClient := newNacosClient()
+		existing, err := nacosClient.GetNamespace(id)
+		checkError(err)
+		if existing != nil || id == "public" {
+			checkError(fmt.Errorf("namespace %s already exists", id))
+		}
+		checkError(nacosClient.CreateNamespace(id, name, namespaceDesc))
+		fmt.Printf("Created namespace %s (%s)\n", id, name)
+	},
+}
+
+var namespaceUpdateCmd = &cobra.Command{
+	Use:   "namespace-update namespaceId",
+	Short: "Change the name or description of a namespace",
+	Long:  help.NamespaceUpdate.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := args[0]
+		if !cmd.Flags().Changed("name") && !cmd.Flags().Changed("desc") {
+			checkError(fmt.Errorf("nothing to change, pass --name or --desc"))
+		}
+		nacosClient := newNacosClient()
+		ns := mustGetNamespace(nacosClient, id)
+		// The server replaces both, so keep what is not being changed
+		name, desc := ns.NamespaceShowName, ns.NamespaceDesc
+		if cmd.Flags().Changed("name") {
+			name = namespaceName
+		}
+		if cmd.Flags().Changed("desc") {
+			desc = namespaceDesc
+		}
+		checkError(nacosClient.UpdateNamespace(id, name, desc))
+		fmt.Printf("Updated namespace %s\n", id)
+	},
+}
+
+var namespaceDeleteCmd = &cobra.Command{
+	Use:   "namespace-delete namespaceId",
+	Short: "Delete a namespace",
+	Long:  help.NamespaceDelete.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := args[0]
+		if id == "public" {
+			checkError(fmt.Errorf("the public namespace cannot be deleted"))
+		}
+		nacosClient := newNacosClient()
+		mustGetNamespace(nacosClient, id)
+
+		// The server leaves the configurations of a deleted namespace behind,
+		// unreachable from the console
+		nsClient := nacosClient.WithNamespace(id)
+		items, err := nsClient.ListAllConfigs("", "")
+		checkError(err)
+		if len(items) > 0 && !namespacePurge {
+			checkError(fmt.Errorf("namespace %s still holds %d configuration(s); delete them first or pass --purge", id, len(items)))
+		}
+		var bin recyclebin.Bin
+		if len(items) > 0 {
+			checkError(checkChangeBudget(changeBudget(fileConfig), id, len(items)))
+			if !namespaceNoRecycle {
+				bin, err = openPurgeRecycleBin(nsClient, id)
+				checkError(err)
+			}
+		}
+		action := fmt.Sprintf("delete namespace %s", id)
+		if len(items) > 0 {
+			action = fmt.Sprintf("delete namespace %s and its %d configuration(s)", id, len(items))
+		}
+		checkError(confirmByTyping(action, id, namespaceForce))
+
+		if len(items) > 0 {
+			refs := make([]configRef, len(items))
+			for i, item := range items {
+				refs[i] = configRef{Group: item.GroupName, DataID: item.DataID}
+			}
+			failed := 0
+			for i, err := range deleteConfigs(nsClient, bin, refs, namespaceConcurrency) {
+				if err != nil {
+					failed++
+					fmt.Fprintf(os.Stderr, "Error: delete %s: %v\n", configtree.Key(refs[i].Group, refs[i].DataID), err)
+				}
+			}
+			if failed > 0 {
+				checkError(fmt.Errorf("%d configuration(s) could not be deleted, namespace %s is kept", failed, id))
+			}
+		}
+		checkError(nacosClient.DeleteNamespace(id))
+		if len(items) > 0 {
+			fmt.Printf("Deleted namespace %s (%d configuration(s))\n", id, len(items))
+			if bin != nil {
+				fmt.Printf("Kept in the recycle bin %s, use config-restore-deleted to restore\n", bin.Describe())
+			}
+		} else {
+			fmt.Printf("Deleted namespace %s\n", id)
+		}
+	},
+}
+
+// openPurgeRecycleBin opens the recycle bin for the configurations of a
+// namespace about to be deleted, which must not hold the bin itself
+func openPurgeRecycleBin(nsClient *client.NacosClient, id string) (recyclebin.Bin, error) {
+	if fileConfig != nil && fileConfig.RecycleBin != nil &&
+		fileConfig.RecycleBin.Type == recyclebin.BackendNacos && fileConfig.RecycleBin.Namespace == id {
+		return nil, fmt.Errorf("namespace %s holds the recycle bin; pass --no-recycle-bin to purge it anyway", id)
+	}
+	return openRecycleBin(nsClient)
+}
+
+// mustGetNamespace returns a namespace, exiting when it does not exist
+func mustGetNamespace(nacosClient *client.NacosClient, id string) *client.Namespace {
+	ns, err := nacosClient.GetNamespace(id)
+	checkError(err)
+	if ns == nil && id == "public" {
+		ns, err = nacosClient.GetNamespace("")
+		checkError(err)
+	}
+	if ns == nil {
+		checkError(fmt.Errorf("namespace %s does not exist", id))
+	}
+	return ns
+}
+
+func init() {
+	namespaceListCmd.Flags().StringVarP(&namespaceOutput, "output", "o", "table", "Output format: table or json")
+	namespaceDescribeCmd.Flags().StringVarP(&namespaceOutput, "output", "o", "table", "Output format: table or json")
+	namespaceCreateCmd.Flags().StringVar(&namespaceName, "name", "", "Namespace name (default: the namespace ID)")
+	namespaceCreateCmd.Flags().StringVar(&namespaceDesc, "desc", "", "Namespace description")
+	namespaceUpdateCmd.Flags().StringVar(&namespaceName, "name", "", "New namespace name")
+	namespaceUpdateCmd.Flags().StringVar(&namespaceDesc, "desc", "", "New namespace description")
+	namespaceDeleteCmd.Flags().BoolVar(&namespacePurge, "purge", false, "Delete the configurations of the namespace first")
+	namespaceDeleteCmd.Flags().BoolVar(&namespaceForce, "force", false, "Skip the confirmation prompt")
+	namespaceDeleteCmd.Flags().BoolVar(&namespaceNoRecycle, "no-recycle-bin", false, "Purge without keeping a copy of the configurations in the recycle bin")
+	namespaceDeleteCmd.Flags().IntVar(&namespaceConcurrency, "concurrency", 8, "Maximum number of concurrent requests while purging")
+	rootCmd.AddCommand(namespaceListCmd)
+	rootCmd.AddCommand(namespaceDescribeCmd)
+	rootCmd.AddCommand(namespaceCreateCmd)
+	rootCmd.AddCommand(namespaceUpdateCmd)
+	rootCmd.AddCommand(namespaceDeleteCmd)
+}
@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/backup"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serviceExportOutput      string
+	serviceExportGroup       string
+	serviceExportConcurrency int
+
+	serviceImportToNamespace string
+	serviceImportMapping     string
+	serviceImportOnConflict  string
+	serviceImportDryRun      bool
+	serviceImportConcurrency int
+)
+
+// serviceImportItem is an exported service and where it is imported to
+type serviceImportItem struct {
+	entry  backup.ServiceEntry
+	group  string // group in the target namespace
+	action string // create, overwrite, skip or error
+	err    error
+}
+
+var exportServiceCmd = &cobra.Command{
+	Use:   "service-export",
+	Short: "Export the services, clusters and persistent instances of a namespace to YAML",
+	Long:  help.ServiceExport.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		nacosClient := newNacosClient()
+		output := serviceExportOutput
+		if output == "" {
+			output = backup.NamingBundleName(nacosClient.Namespace, time.Now())
+		}
+
+		bundle, err := exportNaming(nacosClient, serviceExportGroup, serviceExportConcurrency)
+		checkError(err)
+		w, err := storage.Create(output, storageOptions)
+		checkError(err)
+		if err := bundle.Write(w); err != nil {
+			w.Close()
+			checkError(err)
+		}
+		checkError(w.Close())
+		instances := 0
+		for _, svc := range bundle.Services {
+			instances += len(svc.Instances)
+		}
+		fmt.Printf("Exported %d service(s) and %d persistent instance(s) from namespace %s to %s\n",
+			len(bundle.Services), instances, nacosClient.Namespace, output)
+	},
+}
+
+var importServiceCmd = &cobra.Command{
+	Use:   "service-import bundle",
+	Short: "Import a service-export bundle, optionally into another namespace",
+	Long:  help.ServiceImport.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if serviceImportOnConflict != "abort" && serviceImportOnConflict != "skip" && serviceImportOnConflict != "overwrite" {
+			checkError(fmt.Errorf("--on-conflict must be abort, skip or overwrite"))
+		}
+		var mapping *backup.Mapping
+		if serviceImportMapping != "" {
+			var err error
+			mapping, err = backup.LoadMapping(serviceImportMapping)
+			checkError(err)
+		}
+
+		r, err := storage.OpenFile(args[0], storageOptions)
+		checkError(err)
+		bundle, err := backup.LoadNamingBundle(r)
+		r.Close()
+		checkError(err)
+
+		nacosClient := newNacosClient()
+		targetNamespace := nacosClient.Namespace
+		if mapped := mapping.Namespace(bundle.Namespace); mapped != bundle.Namespace {
+			targetNamespace = mapped
+		}
+		if serviceImportToNamespace != "" {
+			targetNamespace = serviceImportToNamespace
+		}
+		if targetNamespace != "public" {
+			ns, err := nacosClient.GetNamespace(targetNamespace)
+			checkError(err)
+			if ns == nil {
+				checkError(fmt.Errorf("target namespace %s does not exist", targetNamespace))
+			}
+		}
+		targetClient := nacosClient.WithNamespace(targetNamespace)
+
+		items := make([]*serviceImportItem, len(bundle.Services))
+		for i, entry := range bundle.Services {
+			items[i] = &serviceImportItem{entry: entry, group: mapping.Group(entry.Group), action: "create"}
+		}
+		checkServiceConflicts(targetClient, items, serviceImportConcurrency)
+
+		conflicts := 0
+		for _, item := range items {
+			if item.action == "overwrite" {
+				conflicts++
+			}
+		}
+		if conflicts > 0 && serviceImportOnConflict == "abort" {
+			for _, item := range items {
+				if item.action == "overwrite" {
+					fmt.Fprintf(os.Stderr, "  exists   %s@@%s\n", item.group, item.entry.Name)
+				}
+			}
+			checkError(fmt.Errorf("%d service(s) already exist in namespace %s (use --on-conflict skip or overwrite)", conflicts, targetNamespace))
+		}
+		if serviceImportOnConflict == "skip" {
+			for _, item := range items {
+				if item.action == "overwrite" {
+					item.action = "skip"
+				}
+			}
+		}
+
+		if serviceImportDryRun {
+			fmt.Printf("Plan for importing %s (namespace %s) into namespace %s (dry run):\n", args[0], bundle.Namespace, targetNamespace)
+		} else {
+			changes := 0
+			for _, item := range items {
+				if item.action != "skip" {
+					changes++
+				}
+			}
+			checkError(checkChangeBudget(changeBudget(fileConfig), targetNamespace, changes))
+			fmt.Printf("Importing %d service(s) from %s (namespace %s) into namespace %s...\n",
+				len(items), args[0], bundle.Namespace, targetNamespace)
+			applyServiceImport(targetClient, items, serviceImportConcurrency)
+		}
+
+		counts := make(map[string]int)
+		for _, item := range items {
+			counts[item.action]++
+			target := item.group + "@@" + item.entry.Name
+			if item.group != item.entry.Group {
+				target = item.entry.Group + "@@" + item.entry.Name + " -> " + target
+			}
+			if item.err != nil {
+				fmt.Printf("  %-10s %s: %v\n", item.action, target, item.err)
+				continue
+			}
+			fmt.Printf("  %-10s %s (%d cluster(s), %d instance(s))\n", item.action, target, len(item.entry.Clusters), len(item.entry.Instances))
+		}
+		fmt.Printf("\nCreated: %d, Overwritten: %d, Skipped: %d, Failed: %d\n",
+			counts["create"], counts["overwrite"], counts["skip"], counts["error"])
+		if counts["error"] > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// exportNaming reads the services of the namespace whose group contains
+// group, with their clusters and persistent instances, concurrently
+func exportNaming(nacosClient *client.NacosClient, group string, concurrency int) (*backup.NamingBundle, error) {
+	services, err := nacosClient.ListAllServices("", group)
+	if err != nil {
+		return nil, err
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	entries := make([]backup.ServiceEntry, len(services))
+	errs := make([]error, len(services))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, svc := range services {
+		wg.Add(1)
+		go func(i int, svc client.Service) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			entries[i], errs[i] = exportService(nacosClient, svc.Name, svc.GroupName)
+		}(i, svc)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return &backup.NamingBundle{
+		Server:    nacosClient.ServerAddr,
+		Namespace: nacosClient.Namespace,
+		CreatedAt: time.Now().UTC(),
+		Services:  entries,
+	}, nil
+}
+
+func exportService(nacosClient *client.NacosClient, name, group string) (backup.ServiceEntry, error) {
+	detail, err := nacosClient.GetService(name, group)
+	if err != nil {
+		return backup.ServiceEntry{}, fmt.Errorf("%s@@%s: %w", group, name, err)
+	}
+	instances, err := nacosClient.ListInstances(name, group, "")
+	if err != nil {
+		return backup.ServiceEntry{}, fmt.Errorf("%s@@%s: %w", group, name, err)
+	}
+	entry := backup.ServiceEntry{
+		Name:             name,
+		Group:            group,
+		ProtectThreshold: detail.ProtectThreshold,
+		Ephemeral:        detail.Ephemeral,
+		Metadata:         detail.Metadata,
+	}
+	for _, c := range detail.Clusters {
+		entry.Clusters = append(entry.Clusters, backup.ClusterEntry{
+			Name:            c.Name,
+			HealthChecker:   c.HealthChecker,
+			CheckPort:       c.CheckPort,
+			UseInstancePort: c.UseInstancePort,
+			Metadata:        c.Metadata,
+		})
+	}
+	for _, inst := range instances {
+		if inst.Ephemeral {
+			continue
+		}
+		entry.Instances = append(entry.Instances, backup.InstanceEntry{
+			IP:       inst.IP,
+			Port:     inst.Port,
+			Cluster:  inst.ClusterName,
+			Weight:   inst.Weight,
+			Enabled:  inst.Enabled,
+			Metadata: inst.Metadata,
+		})
+	}
+	return entry, nil
+}
+
+// checkServiceConflicts marks the items whose service already exists as overwrites
+func checkServiceConflicts(targetClient *client.NacosClient, items []*serviceImportItem, concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func(item *serviceImportItem) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			_, err := targetClient.GetService(item.entry.Name, item.group)
+			switch {
+			case err == nil:
+				item.action = "overwrite"
+			case !errors.Is(err, client.ErrServiceNotFound):
+				item.action, item.err = "error", err
+			}
+		}(item)
+	}
+	wg.Wait()
+}
+
+// applyServiceImport creates or updates the services to import with their
+// clusters, and registers their instances, concurrently
+func applyServiceImport(targetClient *client.NacosClient, items []*serviceImportItem, concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		if item.action != "create" && item.action != "overwrite" {
+			continue
+		}
+		wg.Add(1)
+		go func(item *serviceImportItem) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := importService(targetClient, item); err != nil {
+				item.action, item.err = "error", err
+			}
+		}(item)
+	}
+	wg.Wait()
+}
+
+func importService(targetClient *client.NacosClient, item *serviceImportItem) error {
+	detail := client.ServiceDetail{
+		ServiceName:      item.entry.Name,
+		GroupName:        item.group,
+		ProtectThreshold: item.entry.ProtectThreshold,
+		Ephemeral:        item.entry.Ephemeral,
+		Metadata:         item.entry.Metadata,
+	}
+	write := targetClient.UpdateService
+	if item.action == "create" {
+		write = targetClient.CreateService
+	}
+	if err := write(detail); err != nil {
+		return err
+	}
+	for _, c := range item.entry.Clusters {
+		cluster := client.Cluster{
+			Name:            c.Name,
+			HealthChecker:   c.HealthChecker,
+			CheckPort:       c.CheckPort,
+			UseInstancePort: c.UseInstancePort,
+			Metadata:        c.Metadata,
+		}
+		if err := targetClient.UpdateCluster(item.entry.Name, item.group, cluster); err != nil {
+			return fmt.Errorf("cluster %s: %w", c.Name, err)
+		}
+	}
+	for _, inst := range item.entry.Instances {
+		instance := client.Instance{
+			IP:          inst.IP,
+			Port:        inst.Port,
+			ClusterName: inst.Cluster,
+			Weight:      inst.Weight,
+			Enabled:     inst.Enabled,
+			Healthy:     true,
+			Metadata:    inst.Metadata,
+		}
+		if err := targetClient.RegisterInstance(item.entry.Name, item.group, instance); err != nil {
+			return fmt.Errorf("instance %s:%d: %w", inst.IP, inst.Port, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	exportServiceCmd.Flags().StringVarP(&serviceExportOutput, "output", "o", "", "Bundle file or s3://, oss://, gs:// URL (default: <namespace>-services-<time>.yaml)")
+	exportServiceCmd.Flags().StringVar(&serviceExportGroup, "group", "", "Only export the services of the groups containing this")
+	exportServiceCmd.Flags().IntVar(&serviceExportConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addStorageFlags(exportServiceCmd)
+	rootCmd.AddCommand(exportServiceCmd)
+
+	importServiceCmd.Flags().StringVar(&serviceImportToNamespace, "to-namespace", "", "Namespace to import into (default: the current namespace)")
+	importServiceCmd.Flags().StringVar(&serviceImportMapping, "mapping", "", "Mapping file renaming the namespace and groups")
+	importServiceCmd.Flags().StringVar(&serviceImportOnConflict, "on-conflict", "abort", "When a service exists: abort, skip or overwrite")
+	importServiceCmd.Flags().BoolVar(&serviceImportDryRun, "dry-run", false, "Show what would be imported without changing the server")
+	importServiceCmd.Flags().IntVar(&serviceImportConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addStorageFlags(importServiceCmd)
+	rootCmd.AddCommand(importServiceCmd)
+}
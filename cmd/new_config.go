@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newTemplate string
+	newSet      []string
+	newNoEdit   bool
+)
+
+var newConfigCmd = &cobra.Command{
+	Use:   "config-new dataId [group]",
+	Short: "Scaffold a new configuration from a template, edit and publish it",
+	Long:  help.ConfigNew.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID := args[0]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+
+		if newTemplate == "" {
+			checkError(fmt.Errorf("--template is required (see config-template-list)"))
+		}
+		tmpl, err := templates.Find(templateDir(), newTemplate)
+		checkError(err)
+		values := make(map[string]string)
+		for _, kv := range newSet {
+			name, value, ok := strings.Cut(kv, "=")
+			if !ok || name == "" {
+				checkError(fmt.Errorf("invalid --set %q, expected name=value", kv))
+			}
+			values[name] = value
+		}
+		content, err := tmpl.Render(values)
+		checkError(err)
+
+		nacosClient := newNacosClient()
+		if _, err := nacosClient.GetConfigDetail(dataID, group); err == nil {
+			checkError(fmt.Errorf("%s (%s) already exists, use config-edit to change it", dataID, group))
+		} else if !errors.Is(err, client.ErrConfigNotFound) {
+			checkError(err)
+		}
+
+		if !newNoEdit {
+			content, err = editContent(dataID, content)
+			checkError(err)
+		}
+		if strings.TrimSpace(content) == "" {
+			checkError(fmt.Errorf("content is empty, not publishing"))
+		}
+
+		typ := configtree.TypeOf(dataID)
+		if typ == "text" {
+			typ = configtree.TypeOf(tmpl.Ext)
+		}
+		fmt.Printf("Publishing config: %s (%s)...\n", dataID, group)
+		checkError(nacosClient.PublishConfigWithOptions(dataID, group, content, client.PublishOptions{Type: typ}))
+		fmt.Println("Configuration published successfully")
+	},
+}
+
+var listTemplateCmd = &cobra.Command{
+	Use:   "config-template-list",
+	Short: "List the templates available to config-new",
+	Long:  help.ConfigTemplateList.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := templates.List(templateDir())
+		checkError(err)
+		for _, t := range list {
+			vars, err := t.Vars()
+			checkError(err)
+			var names []string
+			for _, v := range vars {
+				if v.Required {
+					names = append(names, v.Name)
+				} else {
+					names = append(names, fmt.Sprintf("%s=%s", v.Name, v.Default))
+				}
+			}
+			fmt.Printf("%-24s %s\n", t.Name, t.Description)
+			if t.Source != "builtin" {
+				fmt.Printf("%-24s from %s\n", "", t.Source)
+			}
+			if len(names) > 0 {
+				fmt.Printf("%-24s vars: %s\n", "", strings.Join(names, " "))
+			}
+		}
+	},
+}
+
+// templateDir returns the user template directory from the config file,
+// default ~/.nacos-cli/templates
+func templateDir() string {
+	dir := ""
+	if fileConfig != nil {
+		dir = fileConfig.Templates
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return dir
+	}
+	if dir == "" {
+		return filepath.Join(home, ".nacos-cli", "templates")
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		return filepath.Join(home, dir[1:])
+	}
+	return dir
+}
+
+// editContent opens content in the editor and returns the saved result
+func editContent(dataID, content string) (string, error) {
+	tempDir, err := os.MkdirTemp("", "nacos-cli-new-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+	path := filepath.Join(tempDir, filepath.Base(dataID))
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return "", err
+	}
+	if err := runEditor(path); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func init() {
+	newConfigCmd.Flags().StringVar(&newTemplate, "template", "", "Template name (see config-template-list)")
+	newConfigCmd.Flags().StringArrayVar(&newSet, "set", nil, "Template variable as name=value (repeatable)")
+	newConfigCmd.Flags().BoolVar(&newNoEdit, "no-edit", false, "Publish the rendered template without opening the editor")
+	rootCmd.AddCommand(newConfigCmd)
+	rootCmd.AddCommand(listTemplateCmd)
+}
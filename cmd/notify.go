@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/notify"
+)
+
+// notifyRun posts the summary of a run to the webhooks of the profile. A
+// failing webhook is reported but does not fail the run.
+func notifyRun(command string, nacosClient *client.NacosClient, counts map[string]int, changes []notify.Item, failed bool) {
+	if fileConfig == nil || len(fileConfig.Notify) == 0 {
+		return
+	}
+	result := notify.ResultSuccess
+	if failed {
+		result = notify.ResultFailure
+	}
+	summary := notify.Summary{
+		Command:   command,
+		Result:    result,
+		Actor:     currentUserName(),
+		Server:    nacosClient.ServerAddr,
+		Namespace: namespaceLabel(nacosClient.Namespace),
+		Profile:   profile,
+		Ticket:    ticket,
+		Message:   changeMessage,
+		Changes:   changes,
+		Counts:    counts,
+		Time:      time.Now(),
+	}
+	if summary.Changes == nil {
+		summary.Changes = []notify.Item{}
+	}
+	if err := notify.Send(fileConfig.Notify, summary); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// notifyItem records the action on a configuration unless it was left unchanged
+func notifyItem(items []notify.Item, action, key string, err error) []notify.Item {
+	if action == "unchanged" {
+		return items
+	}
+	item := notify.Item{Action: action, Key: key}
+	if err != nil {
+		item.Error = err.Error()
+	}
+	return append(items, item)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+)
+
+// pager is the running pager and stdout the terminal it writes to, while the
+// output of the command is piped through it
+var (
+	pager  *exec.Cmd
+	stdout = os.Stdout
+)
+
+// applyOutputDefault sets the --output format of the command from the per-command
+// default or the config file when it is not given on the command line
+func applyOutputDefault(cmd *cobra.Command) {
+	if fileConfig == nil {
+		return
+	}
+	output := fileConfig.Output
+	if defaults, ok := fileConfig.Commands[cmd.Name()]; ok && defaults.Output != "" {
+		output = defaults.Output
+	}
+	// Only format flags default to table; -o is a file or directory elsewhere
+	flag := cmd.Flags().Lookup("output")
+	if output == "" || flag == nil || flag.Changed || flag.DefValue != "table" {
+		return
+	}
+	checkError(flag.Value.Set(output))
+}
+
+// resolveColorMode fills --color from the config file and validates it
+func resolveColorMode() {
+	if colorMode == "" && fileConfig != nil {
+		colorMode = fileConfig.Color
+	}
+	switch colorMode {
+	case "", "auto", "always", "never":
+	default:
+		checkError(fmt.Errorf("invalid color %q (expected auto, always or never)", colorMode))
+	}
+}
+
+// useColor reports whether output should be colored: --color always or never,
+// otherwise when stdout is a terminal and NO_COLOR is not set
+func useColor() bool {
+	switch colorMode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	return isTerminal(stdout) && os.Getenv("NO_COLOR") == ""
+}
+
+// pagerCommand returns the shell command paging the output, empty for none
+func pagerCommand() string {
+	if noPager {
+		return ""
+	}
+	setting := ""
+	if fileConfig != nil {
+		setting = fileConfig.Pager
+	}
+	switch setting {
+	case "off", "never", "false":
+		return ""
+	case "", "auto":
+		for _, env := range []string{"NACOS_CLI_PAGER", "PAGER"} {
+			if command := os.Getenv(env); command != "" {
+				return command
+			}
+		}
+		if _, err := exec.LookPath("less"); err == nil {
+			return "less"
+		}
+		return ""
+	default:
+		return setting
+	}
+}
+
+// startPager pipes the standard output of the command through the pager when
+// stdout is a terminal. stopPager waits for the pager to exit.
+func startPager() {
+	command := pagerCommand()
+	if command == "" || pager != nil || !isTerminal(os.Stdout) {
+		return
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return
+	}
+	p := exec.Command("sh", "-c", command)
+	p.Stdin, p.Stdout, p.Stderr = r, os.Stdout, os.Stderr
+	// Quit when the output fits on one screen, keep colors and the screen content
+	p.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		p.Env = append(p.Env, "LESS=FRX")
+	}
+	if err := p.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to start pager %q: %v\n", command, err)
+		r.Close()
+		w.Close()
+		return
+	}
+	r.Close()
+	pager = p
+	os.Stdout = w
+}
+
+// stopPager closes the output of the pager and waits for the user to quit it
+func stopPager() {
+	if pager == nil {
+		return
+	}
+	os.Stdout.Close()
+	os.Stdout = stdout
+	pager.Wait()
+	pager = nil
+}
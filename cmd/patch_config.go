@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/yamlutil"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	patchType     string
+	patchContent  string
+	patchFile     string
+	patchMergeKey string
+	patchDryRun   bool
+)
+
+var patchConfigCmd = &cobra.Command{
+	Use:   "config-patch [dataId] [group]",
+	Short: "Apply a JSON Merge Patch or a strategic merge patch to a YAML or JSON configuration",
+	Long:  help.ConfigPatch.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID := args[0]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+
+		if (patchContent == "") == (patchFile == "") {
+			checkError(fmt.Errorf("give the patch with either --patch or --patch-file"))
+		}
+		text := patchContent
+		if patchFile != "" {
+			data, err := os.ReadFile(patchFile)
+			checkError(err)
+			text = string(data)
+		}
+		patch, err := yamlutil.Parse(text)
+		if err != nil {
+			checkError(fmt.Errorf("invalid patch: %w", err))
+		}
+
+		var apply func(doc *yaml.Node) (*yaml.Node, error)
+		switch patchType {
+		case "merge":
+			apply = func(doc *yaml.Node) (*yaml.Node, error) {
+				return yamlutil.MergePatch(doc, patch), nil
+			}
+		case "strategic":
+			apply = func(doc *yaml.Node) (*yaml.Node, error) {
+				return yamlutil.StrategicMergePatch(doc, patch, patchMergeKey)
+			}
+		default:
+			checkError(fmt.Errorf("unknown --type %q (expected merge or strategic)", patchType))
+		}
+
+		checkError(modifyConfig(newNacosClient(), dataID, group, patchType+" patch", "", patchDryRun, apply))
+	},
+}
+
+func init() {
+	patchConfigCmd.Flags().StringVar(&patchType, "type", "merge", "Patch type: merge (JSON Merge Patch, RFC 7386) or strategic (also merges lists by --merge-key)")
+	patchConfigCmd.Flags().StringVar(&patchContent, "patch", "", "Patch document, JSON or YAML")
+	patchConfigCmd.Flags().StringVar(&patchFile, "patch-file", "", "Read the patch document from a file")
+	patchConfigCmd.Flags().StringVar(&patchMergeKey, "merge-key", "name", "Key that identifies items of lists of mappings for --type strategic")
+	patchConfigCmd.Flags().BoolVar(&patchDryRun, "dry-run", false, "Show the diff without publishing")
+	rootCmd.AddCommand(patchConfigCmd)
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/approval"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/notify"
+	"github.com/nov11/nacos-cli/internal/project"
+	"github.com/spf13/cobra"
+)
+
+var (
+	planDir       string
+	planOutput    string
+	planProject   string
+	planTakeOwner bool
+	planDiff      bool
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Write the changes apply would make to a change file for review",
+	Long:  help.Plan.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		if planOutput == "" {
+			checkError(fmt.Errorf("--output is required"))
+		}
+		mode := resolveDiffMode(cmd)
+		proj, err := project.Load(planDir, planProject)
+		checkError(err)
+		entries, err := configtree.Walk(planDir)
+		checkError(err)
+
+		nacosClient := newNacosClient()
+		plan := &approval.Plan{
+			Server:    nacosClient.ServerAddr,
+			Namespace: nacosClient.Namespace,
+			Project:   proj.Name,
+			Author:    currentUserName(),
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		}
+		fmt.Printf("Plan for namespace %s:\n", namespaceLabel(nacosClient.Namespace))
+
+		failed := false
+		unchanged := 0
+		for _, entry := range entries {
+			change, err := planEntry(nacosClient, entry, proj.Name)
+			switch {
+			case err != nil:
+				failed = true
+				fmt.Printf("  %-10s %s: %v\n", "error", entry.Key(), err)
+			case change == nil:
+				unchanged++
+			default:
+				plan.Changes = append(plan.Changes, *change)
+				fmt.Printf("  %-10s %s\n", change.Action, entry.Key())
+			}
+		}
+		if failed {
+			checkError(fmt.Errorf("the plan is incomplete, no change file written"))
+		}
+		if len(plan.Changes) == 0 {
+			fmt.Printf("\nNo changes (%d unchanged)\n", unchanged)
+			return
+		}
+		fmt.Println()
+		if planDiff {
+			printPlanDiff(nacosClient, plan, mode)
+		}
+		checkError(plan.Save(planOutput))
+		fmt.Printf("%d change(s), %d unchanged, written to %s\n", len(plan.Changes), unchanged, planOutput)
+		fmt.Printf("Review with: nacos-cli approve %s\n", planOutput)
+	},
+}
+
+// planEntry returns the change publishing a local file, nil when the server
+// already has its content
+func planEntry(nacosClient *client.NacosClient, entry configtree.Entry, owner string) (*approval.Change, error) {
+	data, err := os.ReadFile(entry.Path)
+	if err != nil {
+		return nil, err
+	}
+	change := &approval.Change{
+		Group:   entry.Group,
+		DataID:  entry.DataID,
+		Action:  approval.ActionCreate,
+		MD5:     listener.CalculateMD5(string(data)),
+		Tags:    project.WithOwner(nil, owner),
+		Content: string(data),
+	}
+	detail, err := nacosClient.GetConfigDetail(entry.DataID, entry.Group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		return change, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	tags := detail.Tags()
+	if current := project.Owner(tags); current != "" && current != owner && !planTakeOwner {
+		return nil, fmt.Errorf("owned by project %q (use --take-ownership)", current)
+	}
+	remoteMD5 := listener.CalculateMD5(detail.Content)
+	if remoteMD5 == change.MD5 && project.Owner(tags) == owner {
+		return nil, nil
+	}
+	change.Action = approval.ActionUpdate
+	change.BaseMD5 = remoteMD5
+	change.Type = detail.Type
+	change.Tags = project.WithOwner(tags, owner)
+	return change, nil
+}
+
+// applyPlan publishes the changes of a reviewed change file after checking its
+// approvals and that the server still has the content it was planned against
+func applyPlan(path string, required int) {
+	plan, err := approval.Load(path)
+	checkError(err)
+	if fileConfig != nil && fileConfig.RequireApprovals > required {
+		required = fileConfig.RequireApprovals
+	}
+
+	var reviewers map[string]string
+	if fileConfig != nil {
+		reviewers = fileConfig.Approvers
+	}
+	valid, problems := plan.Verify(reviewers)
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "Warning: approval by %s\n", problem)
+	}
+	if len(valid) < required {
+		checkError(fmt.Errorf("the change has %d valid approval(s), %d required", len(valid), required))
+	}
+
+	nacosClient := newNacosClient()
+	if plan.Server != nacosClient.ServerAddr {
+		checkError(fmt.Errorf("the change was planned for server %s, not %s", plan.Server, nacosClient.ServerAddr))
+	}
+	if plan.Namespace != nacosClient.Namespace {
+		checkError(fmt.Errorf("the change was planned for namespace %s, not %s (use -n)",
+			namespaceLabel(plan.Namespace), namespaceLabel(nacosClient.Namespace)))
+	}
+
+	approvedBy := "no approvals"
+	if len(valid) > 0 {
+		approvedBy = "approved by " + strings.Join(valid, ", ")
+	}
+	if applyDryRun {
+		fmt.Printf("Plan %s by %s, %s (dry run):\n", path, plan.Author, approvedBy)
+	} else {
+		fmt.Printf("Applying %s by %s, %s, to namespace %s...\n", path, plan.Author, approvedBy, namespaceLabel(nacosClient.Namespace))
+	}
+
+	if !applyDryRun {
+		checkError(checkChangeBudget(changeBudget(fileConfig), nacosClient.Namespace, len(plan.Changes)))
+	}
+	counts := make(map[string]int)
+	failed := false
+	var changes []notify.Item
+	for _, change := range plan.Changes {
+		key := configtree.Key(change.Group, change.DataID)
+		action, err := applyChange(nacosClient, change)
+		counts[action]++
+		changes = notifyItem(changes, action, key, err)
+		if err != nil {
+			failed = true
+			fmt.Printf("  %-10s %s: %v\n", action, key, err)
+			continue
+		}
+		fmt.Printf("  %-10s %s\n", action, key)
+	}
+	fmt.Printf("\nCreated: %d, Updated: %d, Unchanged: %d, Conflicts: %d\n",
+		counts["create"], counts["update"], counts["unchanged"], counts["conflict"])
+	if counts["conflict"] > 0 {
+		fmt.Println("Configurations changed on the server since the plan; plan and review again")
+	}
+	if !applyDryRun {
+		notifyRun("apply --change "+path, nacosClient, counts, changes, failed)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// applyChange publishes one change of a change file and returns the action taken
+func applyChange(nacosClient *client.NacosClient, change approval.Change) (string, error) {
+	detail, err := nacosClient.GetConfigDetail(change.DataID, change.Group)
+	if err != nil && !errors.Is(err, client.ErrConfigNotFound) {
+		return "error", err
+	}
+	current := ""
+	if detail != nil {
+		current = listener.CalculateMD5(detail.Content)
+		if current == change.MD5 {
+			return "unchanged", nil
+		}
+	}
+	if current != change.BaseMD5 {
+		return "conflict", fmt.Errorf("the server content changed since the plan")
+	}
+	if applyDryRun {
+		return change.Action, nil
+	}
+	opts := client.PublishOptions{CasMd5: change.BaseMD5, Tags: change.Tags, Type: change.Type}
+	if err := nacosClient.PublishConfigIdempotent(change.DataID, change.Group, change.Content, opts, publishRetries); err != nil {
+		if errors.Is(err, client.ErrCasMismatch) {
+			return "conflict", fmt.Errorf("the server content changed since the plan")
+		}
+		return "error", err
+	}
+	return change.Action, nil
+}
+
+// namespaceLabel names the public namespace, which has an empty ID
+func namespaceLabel(namespaceID string) string {
+	if namespaceID == "" {
+		return "public"
+	}
+	return namespaceID
+}
+
+func init() {
+	planCmd.Flags().StringVarP(&planDir, "dir", "d", ".", "Directory laid out as <group>/<dataId>")
+	planCmd.Flags().StringVarP(&planOutput, "output", "o", "", "Change file to write (required)")
+	planCmd.Flags().StringVar(&planProject, "project", "", "Project name recorded as owner on the configs (default: name in "+project.FileName+", else directory name)")
+	planCmd.Flags().BoolVar(&planTakeOwner, "take-ownership", false, "Include configurations owned by other projects, taking them over")
+	planCmd.Flags().BoolVar(&planDiff, "diff", false, "Show each change against the current server content")
+	addDiffModeFlag(planCmd)
+	rootCmd.AddCommand(planCmd)
+}
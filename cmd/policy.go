@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+var policyTestVerbose bool
+
+var policyTestCmd = &cobra.Command{
+	Use:   "policy-test [dir]",
+	Short: "Run the test_ rules of the Rego policies",
+	Long:  help.PolicyTest.FormatForCLI("nacos-cli"),
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := policyDir
+		if len(args) == 1 {
+			dir = args[0]
+		} else if dir == "" && fileConfig != nil {
+			dir = fileConfig.PolicyDir
+		}
+		if dir == "" {
+			checkError(fmt.Errorf("no policies: pass a directory, --policy-dir or set policyDir in the profile"))
+		}
+		// The policies must also compile without their tests, as publishes load them
+		_, err := policy.Load(dir)
+		checkError(err)
+		results, err := policy.Test(dir)
+		checkError(err)
+		if len(results) == 0 {
+			checkError(fmt.Errorf("no test_ rules in %s", dir))
+		}
+
+		passed, failed, skipped := 0, 0, 0
+		for _, r := range results {
+			name := r.Package + "." + r.Name
+			switch {
+			case r.Skip:
+				skipped++
+				if policyTestVerbose {
+					fmt.Printf("SKIP  %s\n", name)
+				}
+			case r.Pass:
+				passed++
+				if policyTestVerbose {
+					fmt.Printf("PASS  %s\n", name)
+				}
+			default:
+				failed++
+				fmt.Printf("FAIL  %s\n", name)
+				if r.Error != nil {
+					fmt.Printf("      %v\n", r.Error)
+				}
+			}
+			if r.Output != "" && (policyTestVerbose || !r.Pass) {
+				for _, line := range strings.Split(strings.TrimRight(r.Output, "\n"), "\n") {
+					fmt.Printf("      %s\n", line)
+				}
+			}
+		}
+		fmt.Printf("\nPassed: %d, Failed: %d, Skipped: %d\n", passed, failed, skipped)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	policyTestCmd.Flags().BoolVarP(&policyTestVerbose, "verbose", "v", false, "List passing tests and the print() output of every test")
+	rootCmd.AddCommand(policyTestCmd)
+}
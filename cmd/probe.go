@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/spf13/cobra"
+)
+
+var (
+	probeDataID    string
+	probeGroup     string
+	probeExpectMD5 string
+	probeTimeout   time.Duration
+)
+
+var probeCmd = &cobra.Command{
+	Use:   "probe",
+	Short: "Check that a configuration has the expected MD5",
+	Long:  help.Probe.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		if probeDataID == "" || probeExpectMD5 == "" {
+			fmt.Fprintf(os.Stderr, "Error: --data-id and --expect-md5 are required\n")
+			os.Exit(1)
+		}
+
+		probeGroup = resolveGroup(cmd, probeGroup, "DEFAULT_GROUP")
+
+		nacosClient := newNacosClient()
+		configListener := listener.NewConfigListener(nacosClient.ServerAddr, nacosClient.Username, nacosClient.Password)
+		configListener.SetAccessToken(nacosClient.AccessToken())
+		configListener.SetTransport(nacosClient.Transport())
+
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		defer cancel()
+
+		changed, err := configListener.CheckChanged(ctx, []listener.ConfigItem{{
+			DataID: probeDataID,
+			Group:  probeGroup,
+			Tenant: nacosClient.Namespace,
+			MD5:    probeExpectMD5,
+		}})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: probe failed: %v\n", err)
+			os.Exit(1)
+		}
+		if len(changed) > 0 {
+			fmt.Printf("MISMATCH: %s (%s) differs from expected MD5 %s\n", probeDataID, probeGroup, probeExpectMD5)
+			os.Exit(1)
+		}
+		fmt.Printf("OK: %s (%s) matches MD5 %s\n", probeDataID, probeGroup, probeExpectMD5)
+	},
+}
+
+func init() {
+	probeCmd.Flags().StringVar(&probeDataID, "data-id", "", "Configuration data ID")
+	probeCmd.Flags().StringVar(&probeGroup, "group", "", "Configuration group (default: DEFAULT_GROUP)")
+	probeCmd.Flags().StringVar(&probeExpectMD5, "expect-md5", "", "Expected MD5 of the configuration content")
+	probeCmd.Flags().DurationVar(&probeTimeout, "timeout", 3*time.Second, "Request timeout")
+	rootCmd.AddCommand(probeCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/recording"
+)
+
+var (
+	showTimings bool
+	cpuProfile  string
+	memProfile  string
+
+	// runStart is when the process started, the start of --timings
+	runStart = time.Now()
+
+	// sessionTimer times the requests of the run with --timings, nil otherwise
+	sessionTimer *recording.Timer
+
+	cpuProfileFile *os.File
+)
+
+// startProfiling starts the CPU profile of --cpuprofile
+func startProfiling() {
+	if cpuProfile == "" {
+		return
+	}
+	f, err := os.Create(cpuProfile)
+	checkError(err)
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		checkError(fmt.Errorf("failed to start CPU profile: %w", err))
+	}
+	cpuProfileFile = f
+}
+
+// stopProfiling writes the profiles and prints the timings of the run, once;
+// it runs when the command returns and before exiting on errors
+func stopProfiling() {
+	if cpuProfileFile != nil {
+		pprof.StopCPUProfile()
+		cpuProfileFile.Close()
+		cpuProfileFile = nil
+	}
+	if memProfile != "" {
+		path := memProfile
+		memProfile = ""
+		if err := writeHeapProfile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to write memory profile: %v\n", err)
+		}
+	}
+	if sessionTimer != nil {
+		sessionTimer.Print(os.Stderr, runStart)
+		sessionTimer = nil
+	}
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	runtime.GC()
+	return pprof.WriteHeapProfile(f)
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	servicePruneEmpty     bool
+	servicePruneOlderThan string
+	servicePruneGroup     string
+	servicePruneState     string
+	servicePruneDryRun    bool
+	servicePruneYes       bool
+	servicePruneOutput    string
+)
+
+// prunedService is an empty service with what prune did with it
+type prunedService struct {
+	Name       string    `json:"name"`
+	Group      string    `json:"group"`
+	EmptySince time.Time `json:"emptySince"`
+	Action     string    `json:"action"` // delete, deleted, kept or error
+	Error      string    `json:"error,omitempty"`
+}
+
+var pruneServiceCmd = &cobra.Command{
+	Use:   "service-prune",
+	Short: "Delete the services left without instances",
+	Long:  help.ServicePrune.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !servicePruneEmpty {
+			checkError(fmt.Errorf("--empty is required: services are pruned when they have no instances"))
+		}
+		checkOutputFormat(servicePruneOutput)
+		olderThan, err := parseAge(servicePruneOlderThan)
+		checkError(err)
+		statePath := servicePruneState
+		if statePath == "" {
+			statePath, err = defaultEmptyServicesPath()
+			checkError(err)
+		}
+
+		nacosClient := newNacosClient()
+		services, err := nacosClient.ListAllServices("", servicePruneGroup)
+		checkError(err)
+
+		// The server does not tell how long a service has been empty: the state
+		// file remembers when each one was first found empty
+		state, err := loadEmptyServices(statePath)
+		checkError(err)
+		scope := nacosClient.ServerAddr + "/" + namespaceLabel(nacosClient.Namespace) + "/"
+		now := time.Now()
+		seen := make(map[string]bool)
+		var found []prunedService
+		for _, svc := range services {
+			if svc.IPCount > 0 {
+				continue
+			}
+			key := scope + svc.GroupName + "@@" + svc.Name
+			seen[key] = true
+			since, ok := state[key]
+			if !ok {
+				since = now
+				state[key] = now
+			}
+			action := "delete"
+			if now.Sub(since) < olderThan {
+				action = "kept"
+			}
+			found = append(found, prunedService{Name: svc.Name, Group: svc.GroupName, EmptySince: since, Action: action})
+		}
+		for key := range state {
+			// Services gone or no longer empty start over
+			group, _, _ := strings.Cut(strings.TrimPrefix(key, scope), "@@")
+			if strings.HasPrefix(key, scope) && !seen[key] && strings.Contains(group, servicePruneGroup) {
+				delete(state, key)
+			}
+		}
+
+		toDelete := 0
+		for _, s := range found {
+			if s.Action == "delete" {
+				toDelete++
+			}
+		}
+		if toDelete > 0 && !servicePruneDryRun {
+			checkError(checkChangeBudget(changeBudget(fileConfig), nacosClient.Namespace, toDelete))
+			if !servicePruneYes && !isInteractive() {
+				checkError(fmt.Errorf("refusing to delete without confirmation in non-interactive mode (use --yes)"))
+			}
+			if !servicePruneYes && servicePruneOutput == "table" {
+				printPrunedServices(found)
+				fmt.Println()
+			}
+			checkError(confirmByTyping(fmt.Sprintf("delete %d empty service(s)", toDelete), nacosClient.Namespace, servicePruneYes))
+			for i := range found {
+				s := &found[i]
+				if s.Action != "delete" {
+					continue
+				}
+				if err := nacosClient.DeleteService(s.Name, s.Group); err != nil {
+					s.Action, s.Error = "error", err.Error()
+					continue
+				}
+				s.Action = "deleted"
+				delete(state, scope+s.Group+"@@"+s.Name)
+			}
+		}
+		checkError(saveEmptyServices(statePath, state))
+
+		failed := 0
+		for _, s := range found {
+			if s.Action == "error" {
+				failed++
+			}
+		}
+		if servicePruneOutput == "json" {
+			printJSON(struct {
+				Namespace string          `json:"namespace"`
+				OlderThan string          `json:"olderThan,omitempty"`
+				DryRun    bool            `json:"dryRun"`
+				Services  []prunedService `json:"services"`
+			}{nacosClient.Namespace, servicePruneOlderThan, servicePruneDryRun, found})
+		} else {
+			fmt.Printf("Namespace %s: %d empty service(s)\n", namespaceLabel(nacosClient.Namespace), len(found))
+			if len(found) > 0 {
+				printPrunedServices(found)
+			}
+			if servicePruneDryRun && toDelete > 0 {
+				fmt.Printf("\nDry run: %d service(s) would be deleted\n", toDelete)
+			} else if !servicePruneDryRun {
+				fmt.Printf("\nDeleted: %d, Kept: %d, Failed: %d\n", toDelete-failed, len(found)-toDelete, failed)
+			}
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func printPrunedServices(found []prunedService) {
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%-32s %-20s %-17s %s\n", "Service", "Group", "Empty Since", "Action")
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+	for _, s := range found {
+		action := s.Action
+		if s.Error != "" {
+			action += ": " + s.Error
+		}
+		fmt.Printf("%-32s %-20s %-17s %s\n", s.Name, s.Group, s.EmptySince.Local().Format("2006-01-02 15:04"), action)
+	}
+}
+
+// parseAge parses a duration that may also be given in days, e.g. 7d
+func parseAge(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// defaultEmptyServicesPath returns ~/.nacos-cli/empty-services.json
+func defaultEmptyServicesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".nacos-cli", "empty-services.json"), nil
+}
+
+// loadEmptyServices reads when services were first found empty, keyed by
+// server/namespace/group@@service
+func loadEmptyServices(path string) (map[string]time.Time, error) {
+	state := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return state, nil
+}
+
+func saveEmptyServices(path string, state map[string]time.Time) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func init() {
+	pruneServiceCmd.Flags().BoolVar(&servicePruneEmpty, "empty", false, "Prune the services without instances (required)")
+	pruneServiceCmd.Flags().StringVar(&servicePruneOlderThan, "older-than", "", "Only prune the services empty for at least this long, e.g. 7d or 12h (default: every empty service)")
+	pruneServiceCmd.Flags().StringVar(&servicePruneGroup, "group", "", "Filter by group (substring match)")
+	pruneServiceCmd.Flags().StringVar(&servicePruneState, "state", "", "File remembering since when services are empty (default: ~/.nacos-cli/empty-services.json)")
+	pruneServiceCmd.Flags().BoolVar(&servicePruneDryRun, "dry-run", false, "Report the empty services without deleting them")
+	pruneServiceCmd.Flags().BoolVarP(&servicePruneYes, "yes", "y", false, "Delete without asking for confirmation")
+	pruneServiceCmd.Flags().StringVarP(&servicePruneOutput, "output", "o", "table", "Output format: table or json")
+	rootCmd.AddCommand(pruneServiceCmd)
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/config"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/policy"
+)
+
+var (
+	// hookCommand is the name of the running command, passed to pre-publish hooks
+	hookCommand string
+	// policyDir holds the Rego policies every publish is evaluated against
+	// (default: policyDir of the profile)
+	policyDir string
+)
+
+// defaultHookTimeout bounds a pre-publish hook without a timeout
+const defaultHookTimeout = 30 * time.Second
+
+// prePublishHook evaluates the Rego policies of --policy-dir or the profile
+// and runs its prePublishHooks before every publish of its client, in order;
+// a denying policy or the first hook that exits non-zero blocks the publish
+// with its output as the reason. nil when there is neither.
+func prePublishHook(cfg *config.Config, profileName, server, user string) (client.PublishHook, error) {
+	dir := policyDir
+	if dir == "" && cfg != nil {
+		dir = cfg.PolicyDir
+	}
+	var engine *policy.Engine
+	if dir != "" {
+		var err error
+		if engine, err = policy.Load(dir); err != nil {
+			return nil, err
+		}
+	}
+	var hooks []config.HookConfig
+	if cfg != nil {
+		hooks = cfg.PrePublishHooks
+	}
+	if engine == nil && len(hooks) == 0 {
+		return nil, nil
+	}
+	env := []string{
+		"NACOS_SERVER=" + server,
+		"NACOS_PROFILE=" + profileName,
+		"NACOS_USER=" + user,
+		"NACOS_COMMAND=" + hookCommand,
+		"NACOS_TICKET=" + ticket,
+	}
+	return func(req client.PublishRequest) error {
+		if engine != nil {
+			if err := evaluatePolicies(engine, req, profileName, user); err != nil {
+				return err
+			}
+		}
+		for _, hook := range hooks {
+			if err := runPublishHook(hook, req, env); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// evaluatePolicies blocks a publish the deny rules match and prints the
+// messages of the warn rules
+func evaluatePolicies(engine *policy.Engine, req client.PublishRequest, profileName, user string) error {
+	key := configtree.Key(req.Group, req.DataID)
+	typ := req.Options.Type
+	if typ == "" {
+		typ = configtree.TypeOf(req.DataID)
+	}
+	input := policy.Input{
+		Namespace: req.Namespace,
+		Group:     req.Group,
+		DataID:    req.DataID,
+		Type:      typ,
+		Tags:      req.Options.Tags,
+		Desc:      req.Options.Desc,
+		Content:   req.Content,
+		Parsed:    policy.Parse(typ, req.Content),
+		Command:   hookCommand,
+		User:      user,
+		Profile:   profileName,
+		Ticket:    ticket,
+	}
+	previous, err := req.Current()
+	if err != nil && !errors.Is(err, client.ErrConfigNotFound) {
+		return fmt.Errorf("policy: fetch current %s: %w", key, err)
+	}
+	if err == nil {
+		input.Previous = &previous
+	}
+	// previous is "" for a new configuration, so its diff adds every line
+	input.Diff, err = diff.Diff("line", diff.Document{Name: key, Content: previous}, diff.Document{Name: key, Content: req.Content}, diff.Options{})
+	if err != nil {
+		return err
+	}
+
+	result, err := engine.Evaluate(input)
+	if err != nil {
+		return fmt.Errorf("policy: %w", err)
+	}
+	for _, msg := range result.Warn {
+		fmt.Fprintf(os.Stderr, "Policy warning for %s: %s\n", key, msg)
+	}
+	if len(result.Deny) > 0 {
+		return fmt.Errorf("policy: %s", strings.Join(result.Deny, "; "))
+	}
+	return nil
+}
+
+func runPublishHook(hook config.HookConfig, req client.PublishRequest, env []string) error {
+	name := hook.Name
+	if name == "" {
+		name = hook.Command
+	}
+	timeout := defaultHookTimeout
+	if hook.Timeout != "" {
+		d, err := time.ParseDuration(hook.Timeout)
+		if err != nil {
+			return fmt.Errorf("hook %s: invalid timeout %q: %w", name, hook.Timeout, err)
+		}
+		timeout = d
+	}
+	typ := req.Options.Type
+	if typ == "" {
+		typ = configtree.TypeOf(req.DataID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	c := exec.CommandContext(ctx, "sh", "-c", hook.Command)
+	c.Stdin = strings.NewReader(req.Content)
+	c.Env = append(append(os.Environ(), env...),
+		"NACOS_NAMESPACE="+req.Namespace,
+		"NACOS_GROUP="+req.Group,
+		"NACOS_DATA_ID="+req.DataID,
+		"NACOS_TYPE="+typ,
+		"NACOS_TAGS="+strings.Join(req.Options.Tags, ","),
+		"NACOS_DESC="+req.Options.Desc,
+	)
+	var out bytes.Buffer
+	c.Stdout = &out
+	c.Stderr = &out
+	err := c.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("hook %s timed out after %s", name, timeout)
+	}
+	if err != nil {
+		reason := strings.TrimSpace(out.String())
+		if reason == "" {
+			reason = err.Error()
+		}
+		return fmt.Errorf("hook %s: %s", name, reason)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/lock"
+	"github.com/nov11/nacos-cli/internal/recyclebin"
+	"github.com/nov11/nacos-cli/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pullDest        string
+	pullDataID      string
+	pullGroup       string
+	pullConcurrency int
+)
+
+var pullConfigCmd = &cobra.Command{
+	Use:   "config-pull",
+	Short: "Download configurations to a <group>/<dataId> directory tree",
+	Long:  help.ConfigPull.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		nacosClient := newNacosClient()
+		group := resolveGroup(cmd, pullGroup, "")
+
+		items, err := nacosClient.ListAllConfigs(pullDataID, group)
+		checkError(err)
+
+		var refs []configRef
+		var types []string
+		for _, item := range items {
+			if group == "" && isInternalGroup(item.GroupName) {
+				continue
+			}
+			if !configtree.ValidName(item.GroupName) || !configtree.ValidName(item.DataID) {
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s/%s: not usable as a file path\n", item.GroupName, item.DataID)
+				continue
+			}
+			refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+			types = append(types, item.Type)
+		}
+
+		fmt.Printf("Pulling %d configuration(s) from namespace %s to %s...\n", len(refs), nacosClient.Namespace, pullDest)
+		contents, errs := fetchConfigs(nacosClient, refs, pullConcurrency)
+
+		manifest := &configtree.Manifest{
+			Server:    nacosClient.ServerAddr,
+			Namespace: nacosClient.Namespace,
+			PulledAt:  time.Now(),
+		}
+		failed := 0
+		for i, ref := range refs {
+			key := configtree.Key(ref.Group, ref.DataID)
+			if errs[i] != nil {
+				failed++
+				fmt.Printf("  %-8s %s: %v\n", "error", key, errs[i])
+				continue
+			}
+			path := configtree.Path(pullDest, ref.Group, ref.DataID)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				checkError(err)
+			}
+			if err := os.WriteFile(path, []byte(contents[i]), 0644); err != nil {
+				failed++
+				fmt.Printf("  %-8s %s: %v\n", "error", key, err)
+				continue
+			}
+			manifest.Entries = append(manifest.Entries, configtree.ManifestEntry{
+				Group:  ref.Group,
+				DataID: ref.DataID,
+				MD5:    listener.CalculateMD5(contents[i]),
+				Type:   types[i],
+			})
+			fmt.Printf("  %-8s %s\n", "pulled", key)
+		}
+
+		checkError(os.MkdirAll(pullDest, 0755))
+		checkError(manifest.Save(pullDest))
+
+		fmt.Printf("\nPulled: %d, Failed: %d\n", len(manifest.Entries), failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// isInternalGroup reports whether a group holds nacos-cli bookkeeping (apply state, locks)
+func isInternalGroup(group string) bool {
+	return group == state.DefaultGroup || group == lock.Group || group == recyclebin.Group
+}
+
+func init() {
+	pullConfigCmd.Flags().StringVar(&pullDest, "dest", ".", "Destination directory")
+	pullConfigCmd.Flags().StringVar(&pullDataID, "data-id", "", "Filter by data ID (supports wildcard *)")
+	pullConfigCmd.Flags().StringVar(&pullGroup, "group", "", "Filter by group (supports wildcard *)")
+	pullConfigCmd.Flags().IntVar(&pullConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	rootCmd.AddCommand(pullConfigCmd)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/notify"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pushDryRun      bool
+	pushConcurrency int
+)
+
+var pushConfigCmd = &cobra.Command{
+	Use:   "config-push [dir]",
+	Short: "Publish a <group>/<dataId> directory tree, skipping unchanged files",
+	Long:  help.ConfigPush.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		entries, err := configtree.Walk(dir)
+		checkError(err)
+
+		nacosClient := newNacosClient()
+
+		refs := make([]configRef, len(entries))
+		for i, entry := range entries {
+			refs[i] = configRef{Group: entry.Group, DataID: entry.DataID}
+		}
+		remotes, errs := fetchConfigs(nacosClient, refs, pushConcurrency)
+		if !pushDryRun {
+			checkError(checkChangeBudget(changeBudget(fileConfig), nacosClient.Namespace, pushChanges(entries, remotes, errs)))
+		}
+
+		if pushDryRun {
+			fmt.Printf("Plan for namespace %s (dry run):\n", nacosClient.Namespace)
+		} else {
+			fmt.Printf("Pushing %d file(s) to namespace %s...\n", len(entries), nacosClient.Namespace)
+		}
+
+		counts := make(map[string]int)
+		var changes []notify.Item
+		for i, entry := range entries {
+			action, err := pushEntry(nacosClient, entry, remotes[i], errs[i])
+			counts[action]++
+			changes = notifyItem(changes, action, entry.Key(), err)
+			if err != nil {
+				fmt.Printf("  %-10s %s: %v\n", action, entry.Key(), err)
+				continue
+			}
+			fmt.Printf("  %-10s %s\n", action, entry.Key())
+		}
+
+		fmt.Printf("\nCreated: %d, Updated: %d, Unchanged: %d, Failed: %d\n",
+			counts["create"], counts["update"], counts["unchanged"], counts["error"])
+		if !pushDryRun {
+			notifyRun("config-push", nacosClient, counts, changes, counts["error"] > 0)
+		}
+		if counts["error"] > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// pushChanges returns how many files differ from the server content. Files
+// that cannot be read or fetched count as changes.
+func pushChanges(entries []configtree.Entry, remotes []string, errs []error) int {
+	changes := 0
+	for i, entry := range entries {
+		data, err := os.ReadFile(entry.Path)
+		if err != nil || errs[i] != nil || listener.CalculateMD5(string(data)) != listener.CalculateMD5(remotes[i]) {
+			changes++
+		}
+	}
+	return changes
+}
+
+// pushEntry publishes one file unless its MD5 matches the server content
+func pushEntry(nacosClient *client.NacosClient, entry configtree.Entry, remote string, fetchErr error) (string, error) {
+	if fetchErr != nil && !errors.Is(fetchErr, client.ErrConfigNotFound) {
+		return "error", fetchErr
+	}
+	data, err := os.ReadFile(entry.Path)
+	if err != nil {
+		return "error", err
+	}
+	local := string(data)
+
+	action := "update"
+	if fetchErr != nil || remote == "" {
+		action = "create"
+	} else if listener.CalculateMD5(local) == listener.CalculateMD5(remote) {
+		return "unchanged", nil
+	}
+	if pushDryRun {
+		return action, nil
+	}
+	opts := client.PublishOptions{Type: configtree.TypeOf(entry.DataID)}
+	if err := nacosClient.PublishConfigIdempotent(entry.DataID, entry.Group, local, opts, publishRetries); err != nil {
+		return "error", err
+	}
+	return action, nil
+}
+
+func init() {
+	pushConfigCmd.Flags().BoolVar(&pushDryRun, "dry-run", false, "Show what would be published without publishing")
+	pushConfigCmd.Flags().IntVar(&pushConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	rootCmd.AddCommand(pushConfigCmd)
+}
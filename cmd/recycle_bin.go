@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/config"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/recyclebin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreID    string
+	restoreForce bool
+)
+
+var restoreDeletedCmd = &cobra.Command{
+	Use:   "config-restore-deleted [dataId] [group]",
+	Short: "List or restore configurations deleted with config-delete",
+	Long:  help.ConfigRestoreDeleted.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(0, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		nacosClient := newNacosClient()
+		bin, err := openRecycleBin(nacosClient)
+		checkError(err)
+		if bin == nil {
+			checkError(fmt.Errorf("the recycle bin is disabled (recycleBin.type: none)"))
+		}
+		items, err := bin.List(nacosClient.Namespace)
+		checkError(err)
+
+		if len(args) == 0 && restoreID == "" {
+			printRecycleBin(bin, nacosClient.Namespace, items)
+			return
+		}
+
+		item, err := findDeleted(cmd, items, args)
+		checkError(err)
+
+		_, err = nacosClient.GetConfig(item.DataID, item.Group)
+		if err == nil && !restoreForce {
+			checkError(fmt.Errorf("%s exists again, use --force to overwrite it", configtree.Key(item.Group, item.DataID)))
+		}
+		if err != nil && !errors.Is(err, client.ErrConfigNotFound) {
+			checkError(err)
+		}
+
+		opts := client.PublishOptions{Type: item.Type, Tags: item.Tags}
+		checkError(nacosClient.PublishConfigWithOptions(item.DataID, item.Group, item.Content, opts))
+		checkError(bin.Remove(*item))
+		fmt.Printf("Restored %s (deleted %s by %s)\n", configtree.Key(item.Group, item.DataID),
+			item.DeletedAt.Format("2006-01-02 15:04:05"), item.DeletedBy)
+	},
+}
+
+// openRecycleBin opens the recycle bin configured in the config file, local by default
+func openRecycleBin(nacosClient *client.NacosClient) (recyclebin.Bin, error) {
+	return openRecycleBinOf(fileConfig, nacosClient)
+}
+
+// openRecycleBinOf opens the recycle bin configured in cfg, which may be nil
+func openRecycleBinOf(cfg *config.Config, nacosClient *client.NacosClient) (recyclebin.Bin, error) {
+	var binCfg config.RecycleBinConfig
+	if cfg != nil && cfg.RecycleBin != nil {
+		binCfg = *cfg.RecycleBin
+	}
+	return recyclebin.Open(binCfg, nacosClient)
+}
+
+// findDeleted picks the item to restore: the one with --id, else the most
+// recently deleted copy of the data ID
+func findDeleted(cmd *cobra.Command, items []recyclebin.Item, args []string) (*recyclebin.Item, error) {
+	if restoreID != "" {
+		for i := range items {
+			if items[i].ID == restoreID {
+				return &items[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no recycle bin item with ID %s", restoreID)
+	}
+
+	dataID := args[0]
+	group := ""
+	if len(args) > 1 {
+		group = args[1]
+	}
+	group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+	for i := range items {
+		if items[i].DataID == dataID && items[i].Group == group {
+			return &items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not in the recycle bin", configtree.Key(group, dataID))
+}
+
+func printRecycleBin(bin recyclebin.Bin, namespace string, items []recyclebin.Item) {
+	if len(items) == 0 {
+		fmt.Printf("The recycle bin %s has no configurations deleted from namespace %s\n", bin.Describe(), namespace)
+		return
+	}
+	fmt.Printf("%d configuration(s) deleted from namespace %s (%s)\n", len(items), namespace, bin.Describe())
+	fmt.Println()
+	fmt.Println("════════════════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%-20s %-12s %-40s %s\n", "Deleted", "By", "Config", "ID")
+	fmt.Println("────────────────────────────────────────────────────────────────────────────────────────────────")
+	for _, item := range items {
+		fmt.Printf("%-20s %-12s %-40s %s\n", item.DeletedAt.Format("2006-01-02 15:04:05"), item.DeletedBy,
+			configtree.Key(item.Group, item.DataID), item.ID)
+	}
+}
+
+// keepDeleted snapshots a configuration into the recycle bin before it is deleted
+func keepDeleted(nacosClient *client.NacosClient, bin recyclebin.Bin, ref configRef) error {
+	detail, err := nacosClient.GetConfigDetail(ref.DataID, ref.Group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if detail.GroupName == "" {
+		detail.GroupName = ref.Group
+	}
+	if detail.DataID == "" {
+		detail.DataID = ref.DataID
+	}
+	return bin.Put(recyclebin.NewItem(nacosClient.Namespace, detail, currentUserName()))
+}
+
+func init() {
+	restoreDeletedCmd.Flags().StringVar(&restoreID, "id", "", "Restore the recycle bin item with this ID instead of the latest copy")
+	restoreDeletedCmd.Flags().BoolVar(&restoreForce, "force", false, "Overwrite the configuration if it exists again")
+	rootCmd.AddCommand(restoreDeletedCmd)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	staleDataID      string
+	staleGroup       string
+	staleOutput      string
+	staleConcurrency int
+	staleExitCode    bool
+)
+
+// staleListener is a client that does not have the current content of a configuration
+type staleListener struct {
+	Config    string `json:"config"` // group/dataId
+	IP        string `json:"ip"`
+	MD5       string `json:"md5"`
+	ServerMD5 string `json:"serverMd5"`
+}
+
+// staleReport is the result of a report-stale-listeners run
+type staleReport struct {
+	Namespace string            `json:"namespace"`
+	Checked   int               `json:"checked"`   // configurations checked
+	Listeners int               `json:"listeners"` // listening clients, current or not
+	Stale     []staleListener   `json:"stale"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+var reportStaleListenersCmd = &cobra.Command{
+	Use:   "report-stale-listeners",
+	Short: "List clients listening with an out-of-date MD5 across a namespace",
+	Long:  help.ReportStaleListeners.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(staleOutput)
+		nacosClient := newNacosClient()
+		group := resolveGroup(cmd, staleGroup, "")
+
+		items, err := nacosClient.ListAllConfigs(staleDataID, group)
+		checkError(err)
+		var refs []configRef
+		for _, item := range items {
+			if group == "" && isInternalGroup(item.GroupName) {
+				continue
+			}
+			refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+		}
+
+		md5s, states, errs := fetchListeners(nacosClient, refs, staleConcurrency)
+		report := &staleReport{Namespace: nacosClient.Namespace, Checked: len(refs), Stale: []staleListener{}}
+		for i, ref := range refs {
+			key := configtree.Key(ref.Group, ref.DataID)
+			if errs[i] != nil {
+				if report.Errors == nil {
+					report.Errors = make(map[string]string)
+				}
+				report.Errors[key] = errs[i].Error()
+				continue
+			}
+			report.Listeners += len(states[i])
+			for _, s := range states[i] {
+				if !s.Current {
+					report.Stale = append(report.Stale, staleListener{Config: key, IP: s.IP, MD5: s.MD5, ServerMD5: md5s[i]})
+				}
+			}
+		}
+
+		if staleOutput == "json" {
+			printJSON(report)
+		} else {
+			printStaleReport(report)
+		}
+		if staleExitCode && len(report.Stale) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func printStaleReport(report *staleReport) {
+	fmt.Printf("Checked %d configuration(s) in namespace %s\n\n", report.Checked, report.Namespace)
+	if len(report.Stale) > 0 {
+		fmt.Println("═══════════════════════════════════════════════════════════════════════════════════════════════════════")
+		fmt.Printf("%-36s %-18s %-34s %s\n", "CONFIG", "CLIENT", "CLIENT MD5", "SERVER MD5")
+		fmt.Println("───────────────────────────────────────────────────────────────────────────────────────────────────────")
+		for _, s := range report.Stale {
+			fmt.Printf("%-36s %-18s %-34s %s\n", s.Config, s.IP, s.MD5, s.ServerMD5)
+		}
+		fmt.Println()
+	}
+	keys := make([]string, 0, len(report.Errors))
+	for key := range report.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("  %-8s %s: %s\n", "error", key, report.Errors[key])
+	}
+	if len(report.Errors) > 0 {
+		fmt.Println()
+	}
+	fmt.Printf("Listening clients: %d, Stale: %d, Errors: %d\n", report.Listeners, len(report.Stale), len(report.Errors))
+}
+
+func init() {
+	reportStaleListenersCmd.Flags().StringVar(&staleDataID, "data-id", "", "Filter by data ID (supports wildcard *)")
+	reportStaleListenersCmd.Flags().StringVar(&staleGroup, "group", "", "Filter by group (supports wildcard *)")
+	reportStaleListenersCmd.Flags().StringVarP(&staleOutput, "output", "o", "table", "Output format: table or json")
+	reportStaleListenersCmd.Flags().IntVar(&staleConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	reportStaleListenersCmd.Flags().BoolVar(&staleExitCode, "exit-code", false, "Exit with code 1 when stale clients are found")
+	rootCmd.AddCommand(reportStaleListenersCmd)
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollbackRevision    int64
+	rollbackDryRun      bool
+	rollbackYes         bool
+	rollbackSnapshotDir string
+)
+
+var rollbackConfigCmd = &cobra.Command{
+	Use:   "config-rollback dataId [group]",
+	Short: "Restore a configuration to a revision of its history",
+	Long:  help.ConfigRollback.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID := args[0]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+		if rollbackRevision <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: --revision is required\n")
+			os.Exit(1)
+		}
+
+		nacosClient := newNacosClient()
+		rev, err := nacosClient.GetConfigHistory(dataID, group, rollbackRevision)
+		if err != nil {
+			checkError(fmt.Errorf("get revision %d: %w", rollbackRevision, err))
+		}
+		rollbackTo(nacosClient, dataID, group, rev)
+	},
+}
+
+var revertLastCmd = &cobra.Command{
+	Use:   "config-revert-last dataId [group]",
+	Short: "Undo the most recent change of a configuration",
+	Long:  help.ConfigRevertLast.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID := args[0]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+
+		nacosClient := newNacosClient()
+		currentMD5 := ""
+		if content, err := nacosClient.GetConfig(dataID, group); err == nil {
+			currentMD5 = listener.CalculateMD5(content)
+		} else if !errors.Is(err, client.ErrConfigNotFound) {
+			checkError(err)
+		}
+		revisions, err := nacosClient.ListAllConfigHistory(dataID, group)
+		checkError(err)
+		// The previous content is the newest revision with other content: depending
+		// on the server, the newest entry holds either the content it replaced or
+		// the content it published
+		for _, r := range revisions {
+			if r.Md5 == currentMD5 {
+				continue
+			}
+			rev, err := nacosClient.GetConfigHistory(dataID, group, r.ID)
+			if err != nil {
+				checkError(fmt.Errorf("get revision %d: %w", r.ID, err))
+			}
+			rollbackTo(nacosClient, dataID, group, rev)
+			return
+		}
+		checkError(fmt.Errorf("no earlier revision of %s/%s with different content", group, dataID))
+	},
+}
+
+// rollbackTo republishes the content of a revision after showing the diff,
+// asking for confirmation and saving the current content to a snapshot file
+func rollbackTo(nacosClient *client.NacosClient, dataID, group string, rev *client.ConfigHistory) {
+	current := &client.ConfigDetail{}
+	exists := true
+	detail, err := nacosClient.GetConfigDetail(dataID, group)
+	switch {
+	case errors.Is(err, client.ErrConfigNotFound):
+		exists = false
+	case err != nil:
+		checkError(err)
+	default:
+		current = detail
+	}
+
+	fmt.Printf("Rolling back %s/%s (namespace %s) to revision %d (%s by %s)\n",
+		group, dataID, nacosClient.Namespace, rev.ID, time.UnixMilli(rev.CreateTime).Format("2006-01-02 15:04:05"), rev.SrcUser)
+	if rev.Desc != "" {
+		fmt.Printf("Description: %s\n", rev.Desc)
+	}
+	fmt.Println()
+	if exists && rev.Content == current.Content {
+		fmt.Println("The revision has the current content, nothing to roll back")
+		return
+	}
+	currentName := fmt.Sprintf("%s/%s (current)", group, dataID)
+	if !exists {
+		currentName = fmt.Sprintf("%s/%s (deleted)", group, dataID)
+	}
+	output, err := diff.Diff("line",
+		diff.Document{Name: currentName, Content: current.Content},
+		diff.Document{Name: fmt.Sprintf("%s/%s (revision %d)", group, dataID, rev.ID), Content: rev.Content},
+		diff.Options{Color: useColor()})
+	checkError(err)
+	fmt.Print(output)
+	fmt.Println()
+
+	if rollbackDryRun {
+		fmt.Println("Dry run: nothing published")
+		return
+	}
+	if !rollbackYes && !isInteractive() {
+		checkError(fmt.Errorf("refusing to roll back without confirmation in non-interactive mode (use --yes)"))
+	}
+	if !confirmYesNo(fmt.Sprintf("Publish revision %d of %s (%s)?", rev.ID, dataID, group), rollbackYes) {
+		fmt.Println("Cancelled")
+		return
+	}
+
+	opts := client.PublishOptions{Type: current.Type, Tags: current.Tags()}
+	snapshot := ""
+	if exists {
+		snapshot, err = writeRollbackSnapshot(nacosClient.Namespace, group, dataID, current.Content)
+		if err != nil {
+			checkError(fmt.Errorf("save snapshot before rollback: %w", err))
+		}
+		fmt.Printf("Saved the current content to %s\n", snapshot)
+		opts.CasMd5 = listener.CalculateMD5(current.Content)
+	}
+	if err := nacosClient.PublishConfigWithOptions(dataID, group, rev.Content, opts); err != nil {
+		if errors.Is(err, client.ErrCasMismatch) {
+			checkError(fmt.Errorf("%s/%s changed while rolling back, run the command again", group, dataID))
+		}
+		checkError(err)
+	}
+	fmt.Printf("Rolled back %s/%s to revision %d\n", group, dataID, rev.ID)
+	if snapshot != "" {
+		fmt.Printf("To undo: nacos-cli config-set %s %s -n %s -f %s\n", dataID, group, nacosClient.Namespace, snapshot)
+	}
+}
+
+// writeRollbackSnapshot saves content that a rollback is about to replace, in
+// the form config-set -f takes
+func writeRollbackSnapshot(namespace, group, dataID, content string) (string, error) {
+	if err := os.MkdirAll(rollbackSnapshotDir, 0755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s.%s.%s.%s.bak", namespace, group, dataID, time.Now().Format("20060102-150405"))
+	name = strings.NewReplacer("/", "_", `\`, "_", ":", "_").Replace(name)
+	path := filepath.Join(rollbackSnapshotDir, name)
+	return path, os.WriteFile(path, []byte(content), 0644)
+}
+
+func init() {
+	rollbackConfigCmd.Flags().Int64Var(&rollbackRevision, "revision", 0, "History ID (nid) of the revision to restore, as listed by config-history")
+	rollbackConfigCmd.Flags().Int64Var(&rollbackRevision, "to-revision", 0, "Same as --revision")
+	rollbackConfigCmd.Flags().MarkDeprecated("to-revision", "use --revision")
+	rollbackConfigCmd.Flags().BoolVar(&rollbackDryRun, "dry-run", false, "Show the diff without publishing")
+	rollbackConfigCmd.Flags().BoolVarP(&rollbackYes, "yes", "y", false, "Roll back without asking for confirmation")
+	rollbackConfigCmd.Flags().StringVar(&rollbackSnapshotDir, "snapshot-dir", ".", "Directory for the snapshot of the current content")
+	revertLastCmd.Flags().BoolVar(&rollbackDryRun, "dry-run", false, "Show the diff without publishing")
+	revertLastCmd.Flags().BoolVarP(&rollbackYes, "yes", "y", false, "Revert without asking for confirmation")
+	revertLastCmd.Flags().StringVar(&rollbackSnapshotDir, "snapshot-dir", ".", "Directory for the snapshot of the current content")
+	rootCmd.AddCommand(rollbackConfigCmd)
+	rootCmd.AddCommand(revertLastCmd)
+}
@@ -3,11 +3,8 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"strings"
 
-	"github.com/nov11/nacos-cli/internal/client"
 	"github.com/nov11/nacos-cli/internal/config"
-	"github.com/nov11/nacos-cli/internal/terminal"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +19,25 @@ var (
 	accessKey  string
 	secretKey  string
 	configFile string
+	profile    string
+
+	token        string
+	tokenCommand string
+	tokenConfig  *config.TokenProviderConfig
+	asUser       string
+
+	recordFile string
+	replayFile string
+
+	compensateClockSkew bool
+
+	colorMode string
+	noPager   bool
+
+	debugRequests bool
+
+	// fileConfig is the loaded configuration file with the selected profile applied
+	fileConfig *config.Config
 )
 
 var rootCmd = &cobra.Command{
@@ -30,89 +46,16 @@ var rootCmd = &cobra.Command{
 	Long: `Nacos CLI is a powerful command-line tool for interacting with Nacos.
 It supports configuration management, skill management, and provides an interactive terminal.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// Load configuration from file if specified
-		var fileConfig *config.Config
-		if configFile != "" {
-			cfg, err := config.LoadConfig(configFile)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to load config file: %v\n", err)
-			} else {
-				fileConfig = cfg
-			}
-		}
-
-		// Apply configuration with priority: command line > config file > default
-		// Server address: --server has highest priority
-		if serverAddr == "" {
-			// Try to build from --host and --port
-			if host != "" {
-				if port > 0 {
-					serverAddr = fmt.Sprintf("%s:%d", host, port)
-				} else if strings.Contains(host, ":") {
-					// Host already contains port
-					serverAddr = host
-				} else {
-					// Use default port 8848
-					serverAddr = fmt.Sprintf("%s:8848", host)
-				}
-			} else if fileConfig != nil {
-				// Use from config file
-				serverAddr = fileConfig.GetServerAddr()
-			}
-		}
-
-		// Namespace: command line > config file > default (empty)
-		if namespace == "" && fileConfig != nil && fileConfig.Namespace != "" {
-			namespace = fileConfig.Namespace
-		}
-
-		// AuthType: command line > config file > default nacos
-		if authType == "" {
-			if fileConfig != nil && fileConfig.AuthType != "" {
-				authType = fileConfig.AuthType
-			} else {
-				authType = "nacos"
-			}
-		}
-
-		// Username: command line > config file > default
-		if username == "" {
-			if fileConfig != nil && fileConfig.Username != "" {
-				username = fileConfig.Username
-			} else {
-				username = "nacos"
-			}
-		}
-
-		// Password: command line > config file > default
-		if password == "" {
-			if fileConfig != nil && fileConfig.Password != "" {
-				password = fileConfig.Password
-			} else {
-				password = "nacos"
-			}
-		}
-
-		// AccessKey / SecretKey: command line > config file（AuthType=aliyun 时使用）
-		if accessKey == "" && fileConfig != nil {
-			accessKey = fileConfig.AccessKey
-		}
-		if secretKey == "" && fileConfig != nil {
-			secretKey = fileConfig.SecretKey
-		}
-
-		// Set default server address if still empty
-		if serverAddr == "" {
-			serverAddr = "127.0.0.1:8848"
-		}
+		resolveGlobalFlags(cmd)
+		startProfiling()
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		stopPager()
+		stopProfiling()
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// Default behavior: start interactive terminal
-		nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
-		term := terminal.NewTerminal(nacosClient)
-		if err := term.Start(); err != nil {
-			checkError(err)
-		}
+		startTerminal()
 	},
 }
 
@@ -126,6 +69,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&host, "host", "", "Nacos server host (e.g., 127.0.0.1)")
 	rootCmd.PersistentFlags().IntVar(&port, "port", 0, "Nacos server port (e.g., 8848)")
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "Path to configuration file")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "Profile from the configuration file to use")
 
 	// Global flags - legacy style (for backward compatibility)
 	rootCmd.PersistentFlags().StringVarP(&serverAddr, "server", "s", "", "Nacos server address (e.g., 127.0.0.1:8848)")
@@ -135,14 +79,59 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "Password (nacos auth)")
 	rootCmd.PersistentFlags().StringVar(&accessKey, "access-key", "", "AccessKey (aliyun auth)")
 	rootCmd.PersistentFlags().StringVar(&secretKey, "secret-key", "", "SecretKey (aliyun auth)")
+	rootCmd.PersistentFlags().BoolVar(&compensateClockSkew, "compensate-clock-skew", false, "Sign Aliyun requests with the server time when the local clock is off")
+	rootCmd.PersistentFlags().StringVar(&token, "token", "", "Pre-issued access token (skips username/password login)")
+	rootCmd.PersistentFlags().StringVar(&tokenCommand, "token-command", "", "Command that prints an access token to stdout")
+	rootCmd.PersistentFlags().StringVar(&asUser, "as", "", "Act as another user with a token the profile obtains for it (impersonation)")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "", "Color output: auto, always or never (default auto)")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Do not page long output through $PAGER")
+	rootCmd.PersistentFlags().BoolVar(&iKnowWhatIAmDoing, "i-know-what-i-am-doing", false, "Run operations that the protected profile does not allow")
+	rootCmd.PersistentFlags().StringVar(&overrideReason, "override", "", "Change outside the change windows of the profile, recording the reason in the audit log")
+	rootCmd.PersistentFlags().StringVar(&ticket, "ticket", "", "Ticket of the change, e.g. OPS-1234, tagged on published configs and recorded in the audit log")
+	rootCmd.PersistentFlags().IntVar(&maxChanges, "max-changes", 0, "Abort batch commands that would make more changes in a namespace (default: maxChanges of the profile, else no limit)")
+	rootCmd.PersistentFlags().BoolVar(&confirmOverBudget, "confirm-over-budget", false, "Run batch commands exceeding --max-changes anyway")
+	rootCmd.PersistentFlags().StringVar(&policyDir, "policy-dir", "", "Directory of Rego policies that must allow every published configuration (default: policyDir of the profile)")
+	rootCmd.PersistentFlags().StringVarP(&changeMessage, "message", "m", "", "Reason of the change, published as the description of the configs and shown in their history")
+	rootCmd.PersistentFlags().BoolVar(&debugRequests, "debug", false, "Log every request to stderr, with the verbatim response of server errors")
+	rootCmd.PersistentFlags().BoolVar(&showTimings, "timings", false, "Print where the time of the run went to stderr: login, each kind of request, local processing and rendering")
+	rootCmd.PersistentFlags().StringVar(&cpuProfile, "cpuprofile", "", "Write a CPU profile of the run to this file (for go tool pprof)")
+	rootCmd.PersistentFlags().StringVar(&memProfile, "memprofile", "", "Write a heap profile at the end of the run to this file (for go tool pprof)")
+	rootCmd.PersistentFlags().StringVar(&recordFile, "record", "", "Record all requests and responses of the run to a session file (credentials redacted)")
+	rootCmd.PersistentFlags().StringVar(&replayFile, "replay", "", "Answer requests from a session file written by --record instead of the server")
 
 	// Mark legacy server flag as deprecated but still functional
 	rootCmd.PersistentFlags().MarkDeprecated("server", "use --host and --port instead")
 }
 
+// resolveGroup resolves the group for a command: an empty group falls back to the
+// per-command default, then the profile default, then fallback. Aliases are expanded.
+func resolveGroup(cmd *cobra.Command, group, fallback string) string {
+	if fileConfig == nil {
+		if group == "" {
+			return fallback
+		}
+		return group
+	}
+	if group == "" {
+		if defaults, ok := fileConfig.Commands[cmd.Name()]; ok && defaults.Group != "" {
+			group = defaults.Group
+		} else if fileConfig.Group != "" {
+			group = fileConfig.Group
+		} else {
+			group = fallback
+		}
+	}
+	return fileConfig.ResolveGroup(group)
+}
+
 func checkError(err error) {
 	if err != nil {
+		stopPager()
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if requestID != "" {
+			fmt.Fprintf(os.Stderr, "Request ID: %s\n", requestID)
+		}
+		stopProfiling()
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/config"
+	"github.com/nov11/nacos-cli/internal/fakenacos"
+	"github.com/nov11/nacos-cli/internal/project"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// runCLI runs the root command with args against srv and returns its stdout.
+// Flags and the state resolved from them are reset first, as every run of the
+// binary starts from them.
+func runCLI(t *testing.T, srv *fakenacos.Server, args ...string) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	resetFlags(rootCmd)
+	fileConfig, tokenConfig = nil, nil
+	sessionTransport, sessionTimer, requestID = nil, nil, ""
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+
+	rootCmd.SetArgs(append([]string{"--host", srv.Addr()}, args...))
+	err = rootCmd.Execute()
+	w.Close()
+	os.Stdout = stdout
+	output := <-out
+	if err != nil {
+		t.Fatalf("nacos-cli %s: %v", strings.Join(args, " "), err)
+	}
+	return output
+}
+
+// resetFlags restores the default of every flag of cmd and its subcommands
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			var values []string
+			if def := strings.Trim(f.DefValue, "[]"); def != "" {
+				values = strings.Split(def, ",")
+			}
+			sv.Replace(values)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.PersistentFlags().VisitAll(reset)
+	cmd.Flags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		v1Login  bool
+		wantPath string
+	}{
+		{name: "v3", wantPath: "POST /nacos/v3/auth/user/login"},
+		{name: "v1 fallback", v1Login: true, wantPath: "POST /nacos/v1/auth/login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakenacos.New()
+			defer srv.Close()
+			srv.AuthEnabled = true
+			srv.V1Login = tt.v1Login
+			srv.SetConfig("public", "DEFAULT_GROUP", "app.yaml", "port: 8080")
+
+			out := runCLI(t, srv, "-u", "nacos", "-p", "nacos", "config-get", "app.yaml")
+			if !strings.Contains(out, "port: 8080") {
+				t.Errorf("output %q does not contain the content", out)
+			}
+			if !slices.Contains(srv.Requests(), tt.wantPath) {
+				t.Errorf("requests %v do not contain %s", srv.Requests(), tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.yaml")
+	if err := os.WriteFile(file, []byte("port: 9090"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		want  []string // substrings of the output
+		skip  []string // substrings the output must not have
+		check func(t *testing.T, srv *fakenacos.Server)
+	}{
+		{
+			name: "config-get",
+			args: []string{"config-get", "app.yaml"},
+			want: []string{"Data ID: app.yaml", "Group: DEFAULT_GROUP", "port: 8080"},
+		},
+		{
+			name: "config-get with group",
+			args: []string{"config-get", "db.properties", "DB_GROUP"},
+			want: []string{"Group: DB_GROUP", "url=jdbc:mysql://db"},
+		},
+		{
+			name: "config-set",
+			args: []string{"config-set", "app.yaml", "--file", file},
+			check: func(t *testing.T, srv *fakenacos.Server) {
+				if c, _ := srv.GetConfig("public", "DEFAULT_GROUP", "app.yaml"); c.Content != "port: 9090" {
+					t.Errorf("content = %q, want %q", c.Content, "port: 9090")
+				}
+			},
+		},
+		{
+			name: "config-set new group",
+			args: []string{"config-set", "app.yaml", "APP_GROUP", "--file", file},
+			check: func(t *testing.T, srv *fakenacos.Server) {
+				if _, ok := srv.GetConfig("public", "APP_GROUP", "app.yaml"); !ok {
+					t.Error("app.yaml was not published to APP_GROUP")
+				}
+				if c, _ := srv.GetConfig("public", "DEFAULT_GROUP", "app.yaml"); c.Content != "port: 8080" {
+					t.Errorf("DEFAULT_GROUP content = %q, want it unchanged", c.Content)
+				}
+			},
+		},
+		{
+			name: "config-list",
+			args: []string{"config-list"},
+			want: []string{"app.yaml", "db.properties", "DB_GROUP"},
+		},
+		{
+			name: "config-list by data ID",
+			args: []string{"config-list", "--data-id", "db.*"},
+			want: []string{"db.properties"},
+			skip: []string{"app.yaml"},
+		},
+		{
+			name: "config-delete",
+			args: []string{"config-delete", "app.yaml", "--yes"},
+			want: []string{"Deleted: 1, Failed: 0"},
+			check: func(t *testing.T, srv *fakenacos.Server) {
+				if _, ok := srv.GetConfig("public", "DEFAULT_GROUP", "app.yaml"); ok {
+					t.Error("app.yaml still exists")
+				}
+				if _, ok := srv.GetConfig("public", "DB_GROUP", "db.properties"); !ok {
+					t.Error("db.properties was deleted too")
+				}
+			},
+		},
+		{
+			name: "config-delete missing",
+			args: []string{"config-delete", "app.yaml", "gone.yaml", "--yes"},
+			want: []string{"not found DEFAULT_GROUP/gone.yaml", "The following 1 configuration(s)", "Deleted: 1, Failed: 0, Not found: 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakenacos.New()
+			defer srv.Close()
+			srv.SetConfig("public", "DEFAULT_GROUP", "app.yaml", "port: 8080")
+			srv.SetConfig("public", "DB_GROUP", "db.properties", "url=jdbc:mysql://db")
+
+			out := runCLI(t, srv, tt.args...)
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %q:\n%s", want, out)
+				}
+			}
+			for _, skip := range tt.skip {
+				if strings.Contains(out, skip) {
+					t.Errorf("output contains %q:\n%s", skip, out)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, srv)
+			}
+		})
+	}
+}
+
+func TestConfigSetPath(t *testing.T) {
+	const content = "# pool\nspring:\n  url: \"jdbc:mysql://db\"  # primary\n  maxPoolSize: 10\n"
+	tests := []struct {
+		name    string
+		path    string
+		value   string
+		want    string // content afterwards
+		wantErr bool
+	}{
+		{name: "number", path: "spring.maxPoolSize", value: "50", want: strings.Replace(content, "10", "50", 1)},
+		{name: "quoted string", path: "spring.url", value: "jdbc:mysql://db2", want: strings.Replace(content, "//db\"", "//db2\"", 1)},
+		{name: "new key would reformat", path: "spring.timeout", value: "5", want: content, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakenacos.New()
+			defer srv.Close()
+			srv.SetConfig("public", "DEFAULT_GROUP", "app.yaml", content)
+
+			err := setConfigAtPath(client.NewClient(srv.Addr()), "app.yaml", "DEFAULT_GROUP", tt.path, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if c, _ := srv.GetConfig("public", "DEFAULT_GROUP", "app.yaml"); c.Content != tt.want {
+				t.Errorf("content = %q, want %q", c.Content, tt.want)
+			}
+		})
+	}
+}
+
+func TestGCOperations(t *testing.T) {
+	tests := []struct {
+		name                string
+		dryRun, delete      bool
+		unmodifiedFor, want string
+	}{
+		{name: "expired", want: config.OperationDelete},
+		{name: "expired dry run", dryRun: true, want: config.OperationRead},
+		{name: "stale report", unmodifiedFor: "90d", want: config.OperationRead},
+		{name: "stale delete", unmodifiedFor: "90d", delete: true, want: config.OperationDelete},
+		{name: "stale delete dry run", unmodifiedFor: "90d", delete: true, dryRun: true, want: config.OperationRead},
+	}
+	defer func() { gcDryRun, gcDelete, gcUnmodifiedFor = false, false, "" }()
+	for _, tt := range tests {
+		gcDryRun, gcDelete, gcUnmodifiedFor = tt.dryRun, tt.delete, tt.unmodifiedFor
+		if got := commandOperationsOf(gcCmd); !slices.Equal(got, []string{tt.want}) {
+			t.Errorf("%s: operations = %v, want [%s]", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestManifestImportOperations(t *testing.T) {
+	nacosProject := t.TempDir()
+	if err := os.WriteFile(filepath.Join(nacosProject, project.FileName), []byte("state:\n  type: nacos\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name                string
+		dest, backend, want string
+		stateFile           string
+		dryRun              bool
+	}{
+		{name: "local state", dest: t.TempDir(), want: config.OperationRead},
+		{name: "--state-backend nacos", dest: t.TempDir(), backend: "nacos", want: config.OperationPublish},
+		{name: "project file nacos", dest: nacosProject, want: config.OperationPublish},
+		{name: "project file nacos, --state-file", dest: nacosProject, stateFile: "state.json", want: config.OperationRead},
+		{name: "project file nacos, dry run", dest: nacosProject, dryRun: true, want: config.OperationRead},
+	}
+	defer func() {
+		manifestImportDest, manifestImportStateBackend, manifestImportStateFile, manifestImportDryRun = ".", "", "", false
+	}()
+	for _, tt := range tests {
+		manifestImportDest, manifestImportStateBackend = tt.dest, tt.backend
+		manifestImportStateFile, manifestImportDryRun = tt.stateFile, tt.dryRun
+		if got := commandOperationsOf(manifestImportCmd); !slices.Equal(got, []string{tt.want}) {
+			t.Errorf("%s: operations = %v, want [%s]", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"regexp"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+// sandboxPrefix prefixes sandbox namespace IDs; sandbox-destroy refuses anything else
+const sandboxPrefix = "sandbox-"
+
+var (
+	sandboxName     string
+	sandboxTemplate string
+	sandboxReseed   bool
+	sandboxForce    bool
+)
+
+var sandboxCreateCmd = &cobra.Command{
+	Use:   "sandbox-create",
+	Short: "Create (or reuse) a personal sandbox namespace seeded from a template",
+	Long:  help.SandboxCreate.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := sandboxID()
+		nacosClient := newNacosClient()
+
+		existing, err := nacosClient.GetNamespace(id)
+		checkError(err)
+		seed := sandboxTemplate != ""
+		if existing != nil {
+			fmt.Printf("Reusing sandbox namespace %s\n", id)
+			if seed && !sandboxReseed {
+				fmt.Println("Not seeding an existing sandbox (use --reseed to copy the template again)")
+				seed = false
+			}
+		} else {
+			checkError(nacosClient.CreateNamespace(id, id, "Sandbox of "+currentUserName()))
+			fmt.Printf("Created sandbox namespace %s\n", id)
+		}
+
+		if seed {
+			copied, failed := cloneNamespace(nacosClient.WithNamespace(sandboxTemplate), nacosClient.WithNamespace(id))
+			fmt.Printf("Seeded %d configuration(s) from %s", copied, sandboxTemplate)
+			if failed > 0 {
+				fmt.Printf(", %d failed\n", failed)
+				os.Exit(1)
+			}
+			fmt.Println()
+		}
+		fmt.Printf("\nUse it with: nacos-cli -n %s ...\n", id)
+	},
+}
+
+var sandboxDestroyCmd = &cobra.Command{
+	Use:   "sandbox-destroy",
+	Short: "Delete a sandbox namespace and its configurations",
+	Long:  help.SandboxDestroy.FormatForCLI("nacos-cli"),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := sandboxID()
+		nacosClient := newNacosClient()
+
+		existing, err := nacosClient.GetNamespace(id)
+		checkError(err)
+		if existing == nil {
+			fmt.Printf("Sandbox namespace %s does not exist\n", id)
+			return
+		}
+
+		sandboxClient := nacosClient.WithNamespace(id)
+		items, err := sandboxClient.ListAllConfigs("", "")
+		checkError(err)
+		checkError(confirmByTyping(fmt.Sprintf("delete namespace %s and its %d configuration(s)", id, len(items)), id, sandboxForce))
+
+		for _, item := range items {
+			if err := sandboxClient.DeleteConfig(item.DataID, item.GroupName); err != nil {
+				checkError(fmt.Errorf("delete %s/%s: %w", item.GroupName, item.DataID, err))
+			}
+		}
+		checkError(nacosClient.DeleteNamespace(id))
+		fmt.Printf("Deleted sandbox namespace %s (%d configuration(s))\n", id, len(items))
+	},
+}
+
+// sandboxID returns the sandbox namespace ID: sandbox-<name>, default sandbox-<user>
+func sandboxID() string {
+	name := sandboxName
+	if name == "" {
+		name = currentUserName()
+	}
+	name = strings.TrimPrefix(name, sandboxPrefix)
+	name = regexp.MustCompile(`[^a-zA-Z0-9_-]+`).ReplaceAllString(strings.ToLower(name), "-")
+	if strings.Trim(name, "-") == "" {
+		checkError(fmt.Errorf("cannot derive a sandbox name, use --name"))
+	}
+	return sandboxPrefix + name
+}
+
+// currentUserName returns the OS user name (without a Windows domain)
+func currentUserName() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name := u.Username
+		if i := strings.LastIndex(name, `\`); i >= 0 {
+			name = name[i+1:]
+		}
+		return name
+	}
+	return os.Getenv("USER")
+}
+
+// cloneNamespace copies every configuration of src into dst, except nacos-cli bookkeeping
+func cloneNamespace(src, dst *client.NacosClient) (copied, failed int) {
+	all, err := src.ListAllConfigs("", "")
+	checkError(err)
+	var items []client.Config
+	var refs []configRef
+	for _, item := range all {
+		if isInternalGroup(item.GroupName) {
+			continue
+		}
+		items = append(items, item)
+		refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+	}
+	contents, errs := fetchConfigs(src, refs, 8)
+	for i, ref := range refs {
+		err := errs[i]
+		if err == nil {
+			err = dst.PublishConfigIdempotent(ref.DataID, ref.Group, contents[i], client.PublishOptions{Type: items[i].Type}, publishRetries)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "  error    %s/%s: %v\n", ref.Group, ref.DataID, err)
+			continue
+		}
+		copied++
+	}
+	return copied, failed
+}
+
+func init() {
+	for _, c := range []*cobra.Command{sandboxCreateCmd, sandboxDestroyCmd} {
+		c.Flags().StringVar(&sandboxName, "name", "", "Sandbox name, the namespace ID is sandbox-<name> (default: current user)")
+	}
+	sandboxCreateCmd.Flags().StringVar(&sandboxTemplate, "from", "", "Template namespace ID to copy configurations from")
+	sandboxCreateCmd.Flags().BoolVar(&sandboxReseed, "reseed", false, "Copy the template again into an existing sandbox")
+	sandboxDestroyCmd.Flags().BoolVar(&sandboxForce, "force", false, "Skip the confirmation prompt")
+	rootCmd.AddCommand(sandboxCreateCmd)
+	rootCmd.AddCommand(sandboxDestroyCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/selector"
+	"github.com/spf13/cobra"
+)
+
+// selectorExpr is the --selector of the command being run
+var selectorExpr string
+
+// addSelectorFlag adds --selector to a command working on a set of configurations
+func addSelectorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&selectorExpr, "selector", "", `Limit to the configurations matching an expression, e.g. 'group in (A,B) && dataId =~ "^svc-" && tag == managed'`)
+}
+
+// parseSelector parses --selector, nil when it is not given
+func parseSelector() *selector.Selector {
+	if selectorExpr == "" {
+		return nil
+	}
+	sel, err := selector.Parse(selectorExpr)
+	checkError(err)
+	return sel
+}
+
+// selectConfigs returns the ListAllConfigs items a selector matches, reading
+// the details of every item first when it compares tags
+func selectConfigs(nacosClient *client.NacosClient, sel *selector.Selector, items []client.Config, concurrency int) ([]client.Config, error) {
+	if sel == nil {
+		return items, nil
+	}
+	if sel.NeedsTags() {
+		for i, err := range fillConfigDetails(nacosClient, items, concurrency) {
+			if err != nil {
+				return nil, fmt.Errorf("read the tags of %s: %w", configtree.Key(items[i].GroupName, items[i].DataID), err)
+			}
+		}
+	}
+	var selected []client.Config
+	for _, item := range items {
+		if sel.Match(selectorConfig(item)) {
+			selected = append(selected, item)
+		}
+	}
+	return selected, nil
+}
+
+// selectorConfig returns the metadata of a list item a selector is evaluated against
+func selectorConfig(item client.Config) selector.Config {
+	return selector.Config{
+		Group:   item.GroupName,
+		DataID:  item.DataID,
+		Type:    item.Type,
+		AppName: item.AppName,
+		Tags:    client.SplitTags(item.Tags),
+	}
+}
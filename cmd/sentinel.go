@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flowResource    string
+	flowLimitApp    string
+	flowGrade       string
+	flowCount       float64
+	flowStrategy    string
+	flowRefResource string
+	flowBehavior    string
+	flowWarmUpSec   int
+	flowMaxQueueMs  int
+	flowCluster     bool
+	flowDelete      bool
+)
+
+var sentinelFlowListCmd = &cobra.Command{
+	Use:   "sentinel-flow-list dataId [group]",
+	Short: "List and validate the Sentinel flow rules of a configuration",
+	Long:  help.SentinelFlowList.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID, group := ruleArgs(cmd, args, "SENTINEL_GROUP")
+		content, err := getRuleContent(newNacosClient(), dataID, group)
+		checkError(err)
+		flowRules, err := rules.ParseFlowRules(content)
+		checkError(err)
+
+		fmt.Printf("%d flow rule(s) in %s (%s)\n", len(flowRules), dataID, group)
+		if len(flowRules) == 0 {
+			return
+		}
+		fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+		fmt.Printf("%-30s %-10s %-7s %-10s %-8s %s\n", "Resource", "LimitApp", "Grade", "Count", "Strategy", "Behavior")
+		fmt.Println("───────────────────────────────────────────────────────────────────────────────")
+		for _, r := range flowRules {
+			strategy := rules.StrategyName(r.Strategy)
+			if r.RefResource != "" {
+				strategy += ":" + r.RefResource
+			}
+			app := r.LimitApp
+			if app == "" {
+				app = "default"
+			}
+			fmt.Printf("%-30s %-10s %-7s %-10s %-8s %s\n", r.Resource, app, rules.GradeName(r.Grade),
+				strconv.FormatFloat(r.Count, 'f', -1, 64), strategy, rules.BehaviorName(r.ControlBehavior))
+		}
+	},
+}
+
+var sentinelFlowSetCmd = &cobra.Command{
+	Use:   "sentinel-flow-set dataId [group]",
+	Short: "Add, update or delete a Sentinel flow rule",
+	Long:  help.SentinelFlowSet.FormatForCLI("nacos-cli"),
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataID, group := ruleArgs(cmd, args, "SENTINEL_GROUP")
+		if flowResource == "" {
+			checkError(fmt.Errorf("--resource is required"))
+		}
+		nacosClient := newNacosClient()
+		content, err := getRuleContent(nacosClient, dataID, group)
+		checkError(err)
+		flowRules, err := rules.ParseFlowRules(content)
+		checkError(err)
+
+		i := rules.FindFlowRule(flowRules, flowResource, flowLimitApp)
+		action := "Updated"
+		switch {
+		case flowDelete:
+			if i < 0 {
+				checkError(fmt.Errorf("no flow rule for resource %s", flowResource))
+			}
+			flowRules = append(flowRules[:i], flowRules[i+1:]...)
+			action = "Deleted"
+		case i < 0:
+			if !cmd.Flags().Changed("count") {
+				checkError(fmt.Errorf("--count is required for a new rule"))
+			}
+			flowRules = append(flowRules, rules.FlowRule{Resource: flowResource, LimitApp: "default", Grade: rules.GradeQPS})
+			i = len(flowRules) - 1
+			action = "Added"
+		}
+		if !flowDelete {
+			checkError(applyFlowFlags(cmd, &flowRules[i]))
+		}
+		checkError(rules.ValidateFlowRules(flowRules))
+
+		checkError(publishRules(nacosClient, dataID, group, content, flowRules))
+		fmt.Printf("%s flow rule for %s in %s (%s)\n", action, flowResource, dataID, group)
+	},
+}
+
+// applyFlowFlags copies the flags given on the command line into the rule
+func applyFlowFlags(cmd *cobra.Command, r *rules.FlowRule) error {
+	flags := cmd.Flags()
+	var err error
+	if flags.Changed("limit-app") {
+		r.LimitApp = flowLimitApp
+	}
+	if flags.Changed("grade") {
+		if r.Grade, err = rules.ParseGrade(flowGrade); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("count") {
+		r.Count = flowCount
+	}
+	if flags.Changed("strategy") {
+		if r.Strategy, err = rules.ParseStrategy(flowStrategy); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("ref-resource") {
+		r.RefResource = flowRefResource
+	}
+	if flags.Changed("behavior") {
+		if r.ControlBehavior, err = rules.ParseBehavior(flowBehavior); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("warm-up-sec") {
+		r.WarmUpPeriodSec = flowWarmUpSec
+	}
+	if flags.Changed("max-queue-ms") {
+		r.MaxQueueingTimeMs = flowMaxQueueMs
+	}
+	if flags.Changed("cluster") {
+		r.ClusterMode = flowCluster
+	}
+	return nil
+}
+
+// ruleArgs returns the dataId and group arguments of a rule command
+func ruleArgs(cmd *cobra.Command, args []string, fallbackGroup string) (string, string) {
+	group := ""
+	if len(args) > 1 {
+		group = args[1]
+	}
+	return args[0], resolveGroup(cmd, group, fallbackGroup)
+}
+
+// getRuleContent returns the content of a rule configuration, empty if it does not exist yet
+func getRuleContent(nacosClient *client.NacosClient, dataID, group string) (string, error) {
+	content, err := nacosClient.GetConfig(dataID, group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		return "", nil
+	}
+	return content, err
+}
+
+// publishRules publishes rules as JSON, conditional on the content they were read from
+func publishRules(nacosClient *client.NacosClient, dataID, group, original string, v interface{}) error {
+	content, err := rules.Encode(v)
+	if err != nil {
+		return err
+	}
+	opts := client.PublishOptions{Type: "json"}
+	if original != "" {
+		opts.CasMd5 = listener.CalculateMD5(original)
+	}
+	if err := nacosClient.PublishConfigWithOptions(dataID, group, content, opts); err != nil {
+		if errors.Is(err, client.ErrCasMismatch) {
+			return fmt.Errorf("%w: %s was changed meanwhile, run the command again", err, dataID)
+		}
+		return err
+	}
+	return nil
+}
+
+func init() {
+	f := sentinelFlowSetCmd.Flags()
+	f.StringVar(&flowResource, "resource", "", "Resource name of the rule (required)")
+	f.StringVar(&flowLimitApp, "limit-app", "default", "Origin the rule applies to")
+	f.StringVar(&flowGrade, "grade", "qps", "Threshold type: qps or thread")
+	f.Float64Var(&flowCount, "count", 0, "Threshold (required for a new rule)")
+	f.StringVar(&flowStrategy, "strategy", "direct", "Strategy: direct, relate or chain")
+	f.StringVar(&flowRefResource, "ref-resource", "", "Related resource or entrance for the relate and chain strategies")
+	f.StringVar(&flowBehavior, "behavior", "default", "Control behavior: default, warm-up, rate-limiter or warm-up-rate-limiter")
+	f.IntVar(&flowWarmUpSec, "warm-up-sec", 0, "Warm-up period in seconds")
+	f.IntVar(&flowMaxQueueMs, "max-queue-ms", 0, "Maximum queueing time of the rate limiter in milliseconds")
+	f.BoolVar(&flowCluster, "cluster", false, "Use cluster flow control")
+	f.BoolVar(&flowDelete, "delete", false, "Delete the rule instead")
+	rootCmd.AddCommand(sentinelFlowListCmd)
+	rootCmd.AddCommand(sentinelFlowSetCmd)
+}
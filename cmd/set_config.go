@@ -2,25 +2,49 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/nov11/nacos-cli/internal/client"
 	"github.com/nov11/nacos-cli/internal/help"
 	"github.com/spf13/cobra"
 )
 
-var setConfigFile string
+var (
+	setConfigFile  string
+	setConfigTTL   string
+	setConfigPath  string
+	setConfigValue string
+)
 
 var setConfigCmd = &cobra.Command{
 	Use:   "config-set [dataId] [group]",
 	Short: "Publish a configuration to Nacos",
 	Long:  help.ConfigSet.FormatForCLI("nacos-cli"),
-	Args:  cobra.ExactArgs(2),
+	Args:  cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		dataID := args[0]
-		group := args[1]
+		group := ""
+		if len(args) > 1 {
+			group = args[1]
+		}
+		group = resolveGroup(cmd, group, "DEFAULT_GROUP")
 
+		if setConfigPath != "" || cmd.Flags().Changed("value") {
+			if setConfigPath == "" || !cmd.Flags().Changed("value") {
+				checkError(fmt.Errorf("--path and --value are used together"))
+			}
+			if setConfigFile != "" || setConfigTTL != "" {
+				checkError(fmt.Errorf("--path cannot be combined with --file or --ttl"))
+			}
+			checkError(setConfigAtPath(newNacosClient(), dataID, group, setConfigPath, setConfigValue))
+			return
+		}
+
+		ttl, err := parseAge(setConfigTTL)
+		checkError(err)
 		content, err := readSetConfigContent()
 		checkError(err)
 
@@ -30,13 +54,26 @@ var setConfigCmd = &cobra.Command{
 		}
 
 		// Create Nacos client
-		nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
+		nacosClient := newNacosClient()
 
 		fmt.Printf("Publishing config: %s (%s)...\n", dataID, group)
-		err = nacosClient.PublishConfig(dataID, group, content)
-		checkError(err)
-
-		fmt.Println("Configuration published successfully")
+		if ttl == 0 {
+			checkError(nacosClient.PublishConfig(dataID, group, content))
+			fmt.Println("Configuration published successfully")
+			return
+		}
+		// Tags sent with a publish replace the existing ones
+		detail, err := nacosClient.GetConfigDetail(dataID, group)
+		if err != nil && !errors.Is(err, client.ErrConfigNotFound) {
+			checkError(err)
+		}
+		var tags []string
+		if detail != nil {
+			tags = detail.Tags()
+		}
+		expiry := time.Now().Add(ttl)
+		checkError(nacosClient.PublishConfigWithOptions(dataID, group, content, client.PublishOptions{Tags: client.WithExpiryTags(tags, expiry)}))
+		fmt.Printf("Configuration published successfully, expires %s (deleted by gc)\n", expiry.Format("2006-01-02 15:04"))
 	},
 }
 
@@ -65,5 +102,8 @@ func readSetConfigContent() (string, error) {
 
 func init() {
 	setConfigCmd.Flags().StringVarP(&setConfigFile, "file", "f", "", "Path to config file (default: read from stdin)")
+	setConfigCmd.Flags().StringVar(&setConfigTTL, "ttl", "", "Publish a temporary configuration that gc deletes after this long, e.g. 2h or 7d")
+	setConfigCmd.Flags().StringVar(&setConfigPath, "path", "", "Change only the value at this path of a YAML or JSON configuration")
+	setConfigCmd.Flags().StringVar(&setConfigValue, "value", "", "New value for --path, parsed as YAML: 50 is a number, '\"50\"' a string")
 	rootCmd.AddCommand(setConfigCmd)
 }
@@ -0,0 +1,810 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/backup"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/config"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/selector"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncToProfile   string
+	syncToNamespace string
+	syncPattern     string
+	syncGroup       string
+	syncDelete      bool
+	syncOnce        bool
+	syncDryRun      bool
+	syncInterval    time.Duration
+	syncPoll        time.Duration
+	syncMetricsAddr string
+	syncConcurrency int
+
+	syncBidirectional  bool
+	syncConflictPolicy string
+	syncConflictReport string
+	syncMapping        string
+)
+
+var syncConfigCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Replicate configurations to another cluster or namespace, continuously",
+	Long:  help.Sync.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if syncToProfile == "" {
+			checkError(fmt.Errorf("--to-profile is required"))
+		}
+		switch syncConflictPolicy {
+		case conflictNewerWins, conflictSourceWins, conflictManual:
+		default:
+			checkError(fmt.Errorf("invalid --conflict %q (expected %s, %s or %s)", syncConflictPolicy, conflictNewerWins, conflictSourceWins, conflictManual))
+		}
+		if syncInterval < time.Second {
+			syncInterval = time.Second
+		}
+		group := ""
+		if syncGroup != "" && syncGroup != "*" {
+			group = resolveGroup(cmd, syncGroup, "")
+		}
+		var mapping *backup.Mapping
+		if syncMapping != "" {
+			var err error
+			mapping, err = backup.LoadMapping(syncMapping)
+			checkError(err)
+		}
+		source := newNacosClient()
+		target, targetCfg := syncTarget(source, mapping)
+		if target.ServerAddr == source.ServerAddr && target.Namespace == source.Namespace {
+			checkError(fmt.Errorf("the source and the target are both namespace %s of %s", namespaceLabel(source.Namespace), source.ServerAddr))
+		}
+		if !syncDryRun {
+			operations := []string{config.OperationPublish}
+			if syncDelete {
+				operations = append(operations, config.OperationDelete)
+			}
+			command := strings.Join(os.Args[1:], " ")
+			guard := terminalGuard(targetCfg, syncToProfile, target.ServerAddr)
+			for _, operation := range operations {
+				checkError(guard(operation, target.Namespace, command))
+			}
+			if syncBidirectional {
+				// Changes flow back into the source too
+				guard := terminalGuard(fileConfig, profile, source.ServerAddr)
+				for _, operation := range operations {
+					checkError(guard(operation, source.Namespace, command))
+				}
+			}
+		}
+
+		s := &configSyncer{
+			source:    source,
+			target:    target,
+			pattern:   syncPattern,
+			group:     group,
+			mapping:   mapping,
+			selector:  parseSelector(),
+			budget:    syncBudget(targetCfg),
+			known:     make(map[string]listener.ConfigItem),
+			pending:   make(map[string]time.Time),
+			conflicts: make(map[string]*syncConflict),
+			metrics:   &syncMetrics{},
+		}
+		direction := "to"
+		if syncBidirectional {
+			direction = "with"
+		}
+		logSync("Syncing namespace %s of %s %s namespace %s of %s (profile %s)",
+			namespaceLabel(source.Namespace), source.ServerAddr, direction, namespaceLabel(target.Namespace), target.ServerAddr, syncToProfile)
+		if syncOnce || syncDryRun {
+			failed, err := s.reconcile()
+			checkError(err)
+			if failed > 0 {
+				checkError(fmt.Errorf("%d configuration(s) failed to sync", failed))
+			}
+			if queued := s.queuedConflicts(); queued > 0 {
+				checkError(fmt.Errorf("%d conflict(s) wait for manual resolution", queued))
+			}
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		if syncMetricsAddr != "" {
+			go func() {
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", s.metrics)
+				mux.HandleFunc("/healthz", s.healthz)
+				if err := http.ListenAndServe(syncMetricsAddr, mux); err != nil {
+					checkError(fmt.Errorf("metrics server: %w", err))
+				}
+			}()
+			logSync("Serving metrics on http://%s/metrics and health on /healthz", syncMetricsAddr)
+		}
+
+		if _, err := s.reconcile(); err != nil {
+			logSync("Error: reconcile: %v", err)
+		}
+		nextReconcile := time.Now().Add(syncInterval)
+		if syncBidirectional {
+			targetSide := newSyncSide(target, true)
+			go func() {
+				for ctx.Err() == nil {
+					s.syncKeys(targetSide.wait(ctx, s, syncPoll))
+				}
+			}()
+		}
+		sourceSide := newSyncSide(source, false)
+		for ctx.Err() == nil {
+			if !time.Now().Before(nextReconcile) {
+				if _, err := s.reconcile(); err != nil {
+					logSync("Error: reconcile: %v", err)
+				}
+				nextReconcile = time.Now().Add(syncInterval)
+			}
+			s.syncKeys(sourceSide.wait(ctx, s, time.Until(nextReconcile)))
+		}
+		logSync("Stopped")
+	},
+}
+
+// syncTarget creates the client of the target profile, in --to-namespace, the
+// namespace of the mapping file, the namespace of the profile or else the
+// source namespace
+func syncTarget(source *client.NacosClient, mapping *backup.Mapping) (*client.NacosClient, *config.Config) {
+	if configFile == "" {
+		checkError(fmt.Errorf("--to-profile requires a config file (--config)"))
+	}
+	cfg, err := config.LoadConfig(configFile)
+	checkError(err)
+	cfg, err = cfg.WithProfile(syncToProfile)
+	checkError(err)
+	target, err := clientForConfig(cfg, syncToProfile)
+	checkError(err)
+	ns := syncToNamespace
+	if mapped := mapping.Namespace(source.Namespace); ns == "" && mapped != source.Namespace {
+		ns = mapped
+	}
+	if ns == "" {
+		ns = cfg.Namespace
+	}
+	if ns == "" {
+		ns = source.Namespace
+	}
+	return target.WithNamespace(ns), cfg
+}
+
+// syncSide long-polls one side of a sync for changes of the synced configurations
+type syncSide struct {
+	client   *client.NacosClient
+	target   bool // listens under the target names
+	listener *listener.ConfigListener
+	delay    time.Duration // before retrying a failed long poll
+}
+
+func newSyncSide(c *client.NacosClient, target bool) *syncSide {
+	l := listener.NewConfigListener(c.ServerAddr, c.Username, c.Password)
+	l.SetTransport(c.Transport())
+	l.SetPollTimeout(syncPoll)
+	return &syncSide{client: c, target: target, listener: l, delay: time.Second}
+}
+
+// wait returns the synced configurations that changed on this side within timeout
+func (side *syncSide) wait(ctx context.Context, s *configSyncer, timeout time.Duration) []syncTask {
+	// The token may have been refreshed by other requests
+	side.listener.SetAccessToken(side.client.AccessToken())
+	items, tasks := s.items(side.client.Namespace, side.target)
+	if len(items) == 0 {
+		sleepCtx(ctx, timeout)
+		return nil
+	}
+	changed, err := side.listener.WaitChanged(ctx, items, timeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			logSync("Warning: long polling %s: %v (retrying in %v)", side.client.ServerAddr, err, side.delay)
+			s.metrics.addError()
+			sleepCtx(ctx, side.delay)
+			if side.delay *= 2; side.delay > 30*time.Second {
+				side.delay = 30 * time.Second
+			}
+			// A restarted server may have forgotten the token
+			if listener.IsUnauthorized(err) {
+				if err := side.client.Ping(); err != nil {
+					logSync("Warning: %v", err)
+				}
+			}
+		}
+		return nil
+	}
+	side.delay = time.Second
+	if len(changed) == 0 {
+		// Servers that answer without holding the request
+		sleepCtx(ctx, timeout)
+	}
+	var changedTasks []syncTask
+	for _, item := range changed {
+		if task, ok := tasks[configtree.Key(item.Group, item.DataID)]; ok {
+			changedTasks = append(changedTasks, task)
+		}
+	}
+	return changedTasks
+}
+
+// syncTask is a configuration to sync, by its group/dataId key in the source
+// and in the target. The source key is empty for target configurations that
+// cannot be mapped back to the source.
+type syncTask struct {
+	source string
+	target string
+}
+
+// String returns the source key, followed by the target key when they differ
+func (t syncTask) String() string {
+	switch {
+	case t.source == "":
+		return t.target
+	case t.source != t.target:
+		return t.source + " -> " + t.target
+	default:
+		return t.source
+	}
+}
+
+// syncBudget returns the change budget of a reconcile: the one of the target
+// profile, or of the current one when smaller and changes flow back into it
+func syncBudget(targetCfg *config.Config) int {
+	budget := changeBudget(targetCfg)
+	if source := changeBudget(fileConfig); syncBidirectional && source > 0 && (budget == 0 || source < budget) {
+		budget = source
+	}
+	return budget
+}
+
+// configSyncer replicates the configurations of a source namespace matching a
+// data ID pattern, group and selector into a target namespace, or both ways
+type configSyncer struct {
+	source   *client.NacosClient
+	target   *client.NacosClient
+	pattern  string
+	group    string
+	selector *selector.Selector
+	mapping  *backup.Mapping
+	budget   int // changes a reconcile may make, 0 for no limit
+	metrics  *syncMetrics
+
+	run       sync.Mutex // serializes the syncs of the listeners and reconciles
+	mu        sync.Mutex
+	known     map[string]listener.ConfigItem // source key -> item with the MD5 both sides had at the last sync
+	pending   map[string]time.Time           // target key -> when a change not synced yet was seen
+	conflicts map[string]*syncConflict       // source key -> last conflict of the configuration
+}
+
+// task returns the sync task of a source configuration
+func (s *configSyncer) task(sourceKey string) syncTask {
+	group, dataID := configtree.SplitKey(sourceKey)
+	return syncTask{source: sourceKey, target: configtree.Key(s.mapping.Group(group), s.mapping.DataID(dataID))}
+}
+
+// targetTask returns the sync task of a target configuration, with an empty
+// source key when the mapping cannot be reversed or the source name is out of
+// the scope of the sync
+func (s *configSyncer) targetTask(targetKey string) syncTask {
+	group, dataID := configtree.SplitKey(targetKey)
+	sourceGroup, sourceDataID, ok := s.mapping.Reverse(group, dataID)
+	if !ok || !matchesWildcard(s.pattern, sourceDataID) || !matchesWildcard(s.group, sourceGroup) {
+		return syncTask{target: targetKey}
+	}
+	return syncTask{source: configtree.Key(sourceGroup, sourceDataID), target: targetKey}
+}
+
+// reconcile compares both namespaces in full and syncs every difference. It
+// returns how many configurations failed to sync.
+func (s *configSyncer) reconcile() (int, error) {
+	start := time.Now()
+	sourceItems, err := s.source.ListAllConfigs(s.pattern, s.group)
+	if err != nil {
+		s.metrics.recordReconcile(time.Since(start), 0, err)
+		return 0, fmt.Errorf("list source configurations: %w", err)
+	}
+	present := make(map[string]bool) // source keys, selected or not
+	for _, item := range sourceItems {
+		present[configtree.Key(item.GroupName, item.DataID)] = true
+	}
+	if sourceItems, err = selectConfigs(s.source, s.selector, sourceItems, syncConcurrency); err != nil {
+		s.metrics.recordReconcile(time.Since(start), 0, err)
+		return 0, fmt.Errorf("select source configurations: %w", err)
+	}
+	// Renamed configurations may fall outside the filters in the target
+	targetPattern, targetGroup := s.pattern, s.group
+	if s.mapping != nil {
+		targetPattern, targetGroup = "", ""
+	}
+	targetItems, err := s.target.ListAllConfigs(targetPattern, targetGroup)
+	if err != nil {
+		s.metrics.recordReconcile(time.Since(start), 0, err)
+		return 0, fmt.Errorf("list target configurations: %w", err)
+	}
+	targetMD5 := make(map[string]string)
+	targetByKey := make(map[string]client.Config)
+	for _, item := range targetItems {
+		if !isInternalGroup(item.GroupName) {
+			key := configtree.Key(item.GroupName, item.DataID)
+			targetMD5[key], targetByKey[key] = itemMD5(item), item
+		}
+	}
+
+	var tasks []syncTask
+	seen := make(map[string]bool)    // source keys
+	covered := make(map[string]bool) // target keys
+	for _, item := range sourceItems {
+		if isInternalGroup(item.GroupName) {
+			continue
+		}
+		task := s.task(configtree.Key(item.GroupName, item.DataID))
+		seen[task.source], covered[task.target] = true, true
+		md5 := itemMD5(item)
+		if md5 != "" && md5 == targetMD5[task.target] {
+			s.mu.Lock()
+			s.known[task.source] = listener.ConfigItem{DataID: item.DataID, Group: item.GroupName, MD5: md5}
+			delete(s.pending, task.target)
+			s.mu.Unlock()
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	inSync := len(seen) - len(tasks)
+	if syncBidirectional || syncDelete {
+		// Deleted from the source, or created in the target
+		s.mu.Lock()
+		for key := range s.known {
+			if task := s.task(key); !seen[key] && !present[key] && !covered[task.target] {
+				if _, ok := targetMD5[task.target]; ok {
+					covered[task.target] = true
+					tasks = append(tasks, task)
+				}
+			}
+		}
+		s.mu.Unlock()
+		var targetOnly []syncTask
+		var targetOnlyItems []client.Config
+		for key := range targetMD5 {
+			if covered[key] {
+				continue
+			}
+			task := s.targetTask(key)
+			if task.source == "" || present[task.source] {
+				// Not renamed back by the mapping, out of scope, or left
+				// out by the selector in the source
+				continue
+			}
+			targetOnly = append(targetOnly, task)
+			targetOnlyItems = append(targetOnlyItems, targetByKey[key])
+		}
+		if targetOnly, err = s.selectTargetOnly(targetOnlyItems, targetOnly); err != nil {
+			s.metrics.recordReconcile(time.Since(start), 0, err)
+			return 0, fmt.Errorf("select target configurations: %w", err)
+		}
+		for _, task := range targetOnly {
+			seen[task.source] = true
+			tasks = append(tasks, task)
+		}
+	}
+	s.mu.Lock()
+	for key := range s.known {
+		if !seen[key] {
+			delete(s.known, key)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].target < tasks[j].target })
+
+	if !syncDryRun {
+		if err := checkChangeBudget(s.budget, s.target.Namespace, len(tasks)); err != nil {
+			s.metrics.recordReconcile(time.Since(start), 0, err)
+			return 0, err
+		}
+	}
+	failed, queued := s.syncKeys(tasks)
+	s.metrics.recordReconcile(time.Since(start), failed, nil)
+	if syncDryRun {
+		logSync("Dry run: %d configuration(s) in sync, %d to sync", inSync, len(tasks))
+	} else {
+		logSync("Reconciled %d configuration(s): %d in sync, %d synced, %d failed in %s",
+			len(seen), inSync, len(tasks)-failed-queued, failed, time.Since(start).Round(time.Millisecond))
+	}
+	if queued := s.queuedConflicts(); queued > 0 {
+		logSync("%d conflict(s) wait for manual resolution", queued)
+	}
+	return failed, nil
+}
+
+// selectTargetOnly returns the tasks of the configurations only in the target
+// that the selector matches, evaluated with their source names
+func (s *configSyncer) selectTargetOnly(items []client.Config, tasks []syncTask) ([]syncTask, error) {
+	if s.selector == nil {
+		return tasks, nil
+	}
+	if s.selector.NeedsTags() {
+		for i, err := range fillConfigDetails(s.target, items, syncConcurrency) {
+			if err != nil {
+				return nil, fmt.Errorf("read the tags of %s: %w", tasks[i].target, err)
+			}
+		}
+	}
+	var selected []syncTask
+	for i, item := range items {
+		item.GroupName, item.DataID = configtree.SplitKey(tasks[i].source)
+		if s.selector.Match(selectorConfig(item)) {
+			selected = append(selected, tasks[i])
+		}
+	}
+	return selected, nil
+}
+
+// syncKeys syncs configurations concurrently and returns how many failed and
+// how many were left alone as conflicts queued for manual resolution
+func (s *configSyncer) syncKeys(tasks []syncTask) (int, int) {
+	if len(tasks) == 0 {
+		return 0, 0
+	}
+	s.run.Lock()
+	defer s.run.Unlock()
+	concurrency := syncConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	now := time.Now()
+	s.mu.Lock()
+	for _, task := range tasks {
+		if _, ok := s.pending[task.target]; !ok {
+			s.pending[task.target] = now
+		}
+	}
+	s.mu.Unlock()
+
+	var failed, queued int
+	var failedMu sync.Mutex
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task syncTask) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			syncKey := s.syncOne
+			if syncBidirectional {
+				syncKey = s.syncPair
+			}
+			action, err := syncKey(task)
+			if err != nil {
+				s.metrics.addError()
+				logSync("  %-10s %s: %v", action, task, err)
+				failedMu.Lock()
+				failed++
+				failedMu.Unlock()
+				return
+			}
+			if action == "conflict" {
+				// Logged when queued
+				failedMu.Lock()
+				queued++
+				failedMu.Unlock()
+				return
+			}
+			if action, ok := strings.CutSuffix(action, toSource); ok {
+				logSync("  %-10s %s (in the source)", action, task)
+			} else if action != "unchanged" {
+				logSync("  %-10s %s", action, task)
+			}
+		}(task)
+	}
+	wg.Wait()
+	if err := s.writeConflictReport(); err != nil {
+		logSync("Warning: conflict report: %v", err)
+	}
+	return failed, queued
+}
+
+// syncOne copies a configuration from the source to the target, or deletes it
+// from the target when it no longer exists in the source and --delete is set
+func (s *configSyncer) syncOne(task syncTask) (string, error) {
+	if task.source == "" {
+		return "unchanged", nil
+	}
+	group, dataID := configtree.SplitKey(task.source)
+	detail, err := s.source.GetConfigDetail(dataID, group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		if !syncDelete {
+			s.synced(task, "")
+			return "unchanged", nil
+		}
+		return s.deleteTarget(task)
+	}
+	if err != nil {
+		return "error", err
+	}
+	md5 := listener.CalculateMD5(detail.Content)
+
+	action := "update"
+	targetGroup, targetDataID := configtree.SplitKey(task.target)
+	current, err := s.target.GetConfig(targetDataID, targetGroup)
+	switch {
+	case errors.Is(err, client.ErrConfigNotFound):
+		action = "create"
+	case err != nil:
+		return "error", err
+	case listener.CalculateMD5(current) == md5:
+		s.synced(task, md5)
+		return "unchanged", nil
+	}
+	if syncDryRun {
+		return action, nil
+	}
+	opts := client.PublishOptions{Type: detail.Type, Desc: detail.Desc}
+	if err := s.target.PublishConfigIdempotent(targetDataID, targetGroup, detail.Content, opts, publishRetries); err != nil {
+		return "error", err
+	}
+	s.synced(task, md5)
+	s.metrics.addPublished()
+	return action, nil
+}
+
+// deleteTarget deletes a configuration removed from the source from the target
+func (s *configSyncer) deleteTarget(task syncTask) (string, error) {
+	group, dataID := configtree.SplitKey(task.target)
+	if _, err := s.target.GetConfig(dataID, group); errors.Is(err, client.ErrConfigNotFound) {
+		s.synced(task, "")
+		return "unchanged", nil
+	} else if err != nil {
+		return "error", err
+	}
+	if syncDryRun {
+		return "delete", nil
+	}
+	if err := s.target.DeleteConfig(dataID, group); err != nil {
+		return "error", err
+	}
+	s.synced(task, "")
+	s.metrics.addDeleted()
+	return "delete", nil
+}
+
+// synced records that the target holds the source content of a configuration,
+// with md5 empty when it was deleted from the source
+func (s *configSyncer) synced(task syncTask, md5 string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, task.target)
+	if md5 != "" {
+		group, dataID := configtree.SplitKey(task.source)
+		s.known[task.source] = listener.ConfigItem{DataID: dataID, Group: group, MD5: md5}
+	} else {
+		delete(s.known, task.source)
+	}
+	s.metrics.setLag(s.lagLocked(), len(s.known))
+}
+
+// lagLocked returns how long the oldest change not synced yet has been waiting
+func (s *configSyncer) lagLocked() time.Duration {
+	var oldest time.Time
+	for _, seen := range s.pending {
+		if oldest.IsZero() || seen.Before(oldest) {
+			oldest = seen
+		}
+	}
+	if oldest.IsZero() {
+		return 0
+	}
+	return time.Since(oldest)
+}
+
+// items returns the synced configurations in a stable order for long polling
+// a namespace, under their target names when target is set, and their tasks by
+// the keys of the items
+func (s *configSyncer) items(namespaceID string, target bool) ([]listener.ConfigItem, map[string]syncTask) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]listener.ConfigItem, 0, len(s.known))
+	tasks := make(map[string]syncTask, len(s.known))
+	for key, item := range s.known {
+		task := s.task(key)
+		item.Tenant = namespaceID
+		if target {
+			item.Group, item.DataID = configtree.SplitKey(task.target)
+		}
+		items = append(items, item)
+		tasks[configtree.Key(item.Group, item.DataID)] = task
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return configtree.Key(items[i].Group, items[i].DataID) < configtree.Key(items[j].Group, items[j].DataID)
+	})
+	s.metrics.setLag(s.lagLocked(), len(s.known))
+	return items, tasks
+}
+
+// healthz answers 200 while full reconciles succeed on schedule, 503 otherwise
+func (s *configSyncer) healthz(w http.ResponseWriter, r *http.Request) {
+	m := s.metrics
+	m.mu.Lock()
+	lastSuccess, lastFailure := m.lastSuccess, m.lastFailure
+	m.mu.Unlock()
+	switch {
+	case lastSuccess.IsZero():
+		http.Error(w, "no successful reconcile yet", http.StatusServiceUnavailable)
+	case lastFailure.After(lastSuccess):
+		http.Error(w, "the last reconcile failed", http.StatusServiceUnavailable)
+	case time.Since(lastSuccess) > 2*syncInterval+time.Minute:
+		http.Error(w, fmt.Sprintf("no successful reconcile since %s", lastSuccess.Format(time.RFC3339)), http.StatusServiceUnavailable)
+	default:
+		fmt.Fprintln(w, "ok")
+	}
+}
+
+// matchesWildcard reports whether name matches a pattern where * matches any
+// characters; an empty pattern matches every name
+func matchesWildcard(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+	parts := strings.Split(pattern, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(name)
+}
+
+// itemMD5 returns the MD5 of a listed configuration, empty when the list did not include it
+func itemMD5(item client.Config) string {
+	if item.Md5 != "" {
+		return item.Md5
+	}
+	if item.Content != "" {
+		return listener.CalculateMD5(item.Content)
+	}
+	return ""
+}
+
+func logSync(format string, args ...interface{}) {
+	fmt.Printf("%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+}
+
+// syncMetrics exposes the progress of sync in the Prometheus text format
+type syncMetrics struct {
+	mu           sync.Mutex
+	reconciles   int
+	errors       int
+	published    int
+	deleted      int
+	configs      int
+	lag          time.Duration
+	lastSuccess  time.Time
+	lastFailure  time.Time
+	lastDuration time.Duration
+	conflicts    int
+	queued       int
+}
+
+// recordReconcile records a full reconcile; err is set when it could not list
+// the configurations, failed counts the configurations it could not sync
+func (m *syncMetrics) recordReconcile(duration time.Duration, failed int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reconciles++
+	if err != nil || failed > 0 {
+		if err != nil {
+			m.errors++
+		}
+		m.lastFailure = time.Now()
+		return
+	}
+	m.lastSuccess = time.Now()
+	m.lastDuration = duration
+}
+
+func (m *syncMetrics) addError() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors++
+}
+
+func (m *syncMetrics) addPublished() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.published++
+}
+
+func (m *syncMetrics) addDeleted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleted++
+}
+
+func (m *syncMetrics) addConflict(queued int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.conflicts++
+	m.queued = queued
+}
+
+func (m *syncMetrics) setQueued(queued int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queued = queued
+}
+
+func (m *syncMetrics) setLag(lag time.Duration, configs int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lag, m.configs = lag, configs
+}
+
+func (m *syncMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_reconciles_total Full reconciles attempted.\n# TYPE nacos_cli_sync_reconciles_total counter\n")
+	fmt.Fprintf(w, "nacos_cli_sync_reconciles_total %d\n", m.reconciles)
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_errors_total Failed lists, long polls and configuration syncs.\n# TYPE nacos_cli_sync_errors_total counter\n")
+	fmt.Fprintf(w, "nacos_cli_sync_errors_total %d\n", m.errors)
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_published_total Configurations published on the target.\n# TYPE nacos_cli_sync_published_total counter\n")
+	fmt.Fprintf(w, "nacos_cli_sync_published_total %d\n", m.published)
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_deleted_total Configurations deleted from the target.\n# TYPE nacos_cli_sync_deleted_total counter\n")
+	fmt.Fprintf(w, "nacos_cli_sync_deleted_total %d\n", m.deleted)
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_conflicts_total Configurations found changed on both sides of a bidirectional sync.\n# TYPE nacos_cli_sync_conflicts_total counter\n")
+	fmt.Fprintf(w, "nacos_cli_sync_conflicts_total %d\n", m.conflicts)
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_conflicts_queued Conflicts waiting for manual resolution.\n# TYPE nacos_cli_sync_conflicts_queued gauge\n")
+	fmt.Fprintf(w, "nacos_cli_sync_conflicts_queued %d\n", m.queued)
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_configs Source configurations in sync and listened to.\n# TYPE nacos_cli_sync_configs gauge\n")
+	fmt.Fprintf(w, "nacos_cli_sync_configs %d\n", m.configs)
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_lag_seconds How long the oldest change not synced yet has been waiting.\n# TYPE nacos_cli_sync_lag_seconds gauge\n")
+	fmt.Fprintf(w, "nacos_cli_sync_lag_seconds %.3f\n", m.lag.Seconds())
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_last_success_timestamp_seconds Time of the last full reconcile without errors.\n# TYPE nacos_cli_sync_last_success_timestamp_seconds gauge\n")
+	fmt.Fprintf(w, "nacos_cli_sync_last_success_timestamp_seconds %d\n", unixOrZero(m.lastSuccess))
+	fmt.Fprintf(w, "# HELP nacos_cli_sync_last_reconcile_duration_seconds Duration of the last full reconcile without errors.\n# TYPE nacos_cli_sync_last_reconcile_duration_seconds gauge\n")
+	fmt.Fprintf(w, "nacos_cli_sync_last_reconcile_duration_seconds %.3f\n", m.lastDuration.Seconds())
+}
+
+func init() {
+	syncConfigCmd.Flags().StringVar(&syncToProfile, "to-profile", "", "Profile of the config file to sync to (required)")
+	syncConfigCmd.Flags().StringVar(&syncToNamespace, "to-namespace", "", "Namespace to sync to (default: the namespace of the profile, else the source namespace)")
+	syncConfigCmd.Flags().StringVar(&syncPattern, "pattern", "", "Sync the configurations whose data ID matches (supports wildcard *, default: all)")
+	syncConfigCmd.Flags().StringVar(&syncGroup, "group", "", "Configuration group name or alias (supports wildcard *, default: all groups)")
+	syncConfigCmd.Flags().BoolVar(&syncDelete, "delete", false, "Delete configurations from the target that were deleted from the source")
+	syncConfigCmd.Flags().BoolVar(&syncOnce, "once", false, "Reconcile once and exit instead of running as a daemon")
+	syncConfigCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what one reconcile would sync without changing the target")
+	syncConfigCmd.Flags().DurationVar(&syncInterval, "interval", 5*time.Minute, "How often to compare both namespaces in full")
+	syncConfigCmd.Flags().DurationVar(&syncPoll, "poll-timeout", listener.DefaultPollTimeout, "How long the server may hold a long poll open")
+	syncConfigCmd.Flags().StringVar(&syncMetricsAddr, "metrics-addr", "", "Serve Prometheus metrics and /healthz on this address, e.g. :9109")
+	syncConfigCmd.Flags().IntVar(&syncConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	syncConfigCmd.Flags().BoolVar(&syncBidirectional, "bidirectional", false, "Also sync the changes made in the target back to the source")
+	syncConfigCmd.Flags().StringVar(&syncConflictPolicy, "conflict", conflictNewerWins, "When both sides changed: newer-wins, source-wins or manual")
+	syncConfigCmd.Flags().StringVar(&syncMapping, "mapping", "", "Mapping file renaming the namespace, groups and data IDs in the target")
+	syncConfigCmd.Flags().StringVar(&syncConflictReport, "conflict-report", "", "Write the conflicts of bidirectional syncs to this JSON file")
+	addSelectorFlag(syncConfigCmd)
+	rootCmd.AddCommand(syncConfigCmd)
+}
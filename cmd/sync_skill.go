@@ -6,7 +6,6 @@ import (
 	"os/signal"
 	"syscall"
 
-	"github.com/nov11/nacos-cli/internal/client"
 	"github.com/nov11/nacos-cli/internal/help"
 	"github.com/nov11/nacos-cli/internal/skill"
 	"github.com/nov11/nacos-cli/internal/sync"
@@ -23,12 +22,22 @@ var syncSkillCmd = &cobra.Command{
 	Long:  help.SkillSync.FormatForCLI("nacos-cli"),
 	Args:  cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		var skillNames []string
+		if !syncAllSkills && len(args) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: skill name required (or use --all to sync all skills)\n")
+			fmt.Fprintf(os.Stderr, "\nUsage:\n")
+			fmt.Fprintf(os.Stderr, "  nacos-cli skill-sync <skillName> [skillName2...]\n")
+			fmt.Fprintf(os.Stderr, "  nacos-cli skill-sync --all\n")
+			os.Exit(1)
+		}
+
+		// Create Nacos client
+		nacosClient := newNacosClient()
+
+		skillNames := args
 
 		// Handle --all flag
 		if syncAllSkills {
-			// Create Nacos client to fetch all skills
-			nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
+			// Fetch all skills
 			skillService := skill.NewSkillService(nacosClient)
 
 			fmt.Println("Fetching list of all skills...")
@@ -45,19 +54,8 @@ var syncSkillCmd = &cobra.Command{
 
 			skillNames = skills
 			fmt.Printf("Found %d skills\n\n", len(skillNames))
-		} else if len(args) == 0 {
-			fmt.Fprintf(os.Stderr, "Error: skill name required (or use --all to sync all skills)\n")
-			fmt.Fprintf(os.Stderr, "\nUsage:\n")
-			fmt.Fprintf(os.Stderr, "  nacos-cli skill-sync <skillName> [skillName2...]\n")
-			fmt.Fprintf(os.Stderr, "  nacos-cli skill-sync --all\n")
-			os.Exit(1)
-		} else {
-			skillNames = args
 		}
 
-		// Create Nacos client
-		nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
-
 		// Create skill syncer
 		skillSyncer := sync.NewSkillSyncer(nacosClient, "")
 
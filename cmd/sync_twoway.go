@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/listener"
+)
+
+// Conflict policies of bidirectional syncs
+const (
+	conflictNewerWins  = "newer-wins"  // the side modified last wins
+	conflictSourceWins = "source-wins" // the source wins
+	conflictManual     = "manual"      // neither side changes until resolved by hand
+)
+
+// toSource marks the actions of bidirectional syncs that change the source
+const toSource = ":source"
+
+// syncConflict is a configuration changed on both sides since they were last in sync
+type syncConflict struct {
+	Group          string     `json:"group"`
+	DataID         string     `json:"dataId"`
+	Target         string     `json:"target,omitempty"`    // group/dataId in the target, when renamed
+	SourceMD5      string     `json:"sourceMd5,omitempty"` // empty when deleted in the source
+	TargetMD5      string     `json:"targetMd5,omitempty"` // empty when deleted in the target
+	SourceModified *time.Time `json:"sourceModified,omitempty"`
+	TargetModified *time.Time `json:"targetModified,omitempty"`
+	Resolution     string     `json:"resolution"` // source, target, queued or resolved (queued, then in sync)
+	DetectedAt     time.Time  `json:"detectedAt"`
+}
+
+// syncPair syncs a configuration both ways: the side that changed since the
+// last sync is copied to the other one, and the conflict policy decides when
+// both changed. Without --delete a deletion is undone from the other side.
+func (s *configSyncer) syncPair(task syncTask) (string, error) {
+	if task.source == "" {
+		return "unchanged", nil
+	}
+	group, dataID := configtree.SplitKey(task.source)
+	targetGroup, targetDataID := configtree.SplitKey(task.target)
+	src, err := configDetailOrNil(s.source, dataID, group)
+	if err != nil {
+		return "error", err
+	}
+	dst, err := configDetailOrNil(s.target, targetDataID, targetGroup)
+	if err != nil {
+		return "error", err
+	}
+	srcMD5, dstMD5 := detailMD5(src), detailMD5(dst)
+	if srcMD5 == dstMD5 {
+		s.resolveConflict(task.source)
+		s.synced(task, srcMD5)
+		return "unchanged", nil
+	}
+
+	s.mu.Lock()
+	base := s.known[task.source].MD5
+	s.mu.Unlock()
+	fromSource := true
+	switch {
+	case dstMD5 == base:
+	case srcMD5 == base:
+		fromSource = false
+	default:
+		resolution := s.recordConflict(task, src, dst)
+		if resolution == "queued" {
+			return "conflict", nil
+		}
+		fromSource = resolution == "source"
+	}
+
+	if ((fromSource && src == nil) || (!fromSource && dst == nil)) && !syncDelete {
+		// Deleted on one side: restore it from the other one
+		fromSource = !fromSource
+	}
+	to, detail, suffix := s.target, src, ""
+	toGroup, toDataID := targetGroup, targetDataID
+	if !fromSource {
+		to, detail, suffix = s.source, dst, toSource
+		toGroup, toDataID = group, dataID
+	}
+
+	if detail == nil {
+		if syncDryRun {
+			return "delete" + suffix, nil
+		}
+		if err := to.DeleteConfig(toDataID, toGroup); err != nil {
+			return "error", err
+		}
+		s.synced(task, "")
+		s.metrics.addDeleted()
+		return "delete" + suffix, nil
+	}
+	action := "update"
+	if (to == s.target && dst == nil) || (to == s.source && src == nil) {
+		action = "create"
+	}
+	if syncDryRun {
+		return action + suffix, nil
+	}
+	opts := client.PublishOptions{Type: detail.Type, Desc: detail.Desc}
+	if err := to.PublishConfigIdempotent(toDataID, toGroup, detail.Content, opts, publishRetries); err != nil {
+		return "error", err
+	}
+	s.synced(task, listener.CalculateMD5(detail.Content))
+	s.metrics.addPublished()
+	return action + suffix, nil
+}
+
+// recordConflict records a conflict and returns how the policy resolves it:
+// source, target or queued for manual resolution
+func (s *configSyncer) recordConflict(task syncTask, src, dst *client.ConfigDetail) string {
+	key := task.source
+	group, dataID := configtree.SplitKey(key)
+	c := &syncConflict{
+		Group:          group,
+		DataID:         dataID,
+		SourceMD5:      detailMD5(src),
+		TargetMD5:      detailMD5(dst),
+		SourceModified: detailModified(src),
+		TargetModified: detailModified(dst),
+		DetectedAt:     time.Now(),
+	}
+	if task.target != task.source {
+		c.Target = task.target
+	}
+	switch syncConflictPolicy {
+	case conflictSourceWins:
+		c.Resolution = "source"
+	case conflictNewerWins:
+		// A deleted side has no modify time: the remaining side wins
+		c.Resolution = "source"
+		if src == nil || (dst != nil && dst.ModifyTime > src.ModifyTime) {
+			c.Resolution = "target"
+		}
+	default:
+		c.Resolution = "queued"
+	}
+
+	s.mu.Lock()
+	previous := s.conflicts[key]
+	repeated := previous != nil && previous.Resolution == "queued" && c.Resolution == "queued" &&
+		previous.SourceMD5 == c.SourceMD5 && previous.TargetMD5 == c.TargetMD5
+	if !repeated {
+		s.conflicts[key] = c
+	}
+	queued := s.queuedLocked()
+	s.mu.Unlock()
+	if repeated {
+		return c.Resolution
+	}
+	s.metrics.addConflict(queued)
+	switch c.Resolution {
+	case "queued":
+		logSync("  %-10s %s: changed on both sides, queued for manual resolution", "conflict", task)
+	default:
+		logSync("  %-10s %s: changed on both sides, the %s wins (%s)", "conflict", task, c.Resolution, syncConflictPolicy)
+	}
+	return c.Resolution
+}
+
+// resolveConflict marks a queued conflict resolved once both sides are in sync
+func (s *configSyncer) resolveConflict(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c := s.conflicts[key]; c != nil && c.Resolution == "queued" {
+		c.Resolution = "resolved"
+		s.metrics.setQueued(s.queuedLocked())
+	}
+}
+
+// queuedConflicts returns how many conflicts wait for manual resolution
+func (s *configSyncer) queuedConflicts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queuedLocked()
+}
+
+func (s *configSyncer) queuedLocked() int {
+	queued := 0
+	for _, c := range s.conflicts {
+		if c.Resolution == "queued" {
+			queued++
+		}
+	}
+	return queued
+}
+
+// writeConflictReport writes the last conflict of every configuration to
+// --conflict-report, replacing the file
+func (s *configSyncer) writeConflictReport() error {
+	if syncConflictReport == "" || !syncBidirectional {
+		return nil
+	}
+	s.mu.Lock()
+	conflicts := make([]syncConflict, 0, len(s.conflicts))
+	for _, c := range s.conflicts {
+		conflicts = append(conflicts, *c)
+	}
+	s.mu.Unlock()
+	sort.Slice(conflicts, func(i, j int) bool {
+		return configtree.Key(conflicts[i].Group, conflicts[i].DataID) < configtree.Key(conflicts[j].Group, conflicts[j].DataID)
+	})
+	report := struct {
+		Source    string         `json:"source"`
+		Target    string         `json:"target"`
+		Policy    string         `json:"policy"`
+		UpdatedAt time.Time      `json:"updatedAt"`
+		Conflicts []syncConflict `json:"conflicts"`
+	}{
+		Source:    fmt.Sprintf("%s/%s", s.source.ServerAddr, namespaceLabel(s.source.Namespace)),
+		Target:    fmt.Sprintf("%s/%s", s.target.ServerAddr, namespaceLabel(s.target.Namespace)),
+		Policy:    syncConflictPolicy,
+		UpdatedAt: time.Now(),
+		Conflicts: conflicts,
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(syncConflictReport+".tmp", append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(syncConflictReport+".tmp", syncConflictReport)
+}
+
+// configDetailOrNil returns the detail of a configuration, nil when it does not exist
+func configDetailOrNil(c *client.NacosClient, dataID, group string) (*client.ConfigDetail, error) {
+	detail, err := c.GetConfigDetail(dataID, group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		return nil, nil
+	}
+	return detail, err
+}
+
+// detailMD5 returns the MD5 of the content of a configuration, empty when it does not exist
+func detailMD5(detail *client.ConfigDetail) string {
+	if detail == nil {
+		return ""
+	}
+	return listener.CalculateMD5(detail.Content)
+}
+
+// detailModified returns when a configuration was last modified, nil when unknown
+func detailModified(detail *client.ConfigDetail) *time.Time {
+	if detail == nil || detail.ModifyTime == 0 {
+		return nil
+	}
+	t := time.UnixMilli(detail.ModifyTime)
+	return &t
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/spf13/cobra"
+)
+
+// tagRetries is how often a tag update is retried when the configuration
+// changes between reading and publishing it
+const tagRetries = 3
+
+var (
+	tagPattern     string
+	tagGroup       string
+	tagDryRun      bool
+	tagConcurrency int
+)
+
+var tagAddCmd = &cobra.Command{
+	Use:   "config-tag-add tag...",
+	Short: "Add tags to the configurations matching a pattern",
+	Long:  help.ConfigTagAdd.FormatForCLI("nacos-cli"),
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTagUpdate(cmd, args, func(tags []string) []string {
+			return addTags(tags, args)
+		})
+	},
+}
+
+var tagRmCmd = &cobra.Command{
+	Use:   "config-tag-rm tag...",
+	Short: "Remove tags from the configurations matching a pattern",
+	Long:  help.ConfigTagRm.FormatForCLI("nacos-cli"),
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTagUpdate(cmd, args, func(tags []string) []string {
+			return removeTags(tags, args)
+		})
+	},
+}
+
+// runTagUpdate rewrites the tags of every configuration matching --pattern and
+// --group and reports each one
+func runTagUpdate(cmd *cobra.Command, tags []string, update func([]string) []string) {
+	if tagPattern == "" {
+		checkError(fmt.Errorf("--pattern is required (use '*' for every configuration)"))
+	}
+	for _, tag := range tags {
+		if tag == "" || strings.Contains(tag, ",") {
+			checkError(fmt.Errorf("invalid tag %q (tags cannot be empty or contain commas)", tag))
+		}
+	}
+	group := resolveGroup(cmd, tagGroup, "")
+	nacosClient := newNacosClient()
+	items, err := nacosClient.ListAllConfigs(tagPattern, group)
+	checkError(err)
+	var refs []configRef
+	for _, item := range items {
+		if isInternalGroup(item.GroupName) && item.GroupName != group {
+			continue
+		}
+		refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+	}
+	if len(refs) == 0 {
+		fmt.Println("No matching configurations")
+		return
+	}
+	if !tagDryRun {
+		// Configurations already carrying the tags count too: they are only
+		// known to be unchanged once read
+		checkError(checkChangeBudget(changeBudget(fileConfig), nacosClient.Namespace, len(refs)))
+	}
+
+	actions, errs := updateTags(nacosClient, refs, update, tagConcurrency)
+	counts := make(map[string]int)
+	for i, ref := range refs {
+		key := configtree.Key(ref.Group, ref.DataID)
+		counts[actions[i]]++
+		if errs[i] != nil {
+			fmt.Printf("  %-10s %s: %v\n", actions[i], key, errs[i])
+			continue
+		}
+		fmt.Printf("  %-10s %s\n", actions[i], key)
+	}
+	fmt.Printf("\nUpdated: %d, Unchanged: %d, Failed: %d\n", counts["updated"], counts["unchanged"], counts["error"])
+	if tagDryRun {
+		fmt.Println("Dry run: nothing published")
+	}
+	if counts["error"] > 0 {
+		os.Exit(1)
+	}
+}
+
+// updateTags rewrites the tags of configurations concurrently, with at most
+// concurrency requests in flight. Actions and errors are in the order of refs.
+func updateTags(nacosClient *client.NacosClient, refs []configRef, update func([]string) []string, concurrency int) ([]string, []error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	actions := make([]string, len(refs))
+	errs := make([]error, len(refs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		go func(i int, ref configRef) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			actions[i], errs[i] = updateConfigTags(nacosClient, ref, update)
+		}(i, ref)
+	}
+	wg.Wait()
+	return actions, errs
+}
+
+// updateConfigTags publishes a configuration with updated tags, compare-and-swap
+// against the content it read so that a concurrent change is not overwritten
+func updateConfigTags(nacosClient *client.NacosClient, ref configRef, update func([]string) []string) (string, error) {
+	for attempt := 1; ; attempt++ {
+		detail, err := nacosClient.GetConfigDetail(ref.DataID, ref.Group)
+		if err != nil {
+			return "error", err
+		}
+		tags := detail.Tags()
+		updated := update(tags)
+		if slices.Equal(tags, updated) {
+			return "unchanged", nil
+		}
+		if tagDryRun {
+			return "updated", nil
+		}
+		opts := client.PublishOptions{
+			CasMd5: listener.CalculateMD5(detail.Content),
+			Tags:   updated,
+			Type:   detail.Type,
+		}
+		if changeMessage == "" {
+			opts.Desc = detail.Desc
+		}
+		err = nacosClient.PublishConfigIdempotent(ref.DataID, ref.Group, detail.Content, opts, publishRetries)
+		if errors.Is(err, client.ErrCasMismatch) && attempt < tagRetries {
+			continue
+		}
+		if err != nil {
+			return "error", err
+		}
+		return "updated", nil
+	}
+}
+
+// addTags returns tags with the new tags appended, without duplicates
+func addTags(tags, add []string) []string {
+	result := append([]string(nil), tags...)
+	for _, tag := range add {
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// removeTags returns tags without the removed tags
+func removeTags(tags, remove []string) []string {
+	result := []string{}
+	for _, tag := range tags {
+		if !slices.Contains(remove, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func init() {
+	for _, c := range []*cobra.Command{tagAddCmd, tagRmCmd} {
+		c.Flags().StringVar(&tagPattern, "pattern", "", "Update the configurations whose data ID matches (supports wildcard *)")
+		c.Flags().StringVar(&tagGroup, "group", "", "Configuration group name or alias (supports wildcard *, default: all groups)")
+		c.Flags().BoolVar(&tagDryRun, "dry-run", false, "Show which configurations would change without publishing")
+		c.Flags().IntVar(&tagConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+		rootCmd.AddCommand(c)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/nov11/nacos-cli/internal/client"
 	"github.com/nov11/nacos-cli/internal/help"
 	"github.com/nov11/nacos-cli/internal/skill"
 	"github.com/spf13/cobra"
@@ -29,7 +28,7 @@ var uploadSkillCmd = &cobra.Command{
 		skillPath := args[0]
 
 		// Create Nacos client
-		nacosClient := client.NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey)
+		nacosClient := newNacosClient()
 
 		// Create skill service
 		skillService := skill.NewSkillService(nacosClient)
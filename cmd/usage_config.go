@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/spf13/cobra"
+)
+
+var (
+	usageFile        string
+	usageInterval    time.Duration
+	usageConcurrency int
+	unusedFor        string
+	unusedOutput     string
+	unusedExitCode   bool
+)
+
+// configUsage is what the listener samples of usage-sample saw in a namespace
+type configUsage struct {
+	FirstSample  time.Time            `json:"firstSample"`
+	LastSample   time.Time            `json:"lastSample"`
+	Samples      int                  `json:"samples"`
+	LastListened map[string]time.Time `json:"lastListened"` // group/dataId -> last sample it had a listener in
+}
+
+// unusedConfig is a configuration report-unused-configs found no client for
+type unusedConfig struct {
+	Config       string     `json:"config"` // group/dataId
+	LastListened *time.Time `json:"lastListened"`
+	LastModified time.Time  `json:"lastModified"`
+	ModifiedBy   string     `json:"modifiedBy,omitempty"`
+}
+
+// unusedReport is the result of a report-unused-configs run
+type unusedReport struct {
+	Namespace   string         `json:"namespace"`
+	UnusedFor   string         `json:"unusedFor"`
+	FirstSample time.Time      `json:"firstSample"`
+	Samples     int            `json:"samples"`
+	Checked     int            `json:"checked"`
+	Unused      []unusedConfig `json:"unused"`
+}
+
+var usageSampleCmd = &cobra.Command{
+	Use:   "usage-sample",
+	Short: "Record which configurations have listening clients, once or as a daemon",
+	Long:  help.UsageSample.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := usagePath()
+		checkError(err)
+		nacosClient := newNacosClient()
+		key := nacosClient.ServerAddr + "/" + nacosClient.Namespace
+		sample := func() {
+			listened, err := sampleListeners(nacosClient)
+			if err != nil {
+				logBackup("Sample failed: %v", err)
+				return
+			}
+			// Reload so that daemons of other namespaces sharing the file are kept
+			usage, err := loadConfigUsage(path)
+			checkError(err)
+			u := usage[key]
+			if u == nil {
+				u = &configUsage{FirstSample: time.Now(), LastListened: make(map[string]time.Time)}
+				usage[key] = u
+			}
+			u.LastSample = time.Now()
+			u.Samples++
+			for _, config := range listened {
+				u.LastListened[config] = u.LastSample
+			}
+			checkError(saveConfigUsage(path, usage))
+			logBackup("Sampled namespace %s: %d configuration(s) with listeners", namespaceLabel(nacosClient.Namespace), len(listened))
+		}
+
+		sample()
+		if usageInterval <= 0 {
+			return
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+		for {
+			sleepCtx(ctx, usageInterval)
+			if ctx.Err() != nil {
+				logBackup("Stopped")
+				return
+			}
+			sample()
+		}
+	},
+}
+
+var reportUnusedConfigsCmd = &cobra.Command{
+	Use:   "report-unused-configs",
+	Short: "List configurations no client listened to and nobody changed for a while",
+	Long:  help.ReportUnusedConfigs.FormatForCLI("nacos-cli"),
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(unusedOutput)
+		window, err := parseAge(unusedFor)
+		checkError(err)
+		if window == 0 {
+			checkError(fmt.Errorf("--unused-for must be positive"))
+		}
+		path, err := usagePath()
+		checkError(err)
+		usage, err := loadConfigUsage(path)
+		checkError(err)
+		nacosClient := newNacosClient()
+		u := usage[nacosClient.ServerAddr+"/"+nacosClient.Namespace]
+		if u == nil {
+			checkError(fmt.Errorf("no listener samples of namespace %s in %s; run usage-sample first", namespaceLabel(nacosClient.Namespace), path))
+		}
+		now := time.Now()
+		if covered := now.Sub(u.FirstSample); covered < window {
+			checkError(fmt.Errorf("the samples cover %s, less than --unused-for %s; sample longer first",
+				covered.Round(time.Hour), unusedFor))
+		}
+
+		cutoff := now.Add(-window)
+		items, err := nacosClient.ListAllConfigs("", "")
+		checkError(err)
+		report := &unusedReport{Namespace: nacosClient.Namespace, UnusedFor: unusedFor, FirstSample: u.FirstSample, Samples: u.Samples, Unused: []unusedConfig{}}
+		var candidates []unusedConfig
+		for _, item := range items {
+			if isInternalGroup(item.GroupName) {
+				continue
+			}
+			report.Checked++
+			key := configtree.Key(item.GroupName, item.DataID)
+			c := unusedConfig{Config: key, LastModified: time.UnixMilli(item.ModifyTime)}
+			if t, ok := u.LastListened[key]; ok {
+				if t.After(cutoff) {
+					continue
+				}
+				c.LastListened = &t
+			}
+			candidates = append(candidates, c)
+		}
+
+		// The history has the latest change and who made it; it is empty when
+		// the server already purged it, then the list modify time stands
+		_, firstErr := runParallel(len(candidates), usageConcurrency, func(i int) error {
+			group, dataID := configtree.SplitKey(candidates[i].Config)
+			page, err := nacosClient.ListConfigHistory(dataID, group, 1, 1)
+			if err != nil {
+				return err
+			}
+			if len(page.PageItems) > 0 {
+				h := page.PageItems[0]
+				if modified := time.UnixMilli(h.ModifyTime); modified.After(candidates[i].LastModified) {
+					candidates[i].LastModified = modified
+				}
+				candidates[i].ModifiedBy = h.SrcUser
+			}
+			return nil
+		})
+		checkError(firstErr)
+		for _, c := range candidates {
+			if c.LastModified.Before(cutoff) {
+				report.Unused = append(report.Unused, c)
+			}
+		}
+		sort.Slice(report.Unused, func(i, j int) bool { return report.Unused[i].Config < report.Unused[j].Config })
+
+		if unusedOutput == "json" {
+			printJSON(report)
+		} else {
+			printUnusedReport(report)
+		}
+		if unusedExitCode && len(report.Unused) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// sampleListeners returns the configurations of the namespace that have at
+// least one listening client
+func sampleListeners(nacosClient *client.NacosClient) ([]string, error) {
+	items, err := nacosClient.ListAllConfigs("", "")
+	if err != nil {
+		return nil, err
+	}
+	var refs []configRef
+	for _, item := range items {
+		if !isInternalGroup(item.GroupName) {
+			refs = append(refs, configRef{Group: item.GroupName, DataID: item.DataID})
+		}
+	}
+	listened := make([]bool, len(refs))
+	_, firstErr := runParallel(len(refs), usageConcurrency, func(i int) error {
+		subscribers, err := nacosClient.ListConfigSubscribers(refs[i].DataID, refs[i].Group)
+		listened[i] = len(subscribers) > 0
+		return err
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	var keys []string
+	for i, ref := range refs {
+		if listened[i] {
+			keys = append(keys, configtree.Key(ref.Group, ref.DataID))
+		}
+	}
+	return keys, nil
+}
+
+func printUnusedReport(report *unusedReport) {
+	fmt.Printf("Namespace %s: %d configuration(s) checked against %d sample(s) since %s\n",
+		namespaceLabel(report.Namespace), report.Checked, report.Samples, report.FirstSample.Format("2006-01-02 15:04"))
+	if len(report.Unused) == 0 {
+		fmt.Printf("Every configuration had a listener or a change in the last %s\n", report.UnusedFor)
+		return
+	}
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("%-44s %-17s %-17s %s\n", "Config", "Last listener", "Last modified", "By")
+	fmt.Println("───────────────────────────────────────────────────────────────────────────────────────")
+	for _, c := range report.Unused {
+		listened := "never seen"
+		if c.LastListened != nil {
+			listened = c.LastListened.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%-44s %-17s %-17s %s\n", c.Config, listened, c.LastModified.Format("2006-01-02 15:04"), c.ModifiedBy)
+	}
+	fmt.Printf("\nProbably unused: %d (no listener and no change in %s)\n", len(report.Unused), report.UnusedFor)
+}
+
+// usagePath returns --file, else ~/.nacos-cli/config-usage.json
+func usagePath() (string, error) {
+	if usageFile != "" {
+		return usageFile, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".nacos-cli", "config-usage.json"), nil
+}
+
+// loadConfigUsage reads the listener samples, keyed by server/namespace
+func loadConfigUsage(path string) (map[string]*configUsage, error) {
+	usage := make(map[string]*configUsage)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return usage, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return usage, nil
+}
+
+func saveConfigUsage(path string, usage map[string]*configUsage) error {
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func init() {
+	for _, c := range []*cobra.Command{usageSampleCmd, reportUnusedConfigsCmd} {
+		c.Flags().StringVar(&usageFile, "file", "", "File of the listener samples (default: ~/.nacos-cli/config-usage.json)")
+		c.Flags().IntVar(&usageConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	}
+	usageSampleCmd.Flags().DurationVar(&usageInterval, "interval", 0, "Keep sampling at this interval until interrupted (default: sample once, e.g. from cron)")
+	reportUnusedConfigsCmd.Flags().StringVar(&unusedFor, "unused-for", "30d", "Report configurations without listeners and changes for this long, e.g. 30d or 72h")
+	reportUnusedConfigsCmd.Flags().StringVarP(&unusedOutput, "output", "o", "table", "Output format: table or json")
+	reportUnusedConfigsCmd.Flags().BoolVar(&unusedExitCode, "exit-code", false, "Exit with status 1 when configurations are reported")
+	rootCmd.AddCommand(usageSampleCmd)
+	rootCmd.AddCommand(reportUnusedConfigsCmd)
+}
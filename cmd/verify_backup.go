@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/nov11/nacos-cli/internal/backup"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/lock"
+	"github.com/nov11/nacos-cli/internal/selector"
+	"github.com/nov11/nacos-cli/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyAgainst     string
+	verifyIgnoreExtra bool
+	verifyOutput      string
+	verifyConcurrency int
+)
+
+// verifyResult is the outcome for one configuration
+type verifyResult struct {
+	Config string `json:"config"`
+	Status string `json:"status"` // ok, corrupt, missing, changed, extra or error
+	Detail string `json:"detail,omitempty"`
+}
+
+// verifyReport is the backup-verify report
+type verifyReport struct {
+	Archive   string         `json:"archive"`
+	Namespace string         `json:"namespace"`
+	Selector  string         `json:"selector,omitempty"`
+	Pass      bool           `json:"pass"`
+	Counts    map[string]int `json:"counts"`
+	Results   []verifyResult `json:"results"`
+}
+
+var verifyBackupCmd = &cobra.Command{
+	Use:   "backup-verify archive",
+	Short: "Check that a backup archive matches the configurations on the server",
+	Long:  help.BackupVerify.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(verifyOutput)
+		r, err := storage.OpenFile(args[0], storageOptions)
+		checkError(err)
+		archive, err := backup.Load(r)
+		r.Close()
+		checkError(err)
+
+		nacosClient := newNacosClient()
+		namespace := archive.Manifest.Namespace
+		if verifyAgainst != "" {
+			namespace = verifyAgainst
+		}
+		if namespace == "" {
+			namespace = nacosClient.Namespace
+		}
+		sel := parseSelector()
+		if sel == nil && archive.Manifest.Selector != "" {
+			// The archive holds the configurations it was exported with
+			sel, err = selector.Parse(archive.Manifest.Selector)
+			checkError(err)
+		}
+		report, err := verifyArchive(nacosClient.WithNamespace(namespace), archive, sel)
+		checkError(err)
+		report.Archive = args[0]
+
+		if verifyOutput == "json" {
+			printJSON(report)
+		} else {
+			printVerifyReport(report)
+		}
+		if !report.Pass {
+			os.Exit(1)
+		}
+	},
+}
+
+// verifyArchive compares the archive with the namespace of the client in both
+// directions, limited to the configurations sel matches when not nil. The
+// server metadata decides whether an archived configuration is matched, the
+// archived one when it is missing from the server.
+func verifyArchive(nacosClient *client.NacosClient, archive *backup.Archive, sel *selector.Selector) (*verifyReport, error) {
+	report := &verifyReport{Namespace: nacosClient.Namespace, Selector: sel.String(), Counts: make(map[string]int)}
+	add := func(key, status, detail string) {
+		report.Results = append(report.Results, verifyResult{Config: key, Status: status, Detail: detail})
+		report.Counts[status]++
+	}
+
+	items, err := nacosClient.ListAllConfigs("", "")
+	if err != nil {
+		return nil, err
+	}
+	onServer := make(map[string]bool)
+	for _, item := range items {
+		onServer[configtree.Key(item.GroupName, item.DataID)] = true
+	}
+	if items, err = selectConfigs(nacosClient, sel, items, verifyConcurrency); err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool)
+	for _, item := range items {
+		selected[configtree.Key(item.GroupName, item.DataID)] = true
+	}
+	archived := make(map[string]configtree.ManifestEntry)
+	for _, entry := range archive.Manifest.Entries {
+		archived[configtree.Key(entry.Group, entry.DataID)] = entry
+	}
+	inScope := func(key string) bool {
+		switch {
+		case sel == nil || sel.String() == archive.Manifest.Selector:
+			return true
+		case onServer[key]:
+			return selected[key]
+		}
+		group, dataID := configtree.SplitKey(key)
+		return sel.Match(selector.Config{Group: group, DataID: dataID, Type: archived[key].Type})
+	}
+
+	// The archive itself: every content must match the MD5 recorded when it was taken
+	expected := make(map[string]string)
+	for _, entry := range archive.Manifest.Entries {
+		expected[configtree.Key(entry.Group, entry.DataID)] = entry.MD5
+	}
+	var keys []string
+	for key := range archive.Contents {
+		if inScope(key) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range expected {
+		if _, ok := archive.Contents[key]; !ok && inScope(key) {
+			add(key, "corrupt", "listed in the manifest but missing from the archive")
+		}
+	}
+	sort.Strings(keys)
+
+	refs := make([]configRef, len(keys))
+	for i, key := range keys {
+		group, dataID := configtree.SplitKey(key)
+		refs[i] = configRef{Group: group, DataID: dataID}
+	}
+	contents, errs := fetchConfigs(nacosClient, refs, verifyConcurrency)
+	for i, key := range keys {
+		archived := listener.CalculateMD5(archive.Contents[key])
+		if md5, ok := expected[key]; !ok {
+			add(key, "corrupt", "not listed in the manifest")
+			continue
+		} else if md5 != archived {
+			add(key, "corrupt", fmt.Sprintf("content MD5 %s does not match the manifest %s", archived, md5))
+			continue
+		}
+		switch {
+		case errors.Is(errs[i], client.ErrConfigNotFound):
+			add(key, "missing", "not on the server")
+		case errs[i] != nil:
+			add(key, "error", errs[i].Error())
+		case listener.CalculateMD5(contents[i]) != archived:
+			add(key, "changed", fmt.Sprintf("server MD5 %s, archive MD5 %s", listener.CalculateMD5(contents[i]), archived))
+		default:
+			add(key, "ok", "")
+		}
+	}
+
+	// The server: everything it has should be in the archive
+	for _, item := range items {
+		key := configtree.Key(item.GroupName, item.DataID)
+		if item.GroupName == lock.Group {
+			continue
+		}
+		if _, ok := archive.Contents[key]; !ok {
+			add(key, "extra", "on the server but not in the archive")
+		}
+	}
+
+	sort.SliceStable(report.Results, func(i, j int) bool { return report.Results[i].Config < report.Results[j].Config })
+	failures := len(report.Results) - report.Counts["ok"]
+	if verifyIgnoreExtra {
+		failures -= report.Counts["extra"]
+	}
+	report.Pass = failures == 0
+	return report, nil
+}
+
+func printVerifyReport(report *verifyReport) {
+	fmt.Printf("Verifying %s against namespace %s\n", report.Archive, report.Namespace)
+	if report.Selector != "" {
+		fmt.Printf("Limited to the configurations matching %s\n", report.Selector)
+	}
+	if report.Counts["ok"] < len(report.Results) {
+		fmt.Println()
+	}
+	for _, result := range report.Results {
+		if result.Status == "ok" {
+			continue
+		}
+		fmt.Printf("  %-8s %s: %s\n", result.Status, result.Config, result.Detail)
+	}
+	fmt.Printf("\nOK: %d, Changed: %d, Missing: %d, Extra: %d, Corrupt: %d, Errors: %d\n",
+		report.Counts["ok"], report.Counts["changed"], report.Counts["missing"], report.Counts["extra"],
+		report.Counts["corrupt"], report.Counts["error"])
+	if report.Pass {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+	}
+}
+
+func init() {
+	verifyBackupCmd.Flags().StringVar(&verifyAgainst, "against", "", "Namespace to compare with (default: the namespace the archive was taken from)")
+	verifyBackupCmd.Flags().BoolVar(&verifyIgnoreExtra, "ignore-extra", false, "Pass even if the server has configurations the archive lacks")
+	verifyBackupCmd.Flags().StringVarP(&verifyOutput, "output", "o", "table", "Output format: table or json")
+	verifyBackupCmd.Flags().IntVar(&verifyConcurrency, "concurrency", 8, "Maximum number of concurrent requests")
+	addSelectorFlag(verifyBackupCmd)
+	addStorageFlags(verifyBackupCmd)
+	rootCmd.AddCommand(verifyBackupCmd)
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/configtree"
+	"github.com/nov11/nacos-cli/internal/help"
+	"github.com/nov11/nacos-cli/internal/listener"
+	"github.com/nov11/nacos-cli/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchGroup    string
+	watchInterval time.Duration
+	watchContent  bool
+	watchOutput   string
+	watchPoll     time.Duration
+	watchGRPCPort int
+)
+
+// watchEvent is a change reported by config-watch
+type watchEvent struct {
+	Time    time.Time `json:"time"`
+	Event   string    `json:"event"` // create, update or delete
+	Group   string    `json:"group"`
+	DataID  string    `json:"dataId"`
+	MD5     string    `json:"md5,omitempty"`
+	Content string    `json:"content,omitempty"`
+}
+
+var watchConfigCmd = &cobra.Command{
+	Use:   "config-watch dataIdPattern",
+	Short: "Watch configurations matching a pattern, including ones created later",
+	Long:  help.ConfigWatch.FormatForCLI("nacos-cli"),
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOutputFormat(watchOutput)
+		pattern := args[0]
+		group := watchGroup
+		if group != "*" {
+			group = resolveGroup(cmd, group, "DEFAULT_GROUP")
+		}
+		if watchInterval < time.Second {
+			watchInterval = time.Second
+		}
+
+		nacosClient := newNacosClient()
+		configListener := listener.NewConfigListener(nacosClient.ServerAddr, nacosClient.Username, nacosClient.Password)
+		configListener.SetTransport(nacosClient.Transport())
+		configListener.SetPollTimeout(watchPoll)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		w := &patternWatcher{client: nacosClient, pattern: pattern, group: group, known: make(map[string]listener.ConfigItem)}
+		checkError(w.scan(false))
+		fmt.Fprintf(os.Stderr, "Watching %d configuration(s) matching %s in group %s, namespace %s (Ctrl+C to stop)...\n",
+			len(w.known), pattern, group, nacosClient.Namespace)
+
+		// Nacos 3 pushes created and deleted configurations over gRPC; older
+		// servers are listed every --interval instead
+		grpcAddr, err := watchGRPCAddr(nacosClient.ServerAddr, watchGRPCPort)
+		checkError(err)
+		watcher, err := w.watch(ctx, grpcAddr)
+		fuzzy := err == nil
+		if err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "Warning: fuzzy watch: %v; listing the configurations every %v instead\n", err, watchInterval)
+		}
+		defer func() {
+			if watcher != nil {
+				watcher.Close()
+			}
+		}()
+
+		nextScan := time.Now().Add(watchInterval)
+		nextWatch := time.Now()
+		reconnectDelay, watchDelay := time.Second, time.Second
+		for ctx.Err() == nil {
+			if fuzzy && watcher == nil && !time.Now().Before(nextWatch) {
+				if watcher, err = w.watch(ctx, grpcAddr); err != nil {
+					watcher = nil
+					if ctx.Err() == nil {
+						fmt.Fprintf(os.Stderr, "Warning: fuzzy watch: %v (retrying in %v)\n", err, watchDelay)
+					}
+					nextWatch = time.Now().Add(watchDelay)
+					if watchDelay *= 2; watchDelay > 30*time.Second {
+						watchDelay = 30 * time.Second
+					}
+				} else {
+					watchDelay = time.Second
+				}
+			}
+			if watcher == nil && !time.Now().Before(nextScan) {
+				if err := w.scan(true); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: list configurations: %v\n", err)
+				}
+				nextScan = time.Now().Add(watchInterval)
+			}
+			// The token may have been refreshed by the list request
+			configListener.SetAccessToken(nacosClient.AccessToken())
+
+			wait := watchPoll
+			if watcher == nil {
+				wait = time.Until(nextScan)
+				if fuzzy && time.Until(nextWatch) < wait {
+					wait = time.Until(nextWatch)
+				}
+			}
+			var events <-chan rpc.ConfigEvent
+			var lost <-chan struct{}
+			if watcher != nil {
+				events, lost = watcher.Events(), watcher.Done()
+			}
+			pollCtx, stopPoll := context.WithCancel(ctx)
+			results := make(chan watchPollResult, 1)
+			go func(items []listener.ConfigItem) {
+				var r watchPollResult
+				deadline := time.Now().Add(wait)
+				if len(items) > 0 {
+					r.changed, r.err = configListener.WaitChanged(pollCtx, items, wait)
+				}
+				if r.err == nil && len(r.changed) == 0 {
+					// Servers that answer without holding the request
+					sleepCtx(pollCtx, time.Until(deadline))
+				}
+				results <- r
+			}(w.items())
+
+			select {
+			case r := <-results:
+				stopPoll()
+				if r.err != nil {
+					if ctx.Err() == nil {
+						fmt.Fprintf(os.Stderr, "Warning: long polling: %v (retrying in %v)\n", r.err, reconnectDelay)
+						sleepCtx(ctx, reconnectDelay)
+						if reconnectDelay *= 2; reconnectDelay > 30*time.Second {
+							reconnectDelay = 30 * time.Second
+						}
+						// A restarted server may have forgotten the token
+						if listener.IsUnauthorized(r.err) {
+							if err := nacosClient.Ping(); err != nil {
+								fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+							}
+						}
+					}
+					continue
+				}
+				reconnectDelay = time.Second
+				for _, item := range r.changed {
+					w.refresh(configtree.Key(item.Group, item.DataID))
+				}
+			case e := <-events:
+				// The next long poll includes created configurations
+				stopPoll()
+				<-results
+				w.apply(e)
+			case <-lost:
+				stopPoll()
+				<-results
+				if ctx.Err() == nil {
+					fmt.Fprintf(os.Stderr, "Warning: fuzzy watch: %v (reconnecting)\n", watcher.Err())
+				}
+				watcher.Close()
+				watcher = nil
+				nextScan = time.Now().Add(watchInterval)
+			case <-ctx.Done():
+				stopPoll()
+				<-results
+			}
+		}
+	},
+}
+
+// watchPollResult is the outcome of a long poll run alongside the fuzzy watch
+type watchPollResult struct {
+	changed []listener.ConfigItem
+	err     error
+}
+
+// watchGRPCAddr returns the gRPC address of the server: the HTTP port + 1000
+// unless a port is given
+func watchGRPCAddr(serverAddr string, port int) (string, error) {
+	host, p, err := net.SplitHostPort(serverAddr)
+	if err != nil {
+		return "", fmt.Errorf("invalid server address %q: %w", serverAddr, err)
+	}
+	if port == 0 {
+		httpPort, err := strconv.Atoi(p)
+		if err != nil {
+			return "", fmt.Errorf("invalid port in server address %q", serverAddr)
+		}
+		port = httpPort + 1000
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
+
+// patternWatcher tracks the configurations matching a data ID and group pattern
+type patternWatcher struct {
+	client  *client.NacosClient
+	pattern string
+	group   string
+	known   map[string]listener.ConfigItem // key -> item with the last seen MD5
+}
+
+// scan lists the matching configurations to find created and deleted ones
+func (w *patternWatcher) scan(report bool) error {
+	group := w.group
+	if group == "*" {
+		group = ""
+	}
+	items, err := w.client.ListAllConfigs(w.pattern, group)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if isInternalGroup(item.GroupName) {
+			continue
+		}
+		key := configtree.Key(item.GroupName, item.DataID)
+		seen[key] = true
+		if _, ok := w.known[key]; ok {
+			continue
+		}
+		if !report {
+			md5 := item.Md5
+			if md5 == "" {
+				md5 = listener.CalculateMD5(item.Content)
+			}
+			w.known[key] = listener.ConfigItem{DataID: item.DataID, Group: item.GroupName, Tenant: w.client.Namespace, MD5: md5}
+			continue
+		}
+		w.refresh(key)
+	}
+	for key := range w.known {
+		if !seen[key] {
+			w.refresh(key)
+		}
+	}
+	return nil
+}
+
+// watch fuzzy-watches the pattern; the server first reports the differences
+// from the known configurations, so nothing changed before it is missed
+func (w *patternWatcher) watch(ctx context.Context, addr string) (*rpc.ConfigWatcher, error) {
+	namespace := w.namespace()
+	known := make([]string, 0, len(w.known))
+	for _, item := range w.known {
+		known = append(known, rpc.GroupKey(item.DataID, item.Group, namespace))
+	}
+	opts := rpc.Options{Token: w.client.AccessToken, TLS: w.client.TLSConfig()}
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return rpc.WatchConfigs(dialCtx, addr, opts, w.pattern, w.group, namespace, known)
+}
+
+// apply reports a configuration the fuzzy watch found created or deleted
+func (w *patternWatcher) apply(e rpc.ConfigEvent) {
+	if isInternalGroup(e.Group) {
+		return
+	}
+	key := configtree.Key(e.Group, e.DataID)
+	_, known := w.known[key]
+	switch {
+	case e.Type == rpc.ConfigAdded && !known:
+		w.refresh(key)
+		if _, ok := w.known[key]; !ok {
+			// Deleted again before it could be fetched; the delete follows
+			w.known[key] = listener.ConfigItem{DataID: e.DataID, Group: e.Group, Tenant: w.client.Namespace}
+			printWatchEvent(watchEvent{Event: "create", Group: e.Group, DataID: e.DataID})
+		}
+	case e.Type == rpc.ConfigDeleted && known:
+		delete(w.known, key)
+		printWatchEvent(watchEvent{Event: "delete", Group: e.Group, DataID: e.DataID})
+	}
+}
+
+func (w *patternWatcher) namespace() string {
+	if w.client.Namespace == "" {
+		return "public"
+	}
+	return w.client.Namespace
+}
+
+// refresh fetches a configuration and reports what happened to it since last seen
+func (w *patternWatcher) refresh(key string) {
+	group, dataID := configtree.SplitKey(key)
+	old, known := w.known[key]
+	content, err := w.client.GetConfig(dataID, group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		if known {
+			delete(w.known, key)
+			printWatchEvent(watchEvent{Event: "delete", Group: group, DataID: dataID})
+		}
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: get %s: %v\n", key, err)
+		return
+	}
+	md5 := listener.CalculateMD5(content)
+	if known && old.MD5 == md5 {
+		return
+	}
+	w.known[key] = listener.ConfigItem{DataID: dataID, Group: group, Tenant: w.client.Namespace, MD5: md5}
+	event := watchEvent{Event: "update", Group: group, DataID: dataID, MD5: md5}
+	if !known {
+		event.Event = "create"
+	}
+	if watchContent {
+		event.Content = content
+	}
+	printWatchEvent(event)
+}
+
+// items returns the known configurations in a stable order for long polling
+func (w *patternWatcher) items() []listener.ConfigItem {
+	items := make([]listener.ConfigItem, 0, len(w.known))
+	for _, item := range w.known {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return configtree.Key(items[i].Group, items[i].DataID) < configtree.Key(items[j].Group, items[j].DataID)
+	})
+	return items
+}
+
+func printWatchEvent(e watchEvent) {
+	e.Time = time.Now()
+	if watchOutput == "json" {
+		data, _ := json.Marshal(e)
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Printf("%s %-7s %s", e.Time.Format("15:04:05"), e.Event, configtree.Key(e.Group, e.DataID))
+	if e.MD5 != "" {
+		fmt.Printf(" (md5 %s)", e.MD5)
+	}
+	fmt.Println()
+	if e.Content != "" {
+		fmt.Println(e.Content)
+	}
+}
+
+// sleepCtx sleeps for d or until ctx is cancelled
+func sleepCtx(ctx context.Context, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
+
+func init() {
+	watchConfigCmd.Flags().StringVar(&watchGroup, "group", "", "Group or group pattern, * for all groups (default: DEFAULT_GROUP)")
+	watchConfigCmd.Flags().DurationVar(&watchInterval, "interval", 10*time.Second, "How often to list the matching configurations when the server has no fuzzy watch (before Nacos 3)")
+	watchConfigCmd.Flags().IntVar(&watchGRPCPort, "grpc-port", 0, "gRPC port of the server (default: the HTTP port + 1000)")
+	watchConfigCmd.Flags().BoolVar(&watchContent, "content", false, "Print the new content with each event")
+	watchConfigCmd.Flags().DurationVar(&watchPoll, "poll-timeout", listener.DefaultPollTimeout, "How long the server may hold a long poll open")
+	watchConfigCmd.Flags().StringVarP(&watchOutput, "output", "o", "table", "Output format: table or json (one event per line)")
+	rootCmd.AddCommand(watchConfigCmd)
+}
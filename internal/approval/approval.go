@@ -0,0 +1,174 @@
+// Package approval implements reviewed changes: plan writes the changes to a
+// file, reviewers sign it with their keys and apply --change verifies the
+// signatures before publishing exactly what was reviewed.
+package approval
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Change actions
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+)
+
+// Plan is a set of changes to one namespace with the approvals of its reviewers
+type Plan struct {
+	Server    string     `yaml:"server" json:"server"`
+	Namespace string     `yaml:"namespace" json:"namespace"`
+	Project   string     `yaml:"project,omitempty" json:"project,omitempty"`
+	Author    string     `yaml:"author" json:"author"`
+	CreatedAt string     `yaml:"createdAt" json:"createdAt"` // RFC 3339, UTC
+	Changes   []Change   `yaml:"changes" json:"changes"`
+	Approvals []Approval `yaml:"approvals,omitempty" json:"-"`
+}
+
+// Change is the new content of one configuration
+type Change struct {
+	Group   string   `yaml:"group" json:"group"`
+	DataID  string   `yaml:"dataId" json:"dataId"`
+	Action  string   `yaml:"action" json:"action"`                       // create | update
+	BaseMD5 string   `yaml:"baseMd5,omitempty" json:"baseMd5,omitempty"` // server content the change was planned against
+	MD5     string   `yaml:"md5" json:"md5"`
+	Type    string   `yaml:"type,omitempty" json:"type,omitempty"`
+	Tags    []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Content string   `yaml:"content" json:"content"`
+}
+
+// Approval is the signature of a reviewer over the digest of a plan
+type Approval struct {
+	Reviewer   string `yaml:"reviewer"`
+	ApprovedAt string `yaml:"approvedAt"` // RFC 3339, UTC
+	Digest     string `yaml:"digest"`     // hex SHA-256 of the plan without approvals
+	Signature  string `yaml:"signature"`  // base64 ed25519 signature
+}
+
+// Load reads a plan file
+func Load(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read change file: %w", err)
+	}
+	var p Plan
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse change file %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Save writes the plan file
+func (p *Plan) Save(path string) error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Digest returns the hex SHA-256 of the plan without its approvals, so that
+// any change of the plan invalidates them
+func (p *Plan) Digest() string {
+	data, _ := json.Marshal(p)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// signedMessage is what a reviewer signs
+func signedMessage(a Approval) []byte {
+	return []byte("nacos-cli approval\n" + a.Reviewer + "\n" + a.ApprovedAt + "\n" + a.Digest + "\n")
+}
+
+// Approve signs the plan as reviewer, replacing an earlier approval of the reviewer
+func (p *Plan) Approve(reviewer string, key ed25519.PrivateKey, now time.Time) Approval {
+	a := Approval{Reviewer: reviewer, ApprovedAt: now.UTC().Format(time.RFC3339), Digest: p.Digest()}
+	a.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, signedMessage(a)))
+	approvals := []Approval{a}
+	for _, existing := range p.Approvals {
+		if existing.Reviewer != reviewer {
+			approvals = append(approvals, existing)
+		}
+	}
+	p.Approvals = approvals
+	return a
+}
+
+// Verify checks the approvals against the public keys of the trusted
+// reviewers. It returns the reviewers whose approval is valid, sorted, and a
+// description of each approval that is not.
+func (p *Plan) Verify(reviewers map[string]string) ([]string, []string) {
+	digest := p.Digest()
+	valid := make(map[string]bool)
+	var problems []string
+	for _, a := range p.Approvals {
+		encoded, ok := reviewers[a.Reviewer]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: not a trusted reviewer", a.Reviewer))
+			continue
+		}
+		key, err := ParsePublicKey(encoded)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", a.Reviewer, err))
+			continue
+		}
+		if a.Digest != digest {
+			problems = append(problems, fmt.Sprintf("%s: approved a different version of the change", a.Reviewer))
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(a.Signature)
+		if err != nil || !ed25519.Verify(key, signedMessage(a), signature) {
+			problems = append(problems, fmt.Sprintf("%s: invalid signature", a.Reviewer))
+			continue
+		}
+		valid[a.Reviewer] = true
+	}
+	names := make([]string, 0, len(valid))
+	for name := range valid {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, problems
+}
+
+// GenerateKey returns a new signing key and its public key, both base64 encoded
+func GenerateKey() (private, public string, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(priv.Seed()), base64.StdEncoding.EncodeToString(pub), nil
+}
+
+// LoadPrivateKey reads a signing key written by GenerateKey
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid signing key %s", path)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ParsePublicKey decodes a base64 public key
+func ParsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return ed25519.PublicKey(key), nil
+}
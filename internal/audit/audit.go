@@ -0,0 +1,62 @@
+// Package audit appends the changes made with nacos-cli, and the guard rails
+// they overrode, to a local log of JSON lines.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Entry is one change, or impersonated command, recorded in the audit log
+type Entry struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user"`
+	As         string    `json:"as,omitempty"` // user impersonated with --as
+	Host       string    `json:"host,omitempty"`
+	Profile    string    `json:"profile,omitempty"`
+	Server     string    `json:"server"`
+	Namespace  string    `json:"namespace"`
+	Command    []string  `json:"command"`    // arguments, credentials redacted
+	Operations []string  `json:"operations"` // publish and/or delete, read for impersonated reads
+	Ticket     string    `json:"ticket,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	RequestID  string    `json:"requestId,omitempty"` // X-Request-ID of the requests
+	Overrides  []string  `json:"overrides,omitempty"`
+	Reason     string    `json:"reason,omitempty"` // given with --override
+}
+
+// DefaultPath returns ~/.nacos-cli/audit.log
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".nacos-cli", "audit.log"), nil
+}
+
+// Append writes the entry as one line at the end of the log, creating it
+// readable by its owner only
+func Append(path string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
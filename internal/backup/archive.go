@@ -0,0 +1,120 @@
+// Package backup creates, stores and reads namespace backup archives.
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/configtree"
+)
+
+// Archive is the content of a namespace backup: a zip file laid out as
+// <group>/<dataId>, with the manifest at the root
+type Archive struct {
+	Manifest configtree.Manifest
+	Contents map[string]string // group/dataId -> content
+}
+
+// Write encodes the archive as a zip file
+func (a *Archive) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	manifest, err := json.MarshalIndent(a.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	modified := a.Manifest.PulledAt
+	if err := writeFile(zw, configtree.ManifestFileName, manifest, modified); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(a.Contents))
+	for key := range a.Contents {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := writeFile(zw, key, []byte(a.Contents[key]), modified); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	return nil
+}
+
+// Load reads and decodes an archive from a stream. The zip directory is at the
+// end of the file, so the archive is read completely first.
+func Load(r io.Reader) (*Archive, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	return Read(data)
+}
+
+// Read decodes an archive written by Write
+func Read(data []byte) (*Archive, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	a := &Archive{Contents: make(map[string]string)}
+	hasManifest := false
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		content, err := readFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if f.Name == configtree.ManifestFileName {
+			if err := json.Unmarshal(content, &a.Manifest); err != nil {
+				return nil, fmt.Errorf("parse archive manifest: %w", err)
+			}
+			hasManifest = true
+			continue
+		}
+		group, dataID := configtree.SplitKey(path.Clean(f.Name))
+		if !configtree.ValidName(group) || !configtree.ValidName(dataID) || strings.Contains(dataID, "/") {
+			return nil, fmt.Errorf("read archive: unexpected entry %s", f.Name)
+		}
+		a.Contents[configtree.Key(group, dataID)] = string(content)
+	}
+	if !hasManifest {
+		return nil, fmt.Errorf("read archive: %s is missing", configtree.ManifestFileName)
+	}
+	return a, nil
+}
+
+func writeFile(zw *zip.Writer, name string, data []byte, modified time.Time) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	return nil
+}
+
+func readFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("read archive entry %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read archive entry %s: %w", f.Name, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mapping renames namespaces, groups and data IDs between environments whose
+// naming differs. It is read from a YAML file:
+//
+//	namespaces:
+//	  dev: dev-us
+//	groups:
+//	  GROUP_A: GROUP_B
+//	dataIds:
+//	  - 'prod-(.*).yaml => dr-$1.yaml'
+//
+// Names without an entry are kept. A nil Mapping keeps every name.
+type Mapping struct {
+	Namespaces map[string]string `yaml:"namespaces"`
+	Groups     map[string]string `yaml:"groups"`
+	DataIDs    []string          `yaml:"dataIds"` // rewrite rules, the first matching one applies
+
+	dataIDRules []RewriteRule
+}
+
+// LoadMapping reads a mapping file
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	var m Mapping
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", path, err)
+	}
+	if m.dataIDRules, err = ParseRewriteRules(m.DataIDs); err != nil {
+		return nil, fmt.Errorf("mapping file %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Namespace returns the mapped namespace ID
+func (m *Mapping) Namespace(namespaceID string) string {
+	if m == nil {
+		return namespaceID
+	}
+	if mapped, ok := m.Namespaces[namespaceID]; ok {
+		return mapped
+	}
+	return namespaceID
+}
+
+// Group returns the mapped group
+func (m *Mapping) Group(group string) string {
+	if m == nil {
+		return group
+	}
+	if mapped, ok := m.Groups[group]; ok {
+		return mapped
+	}
+	return group
+}
+
+// DataID returns the data ID rewritten by the first matching rule
+func (m *Mapping) DataID(dataID string) string {
+	if m == nil {
+		return dataID
+	}
+	return Rewrite(m.dataIDRules, dataID)
+}
+
+// Reverse returns the group and data ID that map to group and dataID. It
+// fails when the data IDs are rewritten, as rewrite rules cannot be reversed,
+// or when no single group maps to group.
+func (m *Mapping) Reverse(group, dataID string) (string, string, bool) {
+	if m == nil {
+		return group, dataID, true
+	}
+	if len(m.dataIDRules) > 0 {
+		return "", "", false
+	}
+	source := ""
+	for from, to := range m.Groups {
+		if to != group {
+			continue
+		}
+		if source != "" {
+			return "", "", false
+		}
+		source = from
+	}
+	if source == "" {
+		if _, mapped := m.Groups[group]; mapped {
+			// The group itself maps elsewhere: nothing maps to it
+			return "", "", false
+		}
+		source = group
+	}
+	return source, dataID, true
+}
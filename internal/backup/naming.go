@@ -0,0 +1,81 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NamingBundle is the naming registry of a namespace exported to YAML: its
+// services with their clusters and persistent instances. Ephemeral instances
+// are not exported, their applications register them again.
+type NamingBundle struct {
+	Server    string         `yaml:"server"`
+	Namespace string         `yaml:"namespace"`
+	CreatedAt time.Time      `yaml:"createdAt"`
+	Services  []ServiceEntry `yaml:"services"`
+}
+
+// ServiceEntry is an exported service
+type ServiceEntry struct {
+	Name             string            `yaml:"name"`
+	Group            string            `yaml:"group"`
+	ProtectThreshold float64           `yaml:"protectThreshold"`
+	Ephemeral        bool              `yaml:"ephemeral"`
+	Metadata         map[string]string `yaml:"metadata,omitempty"`
+	Clusters         []ClusterEntry    `yaml:"clusters,omitempty"`
+	Instances        []InstanceEntry   `yaml:"instances,omitempty"`
+}
+
+// ClusterEntry is the health check configuration of an exported cluster
+type ClusterEntry struct {
+	Name            string                 `yaml:"name"`
+	HealthChecker   map[string]interface{} `yaml:"healthChecker,omitempty"`
+	CheckPort       int                    `yaml:"checkPort"`
+	UseInstancePort bool                   `yaml:"useInstancePort"`
+	Metadata        map[string]string      `yaml:"metadata,omitempty"`
+}
+
+// InstanceEntry is an exported persistent instance
+type InstanceEntry struct {
+	IP       string            `yaml:"ip"`
+	Port     int               `yaml:"port"`
+	Cluster  string            `yaml:"cluster"`
+	Weight   float64           `yaml:"weight"`
+	Enabled  bool              `yaml:"enabled"`
+	Metadata map[string]string `yaml:"metadata,omitempty"`
+}
+
+// NamingBundleName names the naming export of a namespace taken at t
+func NamingBundleName(namespace string, t time.Time) string {
+	return namespace + "-services-" + t.UTC().Format("20060102T150405Z") + ".yaml"
+}
+
+// Write writes the bundle as YAML
+func (b *NamingBundle) Write(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(b); err != nil {
+		return fmt.Errorf("failed to write naming bundle: %w", err)
+	}
+	return enc.Close()
+}
+
+// LoadNamingBundle reads a bundle written by Write
+func LoadNamingBundle(r io.Reader) (*NamingBundle, error) {
+	var b NamingBundle
+	if err := yaml.NewDecoder(r).Decode(&b); err != nil {
+		return nil, fmt.Errorf("failed to parse naming bundle: %w", err)
+	}
+	for i, svc := range b.Services {
+		if svc.Name == "" {
+			return nil, fmt.Errorf("naming bundle: service %d has no name", i+1)
+		}
+		if svc.Group == "" {
+			b.Services[i].Group = "DEFAULT_GROUP"
+		}
+	}
+	return &b, nil
+}
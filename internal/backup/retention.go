@@ -0,0 +1,43 @@
+package backup
+
+import (
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/storage"
+)
+
+// ArchiveName names the backup of a namespace taken at t; names sort by time
+func ArchiveName(namespace string, t time.Time) string {
+	return namespace + "-" + t.UTC().Format("20060102T150405Z") + ".zip"
+}
+
+// Prune deletes the oldest backups of a namespace beyond the newest keep ones
+// and returns the deleted names; keep <= 0 keeps everything
+func Prune(store storage.Store, namespace string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	names, err := store.List(namespace + "-")
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, name := range names {
+		// Skip other namespaces sharing the prefix, e.g. dev-eu for dev
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, namespace+"-"), ".zip")
+		if _, err := time.Parse("20060102T150405Z", stamp); err == nil {
+			backups = append(backups, name)
+		}
+	}
+	if len(backups) <= keep {
+		return nil, nil
+	}
+	old := backups[:len(backups)-keep]
+	for _, name := range old {
+		if err := store.Delete(name); err != nil {
+			return nil, err
+		}
+	}
+	return old, nil
+}
@@ -0,0 +1,51 @@
+package backup
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RewriteRule renames data IDs or groups matching a regular expression, e.g.
+// "prod-(.*).yaml => dr-$1.yaml". The pattern must match the whole name.
+type RewriteRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// ParseRewriteRule parses "<pattern> => <replacement>"; the replacement may
+// refer to groups as $1 or ${1}
+func ParseRewriteRule(s string) (RewriteRule, error) {
+	pattern, replacement, ok := strings.Cut(s, "=>")
+	if !ok {
+		return RewriteRule{}, fmt.Errorf("invalid rewrite rule %q: expected '<pattern> => <replacement>'", s)
+	}
+	re, err := regexp.Compile("^(?:" + strings.TrimSpace(pattern) + ")$")
+	if err != nil {
+		return RewriteRule{}, fmt.Errorf("invalid rewrite rule %q: %w", s, err)
+	}
+	return RewriteRule{pattern: re, replacement: strings.TrimSpace(replacement)}, nil
+}
+
+// ParseRewriteRules parses a list of rules
+func ParseRewriteRules(rules []string) ([]RewriteRule, error) {
+	parsed := make([]RewriteRule, 0, len(rules))
+	for _, s := range rules {
+		rule, err := ParseRewriteRule(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, rule)
+	}
+	return parsed, nil
+}
+
+// Rewrite applies the first rule matching name; names no rule matches are kept
+func Rewrite(rules []RewriteRule, name string) string {
+	for _, rule := range rules {
+		if m := rule.pattern.FindStringSubmatchIndex(name); m != nil {
+			return string(rule.pattern.ExpandString(nil, rule.replacement, name, m))
+		}
+	}
+	return name
+}
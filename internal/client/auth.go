@@ -0,0 +1,131 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Permission actions
+const (
+	ActionRead      = "r"
+	ActionWrite     = "w"
+	ActionReadWrite = "rw"
+)
+
+// User is a Nacos user
+type User struct {
+	Username string `json:"username"`
+}
+
+// RoleBinding binds a role to a user
+type RoleBinding struct {
+	Role     string `json:"role"`
+	Username string `json:"username"`
+}
+
+// Permission grants a role an action on a resource
+type Permission struct {
+	Role     string `json:"role"`
+	Resource string `json:"resource"` // namespaceId:group:resource, e.g. team-a:*:*
+	Action   string `json:"action"`   // r, w or rw
+}
+
+// NamespaceResource returns the permission resource covering a whole namespace
+func NamespaceResource(namespaceID string) string {
+	return namespaceID + ":*:*"
+}
+
+// listAuthPages fetches every page of a v3 auth list API
+func listAuthPages[T any](c *NacosClient, op, apiPath string, params url.Values) ([]T, error) {
+	const pageSize = 100
+	var all []T
+	for pageNo := 1; ; pageNo++ {
+		params.Set("pageNo", fmt.Sprintf("%d", pageNo))
+		params.Set("pageSize", fmt.Sprintf("%d", pageSize))
+		data, err := c.doV3(op, "GET", apiPath, params, "")
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			TotalCount int `json:"totalCount"`
+			PageItems  []T `json:"pageItems"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("%s failed: invalid data format: %w", op, err)
+		}
+		all = append(all, page.PageItems...)
+		if len(page.PageItems) < pageSize || len(all) >= page.TotalCount {
+			return all, nil
+		}
+	}
+}
+
+// GetUser returns a user, or nil when it does not exist
+func (c *NacosClient) GetUser(username string) (*User, error) {
+	params := url.Values{}
+	params.Set("username", username)
+	params.Set("search", "accurate")
+	users, err := listAuthPages[User](c, "list users", "/nacos/v3/auth/user/list", params)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if users[i].Username == username {
+			return &users[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ListUsers lists all users
+func (c *NacosClient) ListUsers() ([]User, error) {
+	params := url.Values{}
+	params.Set("search", "blur")
+	return listAuthPages[User](c, "list users", "/nacos/v3/auth/user/list", params)
+}
+
+// CreateUser creates a user
+func (c *NacosClient) CreateUser(username, password string) error {
+	params := url.Values{}
+	params.Set("username", username)
+	params.Set("password", password)
+	_, err := c.doV3("create user", "POST", "/nacos/v3/auth/user", params, "")
+	return err
+}
+
+// ListRoles lists the role bindings, filtered by role and user when not empty
+func (c *NacosClient) ListRoles(role, username string) ([]RoleBinding, error) {
+	params := url.Values{}
+	params.Set("role", role)
+	params.Set("username", username)
+	params.Set("search", "accurate")
+	return listAuthPages[RoleBinding](c, "list roles", "/nacos/v3/auth/role/list", params)
+}
+
+// CreateRole binds a role to a user, creating the role when it is new
+func (c *NacosClient) CreateRole(role, username string) error {
+	params := url.Values{}
+	params.Set("role", role)
+	params.Set("username", username)
+	_, err := c.doV3("create role", "POST", "/nacos/v3/auth/role", params, "")
+	return err
+}
+
+// ListPermissions lists the permissions of a role
+func (c *NacosClient) ListPermissions(role string) ([]Permission, error) {
+	params := url.Values{}
+	params.Set("role", role)
+	params.Set("search", "accurate")
+	return listAuthPages[Permission](c, "list permissions", "/nacos/v3/auth/permission/list", params)
+}
+
+// CreatePermission grants a role an action on a resource
+func (c *NacosClient) CreatePermission(role, resource, action string) error {
+	params := url.Values{}
+	params.Set("role", role)
+	params.Set("resource", resource)
+	params.Set("action", action)
+	_, err := c.doV3("create permission", "POST", "/nacos/v3/auth/permission", params, "")
+	return err
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ConfigCache keeps configuration contents with their MD5. GetConfig asks the
+// server whether a cached MD5 is still current before downloading again.
+type ConfigCache interface {
+	Get(key string) (content, md5 string, ok bool)
+	Put(key, content, md5 string)
+	Delete(key string)
+}
+
+// MemoryCache is a ConfigCache for the lifetime of the process, such as a terminal session
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	MD5     string `json:"md5"`
+	Content string `json:"content"`
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]cacheEntry)}
+}
+
+// Get returns the cached content
+func (m *MemoryCache) Get(key string) (string, string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	return e.Content, e.MD5, ok
+}
+
+// Put stores the content
+func (m *MemoryCache) Put(key, content, md5 string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = cacheEntry{MD5: md5, Content: content}
+}
+
+// Delete forgets the content
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
+
+// DirCache is a ConfigCache in a directory, shared by successive runs such as
+// the steps of a script. Files are readable by the owner only, as
+// configurations often hold credentials.
+type DirCache struct {
+	Dir string
+}
+
+func (d *DirCache) path(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the cached content; unreadable entries are misses
+func (d *DirCache) Get(key string) (string, string, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return "", "", false
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(data, &e); err != nil || e.MD5 != contentMD5(e.Content) {
+		return "", "", false
+	}
+	return e.Content, e.MD5, true
+}
+
+// Put stores the content; failures only cost a download next time
+func (d *DirCache) Put(key, content, md5 string) {
+	data, err := json.Marshal(cacheEntry{MD5: md5, Content: content})
+	if err != nil || os.MkdirAll(d.Dir, 0700) != nil {
+		return
+	}
+	path := d.path(key)
+	if os.WriteFile(path+".tmp", data, 0600) == nil {
+		os.Rename(path+".tmp", path)
+	}
+}
+
+// Delete forgets the content
+func (d *DirCache) Delete(key string) {
+	os.Remove(d.path(key))
+}
+
+// cacheKey identifies a configuration across servers and namespaces
+func (c *NacosClient) cacheKey(dataID, group string) string {
+	return strings.Join([]string{c.ServerAddr, c.Namespace, group, dataID}, "\x00")
+}
+
+// getConfigCached returns the cached content when the server reports the
+// cached MD5 as current. Nacos has no conditional GET, so the check is a
+// listener request that returns at once and carries no content.
+func (c *NacosClient) getConfigCached(dataID, group string) (string, error) {
+	key := c.cacheKey(dataID, group)
+	if content, md5, ok := c.Cache.Get(key); ok {
+		if changed, err := c.configChanged(dataID, group, md5); err == nil && !changed {
+			return content, nil
+		}
+	}
+	content, md5, err := c.getConfig(dataID, group)
+	if err != nil {
+		c.Cache.Delete(key)
+		return "", err
+	}
+	c.Cache.Put(key, content, md5)
+	return content, nil
+}
+
+// configChanged asks the config listener whether the content no longer has the MD5
+func (c *NacosClient) configChanged(dataID, group, md5 string) (bool, error) {
+	listening := dataID + "\x02" + group + "\x02" + md5
+	if c.Namespace != "" {
+		listening += "\x02" + c.Namespace
+	}
+	listening += "\x01"
+
+	apiURL := fmt.Sprintf("%s/nacos/v1/cs/configs/listener", c.baseURL())
+	req := c.httpClient.R().
+		SetFormData(map[string]string{"Listening-Configs": listening}).
+		SetHeader("Long-Pulling-Timeout", "30000").
+		SetHeader("Long-Pulling-Timeout-No-Hangup", "true")
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		req.SetQueryParam("accessToken", c.AccessToken())
+	}
+	c.sign(req, c.Namespace, group)
+	resp, err := req.Post(apiURL)
+	if err != nil {
+		return false, fmt.Errorf("check config failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return false, fmt.Errorf("check config failed: status=%d", resp.StatusCode())
+	}
+	body, err := url.QueryUnescape(strings.TrimSpace(string(resp.Body())))
+	if err != nil {
+		return false, fmt.Errorf("check config failed: %w", err)
+	}
+	return body != "", nil
+}
+
+func contentMD5(content string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Capacity limits the configurations of a namespace, or of a group in it
+type Capacity struct {
+	Quota        int `json:"quota"`        // maximum number of configurations
+	Usage        int `json:"usage"`        // current number of configurations
+	MaxSize      int `json:"maxSize"`      // maximum size of a configuration in bytes
+	MaxAggrCount int `json:"maxAggrCount"` // maximum number of aggregated sub-configurations
+	MaxAggrSize  int `json:"maxAggrSize"`  // maximum size of a sub-configuration in bytes
+}
+
+// GetCapacity returns the capacity of a namespace, or of one of its groups
+// when group is set; nil when none was ever set
+func (c *NacosClient) GetCapacity(namespaceID, group string) (*Capacity, error) {
+	params := url.Values{}
+	params.Set("namespaceId", namespaceID)
+	params.Set("groupName", group)
+	data, err := c.doV3("get capacity", "GET", "/nacos/v3/admin/cs/capacity", params, group)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var capacity Capacity
+	if err := json.Unmarshal(data, &capacity); err != nil {
+		return nil, fmt.Errorf("get capacity failed: invalid data format: %w", err)
+	}
+	return &capacity, nil
+}
+
+// UpdateCapacity sets the limits of a namespace, or of one of its groups when
+// group is set; zero limits are left unchanged
+func (c *NacosClient) UpdateCapacity(namespaceID, group string, capacity Capacity) error {
+	params := url.Values{}
+	params.Set("namespaceId", namespaceID)
+	params.Set("groupName", group)
+	for name, value := range map[string]int{
+		"quota":        capacity.Quota,
+		"maxSize":      capacity.MaxSize,
+		"maxAggrCount": capacity.MaxAggrCount,
+		"maxAggrSize":  capacity.MaxAggrSize,
+	} {
+		if value > 0 {
+			params.Set(name, strconv.Itoa(value))
+		}
+	}
+	_, err := c.doV3("update capacity", "POST", "/nacos/v3/admin/cs/capacity", params, group)
+	return err
+}
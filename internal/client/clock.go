@@ -0,0 +1,89 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// clockSkewWarning is the difference with the server clock above which the
+// timestamps of Aliyun signatures are likely to be rejected
+const clockSkewWarning = time.Minute
+
+// serverClock estimates the offset of the server clock from the Date headers
+// of responses. It is shared by the copies of a client.
+type serverClock struct {
+	mu         sync.Mutex
+	offset     time.Duration // server time minus local time
+	measured   bool
+	compensate bool // sign with the server time instead of the local time
+	warned     bool
+}
+
+// WithClockSkewCompensation signs Aliyun requests with the local time
+// corrected by the offset of the server clock, measured from the Date header
+// of its responses, so a skewed local clock does not invalidate signatures
+func WithClockSkewCompensation() Option {
+	return func(o *clientOptions) {
+		o.compensateClockSkew = true
+	}
+}
+
+// ClockSkew returns the offset of the server clock from the local clock, and
+// false while no response with a Date header has been seen
+func (c *NacosClient) ClockSkew() (time.Duration, bool) {
+	c.clock.mu.Lock()
+	defer c.clock.mu.Unlock()
+	return c.clock.offset, c.clock.measured
+}
+
+// signingTime returns the time to put in signatures
+func (c *NacosClient) signingTime() time.Time {
+	c.clock.mu.Lock()
+	defer c.clock.mu.Unlock()
+	if c.clock.compensate {
+		return time.Now().Add(c.clock.offset)
+	}
+	return time.Now()
+}
+
+// observeServerTime is a response middleware updating the clock offset and
+// warning once when it is large enough to break signatures
+func (c *NacosClient) observeServerTime(_ *resty.Client, resp *resty.Response) error {
+	date, err := http.ParseTime(resp.Header().Get("Date"))
+	if err != nil {
+		return nil
+	}
+	// Date is truncated to the second and the server stamped it somewhere
+	// between sending the request and receiving the response
+	local := resp.ReceivedAt().Add(-resp.Time() / 2)
+	offset := date.Add(500 * time.Millisecond).Sub(local)
+
+	c.clock.mu.Lock()
+	c.clock.offset, c.clock.measured = offset, true
+	warn := !c.clock.warned && !c.clock.compensate && (offset > clockSkewWarning || offset < -clockSkewWarning)
+	if warn {
+		c.clock.warned = true
+	}
+	c.clock.mu.Unlock()
+
+	if warn {
+		direction := "behind"
+		if offset < 0 {
+			direction, offset = "ahead of", -offset
+		}
+		c.logger.Printf("Warning: the local clock is %v %s the server clock. Aliyun signatures carry the local time "+
+			"and may be rejected; correct the clock or use --compensate-clock-skew\n", offset.Round(time.Second), direction)
+	}
+	return nil
+}
+
+// measureClock requests the server root to learn the clock offset before the
+// first signed request
+func (c *NacosClient) measureClock() {
+	if _, err := c.httpClient.R().Get(c.baseURL() + "/nacos/"); err != nil {
+		c.logger.Printf("Warning: could not read the server clock: %v\n", err)
+	}
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ConfigHistory is one revision in the history of a configuration
+type ConfigHistory struct {
+	ID          int64  `json:"id"`
+	DataID      string `json:"dataId"`
+	GroupName   string `json:"groupName"`
+	NamespaceID string `json:"namespaceId"`
+	Content     string `json:"content,omitempty"` // only filled by GetConfigHistory
+	Md5         string `json:"md5"`
+	AppName     string `json:"appName,omitempty"`
+	OpType      string `json:"opType"` // I (insert), U (update) or D (delete)
+	PublishType string `json:"publishType,omitempty"`
+	Desc        string `json:"desc,omitempty"`    // description published with the revision
+	ExtInfo     string `json:"extInfo,omitempty"` // JSON with the advanced attributes, c_desc among them
+	SrcUser     string `json:"srcUser"`
+	SrcIP       string `json:"srcIp"`
+	CreateTime  int64  `json:"createTime"` // milliseconds since epoch
+	ModifyTime  int64  `json:"modifyTime"`
+}
+
+// ConfigHistoryPage is a page of configuration history, newest first
+type ConfigHistoryPage struct {
+	TotalCount     int             `json:"totalCount"`
+	PageNumber     int             `json:"pageNumber"`
+	PagesAvailable int             `json:"pagesAvailable"`
+	PageItems      []ConfigHistory `json:"pageItems"`
+}
+
+// configHistoryV1 is a revision as the v1 history API returns it
+type configHistoryV1 struct {
+	ID               int64  `json:"id"`
+	DataID           string `json:"dataId"`
+	Group            string `json:"group"`
+	Tenant           string `json:"tenant"`
+	Content          string `json:"content"`
+	Md5              string `json:"md5"`
+	AppName          string `json:"appName"`
+	OpType           string `json:"opType"`
+	PublishType      string `json:"publishType"`
+	ExtInfo          string `json:"extInfo"`
+	SrcUser          string `json:"srcUser"`
+	SrcIP            string `json:"srcIp"`
+	CreatedTime      int64  `json:"createdTime"`
+	LastModifiedTime int64  `json:"lastModifiedTime"`
+}
+
+func (h configHistoryV1) convert() ConfigHistory {
+	history := ConfigHistory{
+		ID:          h.ID,
+		DataID:      h.DataID,
+		GroupName:   h.Group,
+		NamespaceID: h.Tenant,
+		Content:     h.Content,
+		Md5:         h.Md5,
+		AppName:     h.AppName,
+		OpType:      h.OpType,
+		PublishType: h.PublishType,
+		ExtInfo:     h.ExtInfo,
+		SrcUser:     h.SrcUser,
+		SrcIP:       h.SrcIP,
+		CreateTime:  h.CreatedTime,
+		ModifyTime:  h.LastModifiedTime,
+	}
+	history.normalize()
+	return history
+}
+
+// ListConfigHistory lists the revisions of a configuration, newest first
+func (c *NacosClient) ListConfigHistory(dataID, group string, pageNo, pageSize int) (*ConfigHistoryPage, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	if c.loginVersion() == "v1" {
+		params := url.Values{}
+		params.Set("search", "accurate")
+		params.Set("dataId", dataID)
+		params.Set("group", group)
+		params.Set("tenant", c.Namespace)
+		params.Set("pageNo", fmt.Sprintf("%d", pageNo))
+		params.Set("pageSize", fmt.Sprintf("%d", pageSize))
+		body, err := c.doHistoryV1("list config history", params, group)
+		if err != nil {
+			return nil, err
+		}
+		var v1Page struct {
+			TotalCount     int               `json:"totalCount"`
+			PageNumber     int               `json:"pageNumber"`
+			PagesAvailable int               `json:"pagesAvailable"`
+			PageItems      []configHistoryV1 `json:"pageItems"`
+		}
+		if err := json.Unmarshal(body, &v1Page); err != nil {
+			return nil, fmt.Errorf("list config history failed: invalid response format: %s", string(body))
+		}
+		page := &ConfigHistoryPage{TotalCount: v1Page.TotalCount, PageNumber: v1Page.PageNumber, PagesAvailable: v1Page.PagesAvailable}
+		for _, h := range v1Page.PageItems {
+			page.PageItems = append(page.PageItems, h.convert())
+		}
+		return page, nil
+	}
+
+	params := url.Values{}
+	params.Set("dataId", dataID)
+	params.Set("groupName", group)
+	params.Set("namespaceId", c.Namespace)
+	params.Set("pageNo", fmt.Sprintf("%d", pageNo))
+	params.Set("pageSize", fmt.Sprintf("%d", pageSize))
+
+	data, err := c.doV3("list config history", "GET", "/nacos/v3/admin/cs/history/list", params, group)
+	if err != nil {
+		return nil, err
+	}
+	var page ConfigHistoryPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("list config history failed: invalid data format: %w", err)
+	}
+	for i := range page.PageItems {
+		page.PageItems[i].normalize()
+	}
+	return &page, nil
+}
+
+// ListAllConfigHistory lists every revision of a configuration, newest first
+func (c *NacosClient) ListAllConfigHistory(dataID, group string) ([]ConfigHistory, error) {
+	const pageSize = 100
+	var all []ConfigHistory
+	for pageNo := 1; ; pageNo++ {
+		page, err := c.ListConfigHistory(dataID, group, pageNo, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.PageItems...)
+		if len(page.PageItems) < pageSize || len(all) >= page.TotalCount {
+			return all, nil
+		}
+	}
+}
+
+// GetConfigHistory gets one revision of a configuration including its content
+func (c *NacosClient) GetConfigHistory(dataID, group string, nid int64) (*ConfigHistory, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	if c.loginVersion() == "v1" {
+		params := url.Values{}
+		params.Set("nid", fmt.Sprintf("%d", nid))
+		params.Set("dataId", dataID)
+		params.Set("group", group)
+		params.Set("tenant", c.Namespace)
+		body, err := c.doHistoryV1("get config history", params, group)
+		if err != nil {
+			return nil, err
+		}
+		var h configHistoryV1
+		if err := json.Unmarshal(body, &h); err != nil {
+			return nil, fmt.Errorf("get config history failed: invalid response format: %s", string(body))
+		}
+		history := h.convert()
+		return &history, nil
+	}
+
+	params := url.Values{}
+	params.Set("dataId", dataID)
+	params.Set("groupName", group)
+	params.Set("namespaceId", c.Namespace)
+	params.Set("nid", fmt.Sprintf("%d", nid))
+
+	data, err := c.doV3("get config history", "GET", "/nacos/v3/admin/cs/history", params, group)
+	if err != nil {
+		return nil, err
+	}
+	var history ConfigHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("get config history failed: invalid data format: %w", err)
+	}
+	history.normalize()
+	return &history, nil
+}
+
+// normalize trims the op type, a CHAR column that may be padded, and takes
+// the description from the ext info when the server does not return it itself
+func (h *ConfigHistory) normalize() {
+	h.OpType = strings.TrimSpace(h.OpType)
+	if h.Desc != "" || h.ExtInfo == "" {
+		return
+	}
+	var ext struct {
+		Desc string `json:"c_desc"`
+	}
+	if json.Unmarshal([]byte(h.ExtInfo), &ext) == nil {
+		h.Desc = ext.Desc
+	}
+}
+
+// doHistoryV1 sends a GET to the v1 history API of servers without the v3 admin API
+func (c *NacosClient) doHistoryV1(op string, params url.Values, group string) ([]byte, error) {
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		params.Set("accessToken", c.AccessToken())
+	}
+	req := c.httpClient.R().SetQueryString(params.Encode())
+	c.sign(req, params.Get("tenant"), group)
+	resp, err := req.Get(c.baseURL() + "/nacos/v1/cs/history")
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", op, err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("%s failed: status=%d, body=%s", op, resp.StatusCode(), string(resp.Body()))
+	}
+	return resp.Body(), nil
+}
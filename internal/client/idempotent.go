@@ -0,0 +1,61 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// PublishConfigIdempotent publishes a configuration like PublishConfigWithOptions,
+// retrying up to retries times when the request fails in transit. A publish
+// that times out may still have landed, so before each retry the server
+// content is compared by MD5 (and the tags, when given): a write that landed
+// counts as published instead of being sent again and adding a second
+// history entry.
+func (c *NacosClient) PublishConfigIdempotent(dataID, group, content string, opts PublishOptions, retries int) error {
+	wantTags := opts.Tags
+	if wantTags != nil && c.ticket != "" {
+		wantTags = WithTicketTag(wantTags, c.ticket)
+	}
+	for attempt := 1; ; attempt++ {
+		err := c.PublishConfigWithOptions(dataID, group, content, opts)
+		if err == nil || !isTransient(err) || attempt > retries {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * time.Second)
+		if c.publishLanded(dataID, group, content, wantTags) {
+			c.logger.Printf("Warning: %v; the configuration %s/%s was published anyway\n", err, group, dataID)
+			return nil
+		}
+		c.logger.Printf("Warning: %v; retrying (%d/%d)\n", err, attempt, retries)
+	}
+}
+
+// publishLanded reports whether the server holds content, and tags when not nil
+func (c *NacosClient) publishLanded(dataID, group, content string, tags []string) bool {
+	detail, err := c.GetConfigDetail(dataID, group)
+	if err != nil || contentMD5(detail.Content) != contentMD5(content) {
+		return false
+	}
+	if tags == nil {
+		return true
+	}
+	got := detail.Tags()
+	want := append([]string(nil), tags...)
+	slices.Sort(got)
+	slices.Sort(want)
+	return slices.Equal(got, want)
+}
+
+// isTransient reports whether a request failed in transit, so that it may or
+// may not have reached the server
+func isTransient(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// ConfigSubscriber is a client listening to a configuration, with the MD5 of
+// the content it last received
+type ConfigSubscriber struct {
+	IP  string `json:"ip"`
+	MD5 string `json:"md5"`
+}
+
+// ListConfigSubscribers lists the clients listening to a configuration, sorted by IP
+func (c *NacosClient) ListConfigSubscribers(dataID, group string) ([]ConfigSubscriber, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	if c.loginVersion() == "v1" {
+		return c.listConfigSubscribersV1(dataID, group)
+	}
+	params := url.Values{}
+	params.Set("dataId", dataID)
+	params.Set("groupName", group)
+	params.Set("namespaceId", c.Namespace)
+	data, err := c.doV3("list config listeners", "GET", "/nacos/v3/admin/cs/config/listener", params, group)
+	if err != nil {
+		return nil, err
+	}
+	var info struct {
+		ListenersStatus map[string]string `json:"listenersStatus"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("list config listeners failed: invalid data format: %w", err)
+	}
+	return sortedSubscribers(info.ListenersStatus), nil
+}
+
+// listConfigSubscribersV1 uses the query variant of the v1 listener API
+func (c *NacosClient) listConfigSubscribersV1(dataID, group string) ([]ConfigSubscriber, error) {
+	params := url.Values{}
+	params.Set("dataId", dataID)
+	params.Set("group", group)
+	if c.Namespace != "" && c.Namespace != "public" {
+		params.Set("tenant", c.Namespace)
+	}
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		params.Set("accessToken", c.AccessToken())
+	}
+	req := c.httpClient.R().SetQueryString(params.Encode())
+	c.sign(req, c.Namespace, group)
+	resp, err := req.Get(fmt.Sprintf("%s/nacos/v1/cs/configs/listener", c.baseURL()))
+	if err != nil {
+		return nil, fmt.Errorf("list config listeners failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("list config listeners failed: status=%d, body=%s", resp.StatusCode(), string(resp.Body()))
+	}
+	// The field name is misspelled by the server
+	var info struct {
+		Status map[string]string `json:"lisentersGroupkeyStatus"`
+	}
+	if err := json.Unmarshal(resp.Body(), &info); err != nil {
+		return nil, fmt.Errorf("list config listeners failed: invalid response format: %s", string(resp.Body()))
+	}
+	return sortedSubscribers(info.Status), nil
+}
+
+func sortedSubscribers(status map[string]string) []ConfigSubscriber {
+	subscribers := make([]ConfigSubscriber, 0, len(status))
+	for ip, md5 := range status {
+		subscribers = append(subscribers, ConfigSubscriber{IP: ip, MD5: md5})
+	}
+	sort.Slice(subscribers, func(i, j int) bool { return subscribers[i].IP < subscribers[j].IP })
+	return subscribers
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrMcpServerNotFound is returned when an MCP server does not exist
+var ErrMcpServerNotFound = errors.New("mcp server not found")
+
+// McpServer is an MCP server definition in the Nacos 3 AI registry
+type McpServer struct {
+	ID                 string            `json:"id,omitempty"`
+	Name               string            `json:"name"`
+	Protocol           string            `json:"protocol"` // stdio, mcp-sse, mcp-streamable, ...
+	FrontProtocol      string            `json:"frontProtocol,omitempty"`
+	Description        string            `json:"description,omitempty"`
+	Repository         json.RawMessage   `json:"repository,omitempty"`
+	VersionDetail      *McpVersionDetail `json:"versionDetail,omitempty"`
+	Version            string            `json:"version,omitempty"`
+	Enabled            bool              `json:"enabled"`
+	RemoteServerConfig json.RawMessage   `json:"remoteServerConfig,omitempty"`
+	LocalServerConfig  json.RawMessage   `json:"localServerConfig,omitempty"`
+	Capabilities       []string          `json:"capabilities,omitempty"`
+}
+
+// McpVersionDetail describes one published version of an MCP server
+type McpVersionDetail struct {
+	Version     string `json:"version"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	IsLatest    bool   `json:"is_latest,omitempty"`
+}
+
+// McpServerDetail is an MCP server with its tools and versions
+type McpServerDetail struct {
+	McpServer
+	NamespaceID      string             `json:"namespaceId,omitempty"`
+	ToolSpec         json.RawMessage    `json:"toolSpec,omitempty"`
+	AllVersions      []McpVersionDetail `json:"allVersions,omitempty"`
+	BackendEndpoints json.RawMessage    `json:"backendEndpoints,omitempty"`
+}
+
+// LatestVersion returns the version of the server marked latest
+func (s *McpServer) LatestVersion() string {
+	if s.VersionDetail != nil && s.VersionDetail.Version != "" {
+		return s.VersionDetail.Version
+	}
+	return s.Version
+}
+
+// McpServerPage is a page of MCP servers
+type McpServerPage struct {
+	TotalCount     int         `json:"totalCount"`
+	PageNumber     int         `json:"pageNumber"`
+	PagesAvailable int         `json:"pagesAvailable"`
+	PageItems      []McpServer `json:"pageItems"`
+}
+
+// McpPublishRequest holds the JSON specifications of an MCP server to publish
+type McpPublishRequest struct {
+	Server   json.RawMessage // McpServer fields, including versionDetail.version
+	Tools    json.RawMessage // optional tool specification
+	Endpoint json.RawMessage // optional endpoint specification for remote servers
+	Latest   bool            // mark the published version as latest (updates only)
+}
+
+// ListMcpServers lists the MCP servers of the namespace. name matches as a
+// substring unless exact is set.
+func (c *NacosClient) ListMcpServers(name string, exact bool, pageNo, pageSize int) (*McpServerPage, error) {
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("mcpName", name)
+	params.Set("search", "blur")
+	if exact {
+		params.Set("search", "accurate")
+	}
+	params.Set("pageNo", fmt.Sprintf("%d", pageNo))
+	params.Set("pageSize", fmt.Sprintf("%d", pageSize))
+
+	data, err := c.doV3("list mcp servers", "GET", "/nacos/v3/admin/ai/mcp/list", params, "")
+	if err != nil {
+		return nil, err
+	}
+	var page McpServerPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("list mcp servers failed: invalid data format: %w", err)
+	}
+	return &page, nil
+}
+
+// GetMcpServer gets an MCP server; an empty version gets the latest one
+func (c *NacosClient) GetMcpServer(name, version string) (*McpServerDetail, error) {
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("mcpName", name)
+	if version != "" {
+		params.Set("version", version)
+	}
+
+	data, err := c.doV3("get mcp server", "GET", "/nacos/v3/admin/ai/mcp", params, "")
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code == 20004 {
+			return nil, fmt.Errorf("%w: %s", ErrMcpServerNotFound, name)
+		}
+		return nil, err
+	}
+	if len(data) == 0 || string(data) == "null" {
+		return nil, fmt.Errorf("%w: %s", ErrMcpServerNotFound, name)
+	}
+	var detail McpServerDetail
+	if err := json.Unmarshal(data, &detail); err != nil {
+		return nil, fmt.Errorf("get mcp server failed: invalid data format: %w", err)
+	}
+	return &detail, nil
+}
+
+// CreateMcpServer registers a new MCP server
+func (c *NacosClient) CreateMcpServer(req McpPublishRequest) error {
+	_, err := c.doV3("create mcp server", "POST", "/nacos/v3/admin/ai/mcp", c.mcpParams(req), "")
+	return err
+}
+
+// UpdateMcpServer publishes a new version of (or updates) an existing MCP server
+func (c *NacosClient) UpdateMcpServer(req McpPublishRequest) error {
+	params := c.mcpParams(req)
+	params.Set("latest", fmt.Sprintf("%t", req.Latest))
+	_, err := c.doV3("update mcp server", "PUT", "/nacos/v3/admin/ai/mcp", params, "")
+	return err
+}
+
+// DeleteMcpServer deletes an MCP server; an empty version deletes all versions
+func (c *NacosClient) DeleteMcpServer(name, version string) error {
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("mcpName", name)
+	if version != "" {
+		params.Set("version", version)
+	}
+	_, err := c.doV3("delete mcp server", "DELETE", "/nacos/v3/admin/ai/mcp", params, "")
+	return err
+}
+
+func (c *NacosClient) mcpParams(req McpPublishRequest) url.Values {
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("serverSpecification", string(req.Server))
+	if len(req.Tools) > 0 {
+		params.Set("toolSpecification", string(req.Tools))
+	}
+	if len(req.Endpoint) > 0 {
+		params.Set("endpointSpecification", string(req.Endpoint))
+	}
+	return params
+}
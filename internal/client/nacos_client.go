@@ -1,14 +1,12 @@
 package client
 
 import (
-	"crypto/hmac"
-	"crypto/sha1"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -19,28 +17,56 @@ const (
 	AuthTypeAliyun = "aliyun" // AccessKey/SecretKey authentication
 )
 
-// NacosClient represents a Nacos API client
+// ErrConfigNotFound is returned (wrapped) when a configuration does not exist
+var ErrConfigNotFound = errors.New("config not found")
+
+// NacosClient represents a Nacos API client. It is safe for concurrent use by
+// multiple goroutines; the exported fields must not be changed once requests
+// are being made. Copies made with WithNamespace share the login session.
 type NacosClient struct {
-	ServerAddr       string
-	Namespace        string
-	AuthType         string
-	Username         string
-	Password         string
-	AccessKey        string
-	SecretKey        string
-	AccessToken      string
-	TokenExpireAt    time.Time
-	authLoginVersion string // "v3" or "v1", determined by first successful login
-	httpClient       *resty.Client
+	ServerAddr    string
+	Namespace     string
+	AuthType      string
+	Username      string
+	Password      string
+	AccessKey     string
+	SecretKey     string
+	TokenProvider TokenProvider // optional, replaces username/password login when set
+	Cache         ConfigCache   // optional, avoids downloading unchanged configurations again
+	scheme        string        // http, or https with WithTLS
+	logger        Logger
+	session       *session
+	clock         *serverClock
+	signer        Signer      // signs requests with aliyun auth
+	ticket        string      // tagged on published configs
+	message       string      // description of published configs
+	publishHook   PublishHook // accepts or blocks publishes
+	stats         *nodeStats
+	httpClient    *resty.Client
+}
+
+// session is the login state of a client. mu is held for the whole of a
+// refresh, so concurrent requests with an expired token wait for one login
+// instead of each logging in.
+type session struct {
+	mu           sync.Mutex
+	accessToken  string
+	expireAt     time.Time
+	loginVersion string // "v3" or "v1", determined by first successful login
 }
 
 // Config represents a Nacos configuration
 type Config struct {
-	DataID    string `json:"dataId"`
-	Group     string `json:"group"`
-	GroupName string `json:"groupName"`
-	Content   string `json:"content"`
-	Type      string `json:"type"`
+	DataID     string `json:"dataId"`
+	Group      string `json:"group"`
+	GroupName  string `json:"groupName"`
+	Content    string `json:"content"`
+	Type       string `json:"type"`
+	Md5        string `json:"md5"`
+	AppName    string `json:"appName"`
+	Tags       string `json:"configTags"` // comma separated; only filled by detail queries
+	CreateTime int64  `json:"createTime"` // milliseconds since epoch
+	ModifyTime int64  `json:"modifyTime"` // milliseconds since epoch
 }
 
 // ConfigListResponse represents the response of list configs API
@@ -58,38 +84,127 @@ type V3Response struct {
 	Data    json.RawMessage `json:"data"`
 }
 
-// NewNacosClient creates a new Nacos client with automatic authentication
-func NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey string) *NacosClient {
-	if namespace == "" {
-		namespace = "public"
+// NewClient creates a Nacos client for the server and logs in. Without
+// WithAuth it logs in as nacos/nacos, the default account of the server.
+func NewClient(serverAddr string, opts ...Option) *NacosClient {
+	o := clientOptions{}
+	for _, opt := range opts {
+		opt(&o)
 	}
-	if authType == "" {
-		if accessKey != "" && secretKey != "" {
-			authType = AuthTypeAliyun
+	if o.namespace == "" {
+		o.namespace = "public"
+	}
+	if o.authType == "" {
+		if o.accessKey != "" && o.secretKey != "" && o.tokenProvider == nil {
+			o.authType = AuthTypeAliyun
 		} else {
-			authType = AuthTypeNacos
+			o.authType = AuthTypeNacos
 		}
 	}
+	if o.username == "" && o.password == "" {
+		o.username, o.password = "nacos", "nacos"
+	}
+	if o.logger == nil {
+		o.logger = stdoutLogger{}
+	}
+	if o.signer == nil && o.accessKey != "" && o.secretKey != "" {
+		o.signer = &SpasSigner{AccessKey: o.accessKey, SecretKey: o.secretKey}
+	}
+	scheme := "http"
+	if o.tlsConfig != nil {
+		scheme = "https"
+	}
 
 	c := &NacosClient{
-		ServerAddr: serverAddr,
-		Namespace:  namespace,
-		AuthType:   authType,
-		Username:   username,
-		Password:   password,
-		AccessKey:  accessKey,
-		SecretKey:  secretKey,
-		httpClient: resty.New(),
+		ServerAddr:    serverAddr,
+		Namespace:     o.namespace,
+		AuthType:      o.authType,
+		Username:      o.username,
+		Password:      o.password,
+		AccessKey:     o.accessKey,
+		SecretKey:     o.secretKey,
+		TokenProvider: o.tokenProvider,
+		scheme:        scheme,
+		logger:        o.logger,
+		session:       &session{},
+		clock:         &serverClock{compensate: o.compensateClockSkew},
+		signer:        o.signer,
+		ticket:        o.ticket,
+		message:       o.message,
+		publishHook:   o.publishHook,
+		stats:         newNodeStats(),
+		httpClient:    o.newHTTPClient(),
+	}
+	c.trackNodeStats()
+
+	// Only signatures depend on the local clock
+	if c.AuthType == AuthTypeAliyun && c.signer != nil {
+		c.httpClient.SetPreRequestHook(c.signRequest)
+		c.httpClient.OnAfterResponse(c.observeServerTime)
+		if c.clock.compensate {
+			c.measureClock()
+		}
 	}
 
 	if c.AuthType == AuthTypeNacos {
-		if err := c.login(); err != nil {
-			fmt.Printf("Warning: Login failed: %v\n", err)
+		c.session.mu.Lock()
+		err := c.refreshToken()
+		c.session.mu.Unlock()
+		if err != nil && c.TokenProvider != nil {
+			c.logger.Printf("Warning: Token acquisition failed: %v\n", err)
+		} else if err != nil {
+			c.logger.Printf("Warning: Login failed: %v\n", err)
 		}
 	}
 	return c
 }
 
+// NewNacosClient creates a new Nacos client with automatic authentication.
+// It is kept for existing callers; new code uses NewClient.
+func NewNacosClient(serverAddr, namespace, authType, username, password, accessKey, secretKey string, opts ...Option) *NacosClient {
+	base := []Option{
+		InNamespace(namespace),
+		WithAuthType(authType),
+		WithAuth(username, password),
+		WithAccessKey(accessKey, secretKey),
+	}
+	return NewClient(serverAddr, append(base, opts...)...)
+}
+
+// NewNacosClientWithTokenProvider creates a new Nacos client that obtains access tokens
+// from the given provider instead of logging in with username/password.
+// It is kept for existing callers; new code uses NewClient with WithTokenProvider.
+func NewNacosClientWithTokenProvider(serverAddr, namespace string, provider TokenProvider, opts ...Option) *NacosClient {
+	base := []Option{InNamespace(namespace), WithTokenProvider(provider)}
+	return NewClient(serverAddr, append(base, opts...)...)
+}
+
+// baseURL returns the scheme and address of the server
+func (c *NacosClient) baseURL() string {
+	return c.scheme + "://" + c.ServerAddr
+}
+
+// AccessToken returns the current access token, empty before the first login
+func (c *NacosClient) AccessToken() string {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+	return c.session.accessToken
+}
+
+// TokenExpireAt returns when the current access token expires, zero if unknown
+func (c *NacosClient) TokenExpireAt() time.Time {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+	return c.session.expireAt
+}
+
+// loginVersion returns the login API version ("v3" or "v1") the server accepted
+func (c *NacosClient) loginVersion() string {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+	return c.session.loginVersion
+}
+
 // isLocalAddr checks if the server address is localhost
 func (c *NacosClient) isLocalAddr() bool {
 	addr := strings.ToLower(c.ServerAddr)
@@ -100,43 +215,44 @@ func (c *NacosClient) isLocalAddr() bool {
 
 // login attempts to authenticate with Nacos server using v3 API first, then falls back to v1.
 // For Nacos 3.x, v3 login succeeds but some legacy v1 APIs (like config list) may return 410 (Gone),
-// so once v3 login succeeds we MUST NOT override authLoginVersion with v1.
+// so once v3 login succeeds we MUST NOT override the login version with v1.
+// The caller holds session.mu.
 func (c *NacosClient) login() error {
 	form := map[string]string{"username": c.Username, "password": c.Password}
 	isLocal := c.isLocalAddr()
 
 	// Prefer v3 login. If we've previously determined v1 only, skip v3.
-	tryV3 := c.authLoginVersion == "" || c.authLoginVersion == "v3"
+	tryV3 := c.session.loginVersion == "" || c.session.loginVersion == "v3"
 	if tryV3 {
-		u := fmt.Sprintf("http://%s/nacos/v3/auth/user/login", c.ServerAddr)
+		u := fmt.Sprintf("%s/nacos/v3/auth/user/login", c.baseURL())
 		resp, err := c.httpClient.R().SetFormData(form).Post(u)
 		if err != nil {
 			if !isLocal {
-				fmt.Printf("v3 login failed: %v\n", err)
+				c.logger.Printf("v3 login failed: %v\n", err)
 			}
 		} else if resp != nil && resp.StatusCode() == 200 && c.applyLoginResponse(resp.Body()) {
-			c.authLoginVersion = "v3"
+			c.session.loginVersion = "v3"
 			return nil
 		} else if !isLocal && resp != nil {
-			fmt.Printf("v3 login failed: status=%d, body=%s\n", resp.StatusCode(), string(resp.Body()))
+			c.logger.Printf("v3 login failed: status=%d, body=%s\n", resp.StatusCode(), string(resp.Body()))
 		}
 	}
 
 	// Fallback to v1 login if v3 is unavailable (e.g., older Nacos versions).
-	u := fmt.Sprintf("http://%s/nacos/v1/auth/login", c.ServerAddr)
+	u := fmt.Sprintf("%s/nacos/v1/auth/login", c.baseURL())
 	resp, err := c.httpClient.R().SetFormData(form).Post(u)
 	if err != nil {
 		if !isLocal {
-			fmt.Printf("v1 login failed: %v\n", err)
+			c.logger.Printf("v1 login failed: %v\n", err)
 		}
 		return err
 	}
 	if resp != nil && resp.StatusCode() == 200 && c.applyLoginResponse(resp.Body()) {
-		c.authLoginVersion = "v1"
+		c.session.loginVersion = "v1"
 		return nil
 	}
 	if !isLocal && resp != nil {
-		fmt.Printf("v1 login failed: status=%d, body=%s\n", resp.StatusCode(), string(resp.Body()))
+		c.logger.Printf("v1 login failed: status=%d, body=%s\n", resp.StatusCode(), string(resp.Body()))
 	}
 	return fmt.Errorf("login failed: status=%d", resp.StatusCode())
 }
@@ -154,81 +270,111 @@ func (c *NacosClient) applyLoginResponse(body []byte) bool {
 }
 
 func (c *NacosClient) applyLoginFromMap(m map[string]interface{}) bool {
-	token, ok := m["accessToken"].(string)
-	if !ok || token == "" {
+	token, ok := tokenFromMap(m)
+	if !ok {
 		return false
 	}
-	c.AccessToken = token
-	var ttlSec int64 = 0
-	switch v := m["tokenTtl"].(type) {
-	case float64:
-		ttlSec = int64(v)
-	case int:
-		ttlSec = int64(v)
-	case int64:
-		ttlSec = v
-	}
-	if ttlSec > 0 {
-		c.TokenExpireAt = time.Now().Add(time.Duration(ttlSec) * time.Second)
-	} else {
-		c.TokenExpireAt = time.Time{}
-	}
+	c.session.accessToken = token.AccessToken
+	c.session.expireAt = token.ExpireAt
 	return true
 }
 
+// refreshToken obtains a new access token from the token provider, or by login
+// when none is set. The caller holds session.mu.
+func (c *NacosClient) refreshToken() error {
+	if c.TokenProvider == nil {
+		return c.login()
+	}
+	token, err := c.TokenProvider.Token()
+	if err != nil {
+		return err
+	}
+	c.session.accessToken = token.AccessToken
+	c.session.expireAt = token.ExpireAt
+	return nil
+}
+
 // ensureTokenValid ensures the access token is valid, refreshing if necessary
 func (c *NacosClient) ensureTokenValid() error {
 	if c.AuthType != AuthTypeNacos {
 		return nil
 	}
-	if c.AccessToken == "" {
-		return c.login()
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+	if c.session.accessToken == "" {
+		return c.refreshToken()
 	}
-	if !c.TokenExpireAt.IsZero() && time.Now().Add(5*time.Second).After(c.TokenExpireAt) {
-		return c.login()
+	if !c.session.expireAt.IsZero() && time.Now().Add(5*time.Second).After(c.session.expireAt) {
+		return c.refreshToken()
 	}
 	return nil
 }
 
-// getSignData builds SPAS signature payload following Aliyun authentication specification
-func getSignData(tenant, group, timeStamp string) string {
-	if tenant == "" {
-		if group == "" {
-			return timeStamp
-		}
-		return group + "+" + timeStamp
-	}
-	if group != "" {
-		return tenant + "+" + group + "+" + timeStamp
+// renewToken replaces a token the server rejected. When another goroutine has
+// already replaced it, the new token is kept rather than logging in again.
+func (c *NacosClient) renewToken(rejected string) error {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+	if c.session.accessToken != rejected {
+		return nil
 	}
-	return tenant + "+" + timeStamp
-}
-
-// spasSign signs data with HMAC-SHA1 and encodes with Base64
-func spasSign(signData, secretKey string) string {
-	mac := hmac.New(sha1.New, []byte(secretKey))
-	mac.Write([]byte(signData))
-	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return c.refreshToken()
 }
 
-// setSpasHeaders sets Aliyun authentication headers for SPAS signature
-func (c *NacosClient) setSpasHeaders(req *resty.Request, tenant, group string) {
-	if c.AuthType != AuthTypeAliyun || c.AccessKey == "" || c.SecretKey == "" {
-		return
+// Ping checks that the server is reachable and accepts the credentials with a
+// one-item config list. A rejected token (server restart, revoked session) is
+// replaced by logging in again once.
+func (c *NacosClient) Ping() error {
+	token := c.AccessToken()
+	_, err := c.ListConfigs("", "", "", 1, 1)
+	if err == nil || c.AuthType != AuthTypeNacos || !isAuthError(err) {
+		return err
 	}
-	ts := strconv.FormatInt(time.Now().UnixMilli(), 10)
-	req.SetHeader("timeStamp", ts)
-	req.SetHeader("Spas-AccessKey", c.AccessKey)
-	normalizedTenant := tenant
-	if normalizedTenant == "public" {
-		normalizedTenant = ""
+	if err := c.renewToken(token); err != nil {
+		return err
 	}
-	signData := getSignData(normalizedTenant, group, ts)
-	req.SetHeader("Spas-Signature", spasSign(signData, c.SecretKey))
+	_, err = c.ListConfigs("", "", "", 1, 1)
+	return err
+}
+
+// isAuthError reports whether a request failed because the token was rejected
+func isAuthError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "status=401") || strings.Contains(msg, "status=403")
 }
 
 // ListConfigs retrieves a list of configurations using v3 or v1 API based on login version
 func (c *NacosClient) ListConfigs(dataID, groupName, namespaceID string, pageNo, pageSize int) (*ConfigListResponse, error) {
+	return c.listConfigs(dataID, groupName, namespaceID, "", "", pageNo, pageSize)
+}
+
+// ListConfigsByTags lists configurations carrying at least one of the tags
+func (c *NacosClient) ListConfigsByTags(dataID, groupName string, tags []string, pageNo, pageSize int) (*ConfigListResponse, error) {
+	return c.listConfigs(dataID, groupName, "", "", strings.Join(tags, ","), pageNo, pageSize)
+}
+
+// ErrContentSearchUnsupported is returned by SearchConfigsByContent when the
+// server rejects the content search parameter (400, 404 or 410)
+var ErrContentSearchUnsupported = errors.New("content search not supported by the server")
+
+// contentSearchRejected reports whether a list status means that the server
+// does not accept the content search parameter
+func contentSearchRejected(status int) bool {
+	return status == 400 || status == 404 || status == 410
+}
+
+// SearchConfigsByContent lists configurations whose content contains the keyword,
+// using the server-side blur search on content
+func (c *NacosClient) SearchConfigsByContent(dataID, groupName, content string, pageNo, pageSize int) (*ConfigListResponse, error) {
+	if !strings.Contains(content, "*") {
+		content = "*" + content + "*"
+	}
+	return c.listConfigs(dataID, groupName, "", content, "", pageNo, pageSize)
+}
+
+// listConfigs lists configurations, optionally filtered by a content pattern
+// and comma separated tags
+func (c *NacosClient) listConfigs(dataID, groupName, namespaceID, content, tags string, pageNo, pageSize int) (*ConfigListResponse, error) {
 	if err := c.ensureTokenValid(); err != nil {
 		return nil, err
 	}
@@ -237,15 +383,21 @@ func (c *NacosClient) ListConfigs(dataID, groupName, namespaceID string, pageNo,
 		ns = c.Namespace
 	}
 
-	if c.authLoginVersion == "v1" {
-		return c.listConfigsV1(dataID, groupName, ns, pageNo, pageSize)
+	if c.loginVersion() == "v1" {
+		return c.listConfigsV1(dataID, groupName, ns, content, tags, pageNo, pageSize)
 	}
 	params := url.Values{}
-	if strings.Contains(dataID, "*") || strings.Contains(groupName, "*") {
+	if strings.Contains(dataID, "*") || strings.Contains(groupName, "*") || content != "" {
 		params.Set("search", "blur")
 	} else {
 		params.Set("search", "accurate")
 	}
+	if content != "" {
+		params.Set("configDetail", content)
+	}
+	if tags != "" {
+		params.Set("configTags", tags)
+	}
 
 	params.Set("dataId", dataID)
 	params.Set("groupName", groupName)
@@ -256,12 +408,12 @@ func (c *NacosClient) ListConfigs(dataID, groupName, namespaceID string, pageNo,
 		params.Set("namespaceId", ns)
 	}
 
-	v3URL := fmt.Sprintf("http://%s/nacos/v3/admin/cs/config/list", c.ServerAddr)
+	v3URL := fmt.Sprintf("%s/nacos/v3/admin/cs/config/list", c.baseURL())
 	req := c.httpClient.R().SetQueryString(params.Encode())
-	if c.AuthType == AuthTypeNacos && c.AccessToken != "" {
-		req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken))
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken()))
 	}
-	c.setSpasHeaders(req, ns, groupName)
+	c.sign(req, ns, groupName)
 	resp, err := req.Get(v3URL)
 
 	if err != nil {
@@ -269,6 +421,9 @@ func (c *NacosClient) ListConfigs(dataID, groupName, namespaceID string, pageNo,
 	}
 
 	if resp.StatusCode() != 200 {
+		if content != "" && contentSearchRejected(resp.StatusCode()) {
+			return nil, fmt.Errorf("list configs failed: %w: status=%d, body=%s", ErrContentSearchUnsupported, resp.StatusCode(), string(resp.Body()))
+		}
 		return nil, fmt.Errorf("list configs failed: status=%d, body=%s", resp.StatusCode(), string(resp.Body()))
 	}
 
@@ -286,17 +441,54 @@ func (c *NacosClient) ListConfigs(dataID, groupName, namespaceID string, pageNo,
 	return &configList, nil
 }
 
+// ListAllConfigs retrieves all configurations matching the filters, following pagination
+func (c *NacosClient) ListAllConfigs(dataID, groupName string) ([]Config, error) {
+	return c.listAllConfigs(dataID, groupName, nil)
+}
+
+// ListAllConfigsByTags retrieves all configurations carrying at least one of
+// the tags, following pagination
+func (c *NacosClient) ListAllConfigsByTags(dataID, groupName string, tags []string) ([]Config, error) {
+	return c.listAllConfigs(dataID, groupName, tags)
+}
+
+func (c *NacosClient) listAllConfigs(dataID, groupName string, tags []string) ([]Config, error) {
+	const pageSize = 200
+	var all []Config
+	for pageNo := 1; ; pageNo++ {
+		page, err := c.listConfigs(dataID, groupName, "", "", strings.Join(tags, ","), pageNo, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.PageItems {
+			if item.GroupName == "" {
+				item.GroupName = item.Group
+			}
+			all = append(all, item)
+		}
+		if len(page.PageItems) < pageSize || len(all) >= page.TotalCount {
+			return all, nil
+		}
+	}
+}
+
 // listConfigsV1 retrieves configurations using Nacos v1 API
-func (c *NacosClient) listConfigsV1(dataID, groupName, namespace string, pageNo, pageSize int) (*ConfigListResponse, error) {
+func (c *NacosClient) listConfigsV1(dataID, groupName, namespace, content, tags string, pageNo, pageSize int) (*ConfigListResponse, error) {
 	if err := c.ensureTokenValid(); err != nil {
 		return nil, err
 	}
 	params := url.Values{}
-	if strings.Contains(dataID, "*") || strings.Contains(groupName, "*") {
+	if strings.Contains(dataID, "*") || strings.Contains(groupName, "*") || content != "" {
 		params.Set("search", "blur")
 	} else {
 		params.Set("search", "accurate")
 	}
+	if content != "" {
+		params.Set("config_detail", content)
+	}
+	if tags != "" {
+		params.Set("config_tags", tags)
+	}
 	params.Set("dataId", dataID)
 	params.Set("group", groupName)
 	params.Set("pageNo", fmt.Sprintf("%d", pageNo))
@@ -306,13 +498,13 @@ func (c *NacosClient) listConfigsV1(dataID, groupName, namespace string, pageNo,
 		params.Set("tenant", namespace)
 	}
 
-	if c.AuthType == AuthTypeNacos && c.AccessToken != "" {
-		params.Set("accessToken", c.AccessToken)
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		params.Set("accessToken", c.AccessToken())
 	}
 
-	v1URL := fmt.Sprintf("http://%s/nacos/v1/cs/configs", c.ServerAddr)
+	v1URL := fmt.Sprintf("%s/nacos/v1/cs/configs", c.baseURL())
 	req := c.httpClient.R().SetQueryString(params.Encode())
-	c.setSpasHeaders(req, namespace, groupName)
+	c.sign(req, namespace, groupName)
 	resp, err := req.Get(v1URL)
 
 	if err != nil {
@@ -320,6 +512,9 @@ func (c *NacosClient) listConfigsV1(dataID, groupName, namespace string, pageNo,
 	}
 
 	if resp.StatusCode() != 200 {
+		if content != "" && contentSearchRejected(resp.StatusCode()) {
+			return nil, fmt.Errorf("v1 list configs failed: %w: status=%d", ErrContentSearchUnsupported, resp.StatusCode())
+		}
 		return nil, fmt.Errorf("v1 list configs failed: status=%d", resp.StatusCode())
 	}
 
@@ -336,6 +531,15 @@ func (c *NacosClient) GetConfig(dataID, group string) (string, error) {
 	if err := c.ensureTokenValid(); err != nil {
 		return "", err
 	}
+	if c.Cache != nil {
+		return c.getConfigCached(dataID, group)
+	}
+	content, _, err := c.getConfig(dataID, group)
+	return content, err
+}
+
+// getConfig downloads a configuration and returns it with its MD5
+func (c *NacosClient) getConfig(dataID, group string) (string, string, error) {
 	params := url.Values{}
 	params.Set("dataId", dataID)
 	params.Set("group", group)
@@ -344,53 +548,134 @@ func (c *NacosClient) GetConfig(dataID, group string) (string, error) {
 		params.Set("tenant", c.Namespace)
 	}
 
-	if c.AuthType == AuthTypeNacos && c.AccessToken != "" {
-		params.Set("accessToken", c.AccessToken)
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		params.Set("accessToken", c.AccessToken())
 	}
 
-	apiURL := fmt.Sprintf("http://%s/nacos/v1/cs/configs", c.ServerAddr)
+	apiURL := fmt.Sprintf("%s/nacos/v1/cs/configs", c.baseURL())
 	req := c.httpClient.R().SetQueryString(params.Encode())
-	c.setSpasHeaders(req, c.Namespace, group)
+	c.sign(req, c.Namespace, group)
 	resp, err := req.Get(apiURL)
 
 	if err != nil {
-		return "", fmt.Errorf("get config failed: %w", err)
+		return "", "", fmt.Errorf("get config failed: %w", err)
 	}
 
+	if resp.StatusCode() == 404 {
+		return "", "", fmt.Errorf("get config failed: status=%d: %w", resp.StatusCode(), ErrConfigNotFound)
+	}
 	if resp.StatusCode() != 200 {
-		return "", fmt.Errorf("get config failed: status=%d", resp.StatusCode())
+		return "", "", fmt.Errorf("get config failed: status=%d", resp.StatusCode())
 	}
 
-	return string(resp.Body()), nil
+	content := string(resp.Body())
+	return content, contentMD5(content), nil
 }
 
 // PublishConfig publishes a configuration
 func (c *NacosClient) PublishConfig(dataID, group, content string) error {
+	return c.PublishConfigWithOptions(dataID, group, content, PublishOptions{})
+}
+
+// PublishOptions holds optional attributes of a publish request
+type PublishOptions struct {
+	CasMd5 string   // publish only if the current content has this MD5 (compare-and-swap)
+	Tags   []string // config tags; replaces the existing tags when set
+	Type   string   // content type: text, json, xml, yaml, html, properties
+	Desc   string   // config description, recorded with the revision in the history
+}
+
+// ErrCasMismatch is returned (wrapped) when a compare-and-swap publish is rejected
+var ErrCasMismatch = errors.New("config was modified concurrently")
+
+// PublishRequest is a configuration about to be published
+type PublishRequest struct {
+	Namespace string
+	Group     string
+	DataID    string
+	Content   string
+	Options   PublishOptions // with the ticket tag and message of the client applied
+
+	// Current fetches the content being replaced; the error wraps
+	// ErrConfigNotFound for a new configuration
+	Current func() (string, error)
+}
+
+// PublishHook accepts a publish by returning nil; an error blocks it
+type PublishHook func(req PublishRequest) error
+
+// ErrPublishBlocked is returned (wrapped) when the publish hook blocks a publish
+var ErrPublishBlocked = errors.New("blocked by pre-publish hook")
+
+// PublishConfigWithOptions publishes a configuration with optional attributes
+func (c *NacosClient) PublishConfigWithOptions(dataID, group, content string, opts PublishOptions) error {
 	if err := c.ensureTokenValid(); err != nil {
 		return err
 	}
+	if c.ticket != "" {
+		tags := opts.Tags
+		if tags == nil {
+			// Tags sent with a publish replace the existing ones
+			detail, err := c.GetConfigDetail(dataID, group)
+			if err != nil && !errors.Is(err, ErrConfigNotFound) {
+				return fmt.Errorf("publish config failed: %w", err)
+			}
+			if detail != nil {
+				tags = detail.Tags()
+			}
+		}
+		opts.Tags = WithTicketTag(tags, c.ticket)
+	}
+	if opts.Desc == "" {
+		opts.Desc = c.message
+	}
+	if c.publishHook != nil {
+		req := PublishRequest{Namespace: c.Namespace, Group: group, DataID: dataID, Content: content, Options: opts,
+			Current: func() (string, error) { return c.GetConfig(dataID, group) }}
+		if err := c.publishHook(req); err != nil {
+			return fmt.Errorf("publish config %s/%s %w: %v", group, dataID, ErrPublishBlocked, err)
+		}
+	}
 	params := map[string]string{
 		"dataId":    dataID,
 		"groupName": group,
 		"content":   content,
 	}
+	if opts.CasMd5 != "" {
+		params["casMd5"] = opts.CasMd5
+	}
+	if len(opts.Tags) > 0 {
+		params["configTags"] = strings.Join(opts.Tags, ",")
+	}
+	if opts.Type != "" {
+		params["type"] = opts.Type
+	}
+	if opts.Desc != "" {
+		params["desc"] = opts.Desc
+	}
 
 	if c.Namespace != "" {
 		params["namespaceId"] = c.Namespace
 	}
 
-	apiURL := fmt.Sprintf("http://%s/nacos/v3/admin/cs/config", c.ServerAddr)
+	apiURL := fmt.Sprintf("%s/nacos/v3/admin/cs/config", c.baseURL())
 	req := c.httpClient.R().SetFormData(params)
-	if c.AuthType == AuthTypeNacos && c.AccessToken != "" {
-		req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken))
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken()))
+	}
+	c.sign(req, c.Namespace, group)
+	if opts.CasMd5 != "" {
+		req.SetHeader("casMd5", opts.CasMd5)
 	}
-	c.setSpasHeaders(req, c.Namespace, group)
 	resp, err := req.Post(apiURL)
 
 	if err != nil {
 		return fmt.Errorf("publish config failed: %w", err)
 	}
 
+	if opts.CasMd5 != "" && resp.StatusCode() == 409 {
+		return fmt.Errorf("publish config failed: %w", ErrCasMismatch)
+	}
 	if resp.StatusCode() != 200 {
 		return fmt.Errorf("publish config failed: status=%d, body=%s", resp.StatusCode(), string(resp.Body()))
 	}
@@ -410,8 +695,204 @@ func (c *NacosClient) PublishConfig(dataID, group, content string) error {
 		return fmt.Errorf("publish config failed: invalid data format: %w", err)
 	}
 	if !result {
+		if opts.CasMd5 != "" {
+			return fmt.Errorf("publish config failed: %w", ErrCasMismatch)
+		}
 		return fmt.Errorf("publish config failed: server returned false")
 	}
 
 	return nil
 }
+
+// DeleteConfig deletes a configuration
+func (c *NacosClient) DeleteConfig(dataID, group string) error {
+	if err := c.ensureTokenValid(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("dataId", dataID)
+	params.Set("groupName", group)
+
+	if c.Namespace != "" {
+		params.Set("namespaceId", c.Namespace)
+	}
+
+	apiURL := fmt.Sprintf("%s/nacos/v3/admin/cs/config", c.baseURL())
+	req := c.httpClient.R().SetQueryString(params.Encode())
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken()))
+	}
+	c.sign(req, c.Namespace, group)
+	resp, err := req.Delete(apiURL)
+
+	if err != nil {
+		return fmt.Errorf("delete config failed: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("delete config failed: status=%d, body=%s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	var v3Resp V3Response
+	if err := json.Unmarshal(resp.Body(), &v3Resp); err != nil {
+		if string(resp.Body()) == "true" {
+			return nil
+		}
+		return fmt.Errorf("delete config failed: invalid response format: %s", string(resp.Body()))
+	}
+	if v3Resp.Code != 0 {
+		return fmt.Errorf("delete config failed: code=%d, message=%s", v3Resp.Code, v3Resp.Message)
+	}
+	return nil
+}
+
+// DeleteConfigIfUnchanged deletes a configuration only if its content still
+// has the MD5, returning ErrCasMismatch otherwise. Nacos has no conditional
+// delete: the check is made right before the delete, and a configuration
+// already gone is not an error.
+func (c *NacosClient) DeleteConfigIfUnchanged(dataID, group, md5 string) error {
+	content, err := c.GetConfig(dataID, group)
+	if errors.Is(err, ErrConfigNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("delete config failed: %w", err)
+	}
+	if contentMD5(content) != md5 {
+		return fmt.Errorf("delete config failed: %w", ErrCasMismatch)
+	}
+	return c.DeleteConfig(dataID, group)
+}
+
+// ConfigDetail represents a configuration with its metadata (v3 admin API)
+type ConfigDetail struct {
+	DataID      string `json:"dataId"`
+	GroupName   string `json:"groupName"`
+	NamespaceID string `json:"namespaceId"`
+	Content     string `json:"content"`
+	Desc        string `json:"desc"`
+	Md5         string `json:"md5"`
+	ConfigTags  string `json:"configTags"`
+	AppName     string `json:"appName"`
+	Type        string `json:"type"`
+	CreateTime  int64  `json:"createTime"`
+	ModifyTime  int64  `json:"modifyTime"`
+	CreateUser  string `json:"createUser"`
+	CreateIP    string `json:"createIp"`
+}
+
+// Tags returns the config tags as a list
+func (d *ConfigDetail) Tags() []string {
+	return SplitTags(d.ConfigTags)
+}
+
+// TicketTagPrefix marks the ticket of the last change in config tags
+const TicketTagPrefix = "ticket:"
+
+// WithTicketTag returns tags with the ticket tag set to ticket, keeping the other tags
+func WithTicketTag(tags []string, ticket string) []string {
+	result := make([]string, 0, len(tags)+1)
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, TicketTagPrefix) {
+			result = append(result, tag)
+		}
+	}
+	return append(result, TicketTagPrefix+ticket)
+}
+
+// TemporaryTag marks configurations published with a time to live, and
+// ExpiresTagPrefix their expiry in the config tags, in UTC
+const (
+	TemporaryTag     = "temporary"
+	ExpiresTagPrefix = "expires:"
+	expiresLayout    = "20060102T150405Z"
+)
+
+// WithExpiryTags returns tags marking a temporary configuration expiring at
+// expiry, keeping the other tags
+func WithExpiryTags(tags []string, expiry time.Time) []string {
+	result := make([]string, 0, len(tags)+2)
+	for _, tag := range tags {
+		if tag != TemporaryTag && !strings.HasPrefix(tag, ExpiresTagPrefix) {
+			result = append(result, tag)
+		}
+	}
+	return append(result, TemporaryTag, ExpiresTagPrefix+expiry.UTC().Format(expiresLayout))
+}
+
+// TagsExpiry returns the expiry of a temporary configuration from its tags
+func TagsExpiry(tags []string) (time.Time, bool) {
+	for _, tag := range tags {
+		if value, ok := strings.CutPrefix(tag, ExpiresTagPrefix); ok {
+			if t, err := time.Parse(expiresLayout, value); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// SplitTags splits a comma separated config tags value
+func SplitTags(configTags string) []string {
+	var tags []string
+	for _, tag := range strings.Split(configTags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// GetConfigDetail gets a configuration together with its metadata
+func (c *NacosClient) GetConfigDetail(dataID, group string) (*ConfigDetail, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("dataId", dataID)
+	params.Set("groupName", group)
+
+	if c.Namespace != "" {
+		params.Set("namespaceId", c.Namespace)
+	}
+
+	apiURL := fmt.Sprintf("%s/nacos/v3/admin/cs/config?%s", c.baseURL(), params.Encode())
+	req := c.httpClient.R()
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken()))
+	}
+	c.sign(req, c.Namespace, group)
+	resp, err := req.Get(apiURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("get config detail failed: %w", err)
+	}
+
+	if resp.StatusCode() == 404 {
+		return nil, fmt.Errorf("get config detail failed: status=%d: %w", resp.StatusCode(), ErrConfigNotFound)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("get config detail failed: status=%d, body=%s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	var v3Resp V3Response
+	if err := json.Unmarshal(resp.Body(), &v3Resp); err != nil {
+		return nil, fmt.Errorf("get config detail failed: invalid response format: %s", string(resp.Body()))
+	}
+	if v3Resp.Code != 0 {
+		// 20004: config not exist
+		if v3Resp.Code == 20004 {
+			return nil, fmt.Errorf("get config detail failed: %s: %w", v3Resp.Message, ErrConfigNotFound)
+		}
+		return nil, fmt.Errorf("get config detail failed: code=%d, message=%s", v3Resp.Code, v3Resp.Message)
+	}
+	if len(v3Resp.Data) == 0 || string(v3Resp.Data) == "null" {
+		return nil, fmt.Errorf("get config detail failed: %w", ErrConfigNotFound)
+	}
+
+	var detail ConfigDetail
+	if err := json.Unmarshal(v3Resp.Data, &detail); err != nil {
+		return nil, fmt.Errorf("get config detail failed: invalid data format: %w", err)
+	}
+	return &detail, nil
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Namespace represents a Nacos namespace
+type Namespace struct {
+	Namespace         string `json:"namespace"` // namespace ID
+	NamespaceShowName string `json:"namespaceShowName"`
+	NamespaceDesc     string `json:"namespaceDesc"`
+	Quota             int    `json:"quota"`
+	ConfigCount       int    `json:"configCount"`
+	Type              int    `json:"type"` // 0 global, 1 default private, 2 custom
+}
+
+// APIError is a v3 API response with a non-zero code
+type APIError struct {
+	Op      string
+	Code    int
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s failed: code=%d, message=%s", e.Op, e.Code, e.Message)
+}
+
+// doV3 sends an authenticated request to a v3 admin API and returns the data
+// field of the response. Parameters go in the query string for GET/DELETE and
+// in the form body otherwise.
+func (c *NacosClient) doV3(op, method, apiPath string, params url.Values, group string) (json.RawMessage, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	apiURL := c.baseURL() + apiPath
+	req := c.httpClient.R()
+	if method == "GET" || method == "DELETE" {
+		req.SetQueryString(params.Encode())
+	} else {
+		req.SetFormDataFromValues(params)
+	}
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken()))
+	}
+	c.sign(req, params.Get("namespaceId"), group)
+	resp, err := req.Execute(method, apiURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", op, err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("%s failed: status=%d, body=%s", op, resp.StatusCode(), string(resp.Body()))
+	}
+
+	var v3Resp V3Response
+	if err := json.Unmarshal(resp.Body(), &v3Resp); err != nil {
+		return nil, fmt.Errorf("%s failed: invalid response format: %s", op, string(resp.Body()))
+	}
+	if v3Resp.Code != 0 {
+		return nil, &APIError{Op: op, Code: v3Resp.Code, Message: v3Resp.Message}
+	}
+	return v3Resp.Data, nil
+}
+
+// ListNamespaces lists all namespaces
+func (c *NacosClient) ListNamespaces() ([]Namespace, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	if c.loginVersion() == "v1" {
+		body, err := c.doNamespaceV1("list namespaces", "GET", url.Values{})
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Data []Namespace `json:"data"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("list namespaces failed: invalid response format: %s", string(body))
+		}
+		return result.Data, nil
+	}
+
+	data, err := c.doV3("list namespaces", "GET", "/nacos/v3/admin/core/namespace/list", url.Values{}, "")
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []Namespace
+	if err := json.Unmarshal(data, &namespaces); err != nil {
+		return nil, fmt.Errorf("list namespaces failed: invalid data format: %w", err)
+	}
+	return namespaces, nil
+}
+
+// GetNamespace returns a namespace, or nil when it does not exist
+func (c *NacosClient) GetNamespace(namespaceID string) (*Namespace, error) {
+	namespaces, err := c.ListNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	for i := range namespaces {
+		if namespaces[i].Namespace == namespaceID {
+			return &namespaces[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateNamespace creates a namespace with the given ID
+func (c *NacosClient) CreateNamespace(namespaceID, name, desc string) error {
+	if err := c.ensureTokenValid(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("namespaceName", name)
+	params.Set("namespaceDesc", desc)
+	if c.loginVersion() == "v1" {
+		params.Set("customNamespaceId", namespaceID)
+		return c.doNamespaceV1Bool("create namespace", "POST", params)
+	}
+	params.Set("namespaceId", namespaceID)
+	_, err := c.doV3("create namespace", "POST", "/nacos/v3/admin/core/namespace", params, "")
+	return err
+}
+
+// UpdateNamespace changes the name and description of a namespace
+func (c *NacosClient) UpdateNamespace(namespaceID, name, desc string) error {
+	if err := c.ensureTokenValid(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	if c.loginVersion() == "v1" {
+		params.Set("namespace", namespaceID)
+		params.Set("namespaceShowName", name)
+		params.Set("namespaceDesc", desc)
+		return c.doNamespaceV1Bool("update namespace", "PUT", params)
+	}
+	params.Set("namespaceId", namespaceID)
+	params.Set("namespaceName", name)
+	params.Set("namespaceDesc", desc)
+	_, err := c.doV3("update namespace", "PUT", "/nacos/v3/admin/core/namespace", params, "")
+	return err
+}
+
+// DeleteNamespace deletes a namespace
+func (c *NacosClient) DeleteNamespace(namespaceID string) error {
+	if err := c.ensureTokenValid(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("namespaceId", namespaceID)
+	if c.loginVersion() == "v1" {
+		return c.doNamespaceV1Bool("delete namespace", "DELETE", params)
+	}
+	_, err := c.doV3("delete namespace", "DELETE", "/nacos/v3/admin/core/namespace", params, "")
+	return err
+}
+
+// doNamespaceV1 sends a request to the v1 console namespace API of servers
+// without the v3 admin API and returns the response body
+func (c *NacosClient) doNamespaceV1(op, method string, params url.Values) ([]byte, error) {
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		params.Set("accessToken", c.AccessToken())
+	}
+	req := c.httpClient.R()
+	if method == "GET" || method == "DELETE" {
+		req.SetQueryString(params.Encode())
+	} else {
+		req.SetFormDataFromValues(params)
+	}
+	c.sign(req, "", "")
+	resp, err := req.Execute(method, c.baseURL()+"/nacos/v1/console/namespaces")
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", op, err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("%s failed: status=%d, body=%s", op, resp.StatusCode(), string(resp.Body()))
+	}
+	return resp.Body(), nil
+}
+
+// doNamespaceV1Bool sends a change to the v1 console namespace API, which
+// answers true or false
+func (c *NacosClient) doNamespaceV1Bool(op, method string, params url.Values) error {
+	body, err := c.doNamespaceV1(op, method, params)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(body)) != "true" {
+		return fmt.Errorf("%s failed: %s", op, string(body))
+	}
+	return nil
+}
+
+// WithNamespace returns a copy of the client bound to another namespace,
+// sharing the HTTP client and the login session
+func (c *NacosClient) WithNamespace(namespaceID string) *NacosClient {
+	clone := *c
+	if namespaceID == "" {
+		namespaceID = "public"
+	}
+	clone.Namespace = namespaceID
+	return &clone
+}
@@ -0,0 +1,469 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrServiceNotFound is returned (wrapped) when a service does not exist
+var ErrServiceNotFound = errors.New("service not found")
+
+// Response codes of missing services (v3) and of heartbeats of unknown instances (v1)
+const (
+	codeServiceNotFound      = 21008
+	codeBeatInstanceNotFound = 20404
+)
+
+// ErrInstanceNotFound is returned (wrapped) by SendBeat when the server no
+// longer knows the instance, which must be registered again
+var ErrInstanceNotFound = errors.New("instance not found")
+
+// errNamingNotFound is returned by doNamingV1 when the resource does not exist
+var errNamingNotFound = errors.New("not found")
+
+// Service is a service of the naming catalog with its instance counts
+type Service struct {
+	Name                 string `json:"name"`
+	GroupName            string `json:"groupName"`
+	ClusterCount         int    `json:"clusterCount"`
+	IPCount              int    `json:"ipCount"`
+	HealthyInstanceCount int    `json:"healthyInstanceCount"`
+}
+
+// ServicePage is a page of the naming catalog
+type ServicePage struct {
+	TotalCount int       `json:"totalCount"`
+	PageItems  []Service `json:"pageItems"`
+}
+
+// ServiceDetail is the definition of a service
+type ServiceDetail struct {
+	NamespaceID      string            `json:"namespaceId"`
+	ServiceName      string            `json:"serviceName"`
+	GroupName        string            `json:"groupName"`
+	ProtectThreshold float64           `json:"protectThreshold"` // healthy ratio at or below which all instances are returned
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Ephemeral        bool              `json:"ephemeral"`
+	Clusters         []Cluster         `json:"clusters,omitempty"`
+}
+
+// Cluster is a cluster of a service and how its instances are health checked
+type Cluster struct {
+	Name            string                 `json:"clusterName"`
+	HealthChecker   map[string]interface{} `json:"healthChecker,omitempty"` // type (TCP, HTTP, MYSQL or NONE) and its settings
+	CheckPort       int                    `json:"healthyCheckPort"`
+	UseInstancePort bool                   `json:"useInstancePortForCheck"`
+	Metadata        map[string]string      `json:"metadata,omitempty"`
+}
+
+// Instance is an instance of a service
+type Instance struct {
+	InstanceID  string            `json:"instanceId,omitempty"`
+	IP          string            `json:"ip"`
+	Port        int               `json:"port"`
+	Weight      float64           `json:"weight"`
+	Healthy     bool              `json:"healthy"`
+	Enabled     bool              `json:"enabled"`
+	Ephemeral   bool              `json:"ephemeral"`
+	ClusterName string            `json:"clusterName"`
+	ServiceName string            `json:"serviceName,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// ServesTraffic reports whether clients are sent to the instance: it is
+// healthy and enabled
+func (i Instance) ServesTraffic() bool {
+	return i.Healthy && i.Enabled
+}
+
+// ListServices lists a page of the services of the namespace with their
+// instance counts. serviceName and groupName match as substrings.
+func (c *NacosClient) ListServices(serviceName, groupName string, pageNo, pageSize int) (*ServicePage, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("serviceNameParam", serviceName)
+	params.Set("groupNameParam", groupName)
+	params.Set("pageNo", fmt.Sprintf("%d", pageNo))
+	params.Set("pageSize", fmt.Sprintf("%d", pageSize))
+	params.Set("withInstances", "false")
+
+	if c.loginVersion() == "v1" {
+		params.Set("hasIpCount", "true")
+		body, err := c.doNamingV1("list services", "GET", "/nacos/v1/ns/catalog/services", params)
+		if err != nil {
+			return nil, err
+		}
+		var catalog struct {
+			Count       int       `json:"count"`
+			ServiceList []Service `json:"serviceList"`
+		}
+		if err := json.Unmarshal(body, &catalog); err != nil {
+			return nil, fmt.Errorf("list services failed: invalid response format: %s", string(body))
+		}
+		return &ServicePage{TotalCount: catalog.Count, PageItems: catalog.ServiceList}, nil
+	}
+
+	params.Set("ignoreEmptyService", "false")
+	data, err := c.doV3("list services", "GET", "/nacos/v3/admin/ns/service/list", params, groupName)
+	if err != nil {
+		return nil, err
+	}
+	var page ServicePage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("list services failed: invalid data format: %w", err)
+	}
+	return &page, nil
+}
+
+// ListAllServices lists every service matching the filters, following pagination
+func (c *NacosClient) ListAllServices(serviceName, groupName string) ([]Service, error) {
+	const pageSize = 200
+	var all []Service
+	for pageNo := 1; ; pageNo++ {
+		page, err := c.ListServices(serviceName, groupName, pageNo, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.PageItems...)
+		if len(page.PageItems) < pageSize || len(all) >= page.TotalCount {
+			return all, nil
+		}
+	}
+}
+
+// GetService returns the definition of a service
+func (c *NacosClient) GetService(serviceName, groupName string) (*ServiceDetail, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("serviceName", serviceName)
+	params.Set("groupName", groupName)
+
+	if c.loginVersion() == "v1" {
+		body, err := c.doNamingV1("get service", "GET", "/nacos/v1/ns/service", params)
+		if errors.Is(err, errNamingNotFound) {
+			return nil, fmt.Errorf("%w: %s@@%s", ErrServiceNotFound, groupName, serviceName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var v1 struct {
+			ServiceDetail
+			Name     string `json:"name"`
+			Clusters []struct {
+				Cluster
+				Name string `json:"name"`
+			} `json:"clusters"`
+		}
+		if err := json.Unmarshal(body, &v1); err != nil {
+			return nil, fmt.Errorf("get service failed: invalid response format: %s", string(body))
+		}
+		detail := v1.ServiceDetail
+		if detail.ServiceName == "" {
+			// v1 names the service with its group prefix
+			detail.ServiceName = strings.TrimPrefix(v1.Name, groupName+"@@")
+		}
+		for _, cluster := range v1.Clusters {
+			cluster.Cluster.Name = cluster.Name
+			detail.Clusters = append(detail.Clusters, cluster.Cluster)
+		}
+		return &detail, nil
+	}
+
+	data, err := c.doV3("get service", "GET", "/nacos/v3/admin/ns/service", params, groupName)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == codeServiceNotFound {
+		return nil, fmt.Errorf("%w: %s@@%s", ErrServiceNotFound, groupName, serviceName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var v3 struct {
+		ServiceDetail
+		ClusterMap map[string]Cluster `json:"clusterMap"`
+	}
+	if err := json.Unmarshal(data, &v3); err != nil {
+		return nil, fmt.Errorf("get service failed: invalid data format: %w", err)
+	}
+	detail := v3.ServiceDetail
+	for name, cluster := range v3.ClusterMap {
+		cluster.Name = name
+		detail.Clusters = append(detail.Clusters, cluster)
+	}
+	sort.Slice(detail.Clusters, func(i, j int) bool { return detail.Clusters[i].Name < detail.Clusters[j].Name })
+	return &detail, nil
+}
+
+// CreateService creates a service with its protect threshold and metadata
+func (c *NacosClient) CreateService(detail ServiceDetail) error {
+	return c.writeService("create service", "POST", detail)
+}
+
+// UpdateService sets the protect threshold and metadata of a service
+func (c *NacosClient) UpdateService(detail ServiceDetail) error {
+	return c.writeService("update service", "PUT", detail)
+}
+
+func (c *NacosClient) writeService(op, method string, detail ServiceDetail) error {
+	if err := c.ensureTokenValid(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("serviceName", detail.ServiceName)
+	params.Set("groupName", detail.GroupName)
+	params.Set("protectThreshold", strconv.FormatFloat(detail.ProtectThreshold, 'f', -1, 64))
+	params.Set("ephemeral", strconv.FormatBool(detail.Ephemeral))
+	if err := setMetadata(params, detail.Metadata); err != nil {
+		return err
+	}
+	if c.loginVersion() == "v1" {
+		_, err := c.doNamingV1(op, method, "/nacos/v1/ns/service", params)
+		return err
+	}
+	_, err := c.doV3(op, method, "/nacos/v3/admin/ns/service", params, detail.GroupName)
+	return err
+}
+
+// UpdateCluster sets the health check and metadata of a cluster of a service
+func (c *NacosClient) UpdateCluster(serviceName, groupName string, cluster Cluster) error {
+	if err := c.ensureTokenValid(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("serviceName", serviceName)
+	params.Set("groupName", groupName)
+	params.Set("clusterName", cluster.Name)
+	params.Set("checkPort", strconv.Itoa(cluster.CheckPort))
+	params.Set("useInstancePort4Check", strconv.FormatBool(cluster.UseInstancePort))
+	if cluster.HealthChecker != nil {
+		checker, err := json.Marshal(cluster.HealthChecker)
+		if err != nil {
+			return err
+		}
+		params.Set("healthChecker", string(checker))
+	}
+	if err := setMetadata(params, cluster.Metadata); err != nil {
+		return err
+	}
+	if c.loginVersion() == "v1" {
+		_, err := c.doNamingV1("update cluster", "PUT", "/nacos/v1/ns/cluster", params)
+		return err
+	}
+	_, err := c.doV3("update cluster", "PUT", "/nacos/v3/admin/ns/cluster", params, groupName)
+	return err
+}
+
+// DeleteService deletes a service. The server refuses services that still
+// have instances.
+func (c *NacosClient) DeleteService(serviceName, groupName string) error {
+	if err := c.ensureTokenValid(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("serviceName", serviceName)
+	params.Set("groupName", groupName)
+	if c.loginVersion() == "v1" {
+		_, err := c.doNamingV1("delete service", "DELETE", "/nacos/v1/ns/service", params)
+		return err
+	}
+	_, err := c.doV3("delete service", "DELETE", "/nacos/v3/admin/ns/service", params, groupName)
+	return err
+}
+
+// ListInstances lists the instances of a service, of every cluster when
+// cluster is empty
+func (c *NacosClient) ListInstances(serviceName, groupName, cluster string) ([]Instance, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("namespaceId", c.Namespace)
+	params.Set("serviceName", serviceName)
+	params.Set("groupName", groupName)
+	params.Set("healthyOnly", "false")
+
+	if c.loginVersion() == "v1" {
+		if cluster != "" {
+			params.Set("clusters", cluster)
+		}
+		body, err := c.doNamingV1("list instances", "GET", "/nacos/v1/ns/instance/list", params)
+		if errors.Is(err, errNamingNotFound) {
+			return nil, fmt.Errorf("%w: %s@@%s", ErrServiceNotFound, groupName, serviceName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Hosts []Instance `json:"hosts"`
+		}
+		if err := json.Unmarshal(body, &list); err != nil {
+			return nil, fmt.Errorf("list instances failed: invalid response format: %s", string(body))
+		}
+		return list.Hosts, nil
+	}
+
+	if cluster != "" {
+		params.Set("clusterName", cluster)
+	}
+	data, err := c.doV3("list instances", "GET", "/nacos/v3/admin/ns/instance/list", params, groupName)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == codeServiceNotFound {
+		return nil, fmt.Errorf("%w: %s@@%s", ErrServiceNotFound, groupName, serviceName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var instances []Instance
+	if err := json.Unmarshal(data, &instances); err != nil {
+		return nil, fmt.Errorf("list instances failed: invalid data format: %w", err)
+	}
+	return instances, nil
+}
+
+// RegisterInstance registers an instance of a service, or replaces the
+// instance with the same cluster, IP and port
+func (c *NacosClient) RegisterInstance(serviceName, groupName string, inst Instance) error {
+	params := instanceParams(c.Namespace, serviceName, groupName, inst)
+	params.Set("weight", strconv.FormatFloat(inst.Weight, 'f', -1, 64))
+	params.Set("enabled", strconv.FormatBool(inst.Enabled))
+	params.Set("healthy", strconv.FormatBool(inst.Healthy))
+	if err := setMetadata(params, inst.Metadata); err != nil {
+		return err
+	}
+	return c.doInstance("register instance", "POST", params)
+}
+
+// UpdateInstance sets the weight, enabled flag and metadata of a registered
+// instance of a service
+func (c *NacosClient) UpdateInstance(serviceName, groupName string, inst Instance) error {
+	params := instanceParams(c.Namespace, serviceName, groupName, inst)
+	params.Set("weight", strconv.FormatFloat(inst.Weight, 'f', -1, 64))
+	params.Set("enabled", strconv.FormatBool(inst.Enabled))
+	if err := setMetadata(params, inst.Metadata); err != nil {
+		return err
+	}
+	return c.doInstance("update instance", "PUT", params)
+}
+
+// DeregisterInstance removes an instance from a service
+func (c *NacosClient) DeregisterInstance(serviceName, groupName string, inst Instance) error {
+	return c.doInstance("deregister instance", "DELETE", instanceParams(c.Namespace, serviceName, groupName, inst))
+}
+
+// instanceParams identifies an instance in the instance APIs
+func instanceParams(namespace, serviceName, groupName string, inst Instance) url.Values {
+	params := url.Values{}
+	params.Set("namespaceId", namespace)
+	params.Set("serviceName", serviceName)
+	params.Set("groupName", groupName)
+	params.Set("clusterName", inst.ClusterName)
+	params.Set("ip", inst.IP)
+	params.Set("port", strconv.Itoa(inst.Port))
+	params.Set("ephemeral", strconv.FormatBool(inst.Ephemeral))
+	return params
+}
+
+// SendBeat sends a heartbeat keeping an ephemeral instance alive and returns
+// the interval the server asks for, 0 when it does not. Only the v1 API takes
+// heartbeats over HTTP.
+func (c *NacosClient) SendBeat(serviceName, groupName string, inst Instance) (time.Duration, error) {
+	if err := c.ensureTokenValid(); err != nil {
+		return 0, err
+	}
+	beat, err := json.Marshal(map[string]interface{}{
+		"serviceName": groupName + "@@" + serviceName,
+		"ip":          inst.IP,
+		"port":        inst.Port,
+		"cluster":     inst.ClusterName,
+		"weight":      inst.Weight,
+		"metadata":    inst.Metadata,
+		"scheduled":   true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	params := instanceParams(c.Namespace, serviceName, groupName, inst)
+	params.Set("beat", string(beat))
+	body, err := c.doNamingV1("send beat", "PUT", "/nacos/v1/ns/instance/beat", params)
+	if err != nil {
+		return 0, err
+	}
+	var resp struct {
+		Code               int   `json:"code"`
+		ClientBeatInterval int64 `json:"clientBeatInterval"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, fmt.Errorf("send beat failed: invalid response format: %s", string(body))
+	}
+	if resp.Code == codeBeatInstanceNotFound {
+		return 0, fmt.Errorf("%w: %s:%d", ErrInstanceNotFound, inst.IP, inst.Port)
+	}
+	return time.Duration(resp.ClientBeatInterval) * time.Millisecond, nil
+}
+
+// setMetadata sets the metadata parameter of the naming APIs, a JSON object
+func setMetadata(params url.Values, metadata map[string]string) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	params.Set("metadata", string(data))
+	return nil
+}
+
+// doInstance sends a change of an instance to the instance API of the server
+func (c *NacosClient) doInstance(op, method string, params url.Values) error {
+	if err := c.ensureTokenValid(); err != nil {
+		return err
+	}
+	if c.loginVersion() == "v1" {
+		_, err := c.doNamingV1(op, method, "/nacos/v1/ns/instance", params)
+		return err
+	}
+	_, err := c.doV3(op, method, "/nacos/v3/admin/ns/instance", params, params.Get("groupName"))
+	return err
+}
+
+// doNamingV1 sends an authenticated request to a v1 naming API and returns the
+// response body. Parameters go in the query string for GET/DELETE and in the
+// form body otherwise.
+func (c *NacosClient) doNamingV1(op, method, apiPath string, params url.Values) ([]byte, error) {
+	if c.AuthType == AuthTypeNacos && c.AccessToken() != "" {
+		params.Set("accessToken", c.AccessToken())
+	}
+	req := c.httpClient.R()
+	if method == "GET" || method == "DELETE" {
+		req.SetQueryString(params.Encode())
+	} else {
+		req.SetFormDataFromValues(params)
+	}
+	c.sign(req, params.Get("namespaceId"), params.Get("groupName"))
+	resp, err := req.Execute(method, c.baseURL()+apiPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", op, err)
+	}
+	if resp.StatusCode() == 404 || (resp.StatusCode() != 200 && strings.Contains(string(resp.Body()), "not found")) {
+		return nil, fmt.Errorf("%s failed: %w: %s", op, errNamingNotFound, string(resp.Body()))
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("%s failed: status=%d, body=%s", op, resp.StatusCode(), string(resp.Body()))
+	}
+	return resp.Body(), nil
+}
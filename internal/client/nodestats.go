@@ -0,0 +1,152 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// latencyWeight is the weight of a new sample in the moving average latency
+const latencyWeight = 0.2
+
+// NodeStats is the client's view of one server node, built from the requests
+// it sent there
+type NodeStats struct {
+	Node        string        `json:"node"`
+	Requests    int           `json:"requests"`
+	Errors      int           `json:"errors"`  // transport errors and 5xx responses
+	Latency     time.Duration `json:"latency"` // moving average, long polls excluded
+	Healthy     bool          `json:"healthy"` // the last request succeeded
+	LastError   string        `json:"lastError,omitempty"`
+	LastErrorAt time.Time     `json:"lastErrorAt,omitempty"`
+	LastSeen    time.Time     `json:"lastSeen"`
+}
+
+// ErrorRate returns the share of failed requests
+func (s NodeStats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// nodeStats collects NodeStats per node; copies of a client share it
+type nodeStats struct {
+	mu    sync.Mutex
+	nodes map[string]*NodeStats
+}
+
+func newNodeStats() *nodeStats {
+	return &nodeStats{nodes: make(map[string]*NodeStats)}
+}
+
+func (s *nodeStats) record(node string, latency time.Duration, longPoll bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.nodes[node]
+	if !ok {
+		n = &NodeStats{Node: node}
+		s.nodes[node] = n
+	}
+	n.Requests++
+	n.LastSeen = time.Now()
+	n.Healthy = err == nil
+	if err != nil {
+		n.Errors++
+		n.LastError = err.Error()
+		n.LastErrorAt = n.LastSeen
+		return
+	}
+	if longPoll {
+		return
+	}
+	if n.Latency == 0 {
+		n.Latency = latency
+	} else {
+		n.Latency = time.Duration(float64(n.Latency)*(1-latencyWeight) + float64(latency)*latencyWeight)
+	}
+}
+
+// trackNodeStats records the outcome of every request of the HTTP client
+func (c *NacosClient) trackNodeStats() {
+	c.httpClient.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		var err error
+		if resp.StatusCode() >= 500 {
+			err = fmt.Errorf("status %d", resp.StatusCode())
+		}
+		c.stats.record(requestNode(resp.Request), resp.Time(), isLongPoll(resp.Request), err)
+		return nil
+	})
+	c.httpClient.OnError(func(req *resty.Request, err error) {
+		if re, ok := err.(*resty.ResponseError); ok {
+			if re.Response != nil && re.Response.RawResponse != nil {
+				// Already recorded by OnAfterResponse
+				return
+			}
+			err = re.Err
+		}
+		c.stats.record(requestNode(req), 0, false, err)
+	})
+}
+
+// requestNode returns the host:port a request went to
+func requestNode(req *resty.Request) string {
+	if req.RawRequest != nil {
+		return req.RawRequest.URL.Host
+	}
+	if u, err := url.Parse(req.URL); err == nil {
+		return u.Host
+	}
+	return req.URL
+}
+
+// isLongPoll reports whether a request is a listener long poll, held open by
+// the server for up to its timeout
+func isLongPoll(req *resty.Request) bool {
+	return req.Method == http.MethodPost && strings.HasSuffix(requestPath(req), "/configs/listener")
+}
+
+func requestPath(req *resty.Request) string {
+	if req.RawRequest != nil {
+		return req.RawRequest.URL.Path
+	}
+	if u, err := url.Parse(req.URL); err == nil {
+		return u.Path
+	}
+	return req.URL
+}
+
+// NodeStats returns the client's view of the server nodes it sent requests
+// to, sorted by node
+func (c *NacosClient) NodeStats() []NodeStats {
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+	stats := make([]NodeStats, 0, len(c.stats.nodes))
+	for _, n := range c.stats.nodes {
+		stats = append(stats, *n)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Node < stats[j].Node })
+	return stats
+}
+
+// PreferredNode returns the healthy node with the lowest latency, the node
+// reads should go to; the configured server when no node was healthy yet
+func (c *NacosClient) PreferredNode() string {
+	best := ""
+	var bestLatency time.Duration
+	for _, n := range c.NodeStats() {
+		if n.Healthy && n.Latency > 0 && (best == "" || n.Latency < bestLatency) {
+			best, bestLatency = n.Node, n.Latency
+		}
+	}
+	if best == "" {
+		return c.ServerAddr
+	}
+	return best
+}
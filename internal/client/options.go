@@ -0,0 +1,203 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Option customizes a client built by NewClient, before the first login
+type Option func(*clientOptions)
+
+// Logger receives the warnings of a client, such as failed logins.
+// *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+type clientOptions struct {
+	namespace     string
+	authType      string
+	username      string
+	password      string
+	accessKey     string
+	secretKey     string
+	tokenProvider TokenProvider
+	tlsConfig     *tls.Config
+	timeout       time.Duration
+	transport     http.RoundTripper
+	httpClient    *resty.Client
+	logger        Logger
+	signer        Signer
+	ticket        string
+	message       string
+	requestID     string
+	publishHook   PublishHook
+
+	compensateClockSkew bool
+}
+
+// InNamespace binds the client to a namespace (default: public)
+func InNamespace(namespaceID string) Option {
+	return func(o *clientOptions) {
+		o.namespace = namespaceID
+	}
+}
+
+// WithAuth logs in with a Nacos username and password
+func WithAuth(username, password string) Option {
+	return func(o *clientOptions) {
+		o.username, o.password = username, password
+	}
+}
+
+// WithAccessKey signs requests with an Aliyun AccessKey/SecretKey pair instead of logging in
+func WithAccessKey(accessKey, secretKey string) Option {
+	return func(o *clientOptions) {
+		o.accessKey, o.secretKey = accessKey, secretKey
+	}
+}
+
+// WithTokenProvider obtains access tokens from the provider instead of logging in
+func WithTokenProvider(provider TokenProvider) Option {
+	return func(o *clientOptions) {
+		o.tokenProvider = provider
+	}
+}
+
+// WithTLS talks https to the server with the TLS configuration. Config
+// listeners of the listener package are not affected.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *clientOptions) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithTimeout limits each request, including reading the response (default: none)
+func WithTimeout(d time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = d
+	}
+}
+
+// WithTransport sends requests through rt instead of the shared connection
+// pool, e.g. the transport of an httptest server or one with a custom dialer
+// for SSH tunnels and service meshes
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = rt
+	}
+}
+
+// WithHTTPClient sends requests through a preconfigured resty client, keeping
+// its transport, timeouts and middleware
+func WithHTTPClient(httpClient *resty.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithLogger receives the warnings otherwise printed to stdout
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithTicket tags every configuration the client publishes with
+// ticket:<ticket>, so that the change can be traced to its ticket
+func WithTicket(ticket string) Option {
+	return func(o *clientOptions) {
+		o.ticket = ticket
+	}
+}
+
+// WithMessage sets the description of the configurations the client
+// publishes without one, so that the reason of a change shows in the history
+func WithMessage(message string) Option {
+	return func(o *clientOptions) {
+		o.message = message
+	}
+}
+
+// WithPublishHook lets hook accept or block every configuration before the
+// client publishes it
+func WithPublishHook(hook PublishHook) Option {
+	return func(o *clientOptions) {
+		o.publishHook = hook
+	}
+}
+
+// RequestIDHeader carries the request ID set with WithRequestID
+const RequestIDHeader = "X-Request-ID"
+
+// WithRequestID sends the ID in the X-Request-ID header of every request, so
+// that the requests of one run can be found in the server and gateway logs
+func WithRequestID(id string) Option {
+	return func(o *clientOptions) {
+		o.requestID = id
+	}
+}
+
+// WithAuthType selects AuthTypeNacos or AuthTypeAliyun instead of deriving it
+// from the credentials: aliyun when an AccessKey/SecretKey pair is set
+func WithAuthType(authType string) Option {
+	return func(o *clientOptions) {
+		o.authType = authType
+	}
+}
+
+// newHTTPClient builds the resty client described by the options
+func (o *clientOptions) newHTTPClient() *resty.Client {
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = resty.New()
+		switch {
+		case o.transport != nil:
+			httpClient.SetTransport(o.transport)
+		case o.tlsConfig != nil:
+			// The shared pool must not pick up this client's TLS settings
+			t := SharedTransport().Clone()
+			t.TLSClientConfig = o.tlsConfig
+			httpClient.SetTransport(t)
+		default:
+			httpClient.SetTransport(SharedTransport())
+		}
+	}
+	if o.tlsConfig != nil && (o.httpClient != nil || o.transport != nil) {
+		httpClient.SetTLSClientConfig(o.tlsConfig)
+	}
+	if o.timeout > 0 {
+		httpClient.SetTimeout(o.timeout)
+	}
+	if o.requestID != "" {
+		httpClient.SetHeader(RequestIDHeader, o.requestID)
+	}
+	return httpClient
+}
+
+// Transport returns the RoundTripper the client sends its requests through
+func (c *NacosClient) Transport() http.RoundTripper {
+	return c.httpClient.GetClient().Transport
+}
+
+// TLSConfig returns the TLS settings of a client built WithTLS, nil otherwise
+func (c *NacosClient) TLSConfig() *tls.Config {
+	if c.scheme != "https" {
+		return nil
+	}
+	if t, ok := c.Transport().(*http.Transport); ok && t.TLSClientConfig != nil {
+		return t.TLSClientConfig
+	}
+	return &tls.Config{}
+}
+
+// stdoutLogger prints warnings to stdout, as the CLI always has
+type stdoutLogger struct{}
+
+func (stdoutLogger) Printf(format string, args ...interface{}) {
+	fmt.Printf(format, args...)
+}
@@ -0,0 +1,291 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Token is an access token together with its expiry time.
+// A zero ExpireAt means the lifetime is unknown and the token is used until rejected.
+type Token struct {
+	AccessToken string
+	ExpireAt    time.Time
+}
+
+// TokenProvider acquires access tokens for authenticated requests.
+// When a client has no TokenProvider it logs in with Username/Password through
+// the Nacos auth API; a provider replaces that step, e.g. for Nacos deployments
+// fronted by an SSO gateway.
+type TokenProvider interface {
+	Token() (*Token, error)
+}
+
+// StaticTokenProvider always returns the same pre-issued token
+type StaticTokenProvider struct {
+	AccessToken string
+}
+
+// Token returns the static token
+func (p *StaticTokenProvider) Token() (*Token, error) {
+	if p.AccessToken == "" {
+		return nil, fmt.Errorf("static token is empty")
+	}
+	return &Token{AccessToken: p.AccessToken}, nil
+}
+
+// CommandTokenProvider runs an external command and reads the token from its stdout.
+// The output may be either a raw token or a JSON object in the Nacos login
+// response format ({"accessToken": "...", "tokenTtl": 18000}).
+type CommandTokenProvider struct {
+	Command string
+	Env     []string // added to the environment of the command, as KEY=value
+}
+
+// Token executes the command and parses its output
+func (p *CommandTokenProvider) Token() (*Token, error) {
+	if p.Command == "" {
+		return nil, fmt.Errorf("token command is empty")
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", p.Command)
+	} else {
+		cmd = exec.Command("sh", "-c", p.Command)
+	}
+	if len(p.Env) > 0 {
+		cmd.Env = append(os.Environ(), p.Env...)
+	}
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("token command failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if output == "" {
+		return nil, fmt.Errorf("token command returned empty output")
+	}
+	if strings.HasPrefix(output, "{") {
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			return nil, fmt.Errorf("parse token command output: %w", err)
+		}
+		if data, ok := result["data"].(map[string]interface{}); ok {
+			result = data
+		}
+		token, ok := tokenFromMap(result)
+		if !ok {
+			return nil, fmt.Errorf("token command output has no accessToken")
+		}
+		return token, nil
+	}
+	return &Token{AccessToken: output}, nil
+}
+
+// MintedTokenProvider issues tokens for a user itself, signed with the token
+// secret key of the server (nacos.core.auth.plugin.nacos.token.secret.key) as
+// the default Nacos auth plugin does at login. Only an operator holding the
+// key of the server can mint tokens this way.
+type MintedTokenProvider struct {
+	Username  string
+	SecretKey string // base64, as in the server configuration
+	TTL       time.Duration
+}
+
+// Token mints an HS256 JWT with the user as subject
+func (p *MintedTokenProvider) Token() (*Token, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(p.SecretKey))
+	if err != nil {
+		return nil, fmt.Errorf("token secret key is not base64: %w", err)
+	}
+	if len(key) < 32 {
+		return nil, fmt.Errorf("token secret key is shorter than 32 bytes")
+	}
+	expireAt := time.Now().Add(p.TTL)
+	header, _ := json.Marshal(map[string]string{"alg": "HS256"})
+	payload, _ := json.Marshal(map[string]interface{}{"sub": p.Username, "exp": expireAt.Unix()})
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signed))
+	return &Token{
+		AccessToken: signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)),
+		ExpireAt:    expireAt,
+	}, nil
+}
+
+// OIDCDeviceTokenProvider acquires tokens with the OAuth 2.0 device authorization
+// grant (RFC 8628). The user is asked to open a verification URL in a browser,
+// after which the access token (or ID token) is used as the Nacos access token.
+type OIDCDeviceTokenProvider struct {
+	DeviceAuthURL string
+	TokenURL      string
+	ClientID      string
+	Scope         string
+	UseIDToken    bool
+
+	refreshToken string
+	httpClient   *resty.Client
+}
+
+type oidcDeviceAuthResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type oidcTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Token returns a token, refreshing with the refresh token when possible
+// and falling back to a new interactive device authorization otherwise.
+func (p *OIDCDeviceTokenProvider) Token() (*Token, error) {
+	if p.httpClient == nil {
+		p.httpClient = resty.New().SetTransport(SharedTransport())
+	}
+	if p.refreshToken != "" {
+		token, err := p.requestToken(map[string]string{
+			"grant_type":    "refresh_token",
+			"refresh_token": p.refreshToken,
+			"client_id":     p.ClientID,
+		})
+		if err == nil {
+			return token, nil
+		}
+		p.refreshToken = ""
+	}
+	return p.deviceFlow()
+}
+
+// deviceFlow runs the device authorization flow and polls until the user completes sign-in
+func (p *OIDCDeviceTokenProvider) deviceFlow() (*Token, error) {
+	if p.DeviceAuthURL == "" || p.TokenURL == "" || p.ClientID == "" {
+		return nil, fmt.Errorf("oidc provider requires deviceAuthUrl, tokenUrl and clientId")
+	}
+	form := map[string]string{"client_id": p.ClientID}
+	if p.Scope != "" {
+		form["scope"] = p.Scope
+	}
+	resp, err := p.httpClient.R().SetFormData(form).Post(p.DeviceAuthURL)
+	if err != nil {
+		return nil, fmt.Errorf("device authorization failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("device authorization failed: status=%d, body=%s", resp.StatusCode(), string(resp.Body()))
+	}
+	var auth oidcDeviceAuthResponse
+	if err := json.Unmarshal(resp.Body(), &auth); err != nil {
+		return nil, fmt.Errorf("parse device authorization response: %w", err)
+	}
+
+	if auth.VerificationURIComplete != "" {
+		fmt.Fprintf(os.Stderr, "To sign in, open %s\n", auth.VerificationURIComplete)
+	} else {
+		fmt.Fprintf(os.Stderr, "To sign in, open %s and enter code %s\n", auth.VerificationURI, auth.UserCode)
+	}
+
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	expiresIn := time.Duration(auth.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 10 * time.Minute
+	}
+	deadline := time.Now().Add(expiresIn)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+		token, err := p.requestToken(map[string]string{
+			"grant_type":  "urn:ietf:params:oauth:grant-type:device_code",
+			"device_code": auth.DeviceCode,
+			"client_id":   p.ClientID,
+		})
+		if err == nil {
+			return token, nil
+		}
+		switch err.Error() {
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			continue
+		}
+		return nil, err
+	}
+	return nil, fmt.Errorf("device authorization expired before sign-in completed")
+}
+
+// requestToken calls the token endpoint. OAuth error codes are returned as the error text.
+func (p *OIDCDeviceTokenProvider) requestToken(form map[string]string) (*Token, error) {
+	resp, err := p.httpClient.R().SetFormData(form).Post(p.TokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	var result oidcTokenResponse
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return nil, fmt.Errorf("parse token response: status=%d, body=%s", resp.StatusCode(), string(resp.Body()))
+	}
+	if result.Error != "" {
+		if result.Error == "authorization_pending" || result.Error == "slow_down" {
+			return nil, fmt.Errorf("%s", result.Error)
+		}
+		return nil, fmt.Errorf("token request failed: %s %s", result.Error, result.ErrorDescription)
+	}
+
+	accessToken := result.AccessToken
+	if p.UseIDToken {
+		accessToken = result.IDToken
+	}
+	if accessToken == "" {
+		return nil, fmt.Errorf("token response has no token")
+	}
+	if result.RefreshToken != "" {
+		p.refreshToken = result.RefreshToken
+	}
+	token := &Token{AccessToken: accessToken}
+	if result.ExpiresIn > 0 {
+		token.ExpireAt = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// tokenFromMap extracts a token from a Nacos login response object
+func tokenFromMap(m map[string]interface{}) (*Token, bool) {
+	accessToken, ok := m["accessToken"].(string)
+	if !ok || accessToken == "" {
+		return nil, false
+	}
+	token := &Token{AccessToken: accessToken}
+	var ttlSec int64 = 0
+	switch v := m["tokenTtl"].(type) {
+	case float64:
+		ttlSec = int64(v)
+	case int:
+		ttlSec = int64(v)
+	case int64:
+		ttlSec = v
+	}
+	if ttlSec > 0 {
+		token.ExpireAt = time.Now().Add(time.Duration(ttlSec) * time.Second)
+	}
+	return token, true
+}
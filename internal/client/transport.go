@@ -0,0 +1,70 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TransportOptions tunes the HTTP connection pool shared by all clients
+type TransportOptions struct {
+	MaxIdleConnsPerHost int           // idle keep-alive connections kept per server (default: 32)
+	IdleConnTimeout     time.Duration // how long an idle connection is kept (default: 90s)
+	DisableHTTP2        bool          // stay on HTTP/1.1 for TLS endpoints
+}
+
+// DefaultTransportOptions keeps enough idle connections for the default
+// concurrency of bulk commands; net/http keeps only 2 per host, so every
+// other request of a batch would open and close a connection.
+var DefaultTransportOptions = TransportOptions{
+	MaxIdleConnsPerHost: 32,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+var (
+	transportMu     sync.Mutex
+	sharedTransport *http.Transport
+)
+
+// SetTransportOptions replaces the transport used by clients created afterwards.
+// Zero fields take their default.
+func SetTransportOptions(opts TransportOptions) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	sharedTransport = NewTransport(opts)
+}
+
+// NewTransport builds a keep-alive transport with the options
+func NewTransport(opts TransportOptions) *http.Transport {
+	if opts.MaxIdleConnsPerHost <= 0 {
+		opts.MaxIdleConnsPerHost = DefaultTransportOptions.MaxIdleConnsPerHost
+	}
+	if opts.IdleConnTimeout <= 0 {
+		opts.IdleConnTimeout = DefaultTransportOptions.IdleConnTimeout
+	}
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          opts.MaxIdleConnsPerHost * 4,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		IdleConnTimeout:       opts.IdleConnTimeout,
+		ForceAttemptHTTP2:     !opts.DisableHTTP2,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// SharedTransport returns the shared transport, so that clients for other namespaces
+// or profiles of the same server reuse its connections
+func SharedTransport() *http.Transport {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	if sharedTransport == nil {
+		sharedTransport = NewTransport(DefaultTransportOptions)
+	}
+	return sharedTransport
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -19,6 +21,109 @@ type Config struct {
 	AccessKey string `yaml:"accessKey"` // Aliyun AK（AuthType=aliyun 时使用）
 	SecretKey string `yaml:"secretKey"` // Aliyun SK
 	Namespace string `yaml:"namespace"`
+
+	Signer              string `yaml:"signer"`              // aliyun request signing: spas (default) | acs
+	CompensateClockSkew bool   `yaml:"compensateClockSkew"` // sign Aliyun requests with the server time
+
+	Protected         bool     `yaml:"protected"`         // reads only, unless --i-know-what-i-am-doing
+	AllowedOperations []string `yaml:"allowedOperations"` // read, publish, delete (default: all)
+	ChangeWindows     []string `yaml:"changeWindows"`     // cron expressions of the minutes allowing changes, local time
+	RequireTicket     bool     `yaml:"requireTicket"`     // changes need --ticket
+	TicketPattern     string   `yaml:"ticketPattern"`     // regular expression tickets must match, e.g. [A-Z]+-[0-9]+
+	AuditLog          string   `yaml:"auditLog"`          // log of changes and overrides (default ~/.nacos-cli/audit.log, overrides only)
+	MaxChanges        int      `yaml:"maxChanges"`        // changes a batch command may make in a namespace, unless --confirm-over-budget (default: no limit)
+
+	RequireApprovals int               `yaml:"requireApprovals"` // apply only change files with this many valid approvals
+	Approvers        map[string]string `yaml:"approvers"`        // trusted reviewer -> public key printed by approval-keygen
+
+	Notify          []WebhookConfig `yaml:"notify"`          // webhooks receiving the summary of apply and config-push runs
+	PrePublishHooks []HookConfig    `yaml:"prePublishHooks"` // commands that must accept every configuration before it is published
+	PolicyDir       string          `yaml:"policyDir"`       // directory of Rego policies every publish is evaluated against
+
+	TokenProvider *TokenProviderConfig `yaml:"tokenProvider"` // optional, replaces username/password login
+	Impersonation *ImpersonationConfig `yaml:"impersonation"` // lets the profile act as other users with --as
+	RecycleBin    *RecycleBinConfig    `yaml:"recycleBin"`    // where config-delete keeps deleted configurations
+	Transport     *TransportConfig     `yaml:"transport"`     // HTTP connection pool tuning
+
+	Group        string                     `yaml:"group"`        // default group for commands taking a group
+	GroupAliases map[string]string          `yaml:"groupAliases"` // short name -> group, e.g. prod -> PROD_APPLICATION_GROUP
+	Commands     map[string]CommandDefaults `yaml:"commands"`     // per-command defaults keyed by command name
+	Templates    string                     `yaml:"templates"`    // directory of config-new templates (default ~/.nacos-cli/templates)
+	CacheDir     string                     `yaml:"cacheDir"`     // directory caching config contents between runs (default: no cache)
+	FlagsConfig  string                     `yaml:"flagsConfig"`  // [group/]dataId of the feature flags read by flag-* commands (default flags.yaml)
+
+	Prompt      string `yaml:"prompt"`      // terminal prompt with {user}, {server}, {namespace} and {profile} placeholders
+	PromptColor string `yaml:"promptColor"` // red, green, yellow, blue, magenta, cyan or none (default: red for prod, else green)
+
+	Output string `yaml:"output"` // default --output format of commands printing tables: table | json
+	Color  string `yaml:"color"`  // auto (default) | always | never
+	Pager  string `yaml:"pager"`  // auto (default: $PAGER or less) | off | a pager command
+
+	DiffMode string `yaml:"diffMode"` // default --diff-mode of diffs and change previews: line (default) | word | semantic
+
+	Profiles map[string]*Config `yaml:"profiles"` // named profiles overriding the top-level settings
+}
+
+// CommandDefaults holds defaults applied to a single command
+type CommandDefaults struct {
+	Namespace string `yaml:"namespace"`
+	Group     string `yaml:"group"`
+	Output    string `yaml:"output"`
+}
+
+// TokenProviderConfig configures how access tokens are acquired
+type TokenProviderConfig struct {
+	Type    string `yaml:"type"`    // static | command | oidc
+	Token   string `yaml:"token"`   // static: pre-issued access token
+	Command string `yaml:"command"` // command: prints a token (or login JSON) to stdout
+
+	// oidc: OAuth 2.0 device authorization flow
+	DeviceAuthURL string `yaml:"deviceAuthUrl"`
+	TokenURL      string `yaml:"tokenUrl"`
+	ClientID      string `yaml:"clientId"`
+	Scope         string `yaml:"scope"`
+	UseIDToken    bool   `yaml:"useIdToken"` // send the ID token instead of the access token
+}
+
+// ImpersonationConfig configures how tokens for other users are obtained:
+// minted with the token secret key of the server, or printed by a command
+type ImpersonationConfig struct {
+	TokenSecretKey string `yaml:"tokenSecretKey"` // nacos.core.auth.plugin.nacos.token.secret.key of the server
+	Command        string `yaml:"command"`        // prints a token for the user in $NACOS_CLI_AS_USER
+	TokenTTL       string `yaml:"tokenTtl"`       // lifetime of minted tokens (default 30m)
+}
+
+// RecycleBinConfig selects where deleted configurations are kept
+type RecycleBinConfig struct {
+	Type      string `yaml:"type"`      // local (default) | nacos | none
+	Path      string `yaml:"path"`      // local: directory (default ~/.nacos-cli/recycle-bin)
+	Namespace string `yaml:"namespace"` // nacos: namespace holding the snapshots
+}
+
+// WebhookConfig is a webhook notified at the end of runs
+type WebhookConfig struct {
+	Type     string `yaml:"type"` // slack | dingtalk | webhook (default: JSON summary)
+	URL      string `yaml:"url"`
+	On       string `yaml:"on"`       // always (default) | change | failure
+	Template string `yaml:"template"` // text/template of the message, fields of notify.Summary
+	Link     string `yaml:"link"`     // template of the diffs link, e.g. {{env "CI_JOB_URL"}}
+	Secret   string `yaml:"secret"`   // dingtalk: signing secret of the robot
+}
+
+// HookConfig is an external command run before a publish. It receives the
+// content on stdin and the metadata in NACOS_* environment variables; a
+// non-zero exit blocks the publish.
+type HookConfig struct {
+	Name    string `yaml:"name"`    // shown when the hook blocks a publish (default: the command)
+	Command string `yaml:"command"` // shell command, e.g. conftest test --parser yaml -
+	Timeout string `yaml:"timeout"` // duration, e.g. 10s (default 30s)
+}
+
+// TransportConfig tunes the HTTP connection pool
+type TransportConfig struct {
+	MaxIdleConnsPerHost int    `yaml:"maxIdleConnsPerHost"` // default 32
+	IdleTimeout         string `yaml:"idleTimeout"`         // duration, e.g. 90s (default)
+	DisableHTTP2        bool   `yaml:"disableHttp2"`        // stay on HTTP/1.1 for TLS endpoints
 }
 
 // LoadConfig loads configuration from a file
@@ -78,3 +183,81 @@ func (c *Config) GetServerAddr() string {
 	}
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
+
+// WithProfile returns the configuration with the named profile applied on top of
+// the top-level settings. Non-empty profile values override, maps are merged.
+func (c *Config) WithProfile(name string) (*Config, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile not found: %s (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	merged := *c
+	dst := reflect.ValueOf(&merged).Elem()
+	src := reflect.ValueOf(profile).Elem()
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Type().Field(i).Name == "Profiles" {
+			continue
+		}
+		srcField := src.Field(i)
+		if srcField.IsZero() {
+			continue
+		}
+		dstField := dst.Field(i)
+		if srcField.Kind() == reflect.Map && !dstField.IsNil() {
+			combined := reflect.MakeMap(srcField.Type())
+			for _, key := range dstField.MapKeys() {
+				combined.SetMapIndex(key, dstField.MapIndex(key))
+			}
+			for _, key := range srcField.MapKeys() {
+				combined.SetMapIndex(key, srcField.MapIndex(key))
+			}
+			dstField.Set(combined)
+			continue
+		}
+		dstField.Set(srcField)
+	}
+	return &merged, nil
+}
+
+// ProfileNames returns the sorted names of all defined profiles
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Operations that AllowedOperations can list
+const (
+	OperationRead    = "read"
+	OperationPublish = "publish"
+	OperationDelete  = "delete"
+)
+
+// AllowsOperation reports whether the settings allow an operation: a protected
+// profile allows reads only, otherwise AllowedOperations when it is set
+func (c *Config) AllowsOperation(operation string) bool {
+	if c.Protected {
+		return operation == OperationRead
+	}
+	if len(c.AllowedOperations) == 0 {
+		return true
+	}
+	for _, allowed := range c.AllowedOperations {
+		if allowed == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveGroup expands a group alias, returning the group unchanged when it is not an alias
+func (c *Config) ResolveGroup(group string) string {
+	if resolved, ok := c.GroupAliases[group]; ok {
+		return resolved
+	}
+	return group
+}
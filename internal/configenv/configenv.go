@@ -0,0 +1,151 @@
+package configenv
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var invalidEnvChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// EnvName converts a configuration key to an environment variable name
+// (e.g. spring.datasource.url -> SPRING_DATASOURCE_URL)
+func EnvName(prefix, key string) string {
+	name := invalidEnvChars.ReplaceAllString(strings.ToUpper(key), "_")
+	return prefix + name
+}
+
+// IsSupported reports whether the data ID extension can be converted to variables
+func IsSupported(dataID string) bool {
+	switch strings.ToLower(filepath.Ext(dataID)) {
+	case ".properties", ".env", ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// ToEnv converts configuration content to environment variables based on
+// the data ID extension (.properties, .env, .yaml, .yml, .json).
+// Nested YAML/JSON keys are joined with underscores.
+func ToEnv(dataID, content, prefix string) (map[string]string, error) {
+	var flat map[string]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(dataID)) {
+	case ".properties", ".env":
+		flat = ParseProperties(content)
+	case ".yaml", ".yml":
+		var doc interface{}
+		if err = yaml.Unmarshal([]byte(content), &doc); err != nil {
+			return nil, fmt.Errorf("parse %s as YAML: %w", dataID, err)
+		}
+		flat = make(map[string]string)
+		flatten("", doc, flat)
+	case ".json":
+		var doc interface{}
+		decoder := json.NewDecoder(strings.NewReader(content))
+		decoder.UseNumber()
+		if err = decoder.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("parse %s as JSON: %w", dataID, err)
+		}
+		flat = make(map[string]string)
+		flatten("", doc, flat)
+	default:
+		return nil, fmt.Errorf("cannot convert %s to environment variables (supported: .properties, .env, .yaml, .yml, .json)", dataID)
+	}
+
+	env := make(map[string]string, len(flat))
+	for key, value := range flat {
+		env[EnvName(prefix, key)] = value
+	}
+	return env, nil
+}
+
+// Environ formats variables as a sorted KEY=VALUE list for exec.Cmd.Env
+func Environ(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, key+"="+env[key])
+	}
+	return result
+}
+
+// ParseProperties parses Java properties / dotenv style key=value lines
+func ParseProperties(content string) map[string]string {
+	result := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	var pending string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if pending != "" {
+			line = pending + line
+			pending = ""
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		// Line continuation
+		if strings.HasSuffix(line, "\\") {
+			pending = strings.TrimSuffix(line, "\\")
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		idx := strings.IndexAny(line, "=:")
+		if idx <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:idx])
+		value := strings.TrimSpace(line[idx+1:])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// flatten walks a decoded YAML/JSON document and collects leaf values
+func flatten(prefix string, value interface{}, result map[string]string) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "_" + key
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			flatten(join(key), child, result)
+		}
+	case map[interface{}]interface{}:
+		for key, child := range v {
+			flatten(join(fmt.Sprintf("%v", key)), child, result)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flatten(join(fmt.Sprintf("%d", i)), child, result)
+		}
+	case nil:
+		if prefix != "" {
+			result[prefix] = ""
+		}
+	default:
+		if prefix != "" {
+			result[prefix] = fmt.Sprintf("%v", v)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package configref
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/configenv"
+	"github.com/nov11/nacos-cli/internal/yamlutil"
+	"gopkg.in/yaml.v3"
+)
+
+// refPattern matches ${nacos:[GROUP/]dataId[:key]}
+var refPattern = regexp.MustCompile(`\$\{nacos:([^}:]+)(?::([^}]+))?\}`)
+
+// Fetcher returns the content of a configuration, "" when it does not exist
+type Fetcher func(dataID, group string) (string, error)
+
+// Resolver replaces references to other configurations with their content or
+// one of their values, resolving the referenced configurations recursively
+type Resolver struct {
+	fetch    Fetcher
+	resolved map[string]string // group/dataId -> content with its references resolved
+}
+
+// NewResolver creates a resolver fetching referenced configurations with fetch
+func NewResolver(fetch Fetcher) *Resolver {
+	return &Resolver{fetch: fetch, resolved: make(map[string]string)}
+}
+
+// Resolve returns content of dataID in group with its references resolved. A
+// reference without a group points into the same group; without a key it is
+// replaced by the whole referenced configuration.
+func (r *Resolver) Resolve(dataID, group, content string) (string, error) {
+	return r.resolve(content, group, []string{group + "/" + dataID})
+}
+
+func (r *Resolver) resolve(content, group string, chain []string) (string, error) {
+	var out strings.Builder
+	last := 0
+	for _, m := range refPattern.FindAllStringSubmatchIndex(content, -1) {
+		out.WriteString(content[last:m[0]])
+		last = m[1]
+
+		refGroup, refDataID, ok := strings.Cut(content[m[2]:m[3]], "/")
+		if !ok {
+			refGroup, refDataID = group, refGroup
+		}
+		key := ""
+		if m[4] >= 0 {
+			key = content[m[4]:m[5]]
+		}
+		target, err := r.config(refDataID, refGroup, chain)
+		if err != nil {
+			return "", err
+		}
+		if key == "" {
+			out.WriteString(target)
+			continue
+		}
+		value, err := lookup(refDataID, target, key)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", content[m[0]:m[1]], err)
+		}
+		out.WriteString(value)
+	}
+	out.WriteString(content[last:])
+	return out.String(), nil
+}
+
+// config fetches and resolves a referenced configuration once, failing on a
+// configuration that (indirectly) references itself
+func (r *Resolver) config(dataID, group string, chain []string) (string, error) {
+	id := group + "/" + dataID
+	for _, c := range chain {
+		if c == id {
+			return "", fmt.Errorf("reference cycle: %s -> %s", strings.Join(chain, " -> "), id)
+		}
+	}
+	if content, ok := r.resolved[id]; ok {
+		return content, nil
+	}
+	content, err := r.fetch(dataID, group)
+	if err != nil {
+		return "", fmt.Errorf("fetch referenced %s: %w", id, err)
+	}
+	if content == "" {
+		return "", fmt.Errorf("referenced configuration %s not found (from %s)", id, chain[len(chain)-1])
+	}
+	content, err = r.resolve(content, group, append(chain[:len(chain):len(chain)], id))
+	if err != nil {
+		return "", err
+	}
+	r.resolved[id] = content
+	return content, nil
+}
+
+// lookup returns a single value of a configuration: a key of a properties
+// configuration or a path like spring.datasource.url of a YAML or JSON one
+func lookup(dataID, content, key string) (string, error) {
+	switch strings.ToLower(filepath.Ext(dataID)) {
+	case ".properties", ".env":
+		value, ok := configenv.ParseProperties(content)[key]
+		if !ok {
+			return "", fmt.Errorf("%s has no key %s", dataID, key)
+		}
+		return value, nil
+	}
+	doc, err := yamlutil.Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", dataID, err)
+	}
+	node, err := yamlutil.Lookup(doc, key)
+	if err != nil {
+		return "", err
+	}
+	if node == nil {
+		return "", fmt.Errorf("%s has no value at %s", dataID, key)
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("%s at %s is not a single value", dataID, key)
+	}
+	return node.Value, nil
+}
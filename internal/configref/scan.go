@@ -0,0 +1,132 @@
+package configref
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/diff"
+)
+
+// Kinds of dependencies between configurations
+const (
+	KindRef             = "ref"              // ${nacos:...} reference
+	KindSharedConfig    = "shared-config"    // spring.cloud.nacos.config.shared-configs / shared-dataids
+	KindExtensionConfig = "extension-config" // spring.cloud.nacos.config.extension-configs / ext-config
+	KindImport          = "import"           // spring.config.import=nacos:...
+)
+
+const defaultGroup = "DEFAULT_GROUP"
+
+// Dependency is a configuration another one loads or references
+type Dependency struct {
+	Group  string
+	DataID string
+	Kind   string
+}
+
+// Scan returns the configurations the content of dataID in group depends on:
+// its ${nacos:} references and the shared, extension and imported configs of
+// a Spring Cloud Alibaba application config. Duplicates are left out.
+func Scan(dataID, group, content string) []Dependency {
+	var deps []Dependency
+	seen := make(map[Dependency]bool)
+	add := func(d Dependency) {
+		if d.DataID != "" && !seen[d] {
+			seen[d] = true
+			deps = append(deps, d)
+		}
+	}
+
+	for _, m := range refPattern.FindAllStringSubmatch(content, -1) {
+		refGroup, refDataID, ok := strings.Cut(m[1], "/")
+		if !ok {
+			refGroup, refDataID = group, refGroup
+		}
+		add(Dependency{Group: refGroup, DataID: refDataID, Kind: KindRef})
+	}
+
+	values, err := diff.ParseStructured(dataID, content)
+	if err != nil {
+		return deps
+	}
+	// Relaxed binding: shared-configs, sharedConfigs and shared_configs are one key
+	flat := make(map[string]string, len(values))
+	for key, encoded := range values {
+		var value string
+		if json.Unmarshal([]byte(encoded), &value) != nil {
+			value = encoded
+		}
+		flat[normalizeKey(key)] = value
+	}
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	const prefix = "spring.cloud.nacos.config."
+	importGroup := flat[prefix+"group"]
+	if importGroup == "" {
+		importGroup = defaultGroup
+	}
+	for _, key := range keys {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			if key == "spring.config.import" || strings.HasPrefix(key, "spring.config.import[") {
+				for _, location := range strings.Split(flat[key], ",") {
+					if d, ok := parseImport(strings.TrimSpace(location), importGroup); ok {
+						add(d)
+					}
+				}
+			}
+			continue
+		}
+		switch {
+		case rest == "shareddataids":
+			for _, id := range strings.Split(flat[key], ",") {
+				add(Dependency{Group: defaultGroup, DataID: strings.TrimSpace(id), Kind: KindSharedConfig})
+			}
+		case strings.HasSuffix(rest, "].dataid"):
+			list := rest[:strings.Index(rest, "[")]
+			kind := ""
+			switch list {
+			case "sharedconfigs":
+				kind = KindSharedConfig
+			case "extensionconfigs", "extconfig":
+				kind = KindExtensionConfig
+			default:
+				continue
+			}
+			g := flat[prefix+strings.TrimSuffix(rest, "dataid")+"group"]
+			if g == "" {
+				g = defaultGroup
+			}
+			add(Dependency{Group: g, DataID: flat[key], Kind: kind})
+		}
+	}
+	return deps
+}
+
+// parseImport reads a spring.config.import location such as
+// optional:nacos:app.yaml?group=G&refreshEnabled=true
+func parseImport(location, group string) (Dependency, bool) {
+	location = strings.TrimPrefix(location, "optional:")
+	rest, ok := strings.CutPrefix(location, "nacos:")
+	if !ok {
+		return Dependency{}, false
+	}
+	dataID, query, _ := strings.Cut(rest, "?")
+	for _, param := range strings.Split(query, "&") {
+		if value, ok := strings.CutPrefix(param, "group="); ok && value != "" {
+			group = value
+		}
+	}
+	return Dependency{Group: group, DataID: dataID, Kind: KindImport}, dataID != ""
+}
+
+// normalizeKey lowercases a flattened key and drops the - and _ of relaxed
+// binding from its names
+func normalizeKey(key string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+}
@@ -0,0 +1,97 @@
+package configtree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Entry is a configuration file in a directory tree laid out as <dir>/<group>/<dataId>
+type Entry struct {
+	Group  string
+	DataID string
+	Path   string
+}
+
+// Key returns the group/dataId identifier of the entry
+func (e Entry) Key() string {
+	return Key(e.Group, e.DataID)
+}
+
+// Key builds the group/dataId identifier used in state files and manifests
+func Key(group, dataID string) string {
+	return group + "/" + dataID
+}
+
+// SplitKey splits a group/dataId identifier
+func SplitKey(key string) (group, dataID string) {
+	group, dataID, _ = strings.Cut(key, "/")
+	return group, dataID
+}
+
+// Path returns the file path of a configuration inside the tree
+func Path(dir, group, dataID string) string {
+	return filepath.Join(dir, group, dataID)
+}
+
+// TypeOf infers the Nacos content type of a data ID from its file extension
+func TypeOf(dataID string) string {
+	switch strings.ToLower(filepath.Ext(dataID)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	case ".properties":
+		return "properties"
+	case ".xml":
+		return "xml"
+	case ".html", ".htm":
+		return "html"
+	default:
+		return "text"
+	}
+}
+
+// ValidName reports whether a group or data ID can be used as a path element
+// without escaping the tree or colliding with hidden files
+func ValidName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, `/\`)
+}
+
+// Walk lists all configuration files in the tree, sorted by group and data ID.
+// Hidden files and directories (state files, .git) are skipped.
+func Walk(dir string) ([]Entry, error) {
+	groups, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory %s: %w", dir, err)
+	}
+
+	var entries []Entry
+	for _, groupEntry := range groups {
+		if !groupEntry.IsDir() || strings.HasPrefix(groupEntry.Name(), ".") {
+			continue
+		}
+		groupDir := filepath.Join(dir, groupEntry.Name())
+		files, err := os.ReadDir(groupDir)
+		if err != nil {
+			return nil, fmt.Errorf("read directory %s: %w", groupDir, err)
+		}
+		for _, file := range files {
+			if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+				continue
+			}
+			entries = append(entries, Entry{
+				Group:  groupEntry.Name(),
+				DataID: file.Name(),
+				Path:   filepath.Join(groupDir, file.Name()),
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key() < entries[j].Key()
+	})
+	return entries, nil
+}
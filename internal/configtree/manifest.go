@@ -0,0 +1,57 @@
+package configtree
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestFileName is the file describing a pulled tree, at the root of the tree
+const ManifestFileName = ".nacos-manifest.json"
+
+// ManifestEntry describes one configuration of a pulled tree
+type ManifestEntry struct {
+	Group  string `json:"group"`
+	DataID string `json:"dataId"`
+	MD5    string `json:"md5"`
+	Type   string `json:"type,omitempty"`
+
+	// Metadata recorded by manifest-import
+	Tags    []string `json:"tags,omitempty"`
+	Desc    string   `json:"desc,omitempty"`
+	AppName string   `json:"appName,omitempty"`
+}
+
+// Manifest records where a tree was pulled from and what it contained
+type Manifest struct {
+	Server    string          `json:"server"`
+	Namespace string          `json:"namespace"`
+	PulledAt  time.Time       `json:"pulledAt"`
+	Selector  string          `json:"selector,omitempty"` // the configurations were limited to --selector
+	Entries   []ManifestEntry `json:"entries"`
+}
+
+// LoadManifest reads the manifest of a tree
+func LoadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Save writes the manifest to the root of the tree
+func (m *Manifest) Save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFileName), data, 0644)
+}
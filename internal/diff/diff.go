@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Document is one side of a diff
+type Document struct {
+	Name    string // label shown in headers, e.g. "server" or a file path
+	Content string
+	Format  string // data ID or file name whose extension selects the format of structured diffs (default: Name)
+}
+
+// formatName returns the name whose extension gives the format of the document
+func (d Document) formatName() string {
+	if d.Format != "" {
+		return d.Format
+	}
+	return d.Name
+}
+
+// Options controls diff rendering
+type Options struct {
+	Context int  // lines of context around line changes
+	Color   bool // use ANSI colors
+}
+
+// Engine computes a printable diff between two versions of a configuration.
+// It returns an empty string when there are no differences.
+type Engine interface {
+	Diff(oldDoc, newDoc Document, opts Options) (string, error)
+}
+
+var engines = map[string]Engine{
+	"line":     lineEngine{},
+	"word":     wordEngine{},
+	"semantic": semanticEngine{},
+}
+
+// Register adds or replaces a diff engine under the given mode name
+func Register(mode string, engine Engine) {
+	engines[mode] = engine
+}
+
+// Get returns the engine for a mode
+func Get(mode string) (Engine, error) {
+	engine, ok := engines[mode]
+	if !ok {
+		return nil, fmt.Errorf("unknown diff mode: %s (available: %s)", mode, strings.Join(Modes(), ", "))
+	}
+	return engine, nil
+}
+
+// Modes returns the sorted names of all registered engines
+func Modes() []string {
+	modes := make([]string, 0, len(engines))
+	for mode := range engines {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	return modes
+}
+
+// Diff is a shortcut for Get(mode) followed by Engine.Diff
+func Diff(mode string, oldDoc, newDoc Document, opts Options) (string, error) {
+	engine, err := Get(mode)
+	if err != nil {
+		return "", err
+	}
+	return engine.Diff(oldDoc, newDoc, opts)
+}
+
+const (
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+	colorReset = "\033[0m"
+)
+
+func colorize(text, color string, enabled bool) string {
+	if !enabled {
+		return text
+	}
+	return color + text + colorReset
+}
@@ -0,0 +1,108 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lineEngine renders a unified line diff
+type lineEngine struct{}
+
+func (lineEngine) Diff(oldDoc, newDoc Document, opts Options) (string, error) {
+	a := splitLines(oldDoc.Content)
+	b := splitLines(newDoc.Content)
+	ops := computeOps(a, b)
+
+	context := opts.Context
+	if context <= 0 {
+		context = 3
+	}
+	hunks := buildHunks(ops, context)
+	if len(hunks) == 0 {
+		return "", nil
+	}
+
+	var out strings.Builder
+	out.WriteString(colorize("--- "+oldDoc.Name, colorRed, opts.Color) + "\n")
+	out.WriteString(colorize("+++ "+newDoc.Name, colorGreen, opts.Color) + "\n")
+	for _, h := range hunks {
+		aStart, aLen, bStart, bLen := hunkRange(ops[h.start:h.end])
+		header := fmt.Sprintf("@@ -%d,%d +%d,%d @@", aStart, aLen, bStart, bLen)
+		out.WriteString(colorize(header, colorCyan, opts.Color) + "\n")
+		for _, o := range ops[h.start:h.end] {
+			switch o.kind {
+			case opEqual:
+				out.WriteString(" " + a[o.a] + "\n")
+			case opDelete:
+				out.WriteString(colorize("-"+a[o.a], colorRed, opts.Color) + "\n")
+			case opInsert:
+				out.WriteString(colorize("+"+b[o.b], colorGreen, opts.Color) + "\n")
+			}
+		}
+	}
+	return out.String(), nil
+}
+
+// hunk is a range of ops [start, end) rendered together
+type hunk struct {
+	start, end int
+}
+
+// buildHunks groups changed ops with surrounding context, merging overlapping ranges
+func buildHunks(ops []op, context int) []hunk {
+	var hunks []hunk
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i + context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+		if len(hunks) > 0 && start <= hunks[len(hunks)-1].end {
+			hunks[len(hunks)-1].end = end
+		} else {
+			hunks = append(hunks, hunk{start: start, end: end})
+		}
+	}
+	return hunks
+}
+
+// hunkRange computes the 1-based unified diff ranges of a hunk
+func hunkRange(ops []op) (aStart, aLen, bStart, bLen int) {
+	aStart, bStart = -1, -1
+	for _, o := range ops {
+		if o.kind != opInsert {
+			if aStart < 0 {
+				aStart = o.a
+			}
+			aLen++
+		}
+		if o.kind != opDelete {
+			if bStart < 0 {
+				bStart = o.b
+			}
+			bLen++
+		}
+	}
+	// An empty side points at the line before the change, as in GNU diff
+	if aStart < 0 {
+		aStart = ops[0].a - 1
+	}
+	if bStart < 0 {
+		bStart = ops[0].b - 1
+	}
+	return aStart + 1, aLen, bStart + 1, bLen
+}
+
+// splitLines splits content into lines without a trailing empty element
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
@@ -0,0 +1,147 @@
+package diff
+
+import "strings"
+
+// MergeResult is the outcome of a three-way merge
+type MergeResult struct {
+	Content   string // merged content, with conflict markers when Conflicts > 0
+	Conflicts int
+}
+
+// Conflict resolution preferences for Merge3
+const (
+	PreferNone   = ""       // keep conflict markers
+	PreferLocal  = "local"  // resolve conflicting hunks with the local version
+	PreferRemote = "remote" // resolve conflicting hunks with the remote version
+)
+
+// change replaces base lines [start, end) with lines
+type change struct {
+	start, end int
+	lines      []string
+}
+
+// Merge3 merges the local and remote edits of a common base line by line, like diff3.
+// Hunks changed on one side only are taken from that side, identical changes are
+// taken once, and overlapping different changes are conflicts resolved per prefer.
+func Merge3(base, local, remote, prefer string) MergeResult {
+	baseLines := splitLines(base)
+	localLines := splitLines(local)
+	remoteLines := splitLines(remote)
+
+	localChanges := collectChanges(computeOps(baseLines, localLines), localLines)
+	remoteChanges := collectChanges(computeOps(baseLines, remoteLines), remoteLines)
+
+	var out []string
+	var result MergeResult
+	pos, li, ri := 0, 0, 0
+
+	for li < len(localChanges) || ri < len(remoteChanges) {
+		// Start a cluster at the earliest pending change
+		start := -1
+		if li < len(localChanges) {
+			start = localChanges[li].start
+		}
+		if ri < len(remoteChanges) && (start < 0 || remoteChanges[ri].start < start) {
+			start = remoteChanges[ri].start
+		}
+		end := start
+
+		// Grow the cluster while changes from either side touch it
+		var localCluster, remoteCluster []change
+		for {
+			grown := false
+			if li < len(localChanges) && localChanges[li].start <= end {
+				localCluster = append(localCluster, localChanges[li])
+				if localChanges[li].end > end {
+					end = localChanges[li].end
+				}
+				li++
+				grown = true
+			}
+			if ri < len(remoteChanges) && remoteChanges[ri].start <= end {
+				remoteCluster = append(remoteCluster, remoteChanges[ri])
+				if remoteChanges[ri].end > end {
+					end = remoteChanges[ri].end
+				}
+				ri++
+				grown = true
+			}
+			if !grown {
+				break
+			}
+		}
+
+		out = append(out, baseLines[pos:start]...)
+		localText := applyChanges(baseLines, start, end, localCluster)
+		remoteText := applyChanges(baseLines, start, end, remoteCluster)
+
+		switch {
+		case len(remoteCluster) == 0:
+			out = append(out, localText...)
+		case len(localCluster) == 0:
+			out = append(out, remoteText...)
+		case strings.Join(localText, "\n") == strings.Join(remoteText, "\n"):
+			out = append(out, localText...)
+		case prefer == PreferLocal:
+			out = append(out, localText...)
+		case prefer == PreferRemote:
+			out = append(out, remoteText...)
+		default:
+			result.Conflicts++
+			out = append(out, "<<<<<<< local")
+			out = append(out, localText...)
+			out = append(out, "||||||| base")
+			out = append(out, baseLines[start:end]...)
+			out = append(out, "=======")
+			out = append(out, remoteText...)
+			out = append(out, ">>>>>>> remote")
+		}
+		pos = end
+	}
+	out = append(out, baseLines[pos:]...)
+
+	if len(out) > 0 {
+		result.Content = strings.Join(out, "\n") + "\n"
+	}
+	return result
+}
+
+// collectChanges groups consecutive non-equal ops into base range replacements
+func collectChanges(ops []op, side []string) []change {
+	var changes []change
+	var current *change
+	for _, o := range ops {
+		if o.kind == opEqual {
+			if current != nil {
+				changes = append(changes, *current)
+				current = nil
+			}
+			continue
+		}
+		if current == nil {
+			current = &change{start: o.a, end: o.a}
+		}
+		if o.kind == opDelete {
+			current.end = o.a + 1
+		} else {
+			current.lines = append(current.lines, side[o.b])
+		}
+	}
+	if current != nil {
+		changes = append(changes, *current)
+	}
+	return changes
+}
+
+// applyChanges returns base[start:end] with one side's changes applied
+func applyChanges(base []string, start, end int, changes []change) []string {
+	var result []string
+	pos := start
+	for _, c := range changes {
+		result = append(result, base[pos:c.start]...)
+		result = append(result, c.lines...)
+		pos = c.end
+	}
+	return append(result, base[pos:end]...)
+}
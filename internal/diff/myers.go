@@ -0,0 +1,124 @@
+package diff
+
+// opKind is the kind of an edit operation
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// op is a single edit: a token kept, deleted from a, or inserted from b
+type op struct {
+	kind opKind
+	a    int // index in a (equal/delete)
+	b    int // index in b (equal/insert)
+}
+
+// computeOps returns the shortest edit script turning a into b (Myers' O(ND) algorithm)
+func computeOps(a, b []string) []op {
+	// Common prefix and suffix are trimmed first, they are usually most of a config
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []op
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, op{kind: opEqual, a: i, b: i})
+	}
+	for _, o := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		o.a += prefix
+		o.b += prefix
+		ops = append(ops, o)
+	}
+	for i := 0; i < suffix; i++ {
+		ops = append(ops, op{kind: opEqual, a: len(a) - suffix + i, b: len(b) - suffix + i})
+	}
+	return ops
+}
+
+func myers(a, b []string) []op {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace[d] holds v[-(d+1)..d+1] as it was before step d
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		window := make([]int, 2*d+3)
+		copy(window, v[offset-d-1:offset+d+2])
+		trace = append(trace, window)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, d, n, m)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(trace [][]int, last, n, m int) []op {
+	var reversed []op
+	x, y := n, m
+	for d := last; d > 0; d-- {
+		window := trace[d]
+		at := func(k int) int { return window[k+d+1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, op{kind: opEqual, a: x, b: y})
+		}
+		if x == prevX {
+			y--
+			reversed = append(reversed, op{kind: opInsert, a: x, b: y})
+		} else {
+			x--
+			reversed = append(reversed, op{kind: opDelete, a: x, b: y})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		reversed = append(reversed, op{kind: opEqual, a: x, b: y})
+	}
+
+	ops := make([]op, len(reversed))
+	for i := range reversed {
+		ops[i] = reversed[len(reversed)-1-i]
+	}
+	return ops
+}
@@ -0,0 +1,140 @@
+package diff
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// semanticEngine compares YAML/JSON/properties documents structurally:
+// key order, formatting and comments are ignored.
+type semanticEngine struct{}
+
+func (semanticEngine) Diff(oldDoc, newDoc Document, opts Options) (string, error) {
+	oldValues, err := ParseStructured(oldDoc.formatName(), oldDoc.Content)
+	if err != nil {
+		return "", fmt.Errorf("semantic diff of %s: %w", oldDoc.Name, err)
+	}
+	newValues, err := ParseStructured(newDoc.formatName(), newDoc.Content)
+	if err != nil {
+		return "", fmt.Errorf("semantic diff of %s: %w", newDoc.Name, err)
+	}
+
+	keys := make(map[string]bool)
+	for key := range oldValues {
+		keys[key] = true
+	}
+	for key := range newValues {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var out strings.Builder
+	for _, key := range sorted {
+		oldValue, inOld := oldValues[key]
+		newValue, inNew := newValues[key]
+		switch {
+		case inOld && !inNew:
+			out.WriteString(colorize(fmt.Sprintf("- %s: %s", key, oldValue), colorRed, opts.Color) + "\n")
+		case !inOld && inNew:
+			out.WriteString(colorize(fmt.Sprintf("+ %s: %s", key, newValue), colorGreen, opts.Color) + "\n")
+		case oldValue != newValue:
+			out.WriteString(colorize(fmt.Sprintf("~ %s: %s -> %s", key, oldValue, newValue), colorCyan, opts.Color) + "\n")
+		}
+	}
+	return out.String(), nil
+}
+
+// ParseStructured parses YAML, JSON or properties content into a flat map of
+// dotted key paths to JSON-encoded leaf values. The format is chosen from the
+// name's extension, otherwise JSON and then YAML are tried.
+func ParseStructured(name, content string) (map[string]string, error) {
+	result := make(map[string]string)
+	if strings.TrimSpace(content) == "" {
+		return result, nil
+	}
+
+	var doc interface{}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".properties":
+		scanner := bufio.NewScanner(strings.NewReader(content))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+				continue
+			}
+			idx := strings.IndexAny(line, "=:")
+			if idx <= 0 {
+				continue
+			}
+			value, _ := json.Marshal(strings.TrimSpace(line[idx+1:]))
+			result[strings.TrimSpace(line[:idx])] = string(value)
+		}
+		return result, nil
+	case ".json":
+		if err := json.Unmarshal([]byte(content), &doc); err != nil {
+			return nil, fmt.Errorf("parse JSON: %w", err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+			return nil, fmt.Errorf("parse YAML: %w", err)
+		}
+	default:
+		if err := json.Unmarshal([]byte(content), &doc); err != nil {
+			if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+				return nil, fmt.Errorf("content is neither JSON nor YAML: %w", err)
+			}
+		}
+		if _, ok := doc.(string); ok {
+			return nil, fmt.Errorf("content is plain text, not a structured document")
+		}
+	}
+	flattenPaths("", doc, result)
+	return result, nil
+}
+
+// flattenPaths collects leaf values of a decoded document keyed by path
+func flattenPaths(path string, value interface{}, result map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && path != "" {
+			result[path] = "{}"
+		}
+		for key, child := range v {
+			flattenPaths(joinPath(path, key), child, result)
+		}
+	case map[interface{}]interface{}:
+		for key, child := range v {
+			flattenPaths(joinPath(path, fmt.Sprintf("%v", key)), child, result)
+		}
+	case []interface{}:
+		if len(v) == 0 && path != "" {
+			result[path] = "[]"
+		}
+		for i, child := range v {
+			flattenPaths(fmt.Sprintf("%s[%d]", path, i), child, result)
+		}
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			encoded = []byte(fmt.Sprintf("%q", fmt.Sprintf("%v", v)))
+		}
+		result[path] = string(encoded)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var wordPattern = regexp.MustCompile(`\n|[ \t]+|[A-Za-z0-9_]+|[^A-Za-z0-9_\s]`)
+
+// wordEngine renders changed lines with inline word changes, like git diff --word-diff.
+// Removed words are shown as [-old-] and added words as {+new+}.
+type wordEngine struct{}
+
+func (wordEngine) Diff(oldDoc, newDoc Document, opts Options) (string, error) {
+	a := wordPattern.FindAllString(oldDoc.Content, -1)
+	b := wordPattern.FindAllString(newDoc.Content, -1)
+	ops := computeOps(a, b)
+
+	// Render tokens into lines of the new document, remembering which lines changed.
+	// Consecutive deleted or inserted tokens are grouped into one marker.
+	var lines []string
+	var changed []bool
+	var current strings.Builder
+	lineChanged := false
+	runKind := opEqual
+	var run strings.Builder
+
+	closeRun := func() {
+		switch runKind {
+		case opDelete:
+			current.WriteString(colorize("[-"+run.String()+"-]", colorRed, opts.Color))
+		case opInsert:
+			current.WriteString(colorize("{+"+run.String()+"+}", colorGreen, opts.Color))
+		}
+		runKind = opEqual
+		run.Reset()
+	}
+	flush := func() {
+		closeRun()
+		lines = append(lines, current.String())
+		changed = append(changed, lineChanged)
+		current.Reset()
+		lineChanged = false
+	}
+
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			closeRun()
+			if a[o.a] == "\n" {
+				flush()
+				continue
+			}
+			current.WriteString(a[o.a])
+		case opDelete, opInsert:
+			if runKind != o.kind {
+				closeRun()
+				runKind = o.kind
+			}
+			lineChanged = true
+			token := ""
+			if o.kind == opDelete {
+				token = a[o.a]
+			} else {
+				token = b[o.b]
+			}
+			if token != "\n" {
+				run.WriteString(token)
+				continue
+			}
+			run.WriteString("↵")
+			if o.kind == opInsert {
+				// Inserted line breaks start a new line of the new document
+				flush()
+			}
+		}
+	}
+	if current.Len() > 0 || run.Len() > 0 || lineChanged {
+		flush()
+	}
+
+	var out strings.Builder
+	for i, line := range lines {
+		if changed[i] {
+			out.WriteString(colorize(fmt.Sprintf("%5d:", i+1), colorCyan, opts.Color) + " " + line + "\n")
+		}
+	}
+	if out.Len() == 0 {
+		return "", nil
+	}
+	header := colorize("--- "+oldDoc.Name, colorRed, opts.Color) + "\n" +
+		colorize("+++ "+newDoc.Name, colorGreen, opts.Color) + "\n"
+	return header + out.String(), nil
+}
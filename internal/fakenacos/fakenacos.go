@@ -0,0 +1,395 @@
+// Package fakenacos is an in-memory Nacos server for end-to-end tests of the
+// CLI without a cluster. It implements the auth (login, users, roles and
+// permissions), config, config history, capacity, listener, namespace and naming
+// (services and instances) endpoints of the v1 and v3 APIs that the client
+// and the config listener use, on an httptest server. The gRPC port (the HTTP
+// port + 1000) answers the connection setup and the config fuzzy watch.
+//
+//	srv := fakenacos.New()
+//	defer srv.Close()
+//	srv.SetConfig("public", "DEFAULT_GROUP", "app.yaml", "port: 8080")
+//	c := client.NewClient(srv.Addr())
+package fakenacos
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/rpc"
+	"google.golang.org/grpc"
+)
+
+// Config is a configuration held by the server
+type Config struct {
+	Namespace  string
+	Group      string
+	DataID     string
+	Content    string
+	MD5        string
+	Type       string
+	Tags       string // comma separated
+	Desc       string
+	AppName    string
+	CreateTime int64 // milliseconds since epoch
+	ModifyTime int64
+}
+
+// History is a revision of a configuration
+type History struct {
+	ID         int64
+	Namespace  string
+	Group      string
+	DataID     string
+	Content    string
+	MD5        string
+	OpType     string // I, U or D
+	Desc       string
+	SrcUser    string
+	SrcIP      string
+	CreateTime int64
+}
+
+// Namespace is a namespace held by the server; public always exists
+type Namespace struct {
+	ID   string
+	Name string
+	Desc string
+}
+
+// RoleBinding binds a role to a user
+type RoleBinding struct {
+	Role     string
+	Username string
+}
+
+// Permission grants a role an action on a resource; permissions are recorded
+// but not enforced
+type Permission struct {
+	Role     string
+	Resource string
+	Action   string
+}
+
+// Server is a fake Nacos server. Its fields are set before the first request.
+type Server struct {
+	*httptest.Server
+
+	Username     string        // login user (default: nacos)
+	Password     string        // login password (default: nacos)
+	AuthEnabled  bool          // reject requests without a valid access token with 403
+	TokenTTL     time.Duration // lifetime of issued tokens (default: 5h)
+	V1Login      bool          // answer the v3 login with 404, as Nacos 2 does, so clients fall back to v1
+	NoFuzzyWatch bool          // answer the gRPC fuzzy watch with NO_HANDLER, as Nacos 2 does
+
+	mu         sync.Mutex
+	configs    map[configKey]*Config
+	listeners  map[configKey]map[string]string // client IP to the MD5 it listens with
+	history    []History
+	namespaces []Namespace
+	users      map[string]string // created users and their passwords
+	roles      []RoleBinding
+	perms      []Permission
+	capacities map[capacityKey]map[string]int // limits set per namespace or group
+	services   map[serviceKey]*Service
+	tokens     map[string]time.Time
+	requests   []string
+	changed    chan struct{} // closed and replaced on every change, wakes long polls
+	now        func() time.Time
+
+	grpcServer   *grpc.Server
+	grpcListener net.Listener
+	rpcConns     map[string]*rpcConn // by client address
+	pushID       int
+}
+
+type configKey struct {
+	namespace, group, dataID string
+}
+
+type capacityKey struct {
+	namespace, group string
+}
+
+// New starts a server on a random local port whose port + 1000 is free for gRPC
+func New() *Server {
+	for {
+		l, grpcListener, err := listenPair("127.0.0.1:0")
+		if err == nil {
+			return start(l, grpcListener)
+		}
+	}
+}
+
+// NewAt starts a server on the address, e.g. 127.0.0.1:8848, and gRPC on its port + 1000
+func NewAt(addr string) (*Server, error) {
+	l, grpcListener, err := listenPair(addr)
+	if err != nil {
+		return nil, err
+	}
+	return start(l, grpcListener), nil
+}
+
+// listenPair listens on addr and on the port 1000 above it
+func listenPair(addr string) (net.Listener, net.Listener, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	host, portStr, _ := net.SplitHostPort(l.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	grpcListener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port+1000)))
+	if err != nil {
+		l.Close()
+		return nil, nil, fmt.Errorf("listen for gRPC: %w", err)
+	}
+	return l, grpcListener, nil
+}
+
+func start(l, grpcListener net.Listener) *Server {
+	s := newServer()
+	s.Server = &httptest.Server{
+		Listener: l,
+		Config:   &http.Server{Handler: http.HandlerFunc(s.serveHTTP)},
+	}
+	s.Server.Start()
+	s.startGRPC(grpcListener)
+	return s
+}
+
+// Close stops the HTTP and gRPC servers
+func (s *Server) Close() {
+	s.grpcServer.Stop()
+	s.Server.Close()
+}
+
+func newServer() *Server {
+	return &Server{
+		Username:   "nacos",
+		Password:   "nacos",
+		TokenTTL:   5 * time.Hour,
+		configs:    make(map[configKey]*Config),
+		listeners:  make(map[configKey]map[string]string),
+		tokens:     make(map[string]time.Time),
+		users:      make(map[string]string),
+		capacities: make(map[capacityKey]map[string]int),
+		services:   make(map[serviceKey]*Service),
+		rpcConns:   make(map[string]*rpcConn),
+		changed:    make(chan struct{}),
+		now:        time.Now,
+	}
+}
+
+// Addr returns the host:port to pass to the client
+func (s *Server) Addr() string {
+	return s.Listener.Addr().String()
+}
+
+// GRPCAddr returns the host:port of the gRPC server
+func (s *Server) GRPCAddr() string {
+	return s.grpcListener.Addr().String()
+}
+
+// SetConfig publishes a configuration as a user of the console would
+func (s *Server) SetConfig(namespace, group, dataID, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publish(&Config{Namespace: namespace, Group: group, DataID: dataID, Content: content}, "fakenacos", "127.0.0.1")
+}
+
+// GetConfig returns a configuration
+func (s *Server) GetConfig(namespace, group, dataID string) (Config, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.configs[configKey{normalize(namespace), group, dataID}]
+	if !ok {
+		return Config{}, false
+	}
+	return *c, true
+}
+
+// DeleteConfig deletes a configuration
+func (s *Server) DeleteConfig(namespace, group, dataID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(namespace, group, dataID, "fakenacos", "127.0.0.1")
+}
+
+// Configs returns the configurations of a namespace sorted by group and data ID
+func (s *Server) Configs(namespace string) []Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var configs []Config
+	for key, c := range s.configs {
+		if key.namespace == normalize(namespace) {
+			configs = append(configs, *c)
+		}
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].Group != configs[j].Group {
+			return configs[i].Group < configs[j].Group
+		}
+		return configs[i].DataID < configs[j].DataID
+	})
+	return configs
+}
+
+// History returns the revisions of a configuration, newest first
+func (s *Server) History(namespace, group, dataID string) []History {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.revisions(normalize(namespace), group, dataID)
+}
+
+// AddNamespace creates a namespace
+func (s *Server) AddNamespace(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.namespaceIndex(id) < 0 {
+		s.namespaces = append(s.namespaces, Namespace{ID: id, Name: name})
+	}
+}
+
+// AddListener registers a client listening to a configuration with the MD5 of
+// the content it has, as a long poll of the client would
+func (s *Server) AddListener(namespace, group, dataID, ip, md5 string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addListener(configKey{normalize(namespace), group, dataID}, ip, md5)
+}
+
+// Requests returns the requests served so far as "METHOD /path"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// publish stores a configuration and records its history; s.mu is held
+func (s *Server) publish(c *Config, user, ip string) {
+	c.Namespace = normalize(c.Namespace)
+	key := configKey{c.Namespace, c.Group, c.DataID}
+	now := s.now().UnixMilli()
+	op := "I"
+	c.CreateTime = now
+	if old, ok := s.configs[key]; ok {
+		op = "U"
+		c.CreateTime = old.CreateTime
+		if c.Type == "" {
+			c.Type = old.Type
+		}
+	}
+	if c.Type == "" {
+		c.Type = "text"
+	}
+	c.ModifyTime = now
+	c.MD5 = md5Hex(c.Content)
+	s.configs[key] = c
+	s.record(c, op, user, ip)
+}
+
+// remove deletes a configuration and records its history; s.mu is held
+func (s *Server) remove(namespace, group, dataID, user, ip string) {
+	key := configKey{normalize(namespace), group, dataID}
+	c, ok := s.configs[key]
+	if !ok {
+		return
+	}
+	delete(s.configs, key)
+	s.record(c, "D", user, ip)
+}
+
+func (s *Server) record(c *Config, op, user, ip string) {
+	s.history = append(s.history, History{
+		ID:         int64(len(s.history) + 1),
+		Namespace:  c.Namespace,
+		Group:      c.Group,
+		DataID:     c.DataID,
+		Content:    c.Content,
+		MD5:        c.MD5,
+		OpType:     op,
+		Desc:       c.Desc,
+		SrcUser:    user,
+		SrcIP:      ip,
+		CreateTime: c.ModifyTime,
+	})
+	close(s.changed)
+	s.changed = make(chan struct{})
+	switch op {
+	case "I":
+		s.notifyFuzzyWatch(configKey{c.Namespace, c.Group, c.DataID}, rpc.ConfigAdded)
+	case "D":
+		s.notifyFuzzyWatch(configKey{c.Namespace, c.Group, c.DataID}, rpc.ConfigDeleted)
+	}
+}
+
+func (s *Server) revisions(namespace, group, dataID string) []History {
+	var revisions []History
+	for i := len(s.history) - 1; i >= 0; i-- {
+		h := s.history[i]
+		if h.Namespace == namespace && h.Group == group && h.DataID == dataID {
+			revisions = append(revisions, h)
+		}
+	}
+	return revisions
+}
+
+func (s *Server) namespaceIndex(id string) int {
+	for i, ns := range s.namespaces {
+		if ns.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// addListener registers a listening client; s.mu is held
+func (s *Server) addListener(key configKey, ip, md5 string) {
+	if s.listeners[key] == nil {
+		s.listeners[key] = make(map[string]string)
+	}
+	s.listeners[key][ip] = md5
+}
+
+// issueToken creates an access token; s.mu is held
+func (s *Server) issueToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	token := hex.EncodeToString(b)
+	s.tokens[token] = s.now().Add(s.TokenTTL)
+	return token
+}
+
+// authorized reports whether the request carries a valid token; s.mu is held
+func (s *Server) authorized(r *http.Request) bool {
+	if !s.AuthEnabled {
+		return true
+	}
+	token := r.URL.Query().Get("accessToken")
+	if auth := r.Header.Get("Authorization"); len(auth) > 7 && auth[:7] == "Bearer " {
+		token = auth[7:]
+	}
+	expireAt, ok := s.tokens[token]
+	return ok && s.now().Before(expireAt)
+}
+
+// normalize maps the empty namespace ID of the v1 API to public
+func normalize(namespace string) string {
+	if namespace == "" {
+		return "public"
+	}
+	return namespace
+}
+
+func md5Hex(content string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
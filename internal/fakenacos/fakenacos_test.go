@@ -0,0 +1,142 @@
+package fakenacos_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/fakenacos"
+)
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		v1Login  bool
+		password string
+		wantErr  bool
+		wantPath string
+	}{
+		{name: "v3", password: "nacos", wantPath: "POST /nacos/v3/auth/user/login"},
+		{name: "v1 fallback", v1Login: true, password: "nacos", wantPath: "POST /nacos/v1/auth/login"},
+		{name: "wrong password", password: "wrong", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakenacos.New()
+			defer srv.Close()
+			srv.AuthEnabled = true
+			srv.V1Login = tt.v1Login
+			srv.SetConfig("public", "DEFAULT_GROUP", "app.yaml", "port: 8080")
+
+			c := client.NewClient(srv.Addr(), client.WithAuth("nacos", tt.password))
+			content, err := c.GetConfig("app.yaml", "DEFAULT_GROUP")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("GetConfig succeeded with a wrong password")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetConfig: %v", err)
+			}
+			if content != "port: 8080" {
+				t.Errorf("content = %q, want %q", content, "port: 8080")
+			}
+			if !slices.Contains(srv.Requests(), tt.wantPath) {
+				t.Errorf("requests %v do not contain %s", srv.Requests(), tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestAuthRequiresToken(t *testing.T) {
+	srv := fakenacos.New()
+	defer srv.Close()
+	srv.AuthEnabled = true
+
+	query := url.Values{"dataId": {"app.yaml"}, "group": {"DEFAULT_GROUP"}}
+	resp, err := http.Get("http://" + srv.Addr() + "/nacos/v1/cs/configs?" + query.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestConfigLifecycle(t *testing.T) {
+	srv := fakenacos.New()
+	defer srv.Close()
+	c := client.NewClient(srv.Addr())
+
+	if err := c.PublishConfig("app.yaml", "DEFAULT_GROUP", "port: 8080"); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if err := c.PublishConfig("app.yaml", "DEFAULT_GROUP", "port: 9090"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := c.PublishConfig("db.properties", "DB_GROUP", "url=jdbc:mysql://db"); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	got, ok := srv.GetConfig("", "DEFAULT_GROUP", "app.yaml")
+	if !ok || got.Content != "port: 9090" || got.Type != "text" {
+		t.Errorf("GetConfig = %+v, %v", got, ok)
+	}
+	items, err := c.ListAllConfigs("*", "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("listed %d configs, want 2", len(items))
+	}
+
+	if err := c.DeleteConfig("app.yaml", "DEFAULT_GROUP"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := c.GetConfig("app.yaml", "DEFAULT_GROUP"); !errors.Is(err, client.ErrConfigNotFound) {
+		t.Errorf("GetConfig after delete: err = %v, want ErrConfigNotFound", err)
+	}
+	if configs := srv.Configs("public"); len(configs) != 1 || configs[0].DataID != "db.properties" {
+		t.Errorf("Configs = %+v, want only db.properties", configs)
+	}
+
+	var ops []string
+	for _, h := range srv.History("public", "DEFAULT_GROUP", "app.yaml") {
+		ops = append(ops, h.OpType)
+	}
+	if !slices.Equal(ops, []string{"D", "U", "I"}) {
+		t.Errorf("history operations = %v, want [D U I]", ops)
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	srv := fakenacos.New()
+	defer srv.Close()
+	srv.AddNamespace("team-a", "Team A")
+	srv.SetConfig("team-a", "DEFAULT_GROUP", "app.yaml", "port: 8080")
+
+	c := client.NewClient(srv.Addr(), client.InNamespace("team-a"))
+	content, err := c.GetConfig("app.yaml", "DEFAULT_GROUP")
+	if err != nil || content != "port: 8080" {
+		t.Errorf("GetConfig in team-a = %q, %v", content, err)
+	}
+	if configs := srv.Configs("public"); len(configs) != 0 {
+		t.Errorf("public holds %d configs, want 0", len(configs))
+	}
+	namespaces, err := c.ListNamespaces()
+	if err != nil {
+		t.Fatalf("list namespaces: %v", err)
+	}
+	var ids []string
+	for _, ns := range namespaces {
+		ids = append(ids, ns.Namespace)
+	}
+	if !slices.Contains(ids, "public") || !slices.Contains(ids, "team-a") {
+		t.Errorf("namespaces = %v, want public and team-a", ids)
+	}
+}
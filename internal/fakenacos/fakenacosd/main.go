@@ -0,0 +1,36 @@
+// Command fakenacosd runs the fake Nacos server standalone, for trying the
+// CLI without a cluster:
+//
+//	go run ./internal/fakenacos/fakenacosd -addr 127.0.0.1:8848
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/nov11/nacos-cli/internal/fakenacos"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8848", "Address to listen on")
+	auth := flag.Bool("auth", false, "Require an access token (login nacos/nacos)")
+	v1Login := flag.Bool("v1-login", false, "Only accept the v1 login, like Nacos 2")
+	noFuzzyWatch := flag.Bool("no-fuzzy-watch", false, "Reject gRPC fuzzy watches, like Nacos 2")
+	flag.Parse()
+
+	srv, err := fakenacos.NewAt(*addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv.AuthEnabled = *auth
+	srv.V1Login = *v1Login
+	srv.NoFuzzyWatch = *noFuzzyWatch
+	log.Printf("fake Nacos server listening on %s (gRPC %s)", srv.Addr(), srv.GRPCAddr())
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	<-stop
+	srv.Close()
+}
@@ -0,0 +1,245 @@
+package fakenacos
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"path"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// rpcConn is a gRPC connection set up by a client; s.mu guards its patterns
+type rpcConn struct {
+	push     chan *rpc.Payload
+	patterns map[string]bool // fuzzy-watched group key patterns
+}
+
+var requestService = grpc.ServiceDesc{
+	ServiceName: "Request",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "request",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(rpc.Payload)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			return srv.(*Server).rpcRequest(ctx, in), nil
+		},
+	}},
+}
+
+var biStreamService = grpc.ServiceDesc{
+	ServiceName: "BiRequestStream",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "requestBiStream",
+		ServerStreams: true,
+		ClientStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			return srv.(*Server).rpcStream(stream)
+		},
+	}},
+}
+
+// startGRPC serves the gRPC protocol on the listener
+func (s *Server) startGRPC(l net.Listener) {
+	s.grpcListener = l
+	s.grpcServer = grpc.NewServer(grpc.ForceServerCodec(rpc.Codec{}))
+	s.grpcServer.RegisterService(&requestService, s)
+	s.grpcServer.RegisterService(&biStreamService, s)
+	go s.grpcServer.Serve(l)
+}
+
+// rpcRequest answers a unary request
+func (s *Server) rpcRequest(ctx context.Context, in *rpc.Payload) *rpc.Payload {
+	id := connectionID(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, "GRPC "+in.Type)
+
+	if in.Type == "ServerCheckRequest" {
+		return rpcResponse("ServerCheckResponse", map[string]interface{}{"resultCode": 200, "connectionId": id})
+	}
+	if s.AuthEnabled {
+		expireAt, ok := s.tokens[in.Headers["accessToken"]]
+		if !ok || !s.now().Before(expireAt) {
+			return rpcError(403, "user not found!")
+		}
+	}
+	conn := s.rpcConns[id]
+	if conn == nil {
+		return rpcError(rpc.CodeUnregistered, "Connection is unregistered.")
+	}
+
+	switch in.Type {
+	case "HealthCheckRequest":
+		return rpcResponse("HealthCheckResponse", map[string]interface{}{"resultCode": 200})
+	case "ConfigFuzzyWatchRequest":
+		if s.NoFuzzyWatch {
+			break
+		}
+		var req struct {
+			GroupKeyPattern   string   `json:"groupKeyPattern"`
+			ReceivedGroupKeys []string `json:"receivedGroupKeys"`
+			WatchType         string   `json:"watchType"`
+		}
+		json.Unmarshal(in.Body, &req)
+		if req.WatchType == "CANCEL_WATCH" {
+			delete(conn.patterns, req.GroupKeyPattern)
+		} else {
+			conn.patterns[req.GroupKeyPattern] = true
+			s.syncFuzzyWatch(conn, req.GroupKeyPattern, req.ReceivedGroupKeys)
+		}
+		return rpcResponse("ConfigFuzzyWatchResponse", map[string]interface{}{"resultCode": 200})
+	}
+	return rpcError(rpc.CodeNoHandler, "RequestHandler Not Found")
+}
+
+// syncFuzzyWatch pushes the differences between the configurations matching
+// a pattern and the ones the client has; s.mu is held
+func (s *Server) syncFuzzyWatch(conn *rpcConn, pattern string, received []string) {
+	has := make(map[string]bool)
+	for _, key := range received {
+		has[key] = true
+	}
+	type context struct {
+		GroupKey    string `json:"groupKey"`
+		ChangedType string `json:"changedType"`
+	}
+	var contexts []context
+	for key := range s.configs {
+		groupKey := rpc.GroupKey(key.dataID, key.group, key.namespace)
+		if matchFuzzy(pattern, key) && !has[groupKey] {
+			contexts = append(contexts, context{groupKey, rpc.ConfigAdded})
+		}
+		delete(has, groupKey)
+	}
+	for groupKey := range has {
+		contexts = append(contexts, context{groupKey, rpc.ConfigDeleted})
+	}
+	if len(contexts) > 0 {
+		s.pushRPC(conn, "ConfigFuzzyWatchSyncRequest", map[string]interface{}{
+			"syncType": "FUZZY_WATCH_INIT_NOTIFY", "groupKeyPattern": pattern, "contexts": contexts,
+			"totalBatch": 1, "currentBatch": 1,
+		})
+	}
+	s.pushRPC(conn, "ConfigFuzzyWatchSyncRequest", map[string]interface{}{
+		"syncType": "FINISH_FUZZY_WATCH_INIT_NOTIFY", "groupKeyPattern": pattern,
+	})
+}
+
+// notifyFuzzyWatch tells the clients fuzzy-watching a configuration that it
+// was added or deleted; s.mu is held
+func (s *Server) notifyFuzzyWatch(key configKey, changeType string) {
+	for _, conn := range s.rpcConns {
+		for pattern := range conn.patterns {
+			if matchFuzzy(pattern, key) {
+				s.pushRPC(conn, "ConfigFuzzyWatchChangeNotifyRequest", map[string]interface{}{
+					"groupKey": rpc.GroupKey(key.dataID, key.group, key.namespace), "changeType": changeType,
+				})
+				break
+			}
+		}
+	}
+}
+
+// pushRPC queues a request for the stream of a connection, dropping it when
+// the client does not keep up; s.mu is held
+func (s *Server) pushRPC(conn *rpcConn, typ string, body map[string]interface{}) {
+	s.pushID++
+	body["requestId"] = s.pushID
+	data, _ := json.Marshal(body)
+	select {
+	case conn.push <- &rpc.Payload{Type: typ, Body: data}:
+	default:
+	}
+}
+
+// rpcStream registers the connection set up on the stream and sends it the
+// pushed requests until the client goes away
+func (s *Server) rpcStream(stream grpc.ServerStream) error {
+	setup := new(rpc.Payload)
+	if err := stream.RecvMsg(setup); err != nil {
+		return err
+	}
+	if setup.Type != "ConnectionSetupRequest" {
+		return nil
+	}
+	id := connectionID(stream.Context())
+	conn := &rpcConn{push: make(chan *rpc.Payload, 256), patterns: make(map[string]bool)}
+	s.mu.Lock()
+	s.requests = append(s.requests, "GRPC "+setup.Type)
+	s.rpcConns[id] = conn
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.rpcConns, id)
+		s.mu.Unlock()
+	}()
+
+	// Responses to pushed requests are not checked
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if err := stream.RecvMsg(new(rpc.Payload)); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case p := <-conn.push:
+			if err := stream.SendMsg(p); err != nil {
+				return err
+			}
+		case <-closed:
+			return nil
+		}
+	}
+}
+
+// CloseConnections drops every gRPC connection, as a restarting server would
+func (s *Server) CloseConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, conn := range s.rpcConns {
+		select {
+		case conn.push <- &rpc.Payload{Type: "ConnectResetRequest", Body: []byte(`{}`)}:
+		default:
+		}
+		delete(s.rpcConns, id)
+	}
+}
+
+// matchFuzzy reports whether a configuration matches a pattern built by rpc.FuzzyPattern
+func matchFuzzy(pattern string, key configKey) bool {
+	parts := strings.SplitN(pattern, ">>", 3)
+	if len(parts) != 3 || parts[0] != key.namespace {
+		return false
+	}
+	group, _ := path.Match(parts[1], key.group)
+	dataID, _ := path.Match(parts[2], key.dataID)
+	return group && dataID
+}
+
+func connectionID(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func rpcResponse(typ string, body map[string]interface{}) *rpc.Payload {
+	data, _ := json.Marshal(body)
+	return &rpc.Payload{Type: typ, Body: data}
+}
+
+func rpcError(code int, message string) *rpc.Payload {
+	return rpcResponse("ErrorResponse", map[string]interface{}{"resultCode": 500, "errorCode": code, "message": message})
+}
@@ -0,0 +1,691 @@
+package fakenacos
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// v3 response codes used by Nacos
+const (
+	codeParameterMissing = 10000
+	codeConfigNotFound   = 20004
+	codeNamespaceExists  = 22001
+	codeResourceExists   = 22002
+	codeResourceNotFound = 20005
+)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	s.mu.Unlock()
+
+	route := r.Method + " " + r.URL.Path
+	switch route {
+	case "POST /nacos/v3/auth/user/login":
+		if s.V1Login {
+			http.Error(w, "no handler for "+route, http.StatusNotFound)
+			return
+		}
+		s.login(w, r)
+		return
+	case "POST /nacos/v1/auth/login":
+		s.login(w, r)
+		return
+	case "POST /nacos/v1/cs/configs/listener":
+		if !s.checkAuth(w, r) {
+			return
+		}
+		s.listen(w, r)
+		return
+	}
+
+	if !s.checkAuth(w, r) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch route {
+	case "GET /nacos/v1/cs/configs":
+		if r.Form.Get("search") != "" {
+			s.listV1(w, r)
+		} else {
+			s.getV1(w, r)
+		}
+	case "GET /nacos/v3/admin/cs/config/list":
+		s.listV3(w, r)
+	case "GET /nacos/v3/admin/cs/config":
+		s.getV3(w, r)
+	case "POST /nacos/v3/admin/cs/config":
+		s.publishV3(w, r)
+	case "DELETE /nacos/v3/admin/cs/config":
+		s.remove(r.Form.Get("namespaceId"), r.Form.Get("groupName"), r.Form.Get("dataId"), s.Username, clientIP(r))
+		writeV3(w, true)
+	case "GET /nacos/v1/cs/configs/listener":
+		s.listListeners(w, r, false)
+	case "GET /nacos/v3/admin/cs/config/listener":
+		s.listListeners(w, r, true)
+	case "GET /nacos/v3/admin/cs/history/list":
+		s.listHistory(w, r)
+	case "GET /nacos/v3/admin/cs/history":
+		s.getHistory(w, r)
+	case "GET /nacos/v3/admin/cs/capacity":
+		s.getCapacity(w, r)
+	case "POST /nacos/v3/admin/cs/capacity":
+		s.updateCapacity(w, r)
+	case "GET /nacos/v3/admin/core/namespace/list":
+		s.listNamespaces(w)
+	case "POST /nacos/v3/admin/core/namespace":
+		s.createNamespace(w, r)
+	case "PUT /nacos/v3/admin/core/namespace":
+		s.updateNamespace(w, r)
+	case "DELETE /nacos/v3/admin/core/namespace":
+		s.deleteNamespace(w, r)
+	case "GET /nacos/v3/auth/user/list":
+		s.listUsers(w, r)
+	case "POST /nacos/v3/auth/user":
+		s.createUser(w, r)
+	case "GET /nacos/v3/auth/role/list":
+		s.listRoles(w, r)
+	case "POST /nacos/v3/auth/role":
+		s.createRole(w, r)
+	case "GET /nacos/v3/auth/permission/list":
+		s.listPermissions(w, r)
+	case "POST /nacos/v3/auth/permission":
+		s.createPermission(w, r)
+	case "GET /nacos/v3/admin/ns/service/list":
+		s.listServicesV3(w, r)
+	case "GET /nacos/v1/ns/catalog/services":
+		s.listServicesV1(w, r)
+	case "GET /nacos/v3/admin/ns/service":
+		s.getServiceV3(w, r)
+	case "GET /nacos/v1/ns/service":
+		s.getServiceV1(w, r)
+	case "DELETE /nacos/v3/admin/ns/service":
+		s.deleteService(w, r, true)
+	case "DELETE /nacos/v1/ns/service":
+		s.deleteService(w, r, false)
+	case "POST /nacos/v3/admin/ns/service", "PUT /nacos/v3/admin/ns/service",
+		"POST /nacos/v1/ns/service", "PUT /nacos/v1/ns/service":
+		s.writeService(w, r)
+	case "PUT /nacos/v3/admin/ns/cluster", "PUT /nacos/v1/ns/cluster":
+		s.updateCluster(w, r)
+	case "GET /nacos/v3/admin/ns/instance/list":
+		s.listInstances(w, r, true)
+	case "GET /nacos/v1/ns/instance/list":
+		s.listInstances(w, r, false)
+	case "POST /nacos/v3/admin/ns/instance", "POST /nacos/v1/ns/instance":
+		s.registerInstance(w, r)
+	case "PUT /nacos/v3/admin/ns/instance", "PUT /nacos/v1/ns/instance":
+		s.updateInstance(w, r)
+	case "DELETE /nacos/v3/admin/ns/instance", "DELETE /nacos/v1/ns/instance":
+		s.deregisterInstance(w, r)
+	case "PUT /nacos/v1/ns/instance/beat":
+		s.beat(w, r)
+	case "PUT /nacos/v3/admin/ns/health/instance":
+		s.updateHealth(w, r)
+	default:
+		http.Error(w, "no handler for "+route, http.StatusNotFound)
+	}
+}
+
+func (s *Server) checkAuth(w http.ResponseWriter, r *http.Request) bool {
+	s.mu.Lock()
+	ok := s.authorized(r)
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "user not found!", http.StatusForbidden)
+	}
+	return ok
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	username, password := r.Form.Get("username"), r.Form.Get("password")
+	if created, ok := s.users[username]; !(username == s.Username && password == s.Password) && !(ok && password == created) {
+		http.Error(w, "unknown user!", http.StatusForbidden)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"accessToken": s.issueToken(),
+		"tokenTtl":    int64(s.TokenTTL / time.Second),
+		"globalAdmin": true,
+		"username":    username,
+	})
+}
+
+func (s *Server) getV1(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.configs[configKey{normalize(r.Form.Get("tenant")), r.Form.Get("group"), r.Form.Get("dataId")}]
+	if !ok {
+		http.Error(w, "config data not exist", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-MD5", c.MD5)
+	w.Header().Set("Config-Type", c.Type)
+	w.Header().Set("Content-Type", "text/plain;charset=UTF-8")
+	w.Write([]byte(c.Content))
+}
+
+func (s *Server) listV1(w http.ResponseWriter, r *http.Request) {
+	configs := s.search(normalize(r.Form.Get("tenant")), r.Form.Get("dataId"), r.Form.Get("group"),
+		r.Form.Get("config_detail"), r.Form.Get("config_tags"), r.Form.Get("search") == "blur")
+	writeJSON(w, page(configs, r, configItem))
+}
+
+func (s *Server) listV3(w http.ResponseWriter, r *http.Request) {
+	configs := s.search(normalize(r.Form.Get("namespaceId")), r.Form.Get("dataId"), r.Form.Get("groupName"),
+		r.Form.Get("configDetail"), r.Form.Get("configTags"), r.Form.Get("search") == "blur")
+	writeV3(w, page(configs, r, configItem))
+}
+
+// search filters the configurations of a namespace; blur matches * wildcards,
+// or substrings when the pattern has none. Configurations match comma separated
+// tags when they have one of them.
+func (s *Server) search(namespace, dataID, group, content, tags string, blur bool) []*Config {
+	var configs []*Config
+	for key, c := range s.configs {
+		if key.namespace != namespace {
+			continue
+		}
+		if blur {
+			if !matchBlur(dataID, c.DataID) || !matchBlur(group, c.Group) {
+				continue
+			}
+		} else if (dataID != "" && dataID != c.DataID) || (group != "" && group != c.Group) {
+			continue
+		}
+		if content != "" && !strings.Contains(c.Content, strings.Trim(content, "*")) {
+			continue
+		}
+		if tags != "" && !hasAnyTag(c.Tags, tags) {
+			continue
+		}
+		configs = append(configs, c)
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].Group != configs[j].Group {
+			return configs[i].Group < configs[j].Group
+		}
+		return configs[i].DataID < configs[j].DataID
+	})
+	return configs
+}
+
+func matchBlur(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+	if !strings.Contains(pattern, "*") {
+		return strings.Contains(name, pattern)
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+func (s *Server) getV3(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.configs[configKey{normalize(r.Form.Get("namespaceId")), r.Form.Get("groupName"), r.Form.Get("dataId")}]
+	if !ok {
+		writeV3Error(w, http.StatusOK, codeConfigNotFound, "config data not exist")
+		return
+	}
+	writeV3(w, configDetail(c))
+}
+
+func (s *Server) publishV3(w http.ResponseWriter, r *http.Request) {
+	dataID, group := r.Form.Get("dataId"), r.Form.Get("groupName")
+	if dataID == "" || group == "" || r.Form.Get("content") == "" {
+		writeV3Error(w, http.StatusBadRequest, codeParameterMissing, "dataId, groupName and content are required")
+		return
+	}
+	namespace := normalize(r.Form.Get("namespaceId"))
+	old := s.configs[configKey{namespace, group, dataID}]
+	if casMd5 := r.Form.Get("casMd5"); casMd5 != "" && (old == nil || old.MD5 != casMd5) {
+		writeV3Error(w, http.StatusConflict, http.StatusConflict, "cas publish fail, server md5 may have changed")
+		return
+	}
+	c := &Config{
+		Namespace: namespace,
+		Group:     group,
+		DataID:    dataID,
+		Content:   r.Form.Get("content"),
+		Type:      r.Form.Get("type"),
+		Tags:      r.Form.Get("configTags"),
+		Desc:      r.Form.Get("desc"),
+		AppName:   r.Form.Get("appName"),
+	}
+	if old != nil && c.Desc == "" {
+		c.Desc = old.Desc
+	}
+	s.publish(c, s.Username, clientIP(r))
+	writeV3(w, true)
+}
+
+func (s *Server) listHistory(w http.ResponseWriter, r *http.Request) {
+	revisions := s.revisions(normalize(r.Form.Get("namespaceId")), r.Form.Get("groupName"), r.Form.Get("dataId"))
+	items := make([]*History, len(revisions))
+	for i := range revisions {
+		items[i] = &revisions[i]
+	}
+	writeV3(w, page(items, r, func(h *History) map[string]interface{} {
+		item := historyItem(h)
+		delete(item, "content")
+		return item
+	}))
+}
+
+func (s *Server) getHistory(w http.ResponseWriter, r *http.Request) {
+	nid, _ := strconv.ParseInt(r.Form.Get("nid"), 10, 64)
+	if nid < 1 || nid > int64(len(s.history)) {
+		writeV3Error(w, http.StatusOK, codeResourceNotFound, "history not found")
+		return
+	}
+	writeV3(w, historyItem(&s.history[nid-1]))
+}
+
+func (s *Server) listNamespaces(w http.ResponseWriter) {
+	counts := make(map[string]int)
+	for key := range s.configs {
+		counts[key.namespace]++
+	}
+	items := []map[string]interface{}{namespaceItem(Namespace{ID: "public", Name: "public"}, 0, counts["public"])}
+	for _, ns := range s.namespaces {
+		items = append(items, namespaceItem(ns, 2, counts[ns.ID]))
+	}
+	writeV3(w, items)
+}
+
+func (s *Server) createNamespace(w http.ResponseWriter, r *http.Request) {
+	id := r.Form.Get("namespaceId")
+	if id == "" || r.Form.Get("namespaceName") == "" {
+		writeV3Error(w, http.StatusBadRequest, codeParameterMissing, "namespaceId and namespaceName are required")
+		return
+	}
+	if id == "public" || s.namespaceIndex(id) >= 0 {
+		writeV3Error(w, http.StatusOK, codeNamespaceExists, "namespace already exist")
+		return
+	}
+	s.namespaces = append(s.namespaces, Namespace{ID: id, Name: r.Form.Get("namespaceName"), Desc: r.Form.Get("namespaceDesc")})
+	writeV3(w, true)
+}
+
+func (s *Server) updateNamespace(w http.ResponseWriter, r *http.Request) {
+	i := s.namespaceIndex(r.Form.Get("namespaceId"))
+	if i < 0 {
+		writeV3Error(w, http.StatusOK, codeResourceNotFound, "namespace not exist")
+		return
+	}
+	if name := r.Form.Get("namespaceName"); name != "" {
+		s.namespaces[i].Name = name
+	}
+	s.namespaces[i].Desc = r.Form.Get("namespaceDesc")
+	writeV3(w, true)
+}
+
+func (s *Server) deleteNamespace(w http.ResponseWriter, r *http.Request) {
+	if i := s.namespaceIndex(r.Form.Get("namespaceId")); i >= 0 {
+		s.namespaces = append(s.namespaces[:i], s.namespaces[i+1:]...)
+	}
+	writeV3(w, true)
+}
+
+func (s *Server) getCapacity(w http.ResponseWriter, r *http.Request) {
+	key := capacityKey{namespace: r.Form.Get("namespaceId"), group: r.Form.Get("groupName")}
+	limits, ok := s.capacities[key]
+	if !ok {
+		writeV3(w, nil)
+		return
+	}
+	usage := 0
+	for k := range s.configs {
+		if k.namespace == key.namespace && (key.group == "" || k.group == key.group) {
+			usage++
+		}
+	}
+	item := map[string]interface{}{"usage": usage}
+	for name, value := range limits {
+		item[name] = value
+	}
+	writeV3(w, item)
+}
+
+func (s *Server) updateCapacity(w http.ResponseWriter, r *http.Request) {
+	key := capacityKey{namespace: r.Form.Get("namespaceId"), group: r.Form.Get("groupName")}
+	limits := make(map[string]int)
+	for _, name := range []string{"quota", "maxSize", "maxAggrCount", "maxAggrSize"} {
+		if value, err := strconv.Atoi(r.Form.Get(name)); err == nil {
+			limits[name] = value
+		}
+	}
+	if len(limits) == 0 {
+		writeV3Error(w, http.StatusBadRequest, codeParameterMissing, "at least one of quota, maxSize, maxAggrCount and maxAggrSize is required")
+		return
+	}
+	if s.capacities[key] == nil {
+		s.capacities[key] = map[string]int{"quota": 0, "maxSize": 0, "maxAggrCount": 0, "maxAggrSize": 0}
+	}
+	for name, value := range limits {
+		s.capacities[key][name] = value
+	}
+	writeV3(w, true)
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	names := []string{s.Username}
+	for name := range s.users {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var users []string
+	for _, name := range names {
+		if want := r.Form.Get("username"); want == "" || want == name {
+			users = append(users, name)
+		}
+	}
+	writeV3(w, page(users, r, func(name string) map[string]interface{} {
+		return map[string]interface{}{"username": name, "password": "******"}
+	}))
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	username, password := r.Form.Get("username"), r.Form.Get("password")
+	if username == "" || password == "" {
+		writeV3Error(w, http.StatusBadRequest, codeParameterMissing, "username and password are required")
+		return
+	}
+	if _, ok := s.users[username]; ok || username == s.Username {
+		writeV3Error(w, http.StatusOK, codeResourceExists, "user '"+username+"' already exist!")
+		return
+	}
+	s.users[username] = password
+	writeV3(w, "create user ok!")
+}
+
+func (s *Server) listRoles(w http.ResponseWriter, r *http.Request) {
+	var roles []RoleBinding
+	for _, b := range s.roles {
+		if (r.Form.Get("role") == "" || r.Form.Get("role") == b.Role) &&
+			(r.Form.Get("username") == "" || r.Form.Get("username") == b.Username) {
+			roles = append(roles, b)
+		}
+	}
+	writeV3(w, page(roles, r, func(b RoleBinding) map[string]interface{} {
+		return map[string]interface{}{"role": b.Role, "username": b.Username}
+	}))
+}
+
+func (s *Server) createRole(w http.ResponseWriter, r *http.Request) {
+	b := RoleBinding{Role: r.Form.Get("role"), Username: r.Form.Get("username")}
+	if b.Role == "" || b.Username == "" {
+		writeV3Error(w, http.StatusBadRequest, codeParameterMissing, "role and username are required")
+		return
+	}
+	for _, existing := range s.roles {
+		if existing == b {
+			writeV3Error(w, http.StatusOK, codeResourceExists, "user '"+b.Username+"' already bound to the role '"+b.Role+"'!")
+			return
+		}
+	}
+	s.roles = append(s.roles, b)
+	writeV3(w, "add role ok!")
+}
+
+func (s *Server) listPermissions(w http.ResponseWriter, r *http.Request) {
+	var perms []Permission
+	for _, p := range s.perms {
+		if r.Form.Get("role") == "" || r.Form.Get("role") == p.Role {
+			perms = append(perms, p)
+		}
+	}
+	writeV3(w, page(perms, r, func(p Permission) map[string]interface{} {
+		return map[string]interface{}{"role": p.Role, "resource": p.Resource, "action": p.Action}
+	}))
+}
+
+func (s *Server) createPermission(w http.ResponseWriter, r *http.Request) {
+	p := Permission{Role: r.Form.Get("role"), Resource: r.Form.Get("resource"), Action: r.Form.Get("action")}
+	if p.Role == "" || p.Resource == "" || p.Action == "" {
+		writeV3Error(w, http.StatusBadRequest, codeParameterMissing, "role, resource and action are required")
+		return
+	}
+	for _, existing := range s.perms {
+		if existing == p {
+			writeV3Error(w, http.StatusOK, codeResourceExists, "permission already exist!")
+			return
+		}
+	}
+	s.perms = append(s.perms, p)
+	writeV3(w, "add permission ok!")
+}
+
+// listen answers a config listener long poll with the listened configurations
+// whose MD5 differs, waiting for a change up to Long-Pulling-Timeout
+func (s *Server) listen(w http.ResponseWriter, r *http.Request) {
+	listening := r.Form.Get("Listening-Configs")
+	if !strings.Contains(listening, "\x02") {
+		// The listener package escapes the value before form encoding it
+		if unescaped, err := url.QueryUnescape(listening); err == nil {
+			listening = unescaped
+		}
+	}
+	timeout := 30 * time.Second
+	if ms, err := strconv.Atoi(r.Header.Get("Long-Pulling-Timeout")); err == nil {
+		timeout = time.Duration(ms) * time.Millisecond
+	}
+	noHangup := r.Header.Get("Long-Pulling-Timeout-No-Hangup") == "true"
+	listened := parseListening(listening)
+	if !noHangup {
+		s.mu.Lock()
+		for _, l := range listened {
+			s.addListener(l.key, clientIP(r), l.md5)
+		}
+		s.mu.Unlock()
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		s.mu.Lock()
+		changed := s.changedConfigs(listened)
+		wake := s.changed
+		s.mu.Unlock()
+		if changed != "" || noHangup {
+			w.Write([]byte(url.QueryEscape(changed)))
+			return
+		}
+		select {
+		case <-wake:
+		case <-deadline.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// listenedConfig is a line of the Listening-Configs of a long poll
+type listenedConfig struct {
+	key configKey
+	md5 string
+}
+
+// parseListening parses lines of dataId^2group^2md5[^2tenant]^1
+func parseListening(listening string) []listenedConfig {
+	var listened []listenedConfig
+	for _, line := range strings.Split(listening, "\x01") {
+		fields := strings.Split(line, "\x02")
+		if len(fields) < 3 {
+			continue
+		}
+		tenant := ""
+		if len(fields) > 3 {
+			tenant = fields[3]
+		}
+		listened = append(listened, listenedConfig{configKey{normalize(tenant), fields[1], fields[0]}, fields[2]})
+	}
+	return listened
+}
+
+// changedConfigs returns the listened configurations whose MD5 is not
+// current, as dataId^2group[^2tenant]^1; s.mu is held
+func (s *Server) changedConfigs(listened []listenedConfig) string {
+	var changed strings.Builder
+	for _, l := range listened {
+		current := ""
+		if c, ok := s.configs[l.key]; ok {
+			current = c.MD5
+		}
+		if current == l.md5 {
+			continue
+		}
+		changed.WriteString(l.key.dataID + "\x02" + l.key.group)
+		if l.key.namespace != "public" {
+			changed.WriteString("\x02" + l.key.namespace)
+		}
+		changed.WriteString("\x01")
+	}
+	return changed.String()
+}
+
+// listListeners answers the query variant of the listener API with the
+// clients listening to a configuration and their MD5
+func (s *Server) listListeners(w http.ResponseWriter, r *http.Request, v3 bool) {
+	key := configKey{normalize(r.Form.Get("tenant")), r.Form.Get("group"), r.Form.Get("dataId")}
+	if v3 {
+		key = configKey{normalize(r.Form.Get("namespaceId")), r.Form.Get("groupName"), r.Form.Get("dataId")}
+	}
+	status := make(map[string]string)
+	for ip, md5 := range s.listeners[key] {
+		status[ip] = md5
+	}
+	if v3 {
+		writeV3(w, map[string]interface{}{"queryType": "config", "listenersStatus": status})
+		return
+	}
+	writeJSON(w, map[string]interface{}{"collectStatus": 200, "lisentersGroupkeyStatus": status})
+}
+
+// hasAnyTag reports whether the comma separated configTags have one of tags
+func hasAnyTag(configTags, tags string) bool {
+	for _, tag := range strings.Split(tags, ",") {
+		for _, have := range strings.Split(configTags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" && tag == strings.TrimSpace(have) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func configItem(c *Config) map[string]interface{} {
+	return map[string]interface{}{
+		"dataId":     c.DataID,
+		"group":      c.Group,
+		"groupName":  c.Group,
+		"tenant":     c.Namespace,
+		"content":    c.Content,
+		"md5":        c.MD5,
+		"type":       c.Type,
+		"appName":    c.AppName,
+		"createTime": c.CreateTime,
+		"modifyTime": c.ModifyTime,
+	}
+}
+
+func configDetail(c *Config) map[string]interface{} {
+	return map[string]interface{}{
+		"dataId":      c.DataID,
+		"groupName":   c.Group,
+		"namespaceId": c.Namespace,
+		"content":     c.Content,
+		"desc":        c.Desc,
+		"md5":         c.MD5,
+		"configTags":  c.Tags,
+		"appName":     c.AppName,
+		"type":        c.Type,
+		"createTime":  c.CreateTime,
+		"modifyTime":  c.ModifyTime,
+	}
+}
+
+func historyItem(h *History) map[string]interface{} {
+	// Nacos keeps the advanced attributes of a revision as JSON in ext_info
+	extInfo, _ := json.Marshal(map[string]string{"src_user": h.SrcUser, "c_desc": h.Desc})
+	return map[string]interface{}{
+		"id":          h.ID,
+		"dataId":      h.DataID,
+		"groupName":   h.Group,
+		"namespaceId": h.Namespace,
+		"content":     h.Content,
+		"md5":         h.MD5,
+		"opType":      h.OpType,
+		"extInfo":     string(extInfo),
+		"srcUser":     h.SrcUser,
+		"srcIp":       h.SrcIP,
+		"createTime":  h.CreateTime,
+		"modifyTime":  h.CreateTime,
+	}
+}
+
+func namespaceItem(ns Namespace, typ, configCount int) map[string]interface{} {
+	return map[string]interface{}{
+		"namespace":         ns.ID,
+		"namespaceShowName": ns.Name,
+		"namespaceDesc":     ns.Desc,
+		"quota":             200,
+		"configCount":       configCount,
+		"type":              typ,
+	}
+}
+
+// page returns one page of items as pageNo and pageSize ask
+func page[T any](items []T, r *http.Request, convert func(T) map[string]interface{}) map[string]interface{} {
+	pageNo, _ := strconv.Atoi(r.Form.Get("pageNo"))
+	pageSize, _ := strconv.Atoi(r.Form.Get("pageSize"))
+	if pageNo < 1 {
+		pageNo = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	pageItems := []map[string]interface{}{}
+	for i := (pageNo - 1) * pageSize; i < len(items) && i < pageNo*pageSize; i++ {
+		pageItems = append(pageItems, convert(items[i]))
+	}
+	return map[string]interface{}{
+		"totalCount":     len(items),
+		"pageNumber":     pageNo,
+		"pagesAvailable": (len(items) + pageSize - 1) / pageSize,
+		"pageItems":      pageItems,
+	}
+}
+
+func writeV3(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, map[string]interface{}{"code": 0, "message": "success", "data": data})
+}
+
+func writeV3Error(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"code": code, "message": message, "data": nil})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
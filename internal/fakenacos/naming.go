@@ -0,0 +1,493 @@
+package fakenacos
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// v3 response codes of the naming APIs
+const (
+	codeServiceNotFound     = 21008
+	codeInstanceNotFound    = 21009
+	codeServiceHasInstances = 21010
+)
+
+// Service is a service of the naming registry held by the server
+type Service struct {
+	Namespace        string
+	Group            string
+	Name             string
+	ProtectThreshold float64
+	Metadata         map[string]string
+	Ephemeral        bool
+	Clusters         map[string]*Cluster // set by the cluster API; clusters of instances exist implicitly
+	Instances        []Instance
+}
+
+// Cluster is the health check configuration of a cluster of a service
+type Cluster struct {
+	HealthChecker   string // TCP (default), HTTP, MYSQL or NONE
+	CheckPort       int
+	UseInstancePort bool
+	Metadata        map[string]string
+}
+
+// Instance is an instance of a service
+type Instance struct {
+	IP        string
+	Port      int
+	Cluster   string // default: DEFAULT
+	Weight    float64
+	Healthy   bool
+	Enabled   bool
+	Ephemeral bool
+	Metadata  map[string]string
+}
+
+type serviceKey struct {
+	namespace, group, name string
+}
+
+// AddService creates a service without instances, or updates the protect
+// threshold of an existing one
+func (s *Server) AddService(namespace, group, name string, protectThreshold float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.service(namespace, group, name).ProtectThreshold = protectThreshold
+}
+
+// RegisterInstance registers an instance of a service, creating the service,
+// or replaces the instance with the same cluster, IP and port
+func (s *Server) RegisterInstance(namespace, group, service string, inst Instance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.register(s.service(namespace, group, service), inst)
+}
+
+// SetHealthy sets the health of the instances of a service at ip:port
+func (s *Server) SetHealthy(namespace, group, service, ip string, port int, healthy bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if svc := s.services[serviceKey{normalize(namespace), group, service}]; svc != nil {
+		for i := range svc.Instances {
+			if svc.Instances[i].IP == ip && svc.Instances[i].Port == port {
+				svc.Instances[i].Healthy = healthy
+			}
+		}
+	}
+}
+
+// Services returns the services of a namespace sorted by group and name
+func (s *Server) Services(namespace string) []Service {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var services []Service
+	for _, svc := range s.sortedServices(normalize(namespace), "", "") {
+		c := *svc
+		c.Instances = append([]Instance(nil), svc.Instances...)
+		services = append(services, c)
+	}
+	return services
+}
+
+// service returns a service, creating it; s.mu is held
+func (s *Server) service(namespace, group, name string) *Service {
+	key := serviceKey{normalize(namespace), group, name}
+	svc := s.services[key]
+	if svc == nil {
+		svc = &Service{Namespace: key.namespace, Group: group, Name: name, Ephemeral: true}
+		s.services[key] = svc
+	}
+	return svc
+}
+
+// register adds or replaces an instance; s.mu is held
+func (s *Server) register(svc *Service, inst Instance) {
+	if inst.Cluster == "" {
+		inst.Cluster = "DEFAULT"
+	}
+	for i, old := range svc.Instances {
+		if old.Cluster == inst.Cluster && old.IP == inst.IP && old.Port == inst.Port {
+			svc.Instances[i] = inst
+			return
+		}
+	}
+	svc.Instances = append(svc.Instances, inst)
+}
+
+// sortedServices returns the services of a namespace whose group and name
+// contain the filters, sorted by group and name; s.mu is held
+func (s *Server) sortedServices(namespace, group, name string) []*Service {
+	var services []*Service
+	for key, svc := range s.services {
+		if key.namespace == namespace && strings.Contains(key.group, group) && strings.Contains(key.name, name) {
+			services = append(services, svc)
+		}
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Group != services[j].Group {
+			return services[i].Group < services[j].Group
+		}
+		return services[i].Name < services[j].Name
+	})
+	return services
+}
+
+func (s *Server) listServicesV3(w http.ResponseWriter, r *http.Request) {
+	services := s.sortedServices(normalize(r.Form.Get("namespaceId")), r.Form.Get("groupNameParam"), r.Form.Get("serviceNameParam"))
+	writeV3(w, page(services, r, serviceItem))
+}
+
+func (s *Server) listServicesV1(w http.ResponseWriter, r *http.Request) {
+	services := s.sortedServices(normalize(r.Form.Get("namespaceId")), r.Form.Get("groupNameParam"), r.Form.Get("serviceNameParam"))
+	p := page(services, r, serviceItem)
+	writeJSON(w, map[string]interface{}{"count": p["totalCount"], "serviceList": p["pageItems"]})
+}
+
+func (s *Server) getServiceV3(w http.ResponseWriter, r *http.Request) {
+	svc := s.services[serviceKey{normalize(r.Form.Get("namespaceId")), groupParam(r), r.Form.Get("serviceName")}]
+	if svc == nil {
+		writeV3Error(w, http.StatusOK, codeServiceNotFound, "service not found")
+		return
+	}
+	detail := serviceDetail(svc)
+	clusterMap := make(map[string]interface{})
+	for _, name := range clusterNames(svc) {
+		clusterMap[name] = clusterItem(svc, name)
+	}
+	detail["clusterMap"] = clusterMap
+	writeV3(w, detail)
+}
+
+func (s *Server) getServiceV1(w http.ResponseWriter, r *http.Request) {
+	group := groupParam(r)
+	svc := s.services[serviceKey{normalize(r.Form.Get("namespaceId")), group, r.Form.Get("serviceName")}]
+	if svc == nil {
+		http.Error(w, "service not found", http.StatusNotFound)
+		return
+	}
+	detail := serviceDetail(svc)
+	delete(detail, "serviceName")
+	detail["name"] = group + "@@" + svc.Name
+	var clusters []map[string]interface{}
+	for _, name := range clusterNames(svc) {
+		cluster := clusterItem(svc, name)
+		cluster["name"] = name
+		delete(cluster, "clusterName")
+		clusters = append(clusters, cluster)
+	}
+	detail["clusters"] = clusters
+	writeJSON(w, detail)
+}
+
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request, v3 bool) {
+	svc := s.services[serviceKey{normalize(r.Form.Get("namespaceId")), groupParam(r), r.Form.Get("serviceName")}]
+	if svc == nil {
+		if v3 {
+			writeV3Error(w, http.StatusOK, codeServiceNotFound, "service not found")
+		} else {
+			http.Error(w, "service not found", http.StatusNotFound)
+		}
+		return
+	}
+	cluster := r.Form.Get("clusterName")
+	if !v3 {
+		cluster = r.Form.Get("clusters")
+	}
+	items := []map[string]interface{}{}
+	for _, inst := range svc.Instances {
+		if cluster == "" || inst.Cluster == cluster {
+			items = append(items, instanceItem(svc, inst))
+		}
+	}
+	if v3 {
+		writeV3(w, items)
+	} else {
+		writeJSON(w, map[string]interface{}{"name": svc.Group + "@@" + svc.Name, "clusters": cluster, "hosts": items})
+	}
+}
+
+func (s *Server) deleteService(w http.ResponseWriter, r *http.Request, v3 bool) {
+	key := serviceKey{normalize(r.Form.Get("namespaceId")), groupParam(r), r.Form.Get("serviceName")}
+	if svc := s.services[key]; svc != nil && len(svc.Instances) > 0 {
+		if v3 {
+			writeV3Error(w, http.StatusOK, codeServiceHasInstances, "service has instances, can't delete")
+		} else {
+			http.Error(w, "service has instances, can't delete", http.StatusBadRequest)
+		}
+		return
+	}
+	delete(s.services, key)
+	writeOK(w, r)
+}
+
+// writeService creates (POST) or updates (PUT) a service
+func (s *Server) writeService(w http.ResponseWriter, r *http.Request) {
+	if r.Form.Get("serviceName") == "" {
+		writeV3Error(w, http.StatusBadRequest, codeParameterMissing, "serviceName is required")
+		return
+	}
+	key := serviceKey{normalize(r.Form.Get("namespaceId")), groupParam(r), r.Form.Get("serviceName")}
+	if _, exists := s.services[key]; exists == (r.Method == "POST") {
+		if exists {
+			writeV3Error(w, http.StatusOK, codeResourceExists, "service already exists")
+		} else {
+			writeV3Error(w, http.StatusOK, codeServiceNotFound, "service not found")
+		}
+		return
+	}
+	svc := s.service(key.namespace, key.group, key.name)
+	svc.ProtectThreshold, _ = strconv.ParseFloat(r.Form.Get("protectThreshold"), 64)
+	if ephemeral := r.Form.Get("ephemeral"); ephemeral != "" {
+		svc.Ephemeral = ephemeral == "true"
+	}
+	if metadata := r.Form.Get("metadata"); metadata != "" {
+		svc.Metadata = nil
+		json.Unmarshal([]byte(metadata), &svc.Metadata)
+	}
+	writeOK(w, r)
+}
+
+func (s *Server) updateCluster(w http.ResponseWriter, r *http.Request) {
+	svc := s.services[serviceKey{normalize(r.Form.Get("namespaceId")), groupParam(r), r.Form.Get("serviceName")}]
+	if svc == nil {
+		writeV3Error(w, http.StatusOK, codeServiceNotFound, "service not found")
+		return
+	}
+	cluster := &Cluster{HealthChecker: "TCP", UseInstancePort: r.Form.Get("useInstancePort4Check") == "true"}
+	cluster.CheckPort, _ = strconv.Atoi(r.Form.Get("checkPort"))
+	var checker struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal([]byte(r.Form.Get("healthChecker")), &checker) == nil && checker.Type != "" {
+		cluster.HealthChecker = checker.Type
+	}
+	if metadata := r.Form.Get("metadata"); metadata != "" {
+		json.Unmarshal([]byte(metadata), &cluster.Metadata)
+	}
+	if svc.Clusters == nil {
+		svc.Clusters = make(map[string]*Cluster)
+	}
+	svc.Clusters[clusterParam(r)] = cluster
+	writeOK(w, r)
+}
+
+func (s *Server) registerInstance(w http.ResponseWriter, r *http.Request) {
+	port, _ := strconv.Atoi(r.Form.Get("port"))
+	if r.Form.Get("serviceName") == "" || r.Form.Get("ip") == "" || port == 0 {
+		writeV3Error(w, http.StatusBadRequest, codeParameterMissing, "serviceName, ip and port are required")
+		return
+	}
+	inst := Instance{
+		IP:        r.Form.Get("ip"),
+		Port:      port,
+		Cluster:   r.Form.Get("clusterName"),
+		Weight:    1,
+		Healthy:   r.Form.Get("healthy") != "false",
+		Enabled:   r.Form.Get("enabled") != "false",
+		Ephemeral: r.Form.Get("ephemeral") != "false",
+	}
+	if weight, err := strconv.ParseFloat(r.Form.Get("weight"), 64); err == nil {
+		inst.Weight = weight
+	}
+	if metadata := r.Form.Get("metadata"); metadata != "" {
+		json.Unmarshal([]byte(metadata), &inst.Metadata)
+	}
+	s.register(s.service(r.Form.Get("namespaceId"), groupParam(r), r.Form.Get("serviceName")), inst)
+	writeOK(w, r)
+}
+
+func (s *Server) updateInstance(w http.ResponseWriter, r *http.Request) {
+	inst := s.instance(r)
+	if inst == nil {
+		writeV3Error(w, http.StatusOK, codeInstanceNotFound, "instance not found")
+		return
+	}
+	if weight, err := strconv.ParseFloat(r.Form.Get("weight"), 64); err == nil {
+		inst.Weight = weight
+	}
+	if enabled := r.Form.Get("enabled"); enabled != "" {
+		inst.Enabled = enabled == "true"
+	}
+	if metadata := r.Form.Get("metadata"); metadata != "" {
+		inst.Metadata = nil
+		json.Unmarshal([]byte(metadata), &inst.Metadata)
+	}
+	writeOK(w, r)
+}
+
+func (s *Server) deregisterInstance(w http.ResponseWriter, r *http.Request) {
+	svc := s.services[serviceKey{normalize(r.Form.Get("namespaceId")), groupParam(r), r.Form.Get("serviceName")}]
+	if svc != nil {
+		port, _ := strconv.Atoi(r.Form.Get("port"))
+		cluster := clusterParam(r)
+		for i, inst := range svc.Instances {
+			if inst.IP == r.Form.Get("ip") && inst.Port == port && inst.Cluster == cluster {
+				svc.Instances = append(svc.Instances[:i], svc.Instances[i+1:]...)
+				break
+			}
+		}
+	}
+	writeOK(w, r)
+}
+
+// instance returns the instance a request names; s.mu is held
+func (s *Server) instance(r *http.Request) *Instance {
+	svc := s.services[serviceKey{normalize(r.Form.Get("namespaceId")), groupParam(r), r.Form.Get("serviceName")}]
+	if svc == nil {
+		return nil
+	}
+	port, _ := strconv.Atoi(r.Form.Get("port"))
+	cluster := clusterParam(r)
+	for i := range svc.Instances {
+		if inst := &svc.Instances[i]; inst.IP == r.Form.Get("ip") && inst.Port == port && inst.Cluster == cluster {
+			return inst
+		}
+	}
+	return nil
+}
+
+// beat answers a heartbeat, as a v1 server answers ephemeral instances
+func (s *Server) beat(w http.ResponseWriter, r *http.Request) {
+	inst := s.instance(r)
+	if inst == nil {
+		writeJSON(w, map[string]interface{}{"code": 20404})
+		return
+	}
+	inst.Healthy = true
+	writeJSON(w, map[string]interface{}{"code": 10200, "clientBeatInterval": 5000, "lightBeatEnabled": true})
+}
+
+func (s *Server) updateHealth(w http.ResponseWriter, r *http.Request) {
+	svc := s.services[serviceKey{normalize(r.Form.Get("namespaceId")), groupParam(r), r.Form.Get("serviceName")}]
+	if svc == nil {
+		writeV3Error(w, http.StatusOK, codeServiceNotFound, "service not found")
+		return
+	}
+	port, _ := strconv.Atoi(r.Form.Get("port"))
+	for i := range svc.Instances {
+		inst := &svc.Instances[i]
+		if inst.IP == r.Form.Get("ip") && inst.Port == port &&
+			(r.Form.Get("clusterName") == "" || inst.Cluster == r.Form.Get("clusterName")) {
+			inst.Healthy = r.Form.Get("healthy") == "true"
+		}
+	}
+	writeOK(w, r)
+}
+
+// writeOK answers a successful change in the format of the API version
+func writeOK(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/nacos/v1/") {
+		w.Write([]byte("ok"))
+		return
+	}
+	writeV3(w, "ok")
+}
+
+// clusterNames returns the clusters of a service, configured or with
+// instances, sorted
+func clusterNames(svc *Service) []string {
+	names := make(map[string]bool)
+	for name := range svc.Clusters {
+		names[name] = true
+	}
+	for _, inst := range svc.Instances {
+		names[inst.Cluster] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// clusterParam returns the cluster of an instance request, DEFAULT when unset
+func clusterParam(r *http.Request) string {
+	if cluster := r.Form.Get("clusterName"); cluster != "" {
+		return cluster
+	}
+	return "DEFAULT"
+}
+
+// groupParam returns the group of a naming request, DEFAULT_GROUP when unset
+func groupParam(r *http.Request) string {
+	if group := r.Form.Get("groupName"); group != "" {
+		return group
+	}
+	return "DEFAULT_GROUP"
+}
+
+func serviceItem(svc *Service) map[string]interface{} {
+	clusters := make(map[string]bool)
+	healthy := 0
+	for _, inst := range svc.Instances {
+		clusters[inst.Cluster] = true
+		if inst.Healthy {
+			healthy++
+		}
+	}
+	return map[string]interface{}{
+		"name":                 svc.Name,
+		"groupName":            svc.Group,
+		"clusterCount":         len(clusters),
+		"ipCount":              len(svc.Instances),
+		"healthyInstanceCount": healthy,
+		"triggerFlag":          "false",
+	}
+}
+
+func instanceItem(svc *Service, inst Instance) map[string]interface{} {
+	metadata := inst.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	return map[string]interface{}{
+		"instanceId":  inst.IP + "#" + strconv.Itoa(inst.Port) + "#" + inst.Cluster + "#" + svc.Group + "@@" + svc.Name,
+		"ip":          inst.IP,
+		"port":        inst.Port,
+		"weight":      inst.Weight,
+		"healthy":     inst.Healthy,
+		"enabled":     inst.Enabled,
+		"ephemeral":   inst.Ephemeral,
+		"clusterName": inst.Cluster,
+		"serviceName": svc.Group + "@@" + svc.Name,
+		"metadata":    metadata,
+	}
+}
+
+func clusterItem(svc *Service, name string) map[string]interface{} {
+	cluster := svc.Clusters[name]
+	if cluster == nil {
+		cluster = &Cluster{HealthChecker: "TCP", CheckPort: 80, UseInstancePort: true}
+	}
+	metadata := cluster.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	return map[string]interface{}{
+		"clusterName":             name,
+		"healthChecker":           map[string]interface{}{"type": cluster.HealthChecker},
+		"healthyCheckPort":        cluster.CheckPort,
+		"useInstancePortForCheck": cluster.UseInstancePort,
+		"metadata":                metadata,
+	}
+}
+
+func serviceDetail(svc *Service) map[string]interface{} {
+	metadata := svc.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	return map[string]interface{}{
+		"namespaceId":      svc.Namespace,
+		"serviceName":      svc.Name,
+		"groupName":        svc.Group,
+		"protectThreshold": svc.ProtectThreshold,
+		"metadata":         metadata,
+		"ephemeral":        svc.Ephemeral,
+	}
+}
@@ -80,6 +80,10 @@ var (
 			"--group string     Filter by group (supports wildcard *)",
 			"--page int         Page number (default: 1)",
 			"--size int         Page size (default: 20)",
+			"--content-contains Only configs whose content contains the keyword",
+			"--tag string       Only configs with one of the tags (repeatable)",
+			"--detail           Fetch each config's metadata (MD5, modify time, tags)",
+			"--output, -o       Output format: table (default) or json",
 		},
 		Examples: []string{
 			"# List all configurations",
@@ -93,6 +97,31 @@ var (
 			"",
 			"# Combine filters with pagination",
 			"config-list --data-id *config* --group DEFAULT_GROUP --page 1 --size 50",
+			"",
+			"# Search by content (server-side, client-side if unsupported)",
+			"config-list --content-contains redis.host",
+			"",
+			"# Configurations of a release",
+			"config-list --tag release-2024.10 --detail",
+			"",
+			"# Metadata as JSON (md5, type, appName, configTags, createTime, modifyTime)",
+			"config-list --detail -o json",
+		},
+	}
+
+	GroupList = CommandHelp{
+		Command:     "group-list",
+		Description: "List the groups of a namespace with the number of configurations in each.",
+		Parameters: []string{
+			"--group string   Filter by group (supports wildcard *)",
+			"--output, -o     Output format: table (default) or json",
+		},
+		Examples: []string{
+			"# Discover the groups of the dev namespace",
+			"group-list -n dev",
+			"",
+			"# Only skill groups, as JSON",
+			"group-list --group skill_* -o json",
 		},
 	}
 
@@ -101,12 +130,20 @@ var (
 		Description: "Get a specific configuration from Nacos.",
 		Parameters: []string{
 			"dataId          Required. Configuration data ID",
-			"group           Required. Configuration group name",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--path          Print only the value at this path of a YAML or JSON configuration",
+			"--resolve-refs  Replace ${nacos:[GROUP/]dataId[:key]} references with the referenced values",
 		},
 		Examples: []string{
 			"# Get a configuration",
 			"config-get application.yaml DEFAULT_GROUP",
 			"",
+			"# With url: ${nacos:SHARED/common.yaml:db.url} replaced by that value",
+			"config-get application.yaml --resolve-refs",
+			"",
+			"# Get a single value",
+			"config-get application.yaml --path spring.datasource.url",
+			"",
 			"# Get a skill configuration",
 			"config-get skill.json skill_skill-creator",
 		},
@@ -117,13 +154,22 @@ var (
 		Description: "Publish a configuration to Nacos (create or update).",
 		Parameters: []string{
 			"dataId          Required. Configuration data ID",
-			"group           Required. Configuration group name",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
 			"--file, -f      Path to config file (default: read from stdin)",
+			"--ttl           Publish a temporary configuration that gc deletes after this long, e.g. 2h or 7d",
+			"--path          Change only the value at this path of a YAML or JSON configuration, keeping comments",
+			"--value         New value for --path, parsed as YAML (quote it for a string)",
 		},
 		Examples: []string{
 			"# Publish from file",
 			"config-set application.yaml DEFAULT_GROUP --file ./application.yaml",
 			"",
+			"# Change a single value, failing on a concurrent change",
+			"config-set application.yaml --path spring.datasource.maxPoolSize --value 50",
+			"",
+			"# Publish a temporary configuration for a test environment",
+			"config-set feature-x.yaml TEST_GROUP -f ./feature-x.yaml --ttl 2h",
+			"",
 			"# Publish from stdin",
 			" echo 'key: value' | nacos-cli config-set app.yaml DEFAULT_GROUP",
 			"",
@@ -132,6 +178,1527 @@ var (
 		},
 	}
 
+	ConfigCat = CommandHelp{
+		Command:     "config-cat",
+		Description: "Print several configurations of a group, fetched concurrently, in argument order.",
+		Parameters: []string{
+			"dataId...       Required. One or more configuration data IDs",
+			"--group         Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--merge         Deep-merge the YAML/JSON documents into one YAML output",
+			"--concurrency   Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Print with --- # dataId separators",
+			"config-cat a.yaml b.yaml c.yaml --group G",
+			"",
+			"# Merge, later documents overriding earlier ones",
+			"config-cat base.yaml prod.yaml --merge",
+		},
+	}
+
+	ConfigDelete = CommandHelp{
+		Command:     "config-delete",
+		Description: "Delete configurations by data ID, --pattern or --selector. Lists what will be deleted and asks to type the namespace first.",
+		Parameters: []string{
+			"dataId...       Optional. Data IDs to delete (or use --pattern)",
+			"--pattern       Delete the configurations whose data ID matches (supports wildcard *)",
+			"--group         Configuration group name or alias, * for all groups with --pattern (default: DEFAULT_GROUP)",
+			"--selector      Delete the configurations matching an expression, in all groups unless --group is given",
+			"-y, --yes       Delete without asking for confirmation",
+			"--no-recycle-bin  Delete without keeping a copy in the recycle bin",
+			"--concurrency   Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Preview, confirm and delete temporary configurations",
+			"config-delete --pattern 'tmp-*' --group TEST_GROUP",
+			"",
+			"# Delete two configurations from a script",
+			"config-delete a.yaml b.yaml --yes",
+			"",
+			"# Delete what a retired service left behind",
+			"config-delete --selector 'dataId =~ \"^legacy-billing-\" && tag != keep'",
+			"",
+			"Note:",
+			"  - Every configuration is reported as deleted or error; the exit code is 1 if any failed",
+			"  - Data IDs that do not exist are reported as not found and not counted or confirmed",
+			"  - A copy of each is kept in the recycle bin first, see config-restore-deleted",
+			"  - More deletes than --max-changes (maxChanges of the profile) need --confirm-over-budget",
+			"  - nacos-cli bookkeeping groups (NACOS_CLI, NACOS_CLI_LOCK) are skipped with --group *",
+		},
+	}
+
+	ConfigTagAdd = CommandHelp{
+		Command:     "config-tag-add",
+		Description: "Add tags to every configuration whose data ID matches --pattern, keeping their other tags.",
+		Parameters: []string{
+			"tag...          Required. Tags to add",
+			"--pattern       Configurations whose data ID matches (supports wildcard *)",
+			"--group         Configuration group name or alias, supports wildcard * (default: all groups)",
+			"--dry-run       Show which configurations would change without publishing",
+			"--concurrency   Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Mark the configurations of a release",
+			"config-tag-add release-2024.10 --pattern 'app-*' --group PROD",
+			"",
+			"# List them",
+			"config-list --tag release-2024.10",
+			"",
+			"Note:",
+			"  - Each configuration is republished with its content compare-and-swap, retried when it changes meanwhile",
+			"  - Every configuration is reported as updated, unchanged or error; the exit code is 1 if any failed",
+		},
+	}
+
+	ConfigTagRm = CommandHelp{
+		Command:     "config-tag-rm",
+		Description: "Remove tags from every configuration whose data ID matches --pattern.",
+		Parameters: []string{
+			"tag...          Required. Tags to remove",
+			"--pattern       Configurations whose data ID matches (supports wildcard *)",
+			"--group         Configuration group name or alias, supports wildcard * (default: all groups)",
+			"--dry-run       Show which configurations would change without publishing",
+			"--concurrency   Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Remove a release tag everywhere",
+			"config-tag-rm release-2024.10 --pattern '*'",
+		},
+	}
+
+	ConfigRestoreDeleted = CommandHelp{
+		Command:     "config-restore-deleted",
+		Description: "List the configurations config-delete kept in the recycle bin, or publish one again.",
+		Parameters: []string{
+			"dataId          Optional. Restore the most recently deleted copy; without it the bin is listed",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--id            Restore the recycle bin item with this ID",
+			"--force         Overwrite the configuration if it exists again",
+		},
+		Examples: []string{
+			"# What was deleted from the dev namespace",
+			"config-restore-deleted -n dev",
+			"",
+			"# Bring back the latest deleted copy",
+			"config-restore-deleted application.yaml DEFAULT_GROUP",
+			"",
+			"Note:",
+			"  - The content, type and tags are restored; the item leaves the recycle bin",
+			"  - The bin is ~/.nacos-cli/recycle-bin unless recycleBin is set in the config file",
+		},
+	}
+
+	ConfigExport = CommandHelp{
+		Command:     "config-export",
+		Description: "Export all configurations of the namespace to a zip archive laid out as <group>/<dataId>, with a manifest.",
+		Parameters: []string{
+			"-o, --output          Archive file or s3://, oss://, gs:// URL (default: <namespace>-<time>.zip)",
+			"--selector            Export the configurations matching an expression (see the README)",
+			"--concurrency         Maximum number of concurrent requests (default: 8)",
+			"--storage-endpoint    Object storage endpoint, e.g. a MinIO server (default: from the URL scheme)",
+			"--storage-region      Object storage region (default: us-east-1 for s3, cn-hangzhou for oss)",
+			"--storage-path-style  Use path-style bucket URLs (MinIO)",
+		},
+		Examples: []string{
+			"# Export the prod namespace",
+			"config-export -n prod -o prod.zip",
+			"",
+			"# Export straight to Aliyun OSS",
+			"config-export -n prod -o oss://bucket/nacos/prod.zip --storage-region cn-shanghai",
+			"",
+			"# Export the configurations managed by the platform team",
+			"config-export -n prod --selector 'group in (ORDER, PAYMENT) && tag == managed'",
+			"",
+			"Note:",
+			"  - The manifest (.nacos-manifest.json) records the server, namespace, selector and each config's MD5 and type",
+			"  - config-edit locks (group NACOS_CLI_LOCK) are not exported",
+		},
+	}
+
+	ConfigImport = CommandHelp{
+		Command:     "config-import",
+		Description: "Publish the configurations of a config-export archive, optionally into another namespace and renamed.",
+		Parameters: []string{
+			"archive               Required. Archive file or s3://, oss://, gs:// URL",
+			"--to-namespace        Namespace to import into (default: the current namespace)",
+			"--rewrite             Rename data IDs: '<regex> => <replacement>' (repeatable, first match wins)",
+			"--rewrite-group       Rename groups: '<regex> => <replacement>' (repeatable, first match wins)",
+			"--mapping             Mapping file renaming the namespace, groups and data IDs, applied before --rewrite",
+			"--on-conflict         When a configuration exists: abort (default), skip or overwrite",
+			"--dry-run             Show what would be imported without publishing",
+			"--concurrency         Maximum number of concurrent requests (default: 8)",
+			"--storage-endpoint    Object storage endpoint, e.g. a MinIO server (default: from the URL scheme)",
+			"--storage-region      Object storage region (default: us-east-1 for s3, cn-hangzhou for oss)",
+			"--storage-path-style  Use path-style bucket URLs (MinIO)",
+		},
+		Examples: []string{
+			"# Disaster-recovery rehearsal into a parallel namespace",
+			"config-import prod.zip --to-namespace dr --rewrite 'prod-(.*).yaml => dr-$1.yaml' --dry-run",
+			"",
+			"# Restore last night's backup over the current content",
+			"config-import s3://bucket/nacos/prod-20260101T020000Z.zip -n prod --on-conflict overwrite",
+			"",
+			"# Import a dev archive into dev-us, renamed by a mapping file",
+			"config-import dev.zip --mapping mapping.yaml",
+			"",
+			"Note:",
+			"  - Patterns must match the whole name; write ${1} when a capture group is followed by a letter, digit or _",
+			"  - Two configurations rewritten to the same name are rejected before anything is published",
+			"  - nacos-cli bookkeeping groups are skipped when importing into another namespace",
+		},
+	}
+
+	BackupSchedule = CommandHelp{
+		Command:     "backup-schedule",
+		Description: "Run as a daemon that exports namespaces on a cron schedule, stores the archives and deletes old ones.",
+		Parameters: []string{
+			"--cron             Required. Cron schedule in local time, e.g. '0 2 * * *' or @daily",
+			"--dest             Required. Directory or s3://, oss://, gs://bucket/prefix to store the archives in",
+			"--keep             Backups to keep per namespace, 0 to keep all (default: 30)",
+			"--all-namespaces   Back up every namespace instead of the current one",
+			"--run-now          Take a backup at startup too",
+			"--metrics-addr     Serve Prometheus metrics on this address, e.g. :9108",
+			"--storage-endpoint    Object storage endpoint, e.g. a MinIO server (default: from the URL scheme)",
+			"--storage-region      Object storage region (default: us-east-1 for s3, cn-hangzhou for oss)",
+			"--storage-path-style  Use path-style bucket URLs (MinIO)",
+		},
+		Examples: []string{
+			"# Nightly backups of every namespace to S3, keeping a month",
+			"backup-schedule --cron '0 2 * * *' --dest s3://bucket/nacos --keep 30 --all-namespaces",
+			"",
+			"# Hourly backups to a local directory with metrics",
+			"backup-schedule --cron @hourly --dest /var/backups/nacos --metrics-addr :9108",
+			"",
+			"Note:",
+			"  - Archives are named <namespace>-<UTC time>.zip, as written by config-export",
+			"  - Credentials come from AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY (s3), OSS_ACCESS_KEY_ID/",
+			"    OSS_ACCESS_KEY_SECRET (oss) or GCS_HMAC_ACCESS_ID/GCS_HMAC_SECRET HMAC keys (gs);",
+			"    oss and gs fail without their own variables instead of using the AWS ones",
+			"  - nacos_cli_backup_last_success_timestamp_seconds is the metric to alert on",
+		},
+	}
+
+	Sync = CommandHelp{
+		Command:     "sync",
+		Description: "Replicate the configurations of the current namespace to a namespace of another profile: a full reconcile at startup, then listener-driven incremental syncs and a full reconcile every --interval.",
+		Parameters: []string{
+			"--to-profile       Required. Profile of the config file to sync to",
+			"--to-namespace     Namespace to sync to (default: the namespace of the profile, else the source namespace)",
+			"--pattern          Sync the configurations whose data ID matches (supports wildcard *, default: all)",
+			"--group            Configuration group name or alias (supports wildcard *, default: all groups)",
+			"--delete           Delete configurations from the target that were deleted from the source",
+			"--once             Reconcile once and exit, exiting 1 when a configuration failed to sync",
+			"--dry-run          Show what one reconcile would sync without changing the target",
+			"--interval         How often to compare both namespaces in full (default: 5m)",
+			"--poll-timeout     How long the server may hold a long poll open (default: 30s)",
+			"--metrics-addr     Serve Prometheus metrics and /healthz on this address, e.g. :9109",
+			"--concurrency      Maximum number of concurrent requests (default: 8)",
+			"--bidirectional    Also sync the changes made in the target back to the source",
+			"--conflict         When both sides changed: newer-wins (modify time), source-wins or manual (default: newer-wins)",
+			"--conflict-report  Write the conflicts of bidirectional syncs to this JSON file",
+			"--mapping          Mapping file renaming namespaces, groups and data IDs in the target",
+			"--selector         Sync the configurations matching an expression, with their source names",
+		},
+		Examples: []string{
+			"# Replicate the prod namespace to the DR cluster",
+			"sync -n prod --to-profile dr --metrics-addr :9109",
+			"",
+			"# One-off copy of the payment configurations, removing deleted ones",
+			"sync -n prod --to-profile dr --group 'PAYMENT_*' --delete --once",
+			"",
+			"# Replicate only the configurations tagged for DR",
+			"sync -n prod --to-profile dr --selector 'tag == dr && type in (yaml, properties)'",
+			"",
+			"# Active-active during a migration, queueing conflicts for a human",
+			"sync -n prod --to-profile new-dc --bidirectional --conflict manual --conflict-report conflicts.json",
+			"",
+			"# Sync dev to dev-us, where the groups are named differently",
+			"sync -n dev --to-profile us --mapping mapping.yaml",
+			"",
+			"Note:",
+			"  - The guard rails and audit log of the target profile apply to the changes, and of the",
+			"    current profile too with --bidirectional",
+			"  - Bidirectional syncs remember what both sides held at the last sync only while running:",
+			"    at startup, configurations that differ on both sides are conflicts",
+			"  - /healthz answers 503 until a full reconcile succeeds, and when the last one failed",
+			"  - nacos_cli_sync_lag_seconds and nacos_cli_sync_last_success_timestamp_seconds are the metrics to alert on",
+			"  - A reconcile syncing more than --max-changes (maxChanges of the target profile) fails",
+			"    without changing anything, unless --confirm-over-budget is given",
+			"  - dataIds rules of a mapping cannot be reversed: --delete and --bidirectional then only",
+			"    touch target configurations synced from the source",
+		},
+	}
+
+	ConfigChecksum = CommandHelp{
+		Command:     "config-checksum",
+		Description: "Write the checksum of every configuration in a namespace, in the format of sha256sum, or verify a namespace against such a file to detect changes between two points in time.",
+		Parameters: []string{
+			"-o, --output          File to write the checksums to (default: stdout)",
+			"--algorithm           sha256 (default) or md5",
+			"--verify              Compare the namespace with a checksum file instead of writing one",
+			"--ignore-extra        With --verify, pass even if the server has configurations the file lacks",
+			"--selector            Only the configurations matching an expression (default with --verify: the selector of the file)",
+			"--concurrency         Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Record the state of prod",
+			"config-checksum -n prod -o SHA256SUMS",
+			"",
+			"# Later: has anything changed since?",
+			"config-checksum --verify SHA256SUMS",
+			"",
+			"Note:",
+			"  - Lines are \"<hash>  <group>/<dataId>\" after # header comments naming the namespace,",
+			"    algorithm, selector and time; --verify checks that namespace unless -n is given",
+			"  - Statuses: changed, missing (not on the server) and extra (not in the file)",
+			"  - Prints PASS or FAIL and exits with status 1 on FAIL",
+		},
+	}
+
+	BackupVerify = CommandHelp{
+		Command:     "backup-verify",
+		Description: "Check a config-export archive against the server: every archived config must exist with the same MD5, and every config on the server must be in the archive.",
+		Parameters: []string{
+			"archive               Required. Archive file or s3://, oss://, gs:// URL",
+			"--against             Namespace to compare with (default: the namespace the archive was taken from)",
+			"--ignore-extra        Pass even if the server has configurations the archive lacks",
+			"--selector            Only compare the configurations matching an expression (default: the selector of the export)",
+			"-o, --output          Output format: table or json (default: table)",
+			"--concurrency         Maximum number of concurrent requests (default: 8)",
+			"--storage-endpoint    Object storage endpoint, e.g. a MinIO server (default: from the URL scheme)",
+			"--storage-region      Object storage region (default: us-east-1 for s3, cn-hangzhou for oss)",
+			"--storage-path-style  Use path-style bucket URLs (MinIO)",
+		},
+		Examples: []string{
+			"# Verify last night's backup against prod",
+			"backup-verify s3://bucket/nacos/prod-20260101T020000Z.zip --against prod",
+			"",
+			"# Machine-readable report for a monitoring job",
+			"backup-verify prod.zip -o json",
+			"",
+			"Note:",
+			"  - Statuses: ok, changed (MD5 differs), missing (not on the server), extra (not in the archive),",
+			"    corrupt (content does not match the archive manifest) and error",
+			"  - Prints PASS or FAIL and exits with status 1 on FAIL",
+			"  - config-edit locks (group NACOS_CLI_LOCK) are ignored, as config-export skips them",
+			"  - Archives exported with --selector are compared with the matching configurations only",
+		},
+	}
+
+	ConfigPull = CommandHelp{
+		Command:     "config-pull",
+		Description: "Download the configurations of a namespace to a <group>/<dataId> directory tree.",
+		Parameters: []string{
+			"--dest string      Destination directory (default: .)",
+			"--data-id string   Filter by data ID (supports wildcard *)",
+			"--group string     Filter by group (supports wildcard *)",
+			"--concurrency      Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Pull the dev namespace for storage in git",
+			"config-pull -n dev --dest ./configs",
+			"",
+			"# Pull one group only",
+			"config-pull --group PAYMENT_GROUP --dest ./configs",
+			"",
+			"Note:",
+			"  - A .nacos-manifest.json with the namespace and each config's MD5 is written to the root",
+			"  - The tree can be published again with apply or config-push",
+			"  - nacos-cli bookkeeping groups (NACOS_CLI, NACOS_CLI_LOCK) are skipped unless --group is set",
+		},
+	}
+
+	ConfigPush = CommandHelp{
+		Command:     "config-push",
+		Description: "Publish a <group>/<dataId> directory tree, skipping files whose content matches the server.",
+		Parameters: []string{
+			"dir             Optional. Directory laid out as <group>/<dataId> (default: .)",
+			"--dry-run       Show what would be published without publishing",
+			"--concurrency   Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Push a pulled tree to the dev namespace",
+			"config-push ./configs -n dev",
+			"",
+			"Note:",
+			"  - The config type is inferred from the file extension (yaml, json, properties, xml, html, text)",
+			"  - Unlike apply, push keeps no state: local content always wins",
+		},
+	}
+
+	Dev = CommandHelp{
+		Command:     "dev",
+		Description: "Watch a <group>/<dataId> directory tree and publish files as soon as they are saved.",
+		Parameters: []string{
+			"--dir, -d string   Directory laid out as <group>/<dataId> (default: .)",
+			"--delete           Delete configurations whose files are removed",
+			"--debounce         Wait for a file to be quiet this long before publishing (default: 300ms)",
+		},
+		Examples: []string{
+			"# Iterate on configs in a personal namespace",
+			"dev --dir ./configs -n dev-$USER",
+			"",
+			"Note:",
+			"  - Changed files are pushed once at startup, then on every save",
+			"  - Hidden files and editor swap/backup files are ignored",
+		},
+	}
+
+	SandboxCreate = CommandHelp{
+		Command:     "sandbox-create",
+		Description: "Create (or reuse) the personal namespace sandbox-<name>, optionally seeded from a template namespace.",
+		Parameters: []string{
+			"--name string   Sandbox name (default: current user)",
+			"--from string   Template namespace ID to copy all configurations from",
+			"--reseed        Copy the template again when the sandbox already exists",
+		},
+		Examples: []string{
+			"# Create sandbox-$USER as a copy of dev",
+			"sandbox-create --from dev",
+			"",
+			"# Then work in it",
+			"dev --dir ./configs -n sandbox-$USER",
+		},
+	}
+
+	SandboxDestroy = CommandHelp{
+		Command:     "sandbox-destroy",
+		Description: "Delete a sandbox namespace and all its configurations.",
+		Parameters: []string{
+			"--name string   Sandbox name (default: current user)",
+			"--force         Skip the confirmation prompt",
+		},
+		Examples: []string{
+			"sandbox-destroy",
+			"",
+			"Note:",
+			"  - Only namespaces named sandbox-* can be destroyed",
+		},
+	}
+
+	Bootstrap = CommandHelp{
+		Command:     "bootstrap",
+		Description: "Provision a fresh cluster from one declarative file: namespaces with their capacity, groups with their capacity and seed configurations, and the users, roles and permissions of auth-export. What exists is kept: seed configurations are never overwritten, so the file can be applied again.",
+		Parameters: []string{
+			"cluster.yaml    Required. Cluster file; seed config files are relative to it",
+			"--dry-run       Show what would be created without changing anything",
+		},
+		Examples: []string{
+			"# Day 0 of a new cluster",
+			"bootstrap cluster.yaml --dry-run",
+			"bootstrap cluster.yaml",
+			"",
+			"Note:",
+			"  - namespaces: [{id, name, desc, capacity, groups: [{name, capacity, configs: [{dataId, type, content | file}]}]}]",
+			"  - capacity: quota, maxSize, maxAggrCount, maxAggrSize; limits left out are not changed",
+			"  - users and roles as written by auth-export; new users get a generated password, printed once",
+			"  - Requires an admin login",
+		},
+	}
+
+	NamespaceList = CommandHelp{
+		Command:     "namespace-list",
+		Description: "List the namespaces of the server with their name, number of configurations, quota and description.",
+		Parameters: []string{
+			"-o, --output    Output format: table or json (default: table)",
+		},
+		Examples: []string{
+			"namespace-list",
+			"namespace-list -o json",
+		},
+	}
+
+	NamespaceDescribe = CommandHelp{
+		Command:     "namespace-describe",
+		Description: "Show the name, description, type, number of configurations and quota of a namespace.",
+		Parameters: []string{
+			"namespaceId     Required. Namespace ID (public for the default namespace)",
+			"-o, --output    Output format: table or json (default: table)",
+		},
+		Examples: []string{
+			"namespace-describe team-a",
+		},
+	}
+
+	NamespaceCreate = CommandHelp{
+		Command:     "namespace-create",
+		Description: "Create a namespace with the given ID.",
+		Parameters: []string{
+			"namespaceId     Required. Namespace ID",
+			"--name          Namespace name (default: the namespace ID)",
+			"--desc          Namespace description",
+		},
+		Examples: []string{
+			"namespace-create team-a --name \"Team A\" --desc \"Configurations of team A\"",
+			"",
+			"Note:",
+			"  - Use bootstrap-namespace to also create a user and a role for the namespace",
+		},
+	}
+
+	NamespaceUpdate = CommandHelp{
+		Command:     "namespace-update",
+		Description: "Change the name or the description of a namespace; what is not given is kept.",
+		Parameters: []string{
+			"namespaceId     Required. Namespace ID",
+			"--name          New namespace name",
+			"--desc          New namespace description",
+		},
+		Examples: []string{
+			"namespace-update team-a --desc \"Owned by the payments team\"",
+		},
+	}
+
+	NamespaceDelete = CommandHelp{
+		Command:     "namespace-delete",
+		Description: "Delete a namespace after typing its ID to confirm. A namespace that still holds configurations is only deleted with --purge, which deletes them first: the server would leave them behind otherwise.",
+		Parameters: []string{
+			"namespaceId     Required. Namespace ID",
+			"--purge         Delete the configurations of the namespace first",
+			"--no-recycle-bin Purge without keeping a copy in the recycle bin",
+			"--concurrency   Maximum number of concurrent requests while purging (default: 8)",
+			"--force         Skip the confirmation prompt",
+		},
+		Examples: []string{
+			"namespace-delete team-a",
+			"",
+			"# Delete it with its configurations, e.g. from a script",
+			"namespace-delete team-a --purge --force",
+			"",
+			"Note:",
+			"  - The public namespace cannot be deleted",
+			"  - Purged configurations go to the recycle bin and count against --max-changes,",
+			"    like config-delete; the namespace is kept when one of them cannot be deleted",
+		},
+	}
+
+	BootstrapNamespace = CommandHelp{
+		Command:     "bootstrap-namespace",
+		Description: "Create a namespace, a service account user, a role bound to it and the permission of the role on the namespace. Existing parts are reused, so it can run again.",
+		Parameters: []string{
+			"namespaceId     Required. Namespace ID",
+			"--create-user   Required. User to create, or reuse when it exists",
+			"--grant         Access of the role to the namespace: r, w or rw (default: rw)",
+			"--role          Role bound to the user (default: <namespaceId>-<grant>)",
+			"--user-password Password of the new user (default: generated and printed once)",
+			"--name          Namespace name (default: the namespace ID)",
+			"--desc          Namespace description",
+		},
+		Examples: []string{
+			"# Everything a new team needs",
+			"bootstrap-namespace team-a --create-user team-a-deployer --grant rw",
+			"",
+			"Note:",
+			"  - Requires an admin login; the permission resource is <namespaceId>:*:*",
+		},
+	}
+
+	AuthExport = CommandHelp{
+		Command:     "auth-export",
+		Description: "Export all users, roles and permissions to YAML, so RBAC can be kept in version control and rebuilt on a new cluster with auth-import. Passwords are not exported.",
+		Parameters: []string{
+			"-o, --output    File to write the definitions to (default: stdout)",
+		},
+		Examples: []string{
+			"# Snapshot the RBAC of prod",
+			"auth-export -o rbac.yaml",
+			"",
+			"Note:",
+			"  - Requires an admin login",
+		},
+	}
+
+	AuthImport = CommandHelp{
+		Command:     "auth-import",
+		Description: "Create the users, role bindings and permissions of an auth-export file that the server lacks. Existing ones are kept as they are and nothing is removed, so it can run again.",
+		Parameters: []string{
+			"file            Required. YAML file written by auth-export",
+			"--dry-run       Show what would be created without changing anything",
+		},
+		Examples: []string{
+			"# Rebuild RBAC on a new cluster",
+			"auth-import rbac.yaml --profile new-cluster --dry-run",
+			"auth-import rbac.yaml --profile new-cluster",
+			"",
+			"Note:",
+			"  - New users get a generated password, printed once; existing users keep theirs",
+			"  - Requires an admin login",
+		},
+	}
+
+	ConfigEdit = CommandHelp{
+		Command:     "config-edit",
+		Description: "Edit a configuration in $VISUAL/$EDITOR and publish the result.",
+		Parameters: []string{
+			"dataId          Required. Configuration data ID",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--lock-ttl      Lock lifetime without renewal (default: 10m, renewed while editing)",
+			"--no-lock       Edit without taking the advisory lock",
+			"--diff-mode     Diff mode of the preview printed before publishing: line (default), word or semantic",
+		},
+		Examples: []string{
+			"# Edit with the default editor",
+			"config-edit application.yaml DEFAULT_GROUP",
+			"",
+			"# Use VS Code",
+			" EDITOR='code --wait' nacos-cli config-edit application.yaml",
+			"",
+			"Note:",
+			"  - While editing, other config-edit runs on the same dataId fail with",
+			"    \"locked by <user@host> until <time>\"; locks are kept in group NACOS_CLI_LOCK",
+			"  - The publish fails if the config was changed by someone else meanwhile",
+		},
+	}
+
+	ConfigNew = CommandHelp{
+		Command:     "config-new",
+		Description: "Create a configuration from a template, edit it and publish it.",
+		Parameters: []string{
+			"dataId          Required. Configuration data ID",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--template      Required. Template name (see config-template-list)",
+			"--set           Template variable as name=value (repeatable)",
+			"--no-edit       Publish the rendered template without opening the editor",
+		},
+		Examples: []string{
+			"# Scaffold a datasource and review it in the editor",
+			"config-new orders-db.yaml --template spring-datasource --set db=orders",
+			"",
+			"# Publish directly",
+			"config-new order-flow-rules.json SENTINEL_GROUP --template sentinel-flow-rules --set resource=/orders --no-edit",
+			"",
+			"Note:",
+			"  - Built-in templates: spring-datasource, logback, sentinel-flow-rules, gateway-routes",
+			"  - Own templates go in ~/.nacos-cli/templates (or the templates directory of the",
+			"    config file); a file named spring-datasource.yaml replaces the built-in one",
+			"  - In templates, {{ var \"db\" }} is a required variable and {{ var \"port\" \"3306\" }}",
+			"    has a default",
+		},
+	}
+
+	ConfigTemplateList = CommandHelp{
+		Command:     "config-template-list",
+		Description: "List the templates available to config-new with their variables.",
+		Examples: []string{
+			"config-template-list",
+		},
+	}
+
+	SentinelFlowList = CommandHelp{
+		Command:     "sentinel-flow-list",
+		Description: "List the Sentinel flow rules stored in a configuration, validating the rule schema.",
+		Parameters: []string{
+			"dataId          Required. Rule configuration data ID",
+			"group           Optional. Configuration group name or alias (default: SENTINEL_GROUP)",
+		},
+		Examples: []string{
+			"sentinel-flow-list order-service-flow-rules",
+		},
+	}
+
+	SentinelFlowSet = CommandHelp{
+		Command:     "sentinel-flow-set",
+		Description: "Add, update or delete the Sentinel flow rule of a resource.",
+		Parameters: []string{
+			"dataId          Required. Rule configuration data ID",
+			"group           Optional. Configuration group name or alias (default: SENTINEL_GROUP)",
+			"--resource      Required. Resource name; with --limit-app it identifies the rule",
+			"--count         Threshold, required for a new rule",
+			"--grade         qps (default) or thread",
+			"--strategy      direct (default), relate or chain, with --ref-resource",
+			"--behavior      default, warm-up, rate-limiter or warm-up-rate-limiter",
+			"--delete        Delete the rule",
+		},
+		Examples: []string{
+			"# Limit /orders to 50 QPS",
+			"sentinel-flow-set order-service-flow-rules --resource /orders --count 50",
+			"",
+			"# Queue requests instead of rejecting them",
+			"sentinel-flow-set order-service-flow-rules --resource /orders --behavior rate-limiter --max-queue-ms 500",
+			"",
+			"Note:",
+			"  - Only the flags given change an existing rule",
+			"  - The result is validated and published only if nobody changed the rules meanwhile",
+		},
+	}
+
+	GatewayRouteList = CommandHelp{
+		Command:     "gateway-route-list",
+		Description: "List the Spring Cloud Gateway routes stored in a configuration, validating the route schema.",
+		Parameters: []string{
+			"dataId          Required. Route configuration data ID",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+		},
+		Examples: []string{
+			"gateway-route-list gateway-routes.json",
+		},
+	}
+
+	GatewayRouteAdd = CommandHelp{
+		Command:     "gateway-route-add",
+		Description: "Add a Spring Cloud Gateway route to a route configuration.",
+		Parameters: []string{
+			"dataId          Required. Route configuration data ID",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--id            Required. Route ID",
+			"--uri           Required. Route URI, e.g. lb://order-service",
+			"--path          Path predicate pattern (repeatable)",
+			"--predicate     Predicate in shortcut notation, e.g. Method=GET,POST (repeatable)",
+			"--filter        Filter in shortcut notation, e.g. StripPrefix=1 (repeatable)",
+			"--order         Route order",
+			"--replace       Replace an existing route with the same ID",
+		},
+		Examples: []string{
+			"gateway-route-add gateway-routes.json --id orders --uri lb://order-service --path '/api/orders/**' --filter StripPrefix=1",
+		},
+	}
+
+	McpRegistryList = CommandHelp{
+		Command:     "mcp-registry-list",
+		Description: "List the MCP servers in the Nacos 3 AI registry of a namespace.",
+		Parameters: []string{
+			"--name          Filter by name (substring match)",
+			"--output, -o    Output format: table (default) or json",
+		},
+		Examples: []string{
+			"mcp-registry-list",
+			"mcp-registry-list --name weather -o json",
+		},
+	}
+
+	McpRegistryGet = CommandHelp{
+		Command:     "mcp-registry-get",
+		Description: "Show an MCP server definition, its versions and tools.",
+		Parameters: []string{
+			"name            Required. MCP server name",
+			"--version       Version to show (default: latest)",
+			"--output, -o    Output format: table (default) or json",
+		},
+		Examples: []string{
+			"mcp-registry-get weather-mcp",
+			"mcp-registry-get weather-mcp --version 1.0.0 -o json",
+		},
+	}
+
+	McpRegistryPublish = CommandHelp{
+		Command:     "mcp-registry-publish",
+		Description: "Register an MCP server, or publish a new version of an existing one.",
+		Parameters: []string{
+			"--file, -f      Required. Server specification JSON (name, protocol, versionDetail.version, ...)",
+			"--tools         Tool specification JSON",
+			"--endpoint      Endpoint specification JSON, required for remote servers without remoteServerConfig",
+			"--latest        Mark the version as latest (default: true)",
+		},
+		Examples: []string{
+			"mcp-registry-publish -f weather-mcp.json --tools weather-tools.json --endpoint weather-endpoint.json",
+			"",
+			"Note:",
+			"  - The files use the JSON layout of the Nacos console (mcp-registry-get -o json",
+			"    prints it), so a definition can be exported, edited and published again",
+		},
+	}
+
+	ServiceList = CommandHelp{
+		Command:     "service-list",
+		Description: "List the services of a namespace with their instance counts, healthy counts, protect thresholds and health status.",
+		Parameters: []string{
+			"--name string     Filter by service name (substring match)",
+			"--group string    Filter by group (substring match)",
+			"--unhealthy-only  Only list services with unhealthy instances",
+			"--output, -o      Output format: table (default) or json",
+			"--concurrency     Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# The services of the prod namespace",
+			"service-list -n prod",
+			"",
+			"# Services with unhealthy instances, as JSON",
+			"service-list --unhealthy-only -o json",
+			"",
+			"Note:",
+			"  - Status is healthy, degraded (some instances unhealthy), down (none healthy),",
+			"    empty (no instances) or protected (the healthy ratio is at or below the",
+			"    protect threshold, so clients also receive unhealthy instances)",
+		},
+	}
+
+	ServiceHealth = CommandHelp{
+		Command:     "service-health",
+		Description: "Check that a service has at least --min-healthy healthy and enabled instances (exit 0) or not (exit 1). With --watch the check repeats until --timeout elapses and fails as soon as the service drops below the minimum, to verify a deployment.",
+		Parameters: []string{
+			"--name string        Required. Service name",
+			"--group string       Service group (default: DEFAULT_GROUP)",
+			"--cluster string     Only count the instances of this cluster",
+			"--min-healthy int    Minimum number of healthy instances (default: 1)",
+			"--watch              Keep checking for the --timeout window",
+			"--timeout duration   Length of the watch window (default: until interrupted)",
+			"--interval duration  Time between checks with --watch (default: 5s)",
+		},
+		Examples: []string{
+			"# Is orders serving?",
+			"service-health --name orders",
+			"",
+			"# Deployment verification: at least 2 healthy instances for 5 minutes",
+			"service-health --name orders --min-healthy 2 --watch --timeout 300s",
+		},
+	}
+
+	InstanceDrain = CommandHelp{
+		Command:     "instance-drain",
+		Description: "Drain a host for maintenance: find every service instance registered from the IP, disable them (or set their weight to 0), wait for the grace period, then deregister them.",
+		Parameters: []string{
+			"--ip string          Required. IP of the host to drain, or ip:port for one port",
+			"--mode string        disable (default) or weight: set the weight to 0",
+			"--grace duration     Time to wait between draining and deregistering (default: 30s)",
+			"--all-namespaces     Drain the instances of every namespace instead of the current one",
+			"--dry-run            List the instances without changing them",
+			"--yes, -y            Drain without asking for confirmation",
+			"--concurrency        Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# What is registered from the host?",
+			"instance-drain --ip 10.0.0.5 --all-namespaces --dry-run",
+			"",
+			"# Drain it, giving clients a minute to move away",
+			"instance-drain --ip 10.0.0.5 --all-namespaces --grace 1m --yes",
+			"",
+			"Note:",
+			"  - Stop the processes of the host before their ephemeral instances are",
+			"    deregistered, or their heartbeats register them again",
+			"  - The instances count towards the change budget of each namespace",
+		},
+	}
+
+	ServicePrune = CommandHelp{
+		Command:     "service-prune",
+		Description: "Delete the services left without instances, e.g. by crashed applications, after a report. The server does not tell how long a service has been empty, so the state file remembers when each one was first found empty and --older-than counts from there.",
+		Parameters: []string{
+			"--empty               Required. Prune the services without instances",
+			"--older-than string   Only prune the services empty for at least this long, e.g. 7d or 12h",
+			"--group string        Filter by group (substring match)",
+			"--state string        State file (default: ~/.nacos-cli/empty-services.json)",
+			"--dry-run             Report the empty services without deleting them",
+			"--yes, -y             Delete without asking for confirmation",
+			"--output, -o          Output format: table (default) or json",
+		},
+		Examples: []string{
+			"# Which services are empty?",
+			"service-prune --empty --dry-run",
+			"",
+			"# Daily from cron: delete the services empty for a week",
+			"service-prune --empty --older-than 7d --yes -o json",
+			"",
+			"Note:",
+			"  - With --older-than, run it regularly: a service found empty for the first",
+			"    time is only recorded, and one with instances again starts over",
+		},
+	}
+
+	ServiceExport = CommandHelp{
+		Command:     "service-export",
+		Description: "Export the services of the namespace to a YAML bundle: their protect thresholds, metadata, clusters and persistent instances.",
+		Parameters: []string{
+			"-o, --output          Bundle file or s3://, oss://, gs:// URL (default: <namespace>-services-<time>.yaml)",
+			"--group               Only export the services of the groups containing this",
+			"--concurrency         Maximum number of concurrent requests (default: 8)",
+			"--storage-endpoint    Object storage endpoint, e.g. a MinIO server (default: from the URL scheme)",
+			"--storage-region      Object storage region (default: us-east-1 for s3, cn-hangzhou for oss)",
+			"--storage-path-style  Use path-style bucket URLs (MinIO)",
+		},
+		Examples: []string{
+			"# Export the naming registry of prod",
+			"service-export -n prod -o prod-services.yaml",
+			"",
+			"Note:",
+			"  - Ephemeral instances are not exported: their applications register them again",
+		},
+	}
+
+	ServiceImport = CommandHelp{
+		Command:     "service-import",
+		Description: "Create the services of a service-export bundle with their clusters, and register their persistent instances, optionally into another namespace.",
+		Parameters: []string{
+			"bundle                Required. Bundle file or s3://, oss://, gs:// URL",
+			"--to-namespace        Namespace to import into (default: the current namespace)",
+			"--mapping             Mapping file renaming the namespace and groups",
+			"--on-conflict         When a service exists: abort (default), skip or overwrite",
+			"--dry-run             Show what would be imported without changing the server",
+			"--concurrency         Maximum number of concurrent requests (default: 8)",
+			"--storage-endpoint    Object storage endpoint, e.g. a MinIO server (default: from the URL scheme)",
+			"--storage-region      Object storage region (default: us-east-1 for s3, cn-hangzhou for oss)",
+			"--storage-path-style  Use path-style bucket URLs (MinIO)",
+		},
+		Examples: []string{
+			"# Migrate the naming registry of prod to the new cluster",
+			"service-import prod-services.yaml --host nacos-new.internal --to-namespace prod --dry-run",
+			"",
+			"Note:",
+			"  - overwrite updates the service and its clusters and registers the exported",
+			"    instances again; instances missing from the bundle are left alone",
+		},
+	}
+
+	InstanceHeartbeat = CommandHelp{
+		Command:     "instance-heartbeat",
+		Description: "Register an ephemeral instance and keep it alive with heartbeats until interrupted, then deregister it; for integration tests and for simulating instances while troubleshooting.",
+		Parameters: []string{
+			"--service string     Required. Service name",
+			"--ip string          Required. ip:port of the instance",
+			"--group string       Service group (default: DEFAULT_GROUP)",
+			"--cluster string     Cluster of the instance (default: DEFAULT)",
+			"--weight float       Weight of the instance (default: 1)",
+			"--metadata key=value Metadata of the instance (repeatable)",
+			"--interval duration  Time between heartbeats (default: 5s)",
+			"--duration duration  Stop after this long (default: until interrupted)",
+			"--no-deregister      Leave the instance registered on exit, to expire on its own",
+		},
+		Examples: []string{
+			"# Keep a fake orders instance alive during a test run",
+			"instance-heartbeat --service orders --ip 10.0.0.9:8080 --metadata version=canary",
+			"",
+			"# For one minute",
+			"instance-heartbeat --service orders --ip 10.0.0.9:8080 --duration 1m",
+			"",
+			"Note:",
+			"  - Heartbeats use the v1 beat API; an instance the server expired is registered again",
+		},
+	}
+
+	ChaosFlap = CommandHelp{
+		Command:     "chaos-flap",
+		Description: "Register and deregister a synthetic instance of a service every period, to exercise the failover of the applications consuming it. Refuses protected profiles and production-looking profiles or namespaces.",
+		Parameters: []string{
+			"--service string     Required. Service name",
+			"--group string       Service group (default: DEFAULT_GROUP)",
+			"--ip string          ip:port of the synthetic instance (default: 192.0.2.1:8080, unroutable)",
+			"--cluster string     Cluster of the synthetic instance (default: DEFAULT)",
+			"--metadata key=value Metadata of the synthetic instance (repeatable)",
+			"--period duration    Time of one up and down cycle (default: 10s)",
+			"--duration duration  How long to flap (default: 5m)",
+		},
+		Examples: []string{
+			"# Flap an instance of orders in the test namespace for 5 minutes",
+			"chaos-flap --service orders -n test",
+			"",
+			"# Faster, for 1 minute",
+			"chaos-flap --service orders -n test --period 4s --duration 1m",
+			"",
+			"Note:",
+			"  - The instance is up for the first half of each period and carries the metadata chaos=flap",
+			"  - It is deregistered on exit, also on Ctrl+C",
+			"  - --i-know-what-i-am-doing does not lift the production restriction",
+		},
+	}
+
+	Loadgen = CommandHelp{
+		Command:     "loadgen",
+		Description: "Populate a staging cluster with synthetic configurations and services for capacity tests. Configurations are tagged loadgen and loadgen-<run>, services and instances carry the metadata loadgen=<run>, so that loadgen-cleanup removes them. Refuses protected profiles and production-looking profiles or namespaces.",
+		Parameters: []string{
+			"--configs int        Configurations to publish",
+			"--size string        Size of each configuration, e.g. 512, 2kb or 1mb (default: 2kb)",
+			"--services int       Services to create",
+			"--instances int      Persistent instances to register per service, in 198.18.0.0/15",
+			"--group string       Group of the configurations and services (default: LOADGEN_GROUP)",
+			"--prefix string      Prefix of the generated names (default: loadgen)",
+			"--run string         Name of the run, used in the names and tags (default: the current time)",
+			"--concurrency int    Parallel requests (default: 16)",
+		},
+		Examples: []string{
+			"# 10000 configurations of 2 KiB and 500 services of 10 instances",
+			"loadgen -n staging --configs 10000 --size 2kb --services 500 --instances 10",
+			"",
+			"# A named run",
+			"loadgen -n staging --configs 1000 --run baseline",
+		},
+	}
+
+	LoadgenCleanup = CommandHelp{
+		Command:     "loadgen-cleanup",
+		Description: "Remove the configurations and services created by loadgen: configurations by their tag in every group, services with their instances by their metadata in the loadgen group.",
+		Parameters: []string{
+			"--run string         Only remove what this run created (default: every run)",
+			"--group string       Group of the generated services (default: LOADGEN_GROUP)",
+			"--dry-run            Count what would be removed without deleting it",
+			"-y, --yes            Delete without asking for confirmation",
+			"--concurrency int    Parallel requests (default: 16)",
+		},
+		Examples: []string{
+			"# Remove one run",
+			"loadgen-cleanup -n staging --run baseline",
+			"",
+			"# Count what every run left behind",
+			"loadgen-cleanup -n staging --dry-run",
+		},
+	}
+
+	GC = CommandHelp{
+		Command:     "gc",
+		Description: "Delete the temporary configurations published with config-set --ttl whose time to live has passed, reporting them first. Run it from cron or a scheduler to clean up ephemeral test environments. With --unmodified-for, collect the configurations untouched for that long instead: a first run only reports them, and a later run with --delete deletes those of the report that were not modified since.",
+		Parameters: []string{
+			"--group string       Only collect the configurations of this group (default: every group)",
+			"--unmodified-for     Collect the configurations not modified for this long, e.g. 90d, instead of the expired ones",
+			"--exclude-tag string Never collect the configurations with this tag (repeatable)",
+			"--report string      File of the reported stale configurations (default: ~/.nacos-cli/gc-reports.json)",
+			"--delete             Delete the stale configurations of the previous report that were not modified since",
+			"--dry-run            Report what would be deleted without deleting it",
+			"-y, --yes            Delete without asking for confirmation",
+			"--no-recycle-bin     Delete without keeping the configurations in the recycle bin",
+			"--concurrency int    Parallel requests (default: 8)",
+			"-o, --output string  Output format: table or json (default: table)",
+		},
+		Examples: []string{
+			"# Report the expired configurations of the test namespace",
+			"gc -n test --dry-run",
+			"",
+			"# Delete them, e.g. from cron",
+			"gc -n test --yes",
+			"",
+			"# Report the configurations untouched for 90 days, then delete them",
+			"gc -n test --unmodified-for 90d --exclude-tag keep",
+			"gc -n test --unmodified-for 90d --exclude-tag keep --delete",
+			"",
+			"Note:",
+			"  - config-set --ttl tags the configuration temporary and expires:<UTC time>",
+			"  - Stale configurations missing from the report, or modified since, are reported for the next run",
+		},
+	}
+
+	ConfigPatch = CommandHelp{
+		Command:     "config-patch",
+		Description: "Patch a YAML or JSON configuration: read it, apply the patch and publish it only if it did not change meanwhile.",
+		Parameters: []string{
+			"dataId          Required. Configuration data ID",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--type          merge (JSON Merge Patch, RFC 7386: null removes a key) or strategic (default: merge)",
+			"--patch         Patch document, JSON or YAML",
+			"--patch-file    Read the patch document from a file",
+			"--merge-key     Key matching items of lists of mappings for --type strategic (default: name)",
+			"--dry-run       Show the diff without publishing",
+		},
+		Examples: []string{
+			"# Turn on a feature flag",
+			"config-patch app.json --type merge --patch '{\"featureFlags\":{\"newCheckout\":true}}'",
+			"",
+			"# Change one item of a list of routes and remove another",
+			"config-patch gateway.yaml --type strategic --patch '{routes: [{name: api, timeout: 5s}, {name: legacy, $patch: delete}]}'",
+			"",
+			"Note:",
+			"  - The configuration is re-encoded, so one with comments or other formatting is refused",
+		},
+	}
+
+	FlagList = CommandHelp{
+		Command:     "flag-list",
+		Description: "List the feature flags of a flags configuration, validating it: each flag under flags: is true, false or a mapping with enabled, an optional rollout percentage and description.",
+		Parameters: []string{
+			"--data-id       Flags configuration (default: flagsConfig of the profile, else flags.yaml)",
+			"--group         Group of the flags configuration (default: DEFAULT_GROUP)",
+			"-o, --output    Output format: table or json",
+		},
+		Examples: []string{
+			"# List the flags",
+			"flag-list",
+			"",
+			"# Flags of another configuration",
+			"flag-list --data-id checkout-flags.yaml --group SHOP",
+		},
+	}
+
+	FlagEnable = CommandHelp{
+		Command:     "flag-enable",
+		Description: "Enable a feature flag defined in the flags configuration. The change is published only if the configuration did not change meanwhile and is described in its history, with the -m reason.",
+		Parameters: []string{
+			"NAME            Required. Flag to enable",
+			"--rollout       Enable for this percentage of users (0-100)",
+			"--data-id       Flags configuration (default: flagsConfig of the profile, else flags.yaml)",
+			"--group         Group of the flags configuration (default: DEFAULT_GROUP)",
+			"--dry-run       Show the diff without publishing",
+		},
+		Examples: []string{
+			"# Enable a flag",
+			"flag-enable newCheckout -m \"launch\"",
+			"",
+			"# Enable it for a quarter of the users",
+			"flag-enable newCheckout --rollout 25",
+		},
+	}
+
+	FlagDisable = CommandHelp{
+		Command:     "flag-disable",
+		Description: "Disable a feature flag defined in the flags configuration, like flag-enable.",
+		Parameters: []string{
+			"NAME            Required. Flag to disable",
+			"--data-id       Flags configuration (default: flagsConfig of the profile, else flags.yaml)",
+			"--group         Group of the flags configuration (default: DEFAULT_GROUP)",
+			"--dry-run       Show the diff without publishing",
+		},
+		Examples: []string{
+			"# Turn a flag off during an incident",
+			"flag-disable newCheckout -m \"INC-42 checkout errors\"",
+		},
+	}
+
+	ConfigWatch = CommandHelp{
+		Command:     "config-watch",
+		Description: "Watch the configurations matching a data ID pattern and print create, update and delete events. On Nacos 3 the pattern is subscribed to with the gRPC fuzzy watch, so configurations created or deleted later are reported as soon as the server pushes them.",
+		Parameters: []string{
+			"dataIdPattern   Required. Data ID or pattern with *, e.g. 'app-*.yaml'",
+			"--group         Group or group pattern, * for all groups (default: DEFAULT_GROUP)",
+			"--grpc-port     gRPC port of the server (default: the HTTP port + 1000)",
+			"--interval      How often to list the configurations when the server has no fuzzy watch (default: 10s)",
+			"--poll-timeout  How long the server may hold a long poll open (default: 30s)",
+			"--content       Print the new content with each event",
+			"--output, -o    Output format: table (default) or json (one event per line)",
+		},
+		Examples: []string{
+			"# Watch all app configurations in every group",
+			"config-watch 'app-*.yaml' --group '*'",
+			"",
+			"# Feed events to a script",
+			"config-watch '*' -o json | jq -r .dataId",
+			"",
+			"Note:",
+			"  - Creates and deletes come from the fuzzy watch, content changes from HTTP long polling;",
+			"    a configuration created and deleted right away is reported both times",
+			"  - A lost gRPC connection is re-established, and the server reports what changed meanwhile",
+			"  - Servers before Nacos 3 have no fuzzy watch: the configurations are listed every",
+			"    --interval instead, so new ones show up to --interval late and short-lived ones are missed",
+			"  - A failed poll, e.g. while the server restarts, is retried after 1s, 2s, 4s... up to 30s",
+		},
+	}
+
+	ConfigMove = CommandHelp{
+		Command:     "config-mv",
+		Description: "Move a configuration to another namespace or group, exporting its revision history first.",
+		Parameters: []string{
+			"dataId          Required. Configuration data ID",
+			"group           Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--to-namespace  Target namespace ID",
+			"--to-group      Target group (default: the source group)",
+			"--history-file  Audit file (default: <namespace>.<group>.<dataId>.history.json)",
+			"--mapping       Mapping file giving the target namespace, group and data ID",
+			"--force         Overwrite an existing target",
+			"--yes, -y       Delete the source without typing its data ID to confirm",
+		},
+		Examples: []string{
+			"# Move a configuration to the prod namespace",
+			"config-mv application.yaml DEFAULT_GROUP --to-namespace prod",
+			"",
+			"# Rename the group within the current namespace",
+			"config-mv application.yaml --to-group ORDER_GROUP --history-file order.history.json",
+			"",
+			"# Move to the names a mapping file gives",
+			"config-mv application.yaml -n dev --mapping mapping.yaml",
+			"",
+			"Note:",
+			"  - Nacos cannot import history, so the revisions (with content) are written to",
+			"    the audit file before the source is deleted",
+			"  - The source is only deleted after the copy has been published and read back",
+			"  - The audit file holds every revision's content and is only readable by you",
+		},
+	}
+
+	Apply = CommandHelp{
+		Command:     "apply",
+		Description: "Apply a directory of configurations (<group>/<dataId> files) to Nacos.",
+		Parameters: []string{
+			"--dir, -d string      Directory laid out as <group>/<dataId> (default: .)",
+			"--state-file string   Local state file with the last applied content (default: <dir>/.nacos-apply-state.json)",
+			"--state-backend       local, nacos or s3 (default: state.type in <dir>/.nacos-apply.yaml, else local)",
+			"--dry-run             Show what would change without publishing",
+			"--prefer string       Resolve merge conflicts automatically: local or remote",
+			"--prune               Delete configurations applied before but removed locally",
+			"--force               Skip the confirmation prompt for --prune",
+			"--no-recycle-bin      Prune without keeping a copy in the recycle bin",
+			"--project string      Owner recorded in config tags (default: name in .nacos-apply.yaml, else directory name)",
+			"--take-ownership      Modify and prune configurations owned by other projects",
+			"--change string       Apply a change file written by plan instead of the directory",
+			"--require-approvals   With --change, valid approvals required (at least requireApprovals of the profile)",
+		},
+		Examples: []string{
+			"# Preview the changes",
+			"apply --dir ./configs --dry-run",
+			"",
+			"# Apply to the dev namespace",
+			"apply --dir ./configs -n dev",
+			"",
+			"Note:",
+			"  - When the server changed since the last apply, edits are merged three-way",
+			"  - Conflicts are written to the local file with <<<<<<< markers and not published",
+			"  - nacos and s3 state backends let CI runners share state; saves are conditional,",
+			"    a run that lost the race fails instead of overwriting the other run's state",
+			"  - Applied configs are tagged owner:<project>; configs owned by another project",
+			"    are reported as protected and left untouched",
+			"  - apply --change publishes exactly the reviewed content, and only where the",
+			"    server still has the content the change was planned against",
+			"  - --prune asks for confirmation before anything is published; declining",
+			"    leaves the server untouched",
+			"  - Pruned configs are kept in the recycle bin, see config-restore-deleted",
+		},
+	}
+
+	Plan = CommandHelp{
+		Command:     "plan",
+		Description: "Write the changes apply would make for a directory to a change file that reviewers sign with approve.",
+		Parameters: []string{
+			"--dir, -d string      Directory laid out as <group>/<dataId> (default: .)",
+			"--output, -o string   Required. Change file to write",
+			"--project string      Owner recorded in config tags (default: name in .nacos-apply.yaml, else directory name)",
+			"--take-ownership      Include configurations owned by other projects",
+			"--diff                Show each change against the current server content",
+			"--diff-mode           line (default), word, or semantic (YAML/JSON/properties structure)",
+		},
+		Examples: []string{
+			"plan --dir ./configs -n prod --output change.yaml",
+			"approve change.yaml --diff",
+			"apply --change change.yaml -n prod --require-approvals 2",
+			"",
+			"Note:",
+			"  - The change file holds the new contents and the server MD5 they replace;",
+			"    configurations edited on the server meanwhile are reported as conflicts",
+		},
+	}
+
+	Approve = CommandHelp{
+		Command:     "approve",
+		Description: "Review a change file written by plan and add a signed approval to it.",
+		Parameters: []string{
+			"changeFile         Required. Change file written by plan",
+			"--key string       Signing key written by approval-keygen (default: ~/.nacos-cli/approval.key)",
+			"--reviewer string  Reviewer name listed in the approvers of the profile (default: current user)",
+			"--diff             Show each change against the current server content",
+			"--diff-mode        line (default), word, or semantic (YAML/JSON/properties structure)",
+			"--yes, -y          Approve without asking for confirmation",
+		},
+		Examples: []string{
+			"approve change.yaml --diff",
+			"approve change.yaml --diff --diff-mode semantic",
+			"",
+			"Note:",
+			"  - The approval signs a digest of the whole change; editing the file afterwards",
+			"    invalidates it",
+		},
+	}
+
+	ApprovalKeygen = CommandHelp{
+		Command:     "approval-keygen",
+		Description: "Create the ed25519 signing key used by approve and print its public key for the approvers setting.",
+		Parameters: []string{
+			"--output, -o string  Signing key file (default: ~/.nacos-cli/approval.key)",
+			"--force              Replace an existing key",
+		},
+		Examples: []string{
+			"approval-keygen",
+		},
+	}
+
+	ConfigDiff = CommandHelp{
+		Command:     "config-diff",
+		Description: "Show the differences between a configuration in Nacos and a local file.",
+		Parameters: []string{
+			"dataId             Required. Configuration data ID",
+			"group              Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--file, -f         Required. Local file to compare with the server content",
+			"--diff-mode        line (default), word, or semantic (YAML/JSON/properties structure)",
+			"--exit-code        Exit with code 1 when there are differences",
+		},
+		Examples: []string{
+			"# Line diff between the server and a local file",
+			"config-diff application.yaml DEFAULT_GROUP -f ./application.yaml",
+			"",
+			"# Ignore key order and formatting",
+			"config-diff application.yaml -f ./application.yaml --diff-mode semantic",
+		},
+	}
+
+	ConfigImpact = CommandHelp{
+		Command:     "config-impact",
+		Description: "List the clients listening to a configuration, to see who a publish would notify before making it.",
+		Parameters: []string{
+			"--data-id string    Required. Configuration data ID",
+			"--group string      Configuration group (default: DEFAULT_GROUP)",
+			"--file, -f string   New content; shows whether publishing it changes anything",
+			"--output, -o        table (default) or json",
+		},
+		Examples: []string{
+			"# Who listens to app.yaml",
+			"config-impact --data-id app.yaml",
+			"",
+			"# Blast radius of publishing a new version",
+			"config-impact --data-id app.yaml -f new.yaml",
+		},
+	}
+
+	ConfigListeners = CommandHelp{
+		Command:     "config-listeners",
+		Description: "List the clients listening to a configuration with the MD5 each one has; clients without the current content are marked out of date.",
+		Parameters: []string{
+			"--data-id string    Required. Configuration data ID",
+			"--group string      Configuration group (default: DEFAULT_GROUP)",
+			"--output, -o        table (default) or json",
+		},
+		Examples: []string{
+			"# Who is listening to app.yaml",
+			"config-listeners --data-id app.yaml",
+			"",
+			"# In the prod namespace, as JSON",
+			"config-listeners --data-id app.yaml -n prod -o json",
+		},
+	}
+
+	ReportStaleListeners = CommandHelp{
+		Command:     "report-stale-listeners",
+		Description: "Check every configuration of the namespace and list the clients listening with an MD5 that is not the current content, e.g. pods stuck on an old configuration.",
+		Parameters: []string{
+			"--data-id string    Filter by data ID (supports wildcard *)",
+			"--group string      Filter by group (supports wildcard *)",
+			"--output, -o        table (default) or json",
+			"--concurrency int   Maximum number of concurrent requests (default: 8)",
+			"--exit-code         Exit with code 1 when stale clients are found",
+		},
+		Examples: []string{
+			"# Stale clients in the prod namespace",
+			"report-stale-listeners -n prod",
+			"",
+			"# Fail a check when any client of the orders configurations is stale",
+			"report-stale-listeners -n prod --data-id 'orders-*' --exit-code",
+		},
+	}
+
+	UsageSample = CommandHelp{
+		Command:     "usage-sample",
+		Description: "Record which configurations of the namespace have listening clients, for report-unused-configs. Run it from cron or as a daemon with --interval; samples of every server and namespace share one file.",
+		Parameters: []string{
+			"--interval        Keep sampling at this interval until interrupted (default: sample once)",
+			"--file            File of the samples (default: ~/.nacos-cli/config-usage.json)",
+			"--concurrency     Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Sample the prod namespace every 15 minutes",
+			"usage-sample -n prod --interval 15m",
+			"",
+			"Note:",
+			"  - Sample more often than the clients restart, or short-lived ones are missed",
+		},
+	}
+
+	ReportUnusedConfigs = CommandHelp{
+		Command:     "report-unused-configs",
+		Description: "List the probably unused configurations of the namespace: no listening client in any sample of usage-sample and no change in the history for --unused-for. The samples must cover at least that long.",
+		Parameters: []string{
+			"--unused-for      Period without listeners and changes, e.g. 30d or 72h (default: 30d)",
+			"--file            File of the samples (default: ~/.nacos-cli/config-usage.json)",
+			"--output, -o      table (default) or json",
+			"--concurrency     Maximum number of concurrent requests (default: 8)",
+			"--exit-code       Exit with code 1 when configurations are reported",
+		},
+		Examples: []string{
+			"# Candidates for cleanup after a month of sampling",
+			"report-unused-configs -n prod --unused-for 30d",
+			"",
+			"Note:",
+			"  - Clients reading a configuration once without listening are not seen",
+			"  - gc --unmodified-for deletes stale configurations after a report",
+		},
+	}
+
+	ConfigHistory = CommandHelp{
+		Command:     "config-history",
+		Description: "List the revisions of a configuration, newest first, with their history ID, operation, operator, source IP, time and description, or print the content of one revision.",
+		Parameters: []string{
+			"dataId          Configuration data ID",
+			"group           Configuration group (default: DEFAULT_GROUP)",
+			"--limit         Number of most recent revisions to list (default: 20, 0 for all)",
+			"--revision      Print the content of this revision (its history ID)",
+			"-o, --output    Output format: table or json",
+		},
+		Examples: []string{
+			"# Who changed it last?",
+			"config-history application.yaml --limit 5",
+			"",
+			"# Compare a revision with the current content",
+			"config-history application.yaml --revision 42 > old.yaml",
+			"",
+			"Note:",
+			"  - Restore a revision with config-rollback --revision",
+		},
+	}
+
+	ConfigRollback = CommandHelp{
+		Command:     "config-rollback",
+		Description: "Restore a configuration to a revision of its history. The diff is shown first and the current content is saved to a snapshot file before publishing, so the rollback can be undone.",
+		Parameters: []string{
+			"dataId               Required. Configuration data ID",
+			"group                Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--revision int       Required. History ID (nid) of the revision to restore, as listed by config-history",
+			"--dry-run            Show the diff without publishing",
+			"--yes, -y            Roll back without asking for confirmation",
+			"--snapshot-dir       Directory for the snapshot of the current content (default: .)",
+		},
+		Examples: []string{
+			"# See what restoring revision 42 would change",
+			"config-rollback application.yaml --revision 42 --dry-run",
+			"",
+			"# Restore it, keeping a snapshot in ./snapshots",
+			"config-rollback application.yaml DEFAULT_GROUP --revision 42 --snapshot-dir ./snapshots",
+		},
+	}
+
+	ConfigRevertLast = CommandHelp{
+		Command:     "config-revert-last",
+		Description: "Undo the most recent change of a configuration: republish the newest revision of its history with different content, after showing the diff and saving a snapshot like config-rollback.",
+		Parameters: []string{
+			"dataId               Required. Configuration data ID",
+			"group                Optional. Configuration group name or alias (default: DEFAULT_GROUP)",
+			"--dry-run            Show the diff without publishing",
+			"--yes, -y            Revert without asking for confirmation",
+			"--snapshot-dir       Directory for the snapshot of the current content (default: .)",
+		},
+		Examples: []string{
+			"# Undo the last publish of application.yaml",
+			"config-revert-last application.yaml",
+			"",
+			"# Restore a configuration deleted by mistake, without prompting",
+			"config-revert-last application.yaml DEFAULT_GROUP -y",
+		},
+	}
+
+	Probe = CommandHelp{
+		Command:     "probe",
+		Description: "Check that a configuration has the expected MD5 (exit 0) or not (exit 1), for Kubernetes exec probes.",
+		Parameters: []string{
+			"--data-id string      Required. Configuration data ID",
+			"--group string        Configuration group (default: DEFAULT_GROUP)",
+			"--expect-md5 string   Required. Expected MD5 of the configuration content",
+			"--timeout duration    Request timeout (default: 3s)",
+		},
+		Examples: []string{
+			"# Verify the pod runs the intended config version",
+			"probe --data-id app.yaml --expect-md5 5d41402abc4b2a76b9719d911017c592",
+			"",
+			"Note:",
+			"  - The check uses the server's listener cache and returns immediately",
+			"  - Use as a Kubernetes exec liveness/readiness probe",
+		},
+	}
+
+	ClusterClientStats = CommandHelp{
+		Command:     "cluster-client-stats",
+		Description: "Show the client's view of each server node it sent requests to: request count, error rate (transport errors and 5xx), moving average latency and whether the last request succeeded. The node marked * is the healthy one with the lowest latency, which reads prefer.",
+		Parameters: []string{
+			"--probes        Lightweight reads to send to the server before reporting (default: 5)",
+			"-o, --output    Output format: table or json",
+		},
+		Examples: []string{
+			"# How does the server look from here?",
+			"cluster-client-stats --probes 20",
+			"",
+			"Note:",
+			"  - The terminal shows the view of the whole session with the server command",
+		},
+	}
+
+	ConfigGraph = CommandHelp{
+		Command:     "config-graph",
+		Description: "Draw which configurations depend on which as a Graphviz DOT or Mermaid graph. Dependencies are ${nacos:[GROUP/]dataId[:key]} references, spring.cloud.nacos.config shared-configs, shared-dataids and extension-configs, and nacos: locations of spring.config.import. Referenced configurations missing from the namespace are dashed.",
+		Parameters: []string{
+			"--format        Graph format: dot (default) or mermaid",
+			"--data-id       Show only the configurations depending on this one, directly or indirectly",
+			"--group         Group of --data-id (default: DEFAULT_GROUP)",
+			"--selector      Scan only the configurations matching a selector",
+			"--concurrency   Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Render the namespace as an SVG",
+			"config-graph -n prod | dot -Tsvg > configs.svg",
+			"",
+			"# What breaks when common.yaml changes? Paste into a Markdown document",
+			"config-graph --data-id common.yaml --format mermaid",
+		},
+	}
+
+	ManifestImport = CommandHelp{
+		Command:     "manifest-import",
+		Description: "Adopt the configurations already on the server into the apply workflow, like terraform import: each is written to <dest>/<group>/<dataId>, listed with its type, tags, description and app in .nacos-manifest.json, and recorded in the apply state as last applied, so the next apply has a merge base and changes nothing. Configurations owned by another project are skipped.",
+		Parameters: []string{
+			"--dest            Directory to write the tree to (default: .)",
+			"--project         Project name apply records as owner (default: directory name)",
+			"--state-file      Local state file path (default: <dest>/.nacos-apply-state.json)",
+			"--state-backend   State backend: local, nacos or s3",
+			"--selector        Import only the configurations matching a selector",
+			"--force           Overwrite local files whose content differs from the server",
+			"--dry-run         Show what would be imported without writing anything",
+			"--concurrency     Maximum number of concurrent requests (default: 8)",
+		},
+		Examples: []string{
+			"# Put the prod namespace under apply",
+			"manifest-import --namespace prod --dest ./configs",
+			"apply --namespace prod --dir ./configs --dry-run",
+			"",
+			"Note:",
+			"  - .nacos-apply.yaml is written when the directory has none",
+			"  - Until apply labels them with owner:<project>, imported configurations show as adopt",
+		},
+	}
+
+	PolicyTest = CommandHelp{
+		Command:     "policy-test",
+		Description: "Run the test_ rules of the Rego policies that publishes are evaluated against (--policy-dir or policyDir of the profile). Policies define deny and warn rules in package nacos; a publish matching a deny rule is blocked, warn messages are printed. The input has dataId, group, namespace, type, tags, desc, content, parsed (YAML/JSON document or properties map), previous (null for a new configuration), diff, command, user, profile and ticket.",
+		Parameters: []string{
+			"[dir]           Directory of .rego policies and their *_test.rego tests (default: --policy-dir)",
+			"-v, --verbose   List passing tests and the print() output of every test",
+		},
+		Examples: []string{
+			"# Test the policies before committing them",
+			"policy-test ./policies",
+			"",
+			"# Every publish of this run must pass the policies",
+			"config-set app.yaml --policy-dir ./policies < app.yaml",
+			"",
+			"Note:",
+			"  - Exits with status 1 when a test fails",
+			"  - JSON and YAML files in the directory are loaded as data",
+		},
+	}
+
+	Exec = CommandHelp{
+		Command:     "exec",
+		Description: "Fetch configurations and run a command with them injected as environment variables.",
+		Parameters: []string{
+			"--data-id string   Required. Configuration data ID (repeatable)",
+			"--group string     Configuration group (default: DEFAULT_GROUP)",
+			"--prefix string    Prefix for generated variable names",
+			"--as-file          Write configs to temp files referenced by NACOS_CONFIG_<DATAID>",
+			"--resolve-refs     Replace ${nacos:[GROUP/]dataId[:key]} references before injecting",
+			"--watch            Supervise the command and react to config changes",
+			"--restart-signal   On change: restart (default) or a signal such as SIGHUP",
+			"--max-restarts     Crash restarts allowed with --watch (default: 5, -1 unlimited)",
+			"--restart-backoff  Initial crash restart delay, doubled up to --max-backoff",
+			"--poll-timeout     How long the server may hold a long poll open (default: 30s)",
+		},
+		Examples: []string{
+			"# spring.datasource.url in app.properties becomes SPRING_DATASOURCE_URL",
+			"exec --data-id app.properties -- ./myapp",
+			"",
+			"# Multiple configs with a variable prefix",
+			"exec --data-id db.yaml --data-id cache.json --prefix APP_ -- ./myapp --port 8080",
+			"",
+			"# Pass the config as a file: NACOS_CONFIG_LOGBACK_XML=/tmp/.../logback.xml",
+			"exec --data-id logback.xml --as-file -- ./myapp",
+			"",
+			"# Restart the command whenever app.properties changes",
+			"exec --data-id app.properties --watch -- ./myapp",
+			"",
+			"# Rewrite the file and send SIGHUP instead of restarting",
+			"exec --data-id nginx.conf --as-file --watch --restart-signal SIGHUP -- nginx -g 'daemon off;'",
+			"",
+			"Note:",
+			"  - Supported formats: .properties, .env, .yaml, .yml, .json",
+			"  - The command's exit code is returned",
+			"  - --watch follows the injected configs, not the ones they reference",
+		},
+	}
+
 	SkillSync = CommandHelp{
 		Command:     "skill-sync",
 		Description: "Synchronize skills with Nacos (real-time updates).",
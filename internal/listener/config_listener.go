@@ -3,6 +3,7 @@ package listener
 import (
 	"context"
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,6 +12,18 @@ import (
 	"time"
 )
 
+const (
+	// DefaultPollTimeout is how long the server holds a long poll without changes
+	DefaultPollTimeout = 30 * time.Second
+	// pollGrace is added to the poll timeout for the response to arrive
+	pollGrace = 10 * time.Second
+	// requestTimeout bounds the requests that are answered immediately
+	requestTimeout = 10 * time.Second
+	// Reconnect delays after a failed long poll, doubled on every failure
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
 // ConfigItem represents a configuration item being monitored
 type ConfigItem struct {
 	DataID string
@@ -28,21 +41,36 @@ type ConfigListener struct {
 	username    string
 	password    string
 	accessToken string
+	pollTimeout time.Duration
 	httpClient  *http.Client
 }
 
 // NewConfigListener creates a new configuration listener
 func NewConfigListener(serverAddr, username, password string) *ConfigListener {
 	return &ConfigListener{
-		serverAddr: serverAddr,
-		username:   username,
-		password:   password,
-		httpClient: &http.Client{
-			Timeout: 35 * time.Second, // Longer than long-polling timeout
-		},
+		serverAddr:  serverAddr,
+		username:    username,
+		password:    password,
+		pollTimeout: DefaultPollTimeout,
+		// Requests are bounded by their context: long polls by the poll
+		// timeout, other requests by requestTimeout
+		httpClient: &http.Client{},
 	}
 }
 
+// SetPollTimeout sets how long the server may hold a long poll open
+func (l *ConfigListener) SetPollTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		l.pollTimeout = timeout
+	}
+}
+
+// SetTransport sends the listener's requests through rt, such as the
+// transport of the client it listens for
+func (l *ConfigListener) SetTransport(rt http.RoundTripper) {
+	l.httpClient.Transport = rt
+}
+
 // Login gets access token for authentication
 func (l *ConfigListener) Login() error {
 	loginURL := fmt.Sprintf("http://%s/nacos/v1/auth/login", l.serverAddr)
@@ -51,7 +79,14 @@ func (l *ConfigListener) Login() error {
 	data.Set("username", l.username)
 	data.Set("password", l.password)
 
-	resp, err := l.httpClient.PostForm(loginURL, data)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", loginURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := l.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
@@ -76,7 +111,10 @@ func (l *ConfigListener) Login() error {
 	return nil
 }
 
-// StartListening starts long-polling for configuration changes
+// StartListening starts long-polling for configuration changes. Failed polls,
+// such as while the server restarts, are retried with a growing delay. The
+// handler is called at most once per change: the new MD5 is recorded before it
+// runs, so a failing handler is not called again for the same content.
 func (l *ConfigListener) StartListening(items []ConfigItem, handler ChangeHandler, stopCh <-chan struct{}) error {
 	// Keep a map of current items and their MD5
 	currentItems := make(map[string]*ConfigItem)
@@ -94,6 +132,8 @@ func (l *ConfigListener) StartListening(items []ConfigItem, handler ChangeHandle
 		cancel()
 	}()
 
+	delay := minReconnectDelay
+	failing := false
 	for {
 		select {
 		case <-ctx.Done():
@@ -103,17 +143,36 @@ func (l *ConfigListener) StartListening(items []ConfigItem, handler ChangeHandle
 			listeningConfigs := buildListeningConfigs(items)
 
 			// Call listener API
-			changedItems, err := l.longPoll(ctx, listeningConfigs)
+			changedItems, err := l.longPoll(ctx, listeningConfigs, l.pollTimeout, false)
 			if err != nil {
 				// Check if context was cancelled
 				if ctx.Err() != nil {
 					return nil
 				}
-				// Log error and retry
-				fmt.Printf("Long polling error: %v\n", err)
-				time.Sleep(5 * time.Second)
+				fmt.Printf("Long polling error: %v (retrying in %v)\n", err, delay)
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(delay):
+				}
+				delay *= 2
+				if delay > maxReconnectDelay {
+					delay = maxReconnectDelay
+				}
+				failing = true
+				// A restarted server may have forgotten the token
+				if IsUnauthorized(err) && l.username != "" {
+					if err := l.Login(); err != nil {
+						fmt.Printf("Login failed: %v\n", err)
+					}
+				}
 				continue
 			}
+			if failing {
+				fmt.Printf("Long polling resumed\n")
+				failing = false
+				delay = minReconnectDelay
+			}
 
 			// Process changes
 			if len(changedItems) > 0 {
@@ -142,13 +201,12 @@ func (l *ConfigListener) StartListening(items []ConfigItem, handler ChangeHandle
 									// Already deleted and MD5 reset, skip
 									continue
 								}
-								// First time seeing deletion, process it
-								// Config was deleted, call handler to handle deletion
+								// First time seeing deletion, process it. Reset MD5 to
+								// empty first so we can detect if skill is recreated
+								item.MD5 = ""
 								if err := handler(changed.DataID, changed.Group, changed.Tenant); err != nil {
 									fmt.Printf("Handler failed for %s/%s: %v\n", changed.DataID, changed.Group, err)
 								}
-								// Reset MD5 to empty so we can detect if skill is recreated
-								item.MD5 = ""
 							} else {
 								// Item not found in map, this shouldn't happen
 								fmt.Printf("Warning: item not found in currentItems: %s\n", key)
@@ -167,16 +225,13 @@ func (l *ConfigListener) StartListening(items []ConfigItem, handler ChangeHandle
 						}
 					}
 
-					// Call handler
-					if err := handler(changed.DataID, changed.Group, changed.Tenant); err != nil {
-						fmt.Printf("Handler failed for %s/%s: %v\n", changed.DataID, changed.Group, err)
-						continue
-					}
-
-					// Update MD5 only if handler succeeds
+					// Record the MD5 first so the change is handled at most once
 					if item, ok := currentItems[key]; ok {
 						item.MD5 = newMD5
 					}
+					if err := handler(changed.DataID, changed.Group, changed.Tenant); err != nil {
+						fmt.Printf("Handler failed for %s/%s: %v\n", changed.DataID, changed.Group, err)
+					}
 
 					_ = content // Suppress unused warning
 				}
@@ -185,8 +240,29 @@ func (l *ConfigListener) StartListening(items []ConfigItem, handler ChangeHandle
 	}
 }
 
-// longPoll performs a long-polling request
-func (l *ConfigListener) longPoll(ctx context.Context, listeningConfigs string) ([]ConfigItem, error) {
+// SetAccessToken sets the access token used for listener requests (instead of Login)
+func (l *ConfigListener) SetAccessToken(token string) {
+	l.accessToken = token
+}
+
+// CheckChanged asks the server which items differ from its cached MD5 without
+// holding the request open, so the answer comes back immediately
+func (l *ConfigListener) CheckChanged(ctx context.Context, items []ConfigItem) ([]ConfigItem, error) {
+	return l.longPoll(ctx, buildListeningConfigs(items), l.pollTimeout, true)
+}
+
+// WaitChanged long-polls for at most timeout (capped at the poll timeout) and
+// returns the items that changed, or none when the timeout passed without changes
+func (l *ConfigListener) WaitChanged(ctx context.Context, items []ConfigItem, timeout time.Duration) ([]ConfigItem, error) {
+	if timeout > l.pollTimeout {
+		timeout = l.pollTimeout
+	}
+	return l.longPoll(ctx, buildListeningConfigs(items), timeout, false)
+}
+
+// longPoll performs a long-polling request. With noHangup the server answers
+// immediately even when nothing has changed.
+func (l *ConfigListener) longPoll(ctx context.Context, listeningConfigs string, timeout time.Duration, noHangup bool) ([]ConfigItem, error) {
 	listenerURL := fmt.Sprintf("http://%s/nacos/v1/cs/configs/listener", l.serverAddr)
 
 	data := url.Values{}
@@ -196,13 +272,22 @@ func (l *ConfigListener) longPoll(ctx context.Context, listeningConfigs string)
 		listenerURL += "?accessToken=" + l.accessToken
 	}
 
+	deadline := requestTimeout
+	if !noHangup {
+		deadline = timeout + pollGrace
+	}
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
 	req, err := http.NewRequestWithContext(ctx, "POST", listenerURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Long-Pulling-Timeout", "30000")
+	req.Header.Set("Long-Pulling-Timeout", fmt.Sprintf("%d", timeout.Milliseconds()))
+	if noHangup {
+		req.Header.Set("Long-Pulling-Timeout-No-Hangup", "true")
+	}
 
 	resp, err := l.httpClient.Do(req)
 	if err != nil {
@@ -212,7 +297,7 @@ func (l *ConfigListener) longPoll(ctx context.Context, listeningConfigs string)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("listener returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &StatusError{Op: "listener", StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -242,7 +327,13 @@ func (l *ConfigListener) getConfig(dataID, group, tenant string) (string, string
 
 	configURL := fmt.Sprintf("http://%s/nacos/v1/cs/configs?%s", l.serverAddr, params.Encode())
 
-	resp, err := l.httpClient.Get(configURL)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", configURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := l.httpClient.Do(req)
 	if err != nil {
 		return "", "", err
 	}
@@ -250,7 +341,7 @@ func (l *ConfigListener) getConfig(dataID, group, tenant string) (string, string
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", "", fmt.Errorf("get config returned status %d: %s", resp.StatusCode, string(body))
+		return "", "", &StatusError{Op: "get config", StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
 	}
 
 	content, err := io.ReadAll(resp.Body)
@@ -268,6 +359,23 @@ func (l *ConfigListener) getConfig(dataID, group, tenant string) (string, string
 	return string(content), contentMD5, nil
 }
 
+// StatusError is returned when the server answers with an unexpected status
+type StatusError struct {
+	Op         string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d: %s", e.Op, e.StatusCode, e.Body)
+}
+
+// IsUnauthorized reports whether the server rejected the access token
+func IsUnauthorized(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden)
+}
+
 // buildListeningConfigs builds the Listening-Configs parameter
 func buildListeningConfigs(items []ConfigItem) string {
 	var parts []string
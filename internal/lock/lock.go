@@ -0,0 +1,204 @@
+package lock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/listener"
+)
+
+// Group is the group holding lock configurations
+const Group = "NACOS_CLI_LOCK"
+
+// DefaultTTL is how long a lock is valid without renewal
+const DefaultTTL = 10 * time.Minute
+
+// LockedError is returned when a configuration is locked by someone else
+type LockedError struct {
+	Holder    string
+	ExpiresAt time.Time
+}
+
+func (e *LockedError) Error() string {
+	if e.ExpiresAt.IsZero() {
+		return fmt.Sprintf("locked by %s", e.Holder)
+	}
+	return fmt.Sprintf("locked by %s until %s", e.Holder, e.ExpiresAt.Local().Format("15:04"))
+}
+
+// Info is the content of a lock configuration
+type Info struct {
+	Holder     string    `json:"holder"`
+	Session    string    `json:"session"` // distinguishes runs of the same holder
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// Lock is an advisory lock on one configuration, stored as a configuration in
+// the Lock group with the holder identity and an expiry time. Locks that are
+// not renewed expire, so a crashed editor never blocks others for long.
+type Lock struct {
+	client  *client.NacosClient
+	dataID  string // lock configuration data ID
+	holder  string
+	session string
+	ttl     time.Duration
+
+	acquiredAt time.Time
+
+	mu     sync.Mutex
+	md5    string // content MD5 of our lock configuration, for compare-and-swap
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// DataID returns the data ID of the lock configuration for a configuration
+func DataID(group, dataID string) string {
+	return group + ":" + dataID
+}
+
+// DefaultHolder returns user@host for the current process
+func DefaultHolder() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		name += "@" + host
+	}
+	return name
+}
+
+// newSession returns a random identifier for one lock acquisition
+func newSession() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// Acquire takes the lock on group/dataID for holder, failing fast when there is
+// an unexpired lock (also one held by the same holder in another terminal). The lock is renewed in the background until Release.
+func Acquire(nacosClient *client.NacosClient, group, dataID, holder string, ttl time.Duration) (*Lock, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	l := &Lock{
+		client:  nacosClient,
+		dataID:  DataID(group, dataID),
+		holder:  holder,
+		session: newSession(),
+		ttl:     ttl,
+
+		acquiredAt: time.Now(),
+	}
+
+	current, currentMD5, err := l.read()
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && time.Now().Before(current.ExpiresAt) {
+		return nil, &LockedError{Holder: current.Holder, ExpiresAt: current.ExpiresAt}
+	}
+	if err := l.write(currentMD5); err != nil {
+		if errors.Is(err, client.ErrCasMismatch) {
+			return nil, &LockedError{Holder: "another editor (taken concurrently)"}
+		}
+		return nil, err
+	}
+
+	// Creating a lock cannot be conditional, so confirm we won a simultaneous create
+	current, _, err = l.read()
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || current.Session != l.session {
+		if current == nil {
+			return nil, &LockedError{Holder: "another editor"}
+		}
+		return nil, &LockedError{Holder: current.Holder, ExpiresAt: current.ExpiresAt}
+	}
+
+	l.stopCh = make(chan struct{})
+	l.doneCh = make(chan struct{})
+	go l.renew()
+	return l, nil
+}
+
+// Release stops renewal and removes the lock if it is still ours
+func (l *Lock) Release() error {
+	close(l.stopCh)
+	<-l.doneCh
+
+	current, _, err := l.read()
+	if err != nil {
+		return err
+	}
+	if current == nil || current.Session != l.session {
+		return nil
+	}
+	return l.client.DeleteConfig(l.dataID, Group)
+}
+
+// renew extends the expiry while the lock is held
+func (l *Lock) renew() {
+	defer close(l.doneCh)
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stopCh:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			previous := l.md5
+			l.mu.Unlock()
+			if err := l.write(previous); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to renew lock: %v\n", err)
+			}
+		}
+	}
+}
+
+// read returns the current lock, or nil when there is none
+func (l *Lock) read() (*Info, string, error) {
+	content, err := l.client.GetConfig(l.dataID, Group)
+	if errors.Is(err, client.ErrConfigNotFound) || (err == nil && content == "") {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("read lock: %w", err)
+	}
+	var info Info
+	if err := json.Unmarshal([]byte(content), &info); err != nil {
+		// Unreadable lock: treat as expired so it can be replaced
+		return nil, listener.CalculateMD5(content), nil
+	}
+	return &info, listener.CalculateMD5(content), nil
+}
+
+// write publishes our lock with a new expiry, conditional on casMd5 when set
+func (l *Lock) write(casMd5 string) error {
+	now := time.Now()
+	data, err := json.Marshal(Info{Holder: l.holder, Session: l.session, AcquiredAt: l.acquiredAt, ExpiresAt: now.Add(l.ttl)})
+	if err != nil {
+		return err
+	}
+	content := string(data)
+	if err := l.client.PublishConfigWithOptions(l.dataID, Group, content, client.PublishOptions{CasMd5: casMd5}); err != nil {
+		return fmt.Errorf("write lock: %w", err)
+	}
+	l.mu.Lock()
+	l.md5 = listener.CalculateMD5(content)
+	l.mu.Unlock()
+	return nil
+}
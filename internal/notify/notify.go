@@ -0,0 +1,194 @@
+// Package notify posts the summary of apply and push runs to chat webhooks
+// (Slack, DingTalk) or to any HTTP endpoint accepting JSON.
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/config"
+)
+
+// Webhook types
+const (
+	TypeWebhook  = "webhook"
+	TypeSlack    = "slack"
+	TypeDingTalk = "dingtalk"
+)
+
+// Results of a run
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// DefaultTemplate is the message sent when a webhook has no template
+const DefaultTemplate = `{{.Command}} {{if eq .Result "success"}}succeeded{{else}}failed{{end}} on {{.Server}} (namespace {{.Namespace}}) by {{.Actor}}
+{{if .Ticket}}Ticket: {{.Ticket}}
+{{end}}{{if .Message}}{{.Message}}
+{{end}}{{range .Changes}}- {{.Action}} {{.Key}}{{if .Error}}: {{.Error}}{{end}}
+{{else}}No changes
+{{end}}{{if .Link}}Diffs: {{.Link}}
+{{end}}`
+
+// Summary describes a finished run
+type Summary struct {
+	Command   string         `json:"command"`
+	Result    string         `json:"result"` // success | failure
+	Actor     string         `json:"actor"`
+	Server    string         `json:"server"`
+	Namespace string         `json:"namespace"`
+	Profile   string         `json:"profile,omitempty"`
+	Ticket    string         `json:"ticket,omitempty"`
+	Message   string         `json:"message,omitempty"` // --message of the run
+	Changes   []Item         `json:"changes"`           // configurations changed or failed
+	Counts    map[string]int `json:"counts"`            // configurations per action
+	Link      string         `json:"link,omitempty"`
+	Time      time.Time      `json:"time"`
+}
+
+// Item is one configuration of a run
+type Item struct {
+	Action string `json:"action"`
+	Key    string `json:"key"` // group/dataId
+	Error  string `json:"error,omitempty"`
+}
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// Send posts the summary to each webhook whose condition matches it and
+// returns the errors of the webhooks that failed
+func Send(webhooks []config.WebhookConfig, s Summary) error {
+	var errs []error
+	for _, webhook := range webhooks {
+		if !matches(webhook.On, s) {
+			continue
+		}
+		if err := send(webhook, s); err != nil {
+			errs = append(errs, fmt.Errorf("notify %s: %w", describe(webhook), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// matches applies the on condition: always (default), change or failure
+func matches(on string, s Summary) bool {
+	switch on {
+	case "failure":
+		return s.Result == ResultFailure
+	case "change":
+		return s.Result == ResultFailure || len(s.Changes) > 0
+	default:
+		return true
+	}
+}
+
+func send(webhook config.WebhookConfig, s Summary) error {
+	if webhook.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if webhook.Link != "" {
+		link, err := render("link", webhook.Link, s)
+		if err != nil {
+			return err
+		}
+		s.Link = strings.TrimSpace(link)
+	}
+	text := webhook.Template
+	if text == "" {
+		text = DefaultTemplate
+	}
+	message, err := render("template", text, s)
+	if err != nil {
+		return err
+	}
+
+	target := webhook.URL
+	var payload interface{}
+	switch webhook.Type {
+	case TypeSlack:
+		payload = map[string]string{"text": message}
+	case TypeDingTalk:
+		title, _, _ := strings.Cut(message, "\n")
+		payload = map[string]interface{}{
+			"msgtype":  "markdown",
+			"markdown": map[string]string{"title": title, "text": message},
+		}
+		if webhook.Secret != "" {
+			target = dingTalkSign(target, webhook.Secret, time.Now())
+		}
+	case "", TypeWebhook:
+		payload = struct {
+			Message string `json:"message"`
+			Summary
+		}{message, s}
+	default:
+		return fmt.Errorf("unknown type %q (expected %s, %s or %s)", webhook.Type, TypeSlack, TypeDingTalk, TypeWebhook)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(target, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("status=%d", resp.StatusCode)
+	}
+	return nil
+}
+
+// render executes a message template; env reads environment variables such
+// as the ID of the CI build
+func render(name, text string, s Summary) (string, error) {
+	tmpl, err := template.New(name).Funcs(template.FuncMap{"env": os.Getenv}).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", name, err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, s); err != nil {
+		return "", fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return out.String(), nil
+}
+
+// dingTalkSign adds the timestamp and HMAC-SHA256 signature required by
+// DingTalk robots with signing enabled
+func dingTalkSign(target, secret string, now time.Time) string {
+	timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	sign := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	separator := "?"
+	if strings.Contains(target, "?") {
+		separator = "&"
+	}
+	return target + separator + "timestamp=" + timestamp + "&sign=" + url.QueryEscape(sign)
+}
+
+// describe names a webhook in errors without its URL, which holds a token
+func describe(webhook config.WebhookConfig) string {
+	typ := webhook.Type
+	if typ == "" {
+		typ = TypeWebhook
+	}
+	if u, err := url.Parse(webhook.URL); err == nil && u.Host != "" {
+		return typ + " " + u.Host
+	}
+	return typ
+}
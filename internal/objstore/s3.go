@@ -0,0 +1,355 @@
+package objstore
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned (wrapped) when an object does not exist
+	ErrNotFound = errors.New("object not found")
+	// ErrPreconditionFailed is returned (wrapped) when a conditional write is rejected
+	ErrPreconditionFailed = errors.New("object was modified concurrently")
+)
+
+// S3Client is a minimal client for S3-compatible object storage (AWS S3,
+// Aliyun OSS, GCS with HMAC keys, MinIO), signing requests with AWS Signature V4.
+type S3Client struct {
+	Endpoint     string // e.g. s3.us-east-1.amazonaws.com, oss-cn-hangzhou.aliyuncs.com, storage.googleapis.com
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	PathStyle    bool // use <endpoint>/<bucket>/<key> instead of <bucket>.<endpoint>/<key>
+
+	httpClient *http.Client
+}
+
+// NewS3Client creates a client, reading missing credentials from the standard
+// AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY / AWS_SESSION_TOKEN environment variables
+func NewS3Client(endpoint, region, bucket, accessKey, secretKey string) *S3Client {
+	if region == "" {
+		region = "us-east-1"
+	}
+	if endpoint == "" {
+		endpoint = "s3." + region + ".amazonaws.com"
+	}
+	if accessKey == "" {
+		accessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if secretKey == "" {
+		secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	return &S3Client{
+		Endpoint:     endpoint,
+		Region:       region,
+		Bucket:       bucket,
+		AccessKey:    accessKey,
+		SecretKey:    secretKey,
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		httpClient:   &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Get downloads an object and returns its content and ETag
+func (c *S3Client) Get(key string) ([]byte, string, error) {
+	resp, err := c.do(http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("read object %s: %w", key, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", fmt.Errorf("get object %s: %w", key, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("get object %s failed: status=%d, body=%s", key, resp.StatusCode, string(body))
+	}
+	return body, resp.Header.Get("ETag"), nil
+}
+
+// GetStream downloads an object without buffering it; the caller closes the reader
+func (c *S3Client) GetStream(key string) (io.ReadCloser, error) {
+	resp, err := c.do(http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("get object %s: %w", key, ErrNotFound)
+	}
+	return nil, fmt.Errorf("get object %s failed: status=%d, body=%s", key, resp.StatusCode, string(body))
+}
+
+// PutStream uploads size bytes read from r without buffering them, signing the
+// request with an unsigned payload
+func (c *S3Client) PutStream(key string, r io.Reader, size int64) error {
+	resp, err := c.send(http.MethodPut, key, nil, r, size, unsignedPayload, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("put object %s failed: status=%d, body=%s", key, resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// Put uploads an object. A non-empty ifMatch makes the write conditional on the
+// current ETag; ifMatch "*" requires that the object does not exist yet.
+func (c *S3Client) Put(key string, data []byte, ifMatch string) (string, error) {
+	headers := map[string]string{}
+	switch ifMatch {
+	case "":
+	case "*":
+		headers["If-None-Match"] = "*"
+	default:
+		headers["If-Match"] = ifMatch
+	}
+	resp, err := c.do(http.MethodPut, key, nil, data, headers)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusPreconditionFailed || resp.StatusCode == http.StatusConflict {
+		return "", fmt.Errorf("put object %s: %w", key, ErrPreconditionFailed)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("put object %s failed: status=%d, body=%s", key, resp.StatusCode, string(body))
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// Delete removes an object
+func (c *S3Client) Delete(key string) error {
+	resp, err := c.do(http.MethodDelete, key, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("delete object %s failed: status=%d, body=%s", key, resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// Object describes a stored object
+type Object struct {
+	Key          string    `xml:"Key"`
+	Size         int64     `xml:"Size"`
+	LastModified time.Time `xml:"LastModified"`
+}
+
+// List returns the objects whose key starts with prefix, sorted by key
+func (c *S3Client) List(prefix string) ([]Object, error) {
+	var objects []Object
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := c.do(http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("list objects %s: %w", prefix, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("list objects %s failed: status=%d, body=%s", prefix, resp.StatusCode, string(body))
+		}
+
+		var result struct {
+			Contents              []Object `xml:"Contents"`
+			IsTruncated           bool     `xml:"IsTruncated"`
+			NextContinuationToken string   `xml:"NextContinuationToken"`
+		}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("list objects %s: invalid response: %w", prefix, err)
+		}
+		objects = append(objects, result.Contents...)
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// objectURL builds the request URL for a key; the path is escaped as required by SigV4
+func (c *S3Client) objectURL(key string, query url.Values) string {
+	scheme := "https"
+	host := c.Endpoint
+	if i := strings.Index(host, "://"); i >= 0 {
+		scheme = host[:i]
+		host = host[i+3:]
+	}
+	host = strings.TrimSuffix(host, "/")
+
+	path := "/" + uriEncode(strings.TrimPrefix(key, "/"), false)
+	if c.PathStyle {
+		path = "/" + uriEncode(c.Bucket, true) + path
+	} else {
+		host = c.Bucket + "." + host
+	}
+	rawURL := scheme + "://" + host + path
+	if len(query) > 0 {
+		rawURL += "?" + canonicalQuery(query)
+	}
+	return rawURL
+}
+
+// unsignedPayload is the SigV4 payload hash of streamed request bodies
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// do sends a signed request
+func (c *S3Client) do(method, key string, query url.Values, body []byte, headers map[string]string) (*http.Response, error) {
+	return c.send(method, key, query, bytes.NewReader(body), int64(len(body)), sha256Hex(body), headers)
+}
+
+// send sends a request whose body has the given size and SigV4 payload hash
+func (c *S3Client) send(method, key string, query url.Values, body io.Reader, size int64, payloadHash string, headers map[string]string) (*http.Response, error) {
+	if c.Bucket == "" {
+		return nil, fmt.Errorf("object storage bucket is not configured")
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	rawURL := c.objectURL(key, query)
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	c.sign(req, payloadHash, time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, rawURL, err)
+	}
+	return resp, nil
+}
+
+// sign adds the AWS Signature V4 Authorization header
+func (c *S3Client) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	if c.SessionToken != "" {
+		req.Header.Set("x-amz-security-token", c.SessionToken)
+	}
+	if c.AccessKey == "" {
+		return // anonymous access
+	}
+
+	signed := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "if-match" || lower == "if-none-match" {
+			signed[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(signed))
+	for name := range signed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + signed[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+c.SecretKey), date)
+	key = hmacSHA256(key, c.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes query parameters sorted by key with %20 for spaces
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// uriEncode escapes everything except unreserved characters (and '/' unless encodeSlash)
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' || (ch == '/' && !encodeSlash) {
+			b.WriteByte(ch)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,195 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/configenv"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/tester"
+	"gopkg.in/yaml.v3"
+)
+
+// Package is the Rego package policies define their rules in
+const Package = "nacos"
+
+// Input is the configuration change a policy evaluates, available as input
+// in the rules
+type Input struct {
+	Namespace string      `json:"namespace"`
+	Group     string      `json:"group"`
+	DataID    string      `json:"dataId"`
+	Type      string      `json:"type"`
+	Tags      []string    `json:"tags"`
+	Desc      string      `json:"desc"`
+	Content   string      `json:"content"`
+	Parsed    interface{} `json:"parsed"`   // content decoded by type: YAML/JSON document or properties map, null otherwise
+	Previous  *string     `json:"previous"` // content being replaced, null for a new configuration
+	Diff      string      `json:"diff"`     // unified diff from previous to content
+	Command   string      `json:"command"`
+	User      string      `json:"user"`
+	Profile   string      `json:"profile"`
+	Ticket    string      `json:"ticket"`
+}
+
+// Result holds the messages of the deny and warn rules that matched
+type Result struct {
+	Deny []string
+	Warn []string
+}
+
+// Engine evaluates the deny and warn rules of package nacos in the .rego
+// files of a directory; JSON and YAML files there are loaded as data
+type Engine struct {
+	Dir  string
+	deny rego.PreparedEvalQuery
+	warn rego.PreparedEvalQuery
+}
+
+// Load compiles the policies of dir. Test files (*_test.rego) are left out.
+func Load(dir string) (*Engine, error) {
+	ctx := context.Background()
+	prepare := func(rule string) (rego.PreparedEvalQuery, error) {
+		return rego.New(
+			rego.Query("data."+Package+"."+rule),
+			rego.Load([]string{dir}, skipTests),
+		).PrepareForEval(ctx)
+	}
+	deny, err := prepare("deny")
+	if err != nil {
+		return nil, fmt.Errorf("load policies from %s: %w", dir, err)
+	}
+	warn, err := prepare("warn")
+	if err != nil {
+		return nil, fmt.Errorf("load policies from %s: %w", dir, err)
+	}
+	return &Engine{Dir: dir, deny: deny, warn: warn}, nil
+}
+
+func skipTests(_ string, info fs.FileInfo, _ int) bool {
+	return !info.IsDir() && strings.HasSuffix(info.Name(), "_test.rego")
+}
+
+// Evaluate runs the rules on a configuration change
+func (e *Engine) Evaluate(input Input) (Result, error) {
+	doc, err := toDocument(input)
+	if err != nil {
+		return Result{}, err
+	}
+	var result Result
+	if result.Deny, err = messages(e.deny, doc); err != nil {
+		return Result{}, fmt.Errorf("evaluate deny: %w", err)
+	}
+	if result.Warn, err = messages(e.warn, doc); err != nil {
+		return Result{}, fmt.Errorf("evaluate warn: %w", err)
+	}
+	return result, nil
+}
+
+// messages collects the values of a rule, a set or array of messages or a
+// single message; an undefined rule yields none
+func messages(query rego.PreparedEvalQuery, input interface{}) ([]string, error) {
+	rs, err := query.Eval(context.Background(), rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+	var msgs []string
+	for _, r := range rs {
+		for _, expr := range r.Expressions {
+			switch v := expr.Value.(type) {
+			case []interface{}:
+				for _, m := range v {
+					msgs = append(msgs, message(m))
+				}
+			case bool:
+				if v {
+					msgs = append(msgs, "denied")
+				}
+			default:
+				msgs = append(msgs, message(v))
+			}
+		}
+	}
+	sort.Strings(msgs)
+	return msgs, nil
+}
+
+// message renders a rule value; objects with a msg field, as conftest
+// policies produce, show that field
+func message(v interface{}) string {
+	switch m := v.(type) {
+	case string:
+		return m
+	case map[string]interface{}:
+		if s, ok := m["msg"].(string); ok {
+			return s
+		}
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// toDocument converts the input to the plain JSON values OPA evaluates
+func toDocument(input Input) (interface{}, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// Parse decodes content by its type for Input.Parsed; nil when the type is
+// not structured or the content does not parse
+func Parse(typ, content string) interface{} {
+	switch typ {
+	case "properties":
+		return configenv.ParseProperties(content)
+	case "yaml", "json":
+		var doc interface{}
+		if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+			return nil
+		}
+		// Mappings with non-string keys have no JSON form
+		if _, err := json.Marshal(doc); err != nil {
+			return nil
+		}
+		return doc
+	}
+	return nil
+}
+
+// TestResult is the outcome of one test_ rule
+type TestResult struct {
+	Package string
+	Name    string
+	Pass    bool
+	Skip    bool
+	Error   error
+	Output  string // print() output of the test
+}
+
+// Test runs the test_ rules of the policies of dir, test files included
+func Test(dir string) ([]TestResult, error) {
+	results, err := tester.Run(context.Background(), dir)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TestResult, 0, len(results))
+	for _, r := range results {
+		out = append(out, TestResult{
+			Package: strings.TrimPrefix(r.Package, "data."),
+			Name:    r.Name,
+			Pass:    r.Pass(),
+			Skip:    r.Skip,
+			Error:   r.Error,
+			Output:  string(r.Output),
+		})
+	}
+	return out, nil
+}
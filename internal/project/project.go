@@ -0,0 +1,76 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/state"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the per-project settings file at the root of an apply directory
+const FileName = ".nacos-apply.yaml"
+
+// Project holds the settings of a directory managed with apply
+type Project struct {
+	Name  string              `yaml:"name"` // owner recorded on applied configs (default: directory name)
+	State state.BackendConfig `yaml:"state"`
+}
+
+// Load reads <dir>/.nacos-apply.yaml, returning default settings when it does
+// not exist. A non-empty name, e.g. of --project, replaces the project name;
+// the resulting name is validated either way.
+func Load(dir, name string) (*Project, error) {
+	var p Project
+	path := filepath.Join(dir, FileName)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read project file: %w", err)
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("parse project file %s: %w", path, err)
+		}
+	}
+
+	if name != "" {
+		p.Name = name
+	}
+	if p.Name == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		p.Name = filepath.Base(abs)
+	}
+	if strings.ContainsAny(p.Name, ", ") {
+		return nil, fmt.Errorf("invalid project name %q: must not contain commas or spaces", p.Name)
+	}
+	return &p, nil
+}
+
+// OwnerTagPrefix marks the project owning a configuration in its config tags
+const OwnerTagPrefix = "owner:"
+
+// Owner returns the owning project recorded in config tags, or "" when unowned
+func Owner(tags []string) string {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, OwnerTagPrefix) {
+			return strings.TrimPrefix(tag, OwnerTagPrefix)
+		}
+	}
+	return ""
+}
+
+// WithOwner returns tags with the owner tag set to name, keeping the other tags
+func WithOwner(tags []string, name string) []string {
+	result := []string{OwnerTagPrefix + name}
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, OwnerTagPrefix) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Debugger is a RoundTripper that sends requests through Base and logs each one
+// with its status and duration. Server errors are logged with their response
+// headers and body verbatim, secrets redacted, for correlation with the
+// server logs.
+type Debugger struct {
+	Base http.RoundTripper
+	Out  io.Writer
+
+	mu sync.Mutex
+}
+
+// NewDebugger logs the requests sent through base to out
+func NewDebugger(base http.RoundTripper, out io.Writer) *Debugger {
+	return &Debugger{Base: base, Out: out}
+}
+
+// RoundTrip sends the request and logs it
+func (d *Debugger) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := d.Base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	line := fmt.Sprintf("[debug] %s %s", req.Method, redactURL(req.URL))
+	if err != nil {
+		d.printf("%s: %v (%s)\n", line, err, elapsed)
+		return nil, err
+	}
+	body, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !isErrorResponse(resp.StatusCode, body) {
+		d.printf("%s -> %d (%s)\n", line, resp.StatusCode, elapsed)
+		return resp, nil
+	}
+
+	headers := redactHeaders(resp.Header)
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msg := fmt.Sprintf("%s -> %d (%s)\n", line, resp.StatusCode, elapsed)
+	for _, name := range names {
+		msg += fmt.Sprintf("[debug]   %s: %s\n", name, headers[name])
+	}
+	msg += "[debug]   " + strings.TrimRight(secretJSON.ReplaceAllString(string(body), `$1"`+Redacted+`"`), "\n") + "\n"
+	d.printf("%s", msg)
+	return resp, nil
+}
+
+func (d *Debugger) printf(format string, args ...interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fmt.Fprintf(d.Out, format, args...)
+}
+
+// isErrorResponse reports a failed status, or a v3 response with a non-zero code
+func isErrorResponse(status int, body []byte) bool {
+	if status >= 400 {
+		return true
+	}
+	var result struct {
+		Code *int `json:"code"`
+	}
+	if json.Unmarshal(body, &result) != nil || result.Code == nil {
+		return false
+	}
+	return *result.Code != 0 && *result.Code != 200
+}
@@ -0,0 +1,284 @@
+// Package recording records the HTTP exchanges of a run to a session file and
+// replays them later, so that a session attached to a bug report reproduces
+// the problem without access to the server. Credentials are redacted;
+// configuration contents are kept, as they are usually what reproduces it.
+// A Debugger logs the exchanges of a run instead.
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces secrets in a session file
+const Redacted = "REDACTED"
+
+// Interaction is one request and its response
+type Interaction struct {
+	Method          string            `json:"method"`
+	URL             string            `json:"url"` // path and query
+	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody     string            `json:"requestBody,omitempty"`
+	Status          int               `json:"status,omitempty"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody    string            `json:"responseBody,omitempty"`
+	Error           string            `json:"error,omitempty"` // the request failed without a response
+}
+
+// Session is the content of a session file
+type Session struct {
+	RecordedAt   time.Time     `json:"recordedAt"`
+	Args         []string      `json:"args"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+var (
+	secretParams  = []string{"accessToken", "password", "secretKey"}
+	secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Spas-AccessKey", "Spas-Signature", "X-Ca-Key", "X-Ca-Signature"}
+	// skippedHeaders change on every run and do not matter for a replay
+	skippedHeaders = []string{"Date", "Timestamp", "User-Agent", "Content-Length", "Accept-Encoding"}
+	secretJSON     = regexp.MustCompile(`("(?:accessToken|access_token|id_token|refresh_token|password)"\s*:\s*)"[^"]*"`)
+)
+
+// Recorder is a RoundTripper that sends requests through Base and writes every
+// exchange to the session file as it happens, so an aborted run is kept too
+type Recorder struct {
+	Base http.RoundTripper
+	Path string
+
+	mu      sync.Mutex
+	session Session
+}
+
+// NewRecorder records the run with the command line args to path
+func NewRecorder(path string, base http.RoundTripper, args []string) *Recorder {
+	return &Recorder{Base: base, Path: path, session: Session{RecordedAt: time.Now().UTC(), Args: RedactArgs(args)}}
+}
+
+// RoundTrip sends the request and records it with its response
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	interaction := Interaction{
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+		RequestBody:    redactBody(req.Header.Get("Content-Type"), body),
+	}
+
+	resp, err := r.Base.RoundTrip(req)
+	if err != nil {
+		interaction.Error = err.Error()
+		r.add(interaction)
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	interaction.Status = resp.StatusCode
+	interaction.ResponseHeaders = redactHeaders(resp.Header)
+	interaction.ResponseBody = secretJSON.ReplaceAllString(string(respBody), `$1"`+Redacted+`"`)
+	r.add(interaction)
+	return resp, nil
+}
+
+func (r *Recorder) add(interaction Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.session.Interactions = append(r.session.Interactions, interaction)
+	if err := r.save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write session %s: %v\n", r.Path, err)
+	}
+}
+
+func (r *Recorder) save() error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r.session); err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.Path+".tmp", buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(r.Path+".tmp", r.Path)
+}
+
+// Replayer is a RoundTripper answering requests from a session file. Each
+// recorded response is used once, in order, so repeated requests such as
+// polls see the responses they saw when recorded.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// LoadReplayer reads a session file written by a Recorder
+func LoadReplayer(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read session: %w", err)
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("parse session %s: %w", path, err)
+	}
+	return &Replayer{interactions: session.Interactions, used: make([]bool, len(session.Interactions))}, nil
+}
+
+// RoundTrip returns the first unused recorded response to the same request,
+// preferring one whose request body matches too
+func (p *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	target := redactURL(req.URL)
+	requestBody := redactBody(req.Header.Get("Content-Type"), body)
+
+	p.mu.Lock()
+	match := -1
+	for _, sameBody := range []bool{true, false} {
+		for i, interaction := range p.interactions {
+			if p.used[i] || interaction.Method != req.Method || interaction.URL != target {
+				continue
+			}
+			if sameBody && interaction.RequestBody != requestBody {
+				continue
+			}
+			match = i
+			break
+		}
+		if match >= 0 {
+			break
+		}
+	}
+	if match >= 0 {
+		p.used[match] = true
+	}
+	p.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("replay: no recorded response for %s %s", req.Method, target)
+	}
+	interaction := p.interactions[match]
+	if interaction.Error != "" {
+		return nil, fmt.Errorf("replay: %s", interaction.Error)
+	}
+	header := make(http.Header)
+	for key, value := range interaction.ResponseHeaders {
+		header.Set(key, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// readBody reads a body and replaces it with a copy that can be read again
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// redactURL returns the path and the sorted query without secrets
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	redactValues(query)
+	if len(query) == 0 {
+		return u.Path
+	}
+	return u.Path + "?" + query.Encode()
+}
+
+func redactValues(values url.Values) {
+	for _, key := range secretParams {
+		if values.Has(key) {
+			values.Set(key, Redacted)
+		}
+	}
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string)
+	for key := range header {
+		canonical := http.CanonicalHeaderKey(key)
+		if contains(skippedHeaders, canonical) {
+			continue
+		}
+		if contains(secretHeaders, canonical) {
+			redacted[canonical] = Redacted
+			continue
+		}
+		redacted[canonical] = header.Get(key)
+	}
+	return redacted
+}
+
+// redactBody removes secrets from form bodies; other bodies are kept
+func redactBody(contentType string, body []byte) string {
+	if !strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return string(body)
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return string(body)
+	}
+	redactValues(values)
+	return values.Encode()
+}
+
+// RedactArgs hides the values of credential flags on the command line
+func RedactArgs(args []string) []string {
+	secretFlags := []string{"--password", "-p", "--secret-key", "--token", "--access-key", "--user-password"}
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = arg
+		if i > 0 && contains(secretFlags, args[i-1]) {
+			redacted[i] = Redacted
+		}
+		for _, flag := range secretFlags {
+			if strings.HasPrefix(arg, flag+"=") {
+				redacted[i] = flag + "=" + Redacted
+			}
+		}
+	}
+	return redacted
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package recording
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timer is a RoundTripper that sends requests through Base and keeps the
+// duration of each one, response body included, for a breakdown of where the
+// time of a run went.
+type Timer struct {
+	Base http.RoundTripper
+
+	mu    sync.Mutex
+	calls []timedCall
+}
+
+// timedCall is one request seen by a Timer
+type timedCall struct {
+	step       string // "login" or "METHOD /path"
+	start, end time.Time
+}
+
+// NewTimer times the requests sent through base
+func NewTimer(base http.RoundTripper) *Timer {
+	return &Timer{Base: base}
+}
+
+// RoundTrip sends the request and records its duration
+func (t *Timer) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	if err == nil {
+		// Read the body here so that slow transfers count as the request
+		if _, err = readBody(&resp.Body); err != nil {
+			resp = nil
+		}
+	}
+	step := req.Method + " " + req.URL.Path
+	if strings.HasSuffix(req.URL.Path, "/auth/login") || strings.HasSuffix(req.URL.Path, "/auth/user/login") {
+		step = "login"
+	}
+	t.mu.Lock()
+	t.calls = append(t.calls, timedCall{step: step, start: start, end: time.Now()})
+	t.mu.Unlock()
+	return resp, err
+}
+
+// stepTiming is the requests of one step
+type stepTiming struct {
+	step  string
+	count int
+	total time.Duration
+	max   time.Duration
+}
+
+// Print writes the breakdown of the run that started at start: the requests
+// by step, the time spent waiting on the server and the rest, which is local
+// processing and rendering. Concurrent requests overlap, so waiting counts
+// the time at least one request was in flight.
+func (t *Timer) Print(out io.Writer, start time.Time) {
+	t.mu.Lock()
+	calls := append([]timedCall(nil), t.calls...)
+	t.mu.Unlock()
+	end := time.Now()
+
+	steps := make(map[string]*stepTiming)
+	var order []*stepTiming
+	var sum time.Duration
+	for _, c := range calls {
+		s := steps[c.step]
+		if s == nil {
+			s = &stepTiming{step: c.step}
+			steps[c.step] = s
+			order = append(order, s)
+		}
+		d := c.end.Sub(c.start)
+		s.count++
+		s.total += d
+		if d > s.max {
+			s.max = d
+		}
+		sum += d
+	}
+	// Login first, then the slowest steps
+	sort.SliceStable(order, func(i, j int) bool {
+		if (order[i].step == "login") != (order[j].step == "login") {
+			return order[i].step == "login"
+		}
+		return order[i].total > order[j].total
+	})
+
+	sort.Slice(calls, func(i, j int) bool { return calls[i].start.Before(calls[j].start) })
+	var waiting time.Duration
+	var spanStart, spanEnd time.Time
+	for _, c := range calls {
+		if c.start.After(spanEnd) {
+			waiting += spanEnd.Sub(spanStart)
+			spanStart, spanEnd = c.start, c.end
+		} else if c.end.After(spanEnd) {
+			spanEnd = c.end
+		}
+	}
+	waiting += spanEnd.Sub(spanStart)
+	total := end.Sub(start)
+	render := time.Duration(0)
+	if len(calls) > 0 {
+		render = end.Sub(spanEnd)
+	}
+
+	fmt.Fprintln(out, "Timings:")
+	for _, s := range order {
+		fmt.Fprintf(out, "  %-52s %5d x %10s (max %s)\n", s.step, s.count, round(s.total), round(s.max))
+	}
+	fmt.Fprintf(out, "  %-52s %5d x %10s\n", "requests (sum)", len(calls), round(sum))
+	fmt.Fprintf(out, "  %-52s %20s\n", "waiting on the server", round(waiting))
+	fmt.Fprintf(out, "  %-52s %20s\n", "local processing", round(total-waiting-render))
+	fmt.Fprintf(out, "  %-52s %20s\n", "rendering (after the last response)", round(render))
+	fmt.Fprintf(out, "  %-52s %20s\n", "total", round(total))
+}
+
+func round(d time.Duration) time.Duration {
+	if d > time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(10 * time.Microsecond)
+}
@@ -0,0 +1,220 @@
+// Package recyclebin keeps snapshots of deleted configurations so that they
+// can be restored, as Nacos itself only keeps them in the history pages.
+package recyclebin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/config"
+)
+
+// Backend types
+const (
+	BackendLocal = "local"
+	BackendNacos = "nacos"
+)
+
+// Group holds the snapshots in the recycle bin namespace of the nacos backend
+const Group = "NACOS_CLI_RECYCLE"
+
+// Item is the snapshot of a deleted configuration
+type Item struct {
+	ID        string    `json:"id"`
+	Namespace string    `json:"namespace"`
+	Group     string    `json:"group"`
+	DataID    string    `json:"dataId"`
+	Type      string    `json:"type,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	Desc      string    `json:"desc,omitempty"`
+	AppName   string    `json:"appName,omitempty"`
+	Content   string    `json:"content"`
+	DeletedAt time.Time `json:"deletedAt"`
+	DeletedBy string    `json:"deletedBy,omitempty"`
+}
+
+// NewItem snapshots a configuration that is about to be deleted
+func NewItem(namespace string, detail *client.ConfigDetail, deletedBy string) Item {
+	now := time.Now()
+	return Item{
+		ID:        fmt.Sprintf("%s.%s.%s", now.UTC().Format("20060102T150405.000000000"), detail.GroupName, detail.DataID),
+		Namespace: namespace,
+		Group:     detail.GroupName,
+		DataID:    detail.DataID,
+		Type:      detail.Type,
+		Tags:      detail.Tags(),
+		Desc:      detail.Desc,
+		AppName:   detail.AppName,
+		Content:   detail.Content,
+		DeletedAt: now,
+		DeletedBy: deletedBy,
+	}
+}
+
+// Bin stores deleted configurations
+type Bin interface {
+	Put(item Item) error
+	// List returns the items deleted from a namespace, most recent first
+	List(namespace string) ([]Item, error)
+	Remove(item Item) error
+	Describe() string
+}
+
+// Open creates the recycle bin described by cfg; it returns nil for type none.
+// nacosClient is used by the nacos backend, switched to the configured namespace.
+func Open(cfg config.RecycleBinConfig, nacosClient *client.NacosClient) (Bin, error) {
+	switch cfg.Type {
+	case "", BackendLocal:
+		path := cfg.Path
+		if path == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get home directory: %w", err)
+			}
+			path = filepath.Join(home, ".nacos-cli", "recycle-bin")
+		}
+		return &LocalBin{Dir: path}, nil
+	case BackendNacos:
+		if cfg.Namespace == "" {
+			return nil, fmt.Errorf("nacos recycle bin requires a namespace")
+		}
+		return &NacosBin{Client: nacosClient.WithNamespace(cfg.Namespace)}, nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown recycle bin type %q (expected local, nacos or none)", cfg.Type)
+	}
+}
+
+// LocalBin stores each item as a JSON file under <dir>/<namespace>
+type LocalBin struct {
+	Dir string
+}
+
+// Put writes the item file
+func (b *LocalBin) Put(item Item) error {
+	data, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(b.Dir, item.Namespace)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("create recycle bin: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, item.ID+".json"), data, 0600); err != nil {
+		return fmt.Errorf("write recycle bin item: %w", err)
+	}
+	return nil
+}
+
+// List reads the item files of a namespace
+func (b *LocalBin) List(namespace string) ([]Item, error) {
+	files, err := filepath.Glob(filepath.Join(b.Dir, namespace, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var items []Item
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read recycle bin item: %w", err)
+		}
+		var item Item
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, fmt.Errorf("parse recycle bin item %s: %w", file, err)
+		}
+		items = append(items, item)
+	}
+	sortItems(items)
+	return items, nil
+}
+
+// Remove deletes the item file
+func (b *LocalBin) Remove(item Item) error {
+	err := os.Remove(filepath.Join(b.Dir, item.Namespace, item.ID+".json"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove recycle bin item: %w", err)
+	}
+	return nil
+}
+
+// Describe returns a human readable location
+func (b *LocalBin) Describe() string {
+	return b.Dir
+}
+
+// NacosBin stores each item as a configuration in group NACOS_CLI_RECYCLE of a
+// dedicated namespace, with data ID <namespace>.<id>.json
+type NacosBin struct {
+	Client *client.NacosClient
+}
+
+// Put publishes the item configuration
+func (b *NacosBin) Put(item Item) error {
+	data, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		return err
+	}
+	opts := client.PublishOptions{Type: "json"}
+	if err := b.Client.PublishConfigWithOptions(b.dataID(item), Group, string(data), opts); err != nil {
+		return fmt.Errorf("save to recycle bin: %w", err)
+	}
+	return nil
+}
+
+// List reads the item configurations of a namespace
+func (b *NacosBin) List(namespace string) ([]Item, error) {
+	configs, err := b.Client.ListAllConfigs(namespace+".*", Group)
+	if err != nil {
+		return nil, fmt.Errorf("list recycle bin: %w", err)
+	}
+	var items []Item
+	for _, c := range configs {
+		if !strings.HasPrefix(c.DataID, namespace+".") {
+			continue
+		}
+		content, err := b.Client.GetConfig(c.DataID, Group)
+		if err != nil {
+			return nil, fmt.Errorf("read recycle bin item: %w", err)
+		}
+		var item Item
+		if err := json.Unmarshal([]byte(content), &item); err != nil {
+			return nil, fmt.Errorf("parse recycle bin item %s: %w", c.DataID, err)
+		}
+		if item.Namespace == namespace {
+			items = append(items, item)
+		}
+	}
+	sortItems(items)
+	return items, nil
+}
+
+// Remove deletes the item configuration
+func (b *NacosBin) Remove(item Item) error {
+	if err := b.Client.DeleteConfig(b.dataID(item), Group); err != nil {
+		return fmt.Errorf("remove recycle bin item: %w", err)
+	}
+	return nil
+}
+
+// Describe returns a human readable location
+func (b *NacosBin) Describe() string {
+	return "nacos:" + b.Client.Namespace + "/" + Group
+}
+
+func (b *NacosBin) dataID(item Item) string {
+	return item.Namespace + "." + item.ID + ".json"
+}
+
+func sortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+}
@@ -0,0 +1,253 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Error codes of ErrorResponse
+const (
+	CodeUnregistered = 301 // the connection setup has not been processed yet
+	CodeNoHandler    = 302 // the server does not know the request type
+)
+
+// setupWait bounds how long requests wait for the server to register the connection
+const setupWait = 3 * time.Second
+
+// ServerError is a response whose result code is not 200
+type ServerError struct {
+	Type    string // response type, e.g. ErrorResponse
+	Code    int    // error code, e.g. CodeNoHandler
+	Message string
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("%s: code=%d, message=%s", e.Type, e.Code, e.Message)
+}
+
+// response holds the fields every response has
+type response struct {
+	ResultCode int    `json:"resultCode"`
+	ErrorCode  int    `json:"errorCode"`
+	Message    string `json:"message"`
+	RequestID  string `json:"requestId"`
+}
+
+// Options configures a connection
+type Options struct {
+	Token  func() string     // access token sent with every request, empty for none
+	TLS    *tls.Config       // nil for plaintext
+	Labels map[string]string // connection labels, e.g. module: config
+	Push   func(p *Payload)  // called with the requests the server pushes, in order
+}
+
+// Conn is a connection to the gRPC port of a Nacos server (the HTTP port + 1000)
+type Conn struct {
+	ConnectionID string
+
+	cc       *grpc.ClientConn
+	stream   grpc.ClientStream
+	cancel   context.CancelFunc
+	opts     Options
+	clientIP string
+
+	sendMu   sync.Mutex
+	failOnce sync.Once
+	done     chan struct{}
+	err      error
+}
+
+// Dial connects: it checks the server, opens the stream the server pushes
+// requests on and sets the connection up
+func Dial(ctx context.Context, addr string, opts Options) (*Conn, error) {
+	c := &Conn{opts: opts, done: make(chan struct{})}
+	// The connection setup carries the address the server sees the client at
+	var ipMu sync.Mutex
+	var localIP string
+	creds := insecure.NewCredentials()
+	if opts.TLS != nil {
+		creds = credentials.NewTLS(opts.TLS)
+	}
+	cc, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(Codec{})),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+			if err == nil {
+				if host, _, err := net.SplitHostPort(conn.LocalAddr().String()); err == nil {
+					ipMu.Lock()
+					localIP = host
+					ipMu.Unlock()
+				}
+			}
+			return conn, err
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("connect %s: %w", addr, err)
+	}
+	c.cc = cc
+
+	var check struct {
+		response
+		ConnectionID string `json:"connectionId"`
+	}
+	if err := c.unary(ctx, "ServerCheckRequest", map[string]string{"module": "internal"}, &check); err != nil {
+		cc.Close()
+		return nil, fmt.Errorf("connect %s: %w", addr, err)
+	}
+	c.ConnectionID = check.ConnectionID
+	ipMu.Lock()
+	c.clientIP = localIP
+	ipMu.Unlock()
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stream, err := cc.NewStream(streamCtx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, biStreamMethod)
+	if err != nil {
+		cancel()
+		cc.Close()
+		return nil, fmt.Errorf("open stream to %s: %w", addr, err)
+	}
+	c.stream, c.cancel = stream, cancel
+	labels := map[string]string{"source": "sdk"}
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+	setup := map[string]interface{}{"clientVersion": "nacos-cli", "labels": labels, "module": "internal"}
+	if err := c.send(&Payload{Type: "ConnectionSetupRequest", ClientIP: c.clientIP, Body: mustJSON(setup)}); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("set up connection to %s: %w", addr, err)
+	}
+	go c.receive()
+	return c, nil
+}
+
+// Request sends a request and decodes the response into resp. Requests sent
+// right after Dial wait for the server to register the connection.
+func (c *Conn) Request(ctx context.Context, typ string, req, resp interface{}) error {
+	deadline := time.Now().Add(setupWait)
+	for {
+		err := c.unary(ctx, typ, req, resp)
+		var serverErr *ServerError
+		if !errors.As(err, &serverErr) || serverErr.Code != CodeUnregistered || time.Now().After(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.done:
+			return c.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// Done is closed when the connection is lost or closed
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the connection ended, after Done is closed
+func (c *Conn) Err() error {
+	<-c.done
+	return c.err
+}
+
+// Close ends the connection
+func (c *Conn) Close() error {
+	c.fail(errors.New("connection closed"))
+	return nil
+}
+
+func (c *Conn) unary(ctx context.Context, typ string, req, resp interface{}) error {
+	in := &Payload{Type: typ, ClientIP: c.clientIP, Body: mustJSON(req)}
+	if c.opts.Token != nil {
+		if token := c.opts.Token(); token != "" {
+			in.Headers = map[string]string{"accessToken": token}
+		}
+	}
+	out := new(Payload)
+	if err := c.cc.Invoke(ctx, requestMethod, in, out); err != nil {
+		return fmt.Errorf("%s: %w", typ, err)
+	}
+	var status response
+	if err := json.Unmarshal(out.Body, &status); err != nil {
+		return fmt.Errorf("%s: decode %s: %w", typ, out.Type, err)
+	}
+	if status.ResultCode != 200 {
+		return fmt.Errorf("%s: %w", typ, &ServerError{Type: out.Type, Code: status.ErrorCode, Message: status.Message})
+	}
+	if resp != nil {
+		if err := json.Unmarshal(out.Body, resp); err != nil {
+			return fmt.Errorf("%s: decode %s: %w", typ, out.Type, err)
+		}
+	}
+	return nil
+}
+
+// receive answers the requests the server pushes until the stream ends
+func (c *Conn) receive() {
+	for {
+		p := new(Payload)
+		if err := c.stream.RecvMsg(p); err != nil {
+			c.fail(fmt.Errorf("stream: %w", err))
+			return
+		}
+		if !strings.HasSuffix(p.Type, "Request") {
+			continue
+		}
+		var req response
+		json.Unmarshal(p.Body, &req)
+		ack := map[string]interface{}{"resultCode": 200, "requestId": req.RequestID}
+		if err := c.send(&Payload{Type: strings.TrimSuffix(p.Type, "Request") + "Response", ClientIP: c.clientIP, Body: mustJSON(ack)}); err != nil {
+			c.fail(fmt.Errorf("stream: %w", err))
+			return
+		}
+		switch p.Type {
+		case "ConnectResetRequest":
+			c.fail(errors.New("the server asked to reconnect"))
+			return
+		case "ClientDetectionRequest", "SetupAckRequest":
+		default:
+			if c.opts.Push != nil {
+				c.opts.Push(p)
+			}
+		}
+	}
+}
+
+func (c *Conn) send(p *Payload) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return c.stream.SendMsg(p)
+}
+
+// fail ends the connection with err, keeping the first error
+func (c *Conn) fail(err error) {
+	c.failOnce.Do(func() {
+		c.err = err
+		close(c.done)
+		if c.cancel != nil {
+			c.cancel()
+		}
+		c.cc.Close()
+	})
+}
+
+func mustJSON(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("rpc: encode %T: %v", v, err))
+	}
+	return data
+}
@@ -0,0 +1,189 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Change types of fuzzy watch notifications
+const (
+	ConfigAdded   = "ADD_CONFIG"
+	ConfigDeleted = "DELETE_CONFIG"
+)
+
+// ErrFuzzyWatchUnsupported is returned (wrapped) by servers before Nacos 3
+var ErrFuzzyWatchUnsupported = errors.New("fuzzy watch not supported by the server")
+
+// ConfigEvent is a configuration that started or stopped matching a fuzzy
+// watch: created, deleted, or found to differ from the known ones
+type ConfigEvent struct {
+	Namespace string
+	Group     string
+	DataID    string
+	Type      string // ConfigAdded or ConfigDeleted
+}
+
+// GroupKey returns the key Nacos identifies a configuration by,
+// dataId+group+namespace with + and % escaped
+func GroupKey(dataID, group, namespace string) string {
+	return escapeKey(dataID) + "+" + escapeKey(group) + "+" + escapeKey(namespace)
+}
+
+// ParseGroupKey splits a key built by GroupKey
+func ParseGroupKey(key string) (dataID, group, namespace string, err error) {
+	parts := strings.Split(key, "+")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", "", "", fmt.Errorf("invalid group key %q", key)
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "")
+	}
+	return unescapeKey(parts[0]), unescapeKey(parts[1]), unescapeKey(parts[2]), nil
+}
+
+func escapeKey(s string) string {
+	return strings.NewReplacer("%", "%25", "+", "%2B").Replace(s)
+}
+
+func unescapeKey(s string) string {
+	return strings.NewReplacer("%2B", "+", "%25", "%").Replace(s)
+}
+
+// FuzzyPattern returns the group key pattern of a fuzzy watch,
+// namespace>>group>>dataId, where the group and data ID may contain *
+func FuzzyPattern(dataIDPattern, groupPattern, namespace string) string {
+	if namespace == "" {
+		namespace = "public"
+	}
+	return namespace + ">>" + groupPattern + ">>" + dataIDPattern
+}
+
+type fuzzyWatchRequest struct {
+	GroupKeyPattern   string   `json:"groupKeyPattern"`
+	ReceivedGroupKeys []string `json:"receivedGroupKeys"`
+	WatchType         string   `json:"watchType"`
+	Initializing      bool     `json:"initializing"`
+	Module            string   `json:"module"`
+}
+
+type fuzzyWatchSync struct {
+	SyncType string `json:"syncType"`
+	Contexts []struct {
+		GroupKey    string `json:"groupKey"`
+		ChangedType string `json:"changedType"`
+	} `json:"contexts"`
+}
+
+type fuzzyWatchChange struct {
+	GroupKey    string `json:"groupKey"`
+	ChangeType  string `json:"changeType"`
+	ChangedType string `json:"changedType"`
+}
+
+// ConfigWatcher receives the configurations added to and deleted from a
+// fuzzy-watched pattern over a Nacos 3 gRPC connection
+type ConfigWatcher struct {
+	conn   *Conn
+	events chan ConfigEvent
+	stop   chan struct{} // closed with the connection
+}
+
+// WatchConfigs connects to the gRPC address and fuzzy-watches the data ID and
+// group patterns in a namespace; only the token and TLS of opts are used.
+// known lists the group keys of the matching configurations the caller
+// already has; the server reports the differences first, then every
+// configuration created or deleted while the watch lasts.
+func WatchConfigs(ctx context.Context, addr string, opts Options, dataIDPattern, groupPattern, namespace string, known []string) (*ConfigWatcher, error) {
+	w := &ConfigWatcher{events: make(chan ConfigEvent, 256), stop: make(chan struct{})}
+	opts.Labels = map[string]string{"module": "config"}
+	opts.Push = w.push
+	conn, err := Dial(ctx, addr, opts)
+	if err != nil {
+		return nil, err
+	}
+	w.conn = conn
+	go func() {
+		<-conn.Done()
+		close(w.stop)
+	}()
+	if known == nil {
+		known = []string{}
+	}
+	req := fuzzyWatchRequest{
+		GroupKeyPattern:   FuzzyPattern(dataIDPattern, groupPattern, namespace),
+		ReceivedGroupKeys: known,
+		WatchType:         "ADD_WATCH",
+		Initializing:      true,
+		Module:            "config",
+	}
+	if err := conn.Request(ctx, "ConfigFuzzyWatchRequest", req, nil); err != nil {
+		conn.Close()
+		var serverErr *ServerError
+		if errors.As(err, &serverErr) && serverErr.Code == CodeNoHandler {
+			return nil, fmt.Errorf("%w: %v", ErrFuzzyWatchUnsupported, err)
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+// Events delivers the changes in the order the server reported them
+func (w *ConfigWatcher) Events() <-chan ConfigEvent {
+	return w.events
+}
+
+// Done is closed when the connection is lost; the watch must be started again
+func (w *ConfigWatcher) Done() <-chan struct{} {
+	return w.conn.Done()
+}
+
+// Err returns why the watch ended, after Done is closed
+func (w *ConfigWatcher) Err() error {
+	return w.conn.Err()
+}
+
+// Close ends the watch
+func (w *ConfigWatcher) Close() error {
+	return w.conn.Close()
+}
+
+// push turns the notifications of the server into events
+func (w *ConfigWatcher) push(p *Payload) {
+	switch p.Type {
+	case "ConfigFuzzyWatchChangeNotifyRequest":
+		var change fuzzyWatchChange
+		if json.Unmarshal(p.Body, &change) != nil {
+			return
+		}
+		changeType := change.ChangeType
+		if changeType == "" {
+			changeType = change.ChangedType
+		}
+		w.emit(change.GroupKey, changeType)
+	case "ConfigFuzzyWatchSyncRequest":
+		var sync fuzzyWatchSync
+		if json.Unmarshal(p.Body, &sync) != nil {
+			return
+		}
+		for _, c := range sync.Contexts {
+			w.emit(c.GroupKey, c.ChangedType)
+		}
+	}
+}
+
+func (w *ConfigWatcher) emit(groupKey, changeType string) {
+	if changeType != ConfigAdded && changeType != ConfigDeleted {
+		return
+	}
+	dataID, group, namespace, err := ParseGroupKey(groupKey)
+	if err != nil {
+		return
+	}
+	select {
+	case w.events <- ConfigEvent{Namespace: namespace, Group: group, DataID: dataID, Type: changeType}:
+	case <-w.stop:
+	}
+}
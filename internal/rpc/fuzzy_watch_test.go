@@ -0,0 +1,153 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/fakenacos"
+	"github.com/nov11/nacos-cli/internal/rpc"
+)
+
+func TestPayloadRoundTrip(t *testing.T) {
+	in := rpc.Payload{
+		Type:     "ConfigFuzzyWatchRequest",
+		ClientIP: "10.0.0.1",
+		Headers:  map[string]string{"accessToken": "token"},
+		Body:     []byte(`{"watchType":"ADD_WATCH"}`),
+	}
+	var out rpc.Payload
+	if err := out.Unmarshal(in.Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestGroupKey(t *testing.T) {
+	tests := []struct {
+		dataID, group, namespace string
+		key                      string
+	}{
+		{"app.yaml", "DEFAULT_GROUP", "public", "app.yaml+DEFAULT_GROUP+public"},
+		{"a+b%c", "G+1", "dev", "a%2Bb%25c+G%2B1+dev"},
+	}
+	for _, tt := range tests {
+		key := rpc.GroupKey(tt.dataID, tt.group, tt.namespace)
+		if key != tt.key {
+			t.Errorf("GroupKey(%q, %q, %q) = %q, want %q", tt.dataID, tt.group, tt.namespace, key, tt.key)
+		}
+		dataID, group, namespace, err := rpc.ParseGroupKey(key)
+		if err != nil || dataID != tt.dataID || group != tt.group || namespace != tt.namespace {
+			t.Errorf("ParseGroupKey(%q) = %q, %q, %q, %v", key, dataID, group, namespace, err)
+		}
+	}
+}
+
+func TestWatchConfigs(t *testing.T) {
+	srv := fakenacos.New()
+	defer srv.Close()
+	srv.SetConfig("public", "DEFAULT_GROUP", "app-a.yaml", "a: 1")
+	srv.SetConfig("public", "DEFAULT_GROUP", "app-b.yaml", "b: 1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// The caller knows app-a.yaml and a config deleted since
+	known := []string{rpc.GroupKey("app-a.yaml", "DEFAULT_GROUP", "public"), rpc.GroupKey("app-old.yaml", "DEFAULT_GROUP", "public")}
+	w, err := rpc.WatchConfigs(ctx, srv.GRPCAddr(), rpc.Options{}, "app-*", "DEFAULT_GROUP", "public", known)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	next := func() rpc.ConfigEvent {
+		t.Helper()
+		select {
+		case e := <-w.Events():
+			return e
+		case <-ctx.Done():
+			t.Fatal("no event")
+		}
+		return rpc.ConfigEvent{}
+	}
+	initial := map[string]string{}
+	for i := 0; i < 2; i++ {
+		e := next()
+		initial[e.DataID] = e.Type
+	}
+	if want := map[string]string{"app-b.yaml": rpc.ConfigAdded, "app-old.yaml": rpc.ConfigDeleted}; !reflect.DeepEqual(initial, want) {
+		t.Errorf("initial events = %v, want %v", initial, want)
+	}
+
+	// A config created and deleted right away is reported both times, others not at all
+	srv.SetConfig("public", "DEFAULT_GROUP", "app-c.yaml", "c: 1")
+	srv.SetConfig("public", "DEFAULT_GROUP", "other.yaml", "x: 1")
+	srv.SetConfig("public", "DEFAULT_GROUP", "app-a.yaml", "a: 2")
+	srv.DeleteConfig("public", "DEFAULT_GROUP", "app-c.yaml")
+	for _, want := range []rpc.ConfigEvent{
+		{Namespace: "public", Group: "DEFAULT_GROUP", DataID: "app-c.yaml", Type: rpc.ConfigAdded},
+		{Namespace: "public", Group: "DEFAULT_GROUP", DataID: "app-c.yaml", Type: rpc.ConfigDeleted},
+	} {
+		if e := next(); e != want {
+			t.Errorf("event = %+v, want %+v", e, want)
+		}
+	}
+}
+
+func TestWatchConfigsAuth(t *testing.T) {
+	srv := fakenacos.New()
+	defer srv.Close()
+	srv.AuthEnabled = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := rpc.WatchConfigs(ctx, srv.GRPCAddr(), rpc.Options{}, "*", "*", "public", nil); err == nil {
+		t.Error("watch without a token succeeded")
+	}
+
+	c := client.NewClient(srv.Addr())
+	w, err := rpc.WatchConfigs(ctx, srv.GRPCAddr(), rpc.Options{Token: c.AccessToken}, "*", "*", "public", nil)
+	if err != nil {
+		t.Fatalf("watch with a token: %v", err)
+	}
+	w.Close()
+}
+
+func TestWatchConfigsUnsupported(t *testing.T) {
+	srv := fakenacos.New()
+	defer srv.Close()
+	srv.NoFuzzyWatch = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := rpc.WatchConfigs(ctx, srv.GRPCAddr(), rpc.Options{}, "*", "*", "public", nil)
+	if !errors.Is(err, rpc.ErrFuzzyWatchUnsupported) {
+		t.Errorf("err = %v, want ErrFuzzyWatchUnsupported", err)
+	}
+}
+
+func TestWatchConfigsReset(t *testing.T) {
+	srv := fakenacos.New()
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	w, err := rpc.WatchConfigs(ctx, srv.GRPCAddr(), rpc.Options{}, "*", "*", "public", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	srv.CloseConnections()
+	select {
+	case <-w.Done():
+		if w.Err() == nil {
+			t.Error("Err() = nil after the server reset the connection")
+		}
+	case <-ctx.Done():
+		t.Fatal("watch did not end after the server reset the connection")
+	}
+}
@@ -0,0 +1,165 @@
+// Package rpc speaks the gRPC protocol of Nacos 2 and 3: every request and
+// response is a Payload whose metadata names the Java class of the JSON body,
+// sent over the unary Request service or the BiRequestStream the server pushes
+// requests on. The messages are encoded by hand, so no generated code is needed.
+package rpc
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Method names of the services in nacos_grpc_service.proto, which has no package
+const (
+	requestMethod  = "/Request/request"
+	biStreamMethod = "/BiRequestStream/requestBiStream"
+)
+
+// Payload is the message of every Nacos gRPC call
+type Payload struct {
+	Type     string            // Java class name of the body, e.g. ServerCheckRequest
+	ClientIP string            // address of the client, required by the connection setup
+	Headers  map[string]string // request headers, e.g. accessToken
+	Body     []byte            // JSON body (the value of a google.protobuf.Any)
+}
+
+// Marshal encodes the payload:
+//
+//	message Metadata { string type = 3; map<string, string> headers = 7; string clientIp = 8; }
+//	message Payload { Metadata metadata = 2; google.protobuf.Any body = 3; }
+func (p *Payload) Marshal() []byte {
+	var meta []byte
+	meta = appendString(meta, 3, p.Type)
+	for key, value := range p.Headers {
+		var entry []byte
+		entry = appendString(entry, 1, key)
+		entry = appendString(entry, 2, value)
+		meta = protowire.AppendTag(meta, 7, protowire.BytesType)
+		meta = protowire.AppendBytes(meta, entry)
+	}
+	meta = appendString(meta, 8, p.ClientIP)
+
+	var body []byte
+	if len(p.Body) > 0 {
+		body = protowire.AppendTag(body, 2, protowire.BytesType)
+		body = protowire.AppendBytes(body, p.Body)
+	}
+
+	var b []byte
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, meta)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	b = protowire.AppendBytes(b, body)
+	return b
+}
+
+// Unmarshal decodes a payload written by Marshal or a Nacos server
+func (p *Payload) Unmarshal(b []byte) error {
+	*p = Payload{}
+	return walkFields(b, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 2:
+			return p.unmarshalMetadata(value)
+		case 3:
+			return walkFields(value, func(num protowire.Number, value []byte) error {
+				if num == 2 {
+					p.Body = append([]byte(nil), value...)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+}
+
+func (p *Payload) unmarshalMetadata(b []byte) error {
+	return walkFields(b, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 3:
+			p.Type = string(value)
+		case 8:
+			p.ClientIP = string(value)
+		case 7:
+			var key, val string
+			err := walkFields(value, func(num protowire.Number, value []byte) error {
+				if num == 1 {
+					key = string(value)
+				} else if num == 2 {
+					val = string(value)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if p.Headers == nil {
+				p.Headers = make(map[string]string)
+			}
+			p.Headers[key] = val
+		}
+		return nil
+	})
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+// walkFields calls fn with the length-delimited fields of a message and skips the others
+func walkFields(b []byte, fn func(num protowire.Number, value []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("decode payload: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return fmt.Errorf("decode payload: %w", protowire.ParseError(n))
+			}
+			b = b[n:]
+			continue
+		}
+		value, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return fmt.Errorf("decode payload: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+		if err := fn(num, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Codec is the gRPC codec of payloads, used by the client and by fake servers
+type Codec struct{}
+
+// Marshal encodes a *Payload
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	p, ok := v.(*Payload)
+	if !ok {
+		return nil, fmt.Errorf("rpc codec: cannot marshal %T", v)
+	}
+	return p.Marshal(), nil
+}
+
+// Unmarshal decodes into a *Payload
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	p, ok := v.(*Payload)
+	if !ok {
+		return fmt.Errorf("rpc codec: cannot unmarshal into %T", v)
+	}
+	return p.Unmarshal(data)
+}
+
+// Name is the content subtype the servers expect (application/grpc+proto)
+func (Codec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RouteDefinition is a Spring Cloud Gateway route as stored for dynamic routing
+type RouteDefinition struct {
+	ID         string                 `json:"id"`
+	URI        string                 `json:"uri"`
+	Order      int                    `json:"order"`
+	Predicates []Definition           `json:"predicates"`
+	Filters    []Definition           `json:"filters"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Definition is a named predicate or filter with its arguments
+type Definition struct {
+	Name string            `json:"name"`
+	Args map[string]string `json:"args,omitempty"`
+}
+
+// ParseShortcut parses the shortcut notation "Path=/a/**,/b/**" into a
+// definition with generated argument keys, as Spring Cloud Gateway does
+func ParseShortcut(s string) (Definition, error) {
+	name, args, _ := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Definition{}, fmt.Errorf("invalid definition %q (expected Name=arg1,arg2)", s)
+	}
+	d := Definition{Name: name}
+	if args != "" {
+		d.Args = make(map[string]string)
+		for i, arg := range strings.Split(args, ",") {
+			d.Args[fmt.Sprintf("_genkey_%d", i)] = strings.TrimSpace(arg)
+		}
+	}
+	return d, nil
+}
+
+// String renders the definition in shortcut notation
+func (d Definition) String() string {
+	if len(d.Args) == 0 {
+		return d.Name
+	}
+	keys := make([]string, 0, len(d.Args))
+	for k := range d.Args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		if strings.HasPrefix(k, "_genkey_") {
+			values[i] = d.Args[k]
+		} else {
+			values[i] = k + ":" + d.Args[k]
+		}
+	}
+	return d.Name + "=" + strings.Join(values, ",")
+}
+
+// ParseRoutes parses and validates a route list; empty content is an empty list
+func ParseRoutes(content string) ([]RouteDefinition, error) {
+	var routes []RouteDefinition
+	if strings.TrimSpace(content) == "" {
+		return routes, nil
+	}
+	if err := decodeStrict(content, &routes); err != nil {
+		return nil, fmt.Errorf("invalid gateway routes: %w", err)
+	}
+	if err := ValidateRoutes(routes); err != nil {
+		return nil, err
+	}
+	return routes, nil
+}
+
+// ValidateRoutes checks route IDs, URIs and predicates
+func ValidateRoutes(routes []RouteDefinition) error {
+	var problems []string
+	seen := make(map[string]bool)
+	for i, r := range routes {
+		at := fmt.Sprintf("route %d (%s)", i+1, r.ID)
+		if r.ID == "" {
+			problems = append(problems, fmt.Sprintf("route %d: id is empty", i+1))
+		} else if seen[r.ID] {
+			problems = append(problems, fmt.Sprintf("%s: duplicate id", at))
+		}
+		seen[r.ID] = true
+		if u, err := url.Parse(r.URI); err != nil || u.Scheme == "" {
+			problems = append(problems, fmt.Sprintf("%s: uri %q needs a scheme such as lb://, http:// or ws://", at, r.URI))
+		}
+		if len(r.Predicates) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: no predicates, the route would never match", at))
+		}
+		for _, d := range append(append([]Definition{}, r.Predicates...), r.Filters...) {
+			if d.Name == "" {
+				problems = append(problems, fmt.Sprintf("%s: predicate or filter without name", at))
+			}
+		}
+	}
+	return problemsError("gateway routes", problems)
+}
+
+// FindRoute returns the index of the route with the given ID, or -1
+func FindRoute(routes []RouteDefinition, id string) int {
+	for i, r := range routes {
+		if r.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Sentinel flow rule grades
+const (
+	GradeThread = 0
+	GradeQPS    = 1
+)
+
+// FlowRule is a Sentinel flow control rule as stored by the Nacos datasource
+type FlowRule struct {
+	Resource          string          `json:"resource"`
+	LimitApp          string          `json:"limitApp,omitempty"`
+	Grade             int             `json:"grade"`
+	Count             float64         `json:"count"`
+	Strategy          int             `json:"strategy"`
+	RefResource       string          `json:"refResource,omitempty"`
+	ControlBehavior   int             `json:"controlBehavior"`
+	WarmUpPeriodSec   int             `json:"warmUpPeriodSec,omitempty"`
+	MaxQueueingTimeMs int             `json:"maxQueueingTimeMs,omitempty"`
+	ClusterMode       bool            `json:"clusterMode"`
+	ClusterConfig     json.RawMessage `json:"clusterConfig,omitempty"`
+}
+
+var (
+	gradeNames    = []string{"thread", "qps"}
+	strategyNames = []string{"direct", "relate", "chain"}
+	behaviorNames = []string{"default", "warm-up", "rate-limiter", "warm-up-rate-limiter"}
+)
+
+// GradeName, StrategyName and BehaviorName render the numeric rule fields
+func GradeName(v int) string    { return enumName(gradeNames, v) }
+func StrategyName(v int) string { return enumName(strategyNames, v) }
+func BehaviorName(v int) string { return enumName(behaviorNames, v) }
+
+// ParseGrade, ParseStrategy and ParseBehavior accept a name or its number
+func ParseGrade(s string) (int, error)    { return parseEnum("grade", gradeNames, s) }
+func ParseStrategy(s string) (int, error) { return parseEnum("strategy", strategyNames, s) }
+func ParseBehavior(s string) (int, error) { return parseEnum("behavior", behaviorNames, s) }
+
+// ParseFlowRules parses and validates a flow rule list; empty content is an empty list
+func ParseFlowRules(content string) ([]FlowRule, error) {
+	var rules []FlowRule
+	if strings.TrimSpace(content) == "" {
+		return rules, nil
+	}
+	if err := decodeStrict(content, &rules); err != nil {
+		return nil, fmt.Errorf("invalid flow rules: %w", err)
+	}
+	if err := ValidateFlowRules(rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ValidateFlowRules checks the fields Sentinel would reject or silently ignore
+func ValidateFlowRules(rules []FlowRule) error {
+	var problems []string
+	seen := make(map[string]bool)
+	for i, r := range rules {
+		at := fmt.Sprintf("rule %d (%s)", i+1, r.Resource)
+		if r.Resource == "" {
+			problems = append(problems, fmt.Sprintf("rule %d: resource is empty", i+1))
+		}
+		if r.Grade != GradeThread && r.Grade != GradeQPS {
+			problems = append(problems, fmt.Sprintf("%s: grade %d is not 0 (thread) or 1 (qps)", at, r.Grade))
+		}
+		if r.Count < 0 {
+			problems = append(problems, fmt.Sprintf("%s: count must not be negative", at))
+		}
+		if r.Strategy < 0 || r.Strategy >= len(strategyNames) {
+			problems = append(problems, fmt.Sprintf("%s: strategy %d is not 0 (direct), 1 (relate) or 2 (chain)", at, r.Strategy))
+		} else if r.Strategy != 0 && r.RefResource == "" {
+			problems = append(problems, fmt.Sprintf("%s: strategy %s requires refResource", at, StrategyName(r.Strategy)))
+		}
+		if r.ControlBehavior < 0 || r.ControlBehavior >= len(behaviorNames) {
+			problems = append(problems, fmt.Sprintf("%s: controlBehavior %d is not 0-3", at, r.ControlBehavior))
+		} else if r.ControlBehavior != 0 && r.Grade != GradeQPS {
+			problems = append(problems, fmt.Sprintf("%s: controlBehavior %s requires grade qps", at, BehaviorName(r.ControlBehavior)))
+		}
+		key := r.Resource + "\x00" + limitApp(r)
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf("%s: duplicate rule for limitApp %s", at, limitApp(r)))
+		}
+		seen[key] = true
+	}
+	return problemsError("flow rules", problems)
+}
+
+// FindFlowRule returns the index of the rule for resource and limitApp, or -1
+func FindFlowRule(rules []FlowRule, resource, app string) int {
+	if app == "" {
+		app = "default"
+	}
+	for i, r := range rules {
+		if r.Resource == resource && limitApp(r) == app {
+			return i
+		}
+	}
+	return -1
+}
+
+func limitApp(r FlowRule) string {
+	if r.LimitApp == "" {
+		return "default"
+	}
+	return r.LimitApp
+}
+
+// Encode renders rules as indented JSON, the format used by the Sentinel dashboard
+func Encode(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// decodeStrict decodes JSON, rejecting unknown fields (usually misspelled keys)
+func decodeStrict(content string, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader([]byte(content)))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+func enumName(names []string, v int) string {
+	if v >= 0 && v < len(names) {
+		return names[v]
+	}
+	return fmt.Sprintf("%d", v)
+}
+
+func parseEnum(what string, names []string, s string) (int, error) {
+	for i, name := range names {
+		if s == name || s == fmt.Sprintf("%d", i) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid %s %q (expected %s)", what, s, strings.Join(names, ", "))
+}
+
+func problemsError(what string, problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid %s:\n  %s", what, strings.Join(problems, "\n  "))
+}
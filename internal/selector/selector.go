@@ -0,0 +1,375 @@
+// Package selector implements the --selector expressions that scope commands
+// to the configurations whose metadata match, e.g.
+//
+//	group in (A, B) && dataId =~ "^svc-" && tag == "managed"
+//
+// A comparison is a field, an operator and a value: == and != compare names,
+// =~ and !~ match a regular expression anywhere in the name, and in / not in
+// compare with a parenthesized list. Comparisons combine with &&, || and !,
+// and parentheses group them. Values are bare words or "quoted" strings.
+// tag compares every tag of a configuration: tag == x holds when x is one of
+// them, tag != x when none is.
+package selector
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Fields are the fields a selector may compare
+var Fields = []string{"group", "dataId", "type", "appName", "tag"}
+
+// Config is the metadata a selector is evaluated against
+type Config struct {
+	Group   string
+	DataID  string
+	Type    string
+	AppName string
+	Tags    []string
+}
+
+// Selector is a parsed selector expression
+type Selector struct {
+	expr string
+	root node
+	tags bool
+}
+
+// Parse parses a selector expression
+func Parse(expr string) (*Selector, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.or()
+	if err == nil && p.peek().kind != tokenEnd {
+		err = p.errorf("unexpected %s", p.peek())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+	return &Selector{expr: expr, root: root, tags: p.tags}, nil
+}
+
+// Match reports whether a configuration is selected. A nil Selector selects
+// every configuration.
+func (s *Selector) Match(c Config) bool {
+	return s == nil || s.root.eval(c)
+}
+
+// NeedsTags reports whether the selector compares tags, which configuration
+// lists do not return: they must be read from the details of each configuration
+func (s *Selector) NeedsTags() bool {
+	return s != nil && s.tags
+}
+
+// String returns the expression the selector was parsed from
+func (s *Selector) String() string {
+	if s == nil {
+		return ""
+	}
+	return s.expr
+}
+
+type node interface {
+	eval(c Config) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(c Config) bool { return n.left.eval(c) && n.right.eval(c) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(c Config) bool { return n.left.eval(c) || n.right.eval(c) }
+
+type notNode struct{ operand node }
+
+func (n notNode) eval(c Config) bool { return !n.operand.eval(c) }
+
+// compareNode compares a field: it holds when a value of the field matches,
+// or with negate when none does
+type compareNode struct {
+	field  string
+	values []string
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (n compareNode) eval(c Config) bool {
+	var names []string
+	switch n.field {
+	case "group":
+		names = []string{c.Group}
+	case "dataId":
+		names = []string{c.DataID}
+	case "type":
+		names = []string{c.Type}
+	case "appName":
+		names = []string{c.AppName}
+	case "tag":
+		names = c.Tags
+	}
+	matched := false
+	for _, name := range names {
+		if (n.re != nil && n.re.MatchString(name)) || (n.re == nil && slices.Contains(n.values, name)) {
+			matched = true
+			break
+		}
+	}
+	return matched != n.negate
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	tags   bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("at offset %d: %s", p.peek().offset, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is(tokenOp, "||") {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is(tokenOp, "&&") {
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	switch t := p.peek(); {
+	case t.is(tokenOp, "!"):
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case t.is(tokenOp, "("):
+		p.next()
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek().is(tokenOp, ")") {
+			return nil, p.errorf("expected ), got %s", p.peek())
+		}
+		p.next()
+		return n, nil
+	}
+	return p.compare()
+}
+
+func (p *parser) compare() (node, error) {
+	t := p.peek()
+	if t.kind != tokenWord || !slices.Contains(Fields, t.text) {
+		return nil, p.errorf("expected a field (%s), got %s", strings.Join(Fields, ", "), t)
+	}
+	p.next()
+	n := compareNode{field: t.text}
+	if n.field == "tag" {
+		p.tags = true
+	}
+
+	op := p.peek()
+	switch {
+	case op.is(tokenOp, "==") || op.is(tokenOp, "!="):
+		p.next()
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		n.values, n.negate = []string{value}, op.text == "!="
+	case op.is(tokenOp, "=~") || op.is(tokenOp, "!~"):
+		p.next()
+		at := p.peek()
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if n.re, err = regexp.Compile(value); err != nil {
+			return nil, fmt.Errorf("at offset %d: %w", at.offset, err)
+		}
+		n.negate = op.text == "!~"
+	case op.is(tokenWord, "in") || op.is(tokenWord, "not"):
+		p.next()
+		if op.text == "not" {
+			if !p.peek().is(tokenWord, "in") {
+				return nil, p.errorf("expected in after not, got %s", p.peek())
+			}
+			p.next()
+			n.negate = true
+		}
+		values, err := p.list()
+		if err != nil {
+			return nil, err
+		}
+		n.values = values
+	default:
+		return nil, p.errorf("expected ==, !=, =~, !~, in or not in after %s, got %s", n.field, op)
+	}
+	return n, nil
+}
+
+// list parses a parenthesized, comma separated list of values
+func (p *parser) list() ([]string, error) {
+	if !p.peek().is(tokenOp, "(") {
+		return nil, p.errorf("expected (, got %s", p.peek())
+	}
+	p.next()
+	var values []string
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		switch t := p.peek(); {
+		case t.is(tokenOp, ","):
+			p.next()
+		case t.is(tokenOp, ")"):
+			p.next()
+			return values, nil
+		default:
+			return nil, p.errorf("expected , or ), got %s", t)
+		}
+	}
+}
+
+func (p *parser) value() (string, error) {
+	t := p.peek()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return "", p.errorf("expected a value, got %s", t)
+	}
+	p.next()
+	return t.text, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lex splits an expression into tokens, ending with a tokenEnd
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(expr) && expr[end] != c {
+				if expr[end] == '\\' && c == '"' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("at offset %d: unterminated string", i)
+			}
+			text := expr[i+1 : end]
+			if c == '"' {
+				var err error
+				if text, err = strconv.Unquote(expr[i : end+1]); err != nil {
+					return nil, fmt.Errorf("at offset %d: invalid string %s", i, expr[i:end+1])
+				}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, offset: i})
+			i = end + 1
+		case strings.HasPrefix(expr[i:], "&&") || strings.HasPrefix(expr[i:], "||") ||
+			strings.HasPrefix(expr[i:], "==") || strings.HasPrefix(expr[i:], "!=") ||
+			strings.HasPrefix(expr[i:], "=~") || strings.HasPrefix(expr[i:], "!~"):
+			tokens = append(tokens, token{kind: tokenOp, text: expr[i : i+2], offset: i})
+			i += 2
+		case strings.IndexByte("!(),", c) >= 0:
+			tokens = append(tokens, token{kind: tokenOp, text: string(c), offset: i})
+			i++
+		case isWordByte(c):
+			end := i
+			for end < len(expr) && isWordByte(expr[end]) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: expr[i:end], offset: i})
+			i = end
+		default:
+			return nil, fmt.Errorf("at offset %d: unexpected %q", i, c)
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, offset: len(expr)}), nil
+}
+
+// isWordByte reports whether c may appear in a bare word: the characters of
+// Nacos names. Wildcards are not, so that PAYMENT_* is an error rather than
+// a name compared literally.
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.IndexByte("_-.:/@", c) >= 0
+}
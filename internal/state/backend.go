@@ -0,0 +1,245 @@
+package state
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/objstore"
+)
+
+// Backend types
+const (
+	BackendLocal = "local"
+	BackendNacos = "nacos"
+	BackendS3    = "s3"
+)
+
+const (
+	DefaultFileName = ".nacos-apply-state.json"
+	DefaultDataID   = "nacos-cli-apply-state.json"
+	DefaultGroup    = "NACOS_CLI"
+)
+
+// ErrConcurrentUpdate is returned (wrapped) when the state was saved by another
+// run after it was loaded, so that concurrent CI runners never overwrite each other
+var ErrConcurrentUpdate = errors.New("apply state was modified by another run")
+
+// Backend loads and saves apply state. Saves are conditional on the state not
+// having changed since it was loaded.
+type Backend interface {
+	Load(namespace string) (*State, error)
+	Save(s *State) error
+	Describe() string
+}
+
+// BackendConfig selects and configures a backend
+type BackendConfig struct {
+	Type string `yaml:"type"` // local (default) | nacos | s3
+
+	// local
+	Path string `yaml:"path"` // default: <dir>/.nacos-apply-state.json
+
+	// nacos: stored as a configuration in the target namespace
+	DataID string `yaml:"dataId"`
+	Group  string `yaml:"group"`
+
+	// s3: any S3-compatible store (AWS S3, Aliyun OSS, GCS interoperability, MinIO)
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	Key       string `yaml:"key"`
+	AccessKey string `yaml:"accessKey"`
+	SecretKey string `yaml:"secretKey"`
+	PathStyle bool   `yaml:"pathStyle"`
+}
+
+// Open creates the backend described by cfg. Relative local paths are resolved against baseDir.
+func Open(cfg BackendConfig, nacosClient *client.NacosClient, baseDir string) (Backend, error) {
+	switch cfg.Type {
+	case "", BackendLocal:
+		path := cfg.Path
+		if path == "" {
+			path = DefaultFileName
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		return &LocalBackend{Path: path}, nil
+	case BackendNacos:
+		b := &NacosBackend{Client: nacosClient, DataID: cfg.DataID, Group: cfg.Group}
+		if b.DataID == "" {
+			b.DataID = DefaultDataID
+		}
+		if b.Group == "" {
+			b.Group = DefaultGroup
+		}
+		return b, nil
+	case BackendS3:
+		if cfg.Bucket == "" {
+			return nil, fmt.Errorf("s3 state backend requires a bucket")
+		}
+		objClient := objstore.NewS3Client(cfg.Endpoint, cfg.Region, cfg.Bucket, cfg.AccessKey, cfg.SecretKey)
+		objClient.PathStyle = cfg.PathStyle
+		key := cfg.Key
+		if key == "" {
+			key = DefaultDataID
+		}
+		return &S3Backend{Client: objClient, Key: key}, nil
+	default:
+		return nil, fmt.Errorf("unknown state backend %q (expected local, nacos or s3)", cfg.Type)
+	}
+}
+
+// LocalBackend stores the state in a file
+type LocalBackend struct {
+	Path string
+}
+
+// Load reads the state file
+func (b *LocalBackend) Load(namespace string) (*State, error) {
+	s, err := Load(b.Path, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := os.ReadFile(b.Path); err == nil {
+		s.revision = md5Hex(data)
+	}
+	return s, nil
+}
+
+// Save writes the state file atomically if nobody else changed it meanwhile
+func (b *LocalBackend) Save(s *State) error {
+	current := ""
+	if data, err := os.ReadFile(b.Path); err == nil {
+		current = md5Hex(data)
+	}
+	if current != s.revision {
+		return fmt.Errorf("save %s: %w", b.Path, ErrConcurrentUpdate)
+	}
+
+	data, err := s.encode()
+	if err != nil {
+		return err
+	}
+	tmp := b.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	if err := os.Rename(tmp, b.Path); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	s.revision = md5Hex(data)
+	return nil
+}
+
+// Describe returns a human readable location
+func (b *LocalBackend) Describe() string {
+	return b.Path
+}
+
+// NacosBackend stores the state as a configuration, published with compare-and-swap
+type NacosBackend struct {
+	Client *client.NacosClient
+	DataID string
+	Group  string
+}
+
+// Load reads the state configuration
+func (b *NacosBackend) Load(namespace string) (*State, error) {
+	content, err := b.Client.GetConfig(b.DataID, b.Group)
+	if errors.Is(err, client.ErrConfigNotFound) || (err == nil && content == "") {
+		return New(namespace), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load state from %s: %w", b.Describe(), err)
+	}
+	s, err := parse([]byte(content), b.Describe(), namespace)
+	if err != nil {
+		return nil, err
+	}
+	s.revision = md5Hex([]byte(content))
+	return s, nil
+}
+
+// Save publishes the state configuration if it still has the loaded MD5.
+// A state created by this run is published unconditionally, as Nacos has no
+// create-if-absent publish.
+func (b *NacosBackend) Save(s *State) error {
+	data, err := s.encode()
+	if err != nil {
+		return err
+	}
+	err = b.Client.PublishConfigWithOptions(b.DataID, b.Group, string(data), client.PublishOptions{CasMd5: s.revision})
+	if errors.Is(err, client.ErrCasMismatch) {
+		return fmt.Errorf("save %s: %w", b.Describe(), ErrConcurrentUpdate)
+	}
+	if err != nil {
+		return fmt.Errorf("save state to %s: %w", b.Describe(), err)
+	}
+	s.revision = md5Hex(data)
+	return nil
+}
+
+// Describe returns a human readable location
+func (b *NacosBackend) Describe() string {
+	return "nacos:" + b.Group + "/" + b.DataID
+}
+
+// S3Backend stores the state as an object, written with ETag preconditions
+type S3Backend struct {
+	Client *objstore.S3Client
+	Key    string
+}
+
+// Load downloads the state object
+func (b *S3Backend) Load(namespace string) (*State, error) {
+	data, etag, err := b.Client.Get(b.Key)
+	if errors.Is(err, objstore.ErrNotFound) {
+		return New(namespace), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load state from %s: %w", b.Describe(), err)
+	}
+	s, err := parse(data, b.Describe(), namespace)
+	if err != nil {
+		return nil, err
+	}
+	s.revision = etag
+	return s, nil
+}
+
+// Save uploads the state object if its ETag is unchanged (or it still does not exist)
+func (b *S3Backend) Save(s *State) error {
+	data, err := s.encode()
+	if err != nil {
+		return err
+	}
+	ifMatch := s.revision
+	if ifMatch == "" {
+		ifMatch = "*"
+	}
+	etag, err := b.Client.Put(b.Key, data, ifMatch)
+	if errors.Is(err, objstore.ErrPreconditionFailed) {
+		return fmt.Errorf("save %s: %w", b.Describe(), ErrConcurrentUpdate)
+	}
+	if err != nil {
+		return fmt.Errorf("save state to %s: %w", b.Describe(), err)
+	}
+	s.revision = etag
+	return nil
+}
+
+// Describe returns a human readable location
+func (b *S3Backend) Describe() string {
+	return "s3://" + b.Client.Bucket + "/" + b.Key
+}
+
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
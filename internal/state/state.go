@@ -0,0 +1,74 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Entry records what was last applied for a configuration
+type Entry struct {
+	MD5       string    `json:"md5"`
+	Content   string    `json:"content"` // last applied content, the base for three-way merges
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+// State is the apply state of a namespace, keyed by group/dataId
+type State struct {
+	Namespace string            `json:"namespace"`
+	Entries   map[string]*Entry `json:"entries"`
+
+	revision string // backend version the state was loaded at, for conditional saves
+}
+
+// New creates an empty state for a namespace
+func New(namespace string) *State {
+	return &State{Namespace: namespace, Entries: make(map[string]*Entry)}
+}
+
+// Load reads a state file, returning an empty state when it does not exist
+func Load(path, namespace string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return New(namespace), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+
+	return parse(data, path, namespace)
+}
+
+// parse decodes a state document read from source
+func parse(data []byte, source, namespace string) (*State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse state %s: %w", source, err)
+	}
+	if s.Entries == nil {
+		s.Entries = make(map[string]*Entry)
+	}
+	if s.Namespace != namespace {
+		return nil, fmt.Errorf("state %s belongs to namespace %q, not %q", source, s.Namespace, namespace)
+	}
+	return &s, nil
+}
+
+// Save writes the state file
+func (s *State) Save(path string) error {
+	data, err := s.encode()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (s *State) encode() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// Record stores the applied content of a configuration
+func (s *State) Record(key, content, md5 string) {
+	s.Entries[key] = &Entry{MD5: md5, Content: content, AppliedAt: time.Now()}
+}
@@ -0,0 +1,288 @@
+// Package storage reads and writes archives in local directories and object
+// storage (AWS S3, Aliyun OSS, Google Cloud Storage) addressed by URL.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/objstore"
+)
+
+// ErrNotFound is returned (wrapped) when a file or object does not exist
+var ErrNotFound = errors.New("not found")
+
+// Store holds files by name under a directory or key prefix
+type Store interface {
+	// Create streams a new file; it is stored when the writer is closed
+	Create(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadCloser, error)
+	// List returns the names starting with prefix, sorted
+	List(prefix string) ([]string, error)
+	Delete(name string) error
+	Describe() string
+}
+
+// Options configures object storage locations. Credentials are read from the
+// environment: AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY for s3://,
+// OSS_ACCESS_KEY_ID / OSS_ACCESS_KEY_SECRET for oss:// and
+// GCS_HMAC_ACCESS_ID / GCS_HMAC_SECRET (HMAC keys) for gs://. The AWS variables,
+// including AWS_SESSION_TOKEN, are only used for s3://.
+type Options struct {
+	Endpoint  string // overrides the scheme's default endpoint, e.g. a MinIO server
+	Region    string // s3: default us-east-1; oss: default cn-hangzhou
+	PathStyle bool
+}
+
+// Open opens a directory or a bucket prefix: /path, file:///path,
+// s3://bucket/prefix, oss://bucket/prefix or gs://bucket/prefix
+func Open(location string, opts Options) (Store, error) {
+	scheme, rest, ok := strings.Cut(location, "://")
+	if !ok {
+		return &DirStore{Dir: location}, nil
+	}
+	if scheme == "file" {
+		return &DirStore{Dir: rest}, nil
+	}
+
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid location %s: missing bucket", location)
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	c, err := newObjectClient(scheme, bucket, opts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location %s: %w", location, err)
+	}
+	return &ObjectStore{Client: c, Scheme: scheme, Prefix: prefix}, nil
+}
+
+// Split splits the URL of a single file into its location and name
+func Split(fileURL string) (location, name string) {
+	if scheme, rest, ok := strings.Cut(fileURL, "://"); ok {
+		dir, name := path.Split(rest)
+		return scheme + "://" + dir, name
+	}
+	dir, name := filepath.Split(fileURL)
+	if dir == "" {
+		dir = "."
+	}
+	return dir, name
+}
+
+// Create streams a new file addressed by URL; see Open
+func Create(fileURL string, opts Options) (io.WriteCloser, error) {
+	location, name := Split(fileURL)
+	store, err := Open(location, opts)
+	if err != nil {
+		return nil, err
+	}
+	return store.Create(name)
+}
+
+// OpenFile opens a file addressed by URL for reading; see Open
+func OpenFile(fileURL string, opts Options) (io.ReadCloser, error) {
+	location, name := Split(fileURL)
+	store, err := Open(location, opts)
+	if err != nil {
+		return nil, err
+	}
+	return store.Open(name)
+}
+
+func newObjectClient(scheme, bucket string, opts Options) (*objstore.S3Client, error) {
+	endpoint, region := opts.Endpoint, opts.Region
+	accessKey, secretKey := "", ""
+	switch scheme {
+	case "s3":
+	case "oss":
+		if region == "" {
+			region = "cn-hangzhou"
+		}
+		if endpoint == "" {
+			endpoint = "oss-" + region + ".aliyuncs.com"
+		}
+		accessKey, secretKey = os.Getenv("OSS_ACCESS_KEY_ID"), os.Getenv("OSS_ACCESS_KEY_SECRET")
+		if accessKey == "" || secretKey == "" {
+			return nil, errors.New("oss:// needs OSS_ACCESS_KEY_ID and OSS_ACCESS_KEY_SECRET")
+		}
+	case "gs":
+		if region == "" {
+			region = "auto"
+		}
+		if endpoint == "" {
+			endpoint = "storage.googleapis.com"
+		}
+		accessKey, secretKey = os.Getenv("GCS_HMAC_ACCESS_ID"), os.Getenv("GCS_HMAC_SECRET")
+		if accessKey == "" || secretKey == "" {
+			return nil, errors.New("gs:// needs GCS_HMAC_ACCESS_ID and GCS_HMAC_SECRET")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported scheme %s (expected s3, oss, gs or file)", scheme)
+	}
+	c := objstore.NewS3Client(endpoint, region, bucket, accessKey, secretKey)
+	if scheme != "s3" {
+		// NewS3Client picks up AWS_SESSION_TOKEN, which other providers reject
+		c.SessionToken = ""
+	}
+	c.PathStyle = opts.PathStyle
+	return c, nil
+}
+
+// DirStore holds files in a local directory
+type DirStore struct {
+	Dir string
+}
+
+// Create writes to a temporary file renamed into place on Close
+func (d *DirStore) Create(name string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(d.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("create directory %s: %w", d.Dir, err)
+	}
+	path := filepath.Join(d.Dir, name)
+	f, err := os.OpenFile(path+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %w", path, err)
+	}
+	return &dirWriter{File: f, path: path}, nil
+}
+
+type dirWriter struct {
+	*os.File
+	path string
+}
+
+func (w *dirWriter) Close() error {
+	if err := w.File.Close(); err != nil {
+		os.Remove(w.Name())
+		return fmt.Errorf("write %s: %w", w.path, err)
+	}
+	if err := os.Rename(w.Name(), w.path); err != nil {
+		return fmt.Errorf("write %s: %w", w.path, err)
+	}
+	return nil
+}
+
+// Open opens the file
+func (d *DirStore) Open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(d.Dir, name))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("open %s: %w", filepath.Join(d.Dir, name), ErrNotFound)
+	}
+	return f, err
+}
+
+// List returns the files starting with prefix
+func (d *DirStore) List(prefix string) ([]string, error) {
+	entries, err := os.ReadDir(d.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", d.Dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, prefix) && !strings.HasSuffix(name, ".tmp") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Delete removes the file
+func (d *DirStore) Delete(name string) error {
+	if err := os.Remove(filepath.Join(d.Dir, name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete %s: %w", name, err)
+	}
+	return nil
+}
+
+// Describe returns the directory
+func (d *DirStore) Describe() string {
+	return d.Dir
+}
+
+// ObjectStore holds files as objects under a key prefix of an S3-compatible bucket
+type ObjectStore struct {
+	Client *objstore.S3Client
+	Scheme string
+	Prefix string
+}
+
+// Create spools the content to a temporary file, uploaded on Close, as S3
+// uploads need the size up front
+func (s *ObjectStore) Create(name string) (io.WriteCloser, error) {
+	f, err := os.CreateTemp("", "nacos-cli-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("create upload buffer: %w", err)
+	}
+	return &objectWriter{File: f, store: s, key: s.Prefix + name}, nil
+}
+
+type objectWriter struct {
+	*os.File
+	store *ObjectStore
+	key   string
+}
+
+func (w *objectWriter) Close() error {
+	defer os.Remove(w.Name())
+	defer w.File.Close()
+	size, err := w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("upload %s: %w", w.key, err)
+	}
+	if _, err := w.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("upload %s: %w", w.key, err)
+	}
+	if err := w.store.Client.PutStream(w.key, w.File, size); err != nil {
+		return fmt.Errorf("upload to %s: %w", w.store.Describe(), err)
+	}
+	return nil
+}
+
+// Open streams the object
+func (s *ObjectStore) Open(name string) (io.ReadCloser, error) {
+	r, err := s.Client.GetStream(s.Prefix + name)
+	if errors.Is(err, objstore.ErrNotFound) {
+		return nil, fmt.Errorf("open %s%s: %w", s.Describe(), name, ErrNotFound)
+	}
+	return r, err
+}
+
+// List returns the objects starting with prefix, relative to the store prefix
+func (s *ObjectStore) List(prefix string) ([]string, error) {
+	objects, err := s.Client.List(s.Prefix + prefix)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, object := range objects {
+		name := strings.TrimPrefix(object.Key, s.Prefix)
+		if !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// Delete removes the object
+func (s *ObjectStore) Delete(name string) error {
+	return s.Client.Delete(s.Prefix + name)
+}
+
+// Describe returns the location URL
+func (s *ObjectStore) Describe() string {
+	return s.Scheme + "://" + s.Client.Bucket + "/" + s.Prefix
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package supervisor
+
+import "syscall"
+
+func init() {
+	signalsByName["SIGUSR1"] = syscall.SIGUSR1
+	signalsByName["SIGUSR2"] = syscall.SIGUSR2
+}
@@ -0,0 +1,164 @@
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// signalsByName lists the signals accepted by ParseSignal
+var signalsByName = map[string]os.Signal{
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGINT":  syscall.SIGINT,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGTERM": syscall.SIGTERM,
+}
+
+// ParseSignal parses a signal name such as SIGHUP or HUP
+func ParseSignal(name string) (os.Signal, error) {
+	upper := strings.ToUpper(name)
+	if !strings.HasPrefix(upper, "SIG") {
+		upper = "SIG" + upper
+	}
+	sig, ok := signalsByName[upper]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signal: %s", name)
+	}
+	return sig, nil
+}
+
+// Supervisor runs a child process and keeps it running. When a change is
+// announced, the child is either sent ReloadSignal or restarted; when the child
+// crashes it is restarted with exponential backoff up to MaxRestarts times.
+type Supervisor struct {
+	Args         []string
+	Prepare      func() ([]string, error) // returns the extra environment, called before every start
+	ReloadSignal os.Signal                // nil means restart on change
+	MaxRestarts  int                      // crash restarts allowed, negative for unlimited
+	Backoff      time.Duration            // initial crash restart delay, doubled each time
+	MaxBackoff   time.Duration
+	StopTimeout  time.Duration // grace period before the child is killed on restart
+}
+
+// Run supervises the child until it exits for good and returns the final exit code
+func (s *Supervisor) Run(changes <-chan struct{}) int {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	backoff := s.Backoff
+	crashes := 0
+
+	for {
+		child, exitCh, err := s.start()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[supervisor] %v\n", err)
+			return 127
+		}
+		startedAt := time.Now()
+
+		restart := false
+		for !restart {
+			select {
+			case sig := <-sigCh:
+				// Forward and wait for the child to exit on its own terms
+				child.Process.Signal(sig)
+				return exitCode(<-exitCh)
+
+			case <-changes:
+				if s.ReloadSignal != nil {
+					fmt.Fprintf(os.Stderr, "[supervisor] Configuration changed, sending %v to pid %d\n", s.ReloadSignal, child.Process.Pid)
+					child.Process.Signal(s.ReloadSignal)
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "[supervisor] Configuration changed, restarting pid %d\n", child.Process.Pid)
+				s.stop(child, exitCh)
+				restart = true
+
+			case err := <-exitCh:
+				code := exitCode(err)
+				if code == 0 {
+					return 0
+				}
+				// A child that ran for a while is considered healthy, reset the backoff
+				if time.Since(startedAt) > s.MaxBackoff {
+					backoff = s.Backoff
+				}
+				crashes++
+				if s.MaxRestarts >= 0 && crashes > s.MaxRestarts {
+					fmt.Fprintf(os.Stderr, "[supervisor] Child exited with code %d, giving up after %d restart(s)\n", code, s.MaxRestarts)
+					return code
+				}
+				fmt.Fprintf(os.Stderr, "[supervisor] Child exited with code %d, restarting in %v (%d/%s)\n", code, backoff, crashes, s.maxRestartsLabel())
+				select {
+				case <-time.After(backoff):
+				case <-sigCh:
+					return code
+				}
+				backoff *= 2
+				if backoff > s.MaxBackoff {
+					backoff = s.MaxBackoff
+				}
+				restart = true
+			}
+		}
+	}
+}
+
+// start prepares the environment and starts the child
+func (s *Supervisor) start() (*exec.Cmd, <-chan error, error) {
+	env, err := s.Prepare()
+	if err != nil {
+		return nil, nil, fmt.Errorf("prepare environment: %w", err)
+	}
+
+	child := exec.Command(s.Args[0], s.Args[1:]...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	child.Env = append(os.Environ(), env...)
+	if err := child.Start(); err != nil {
+		return nil, nil, fmt.Errorf("start %s: %w", s.Args[0], err)
+	}
+
+	exitCh := make(chan error, 1)
+	go func() {
+		exitCh <- child.Wait()
+	}()
+	return child, exitCh, nil
+}
+
+// stop terminates the child gracefully, killing it after StopTimeout
+func (s *Supervisor) stop(child *exec.Cmd, exitCh <-chan error) {
+	child.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-exitCh:
+	case <-time.After(s.StopTimeout):
+		child.Process.Kill()
+		<-exitCh
+	}
+}
+
+func (s *Supervisor) maxRestartsLabel() string {
+	if s.MaxRestarts < 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", s.MaxRestarts)
+}
+
+// exitCode extracts the exit code from a Wait error
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
@@ -118,6 +118,7 @@ func (s *SkillSyncer) StartSyncMultiple(skillNames []string, stopCh <-chan struc
 
 	// Create config listener
 	configListener := listener.NewConfigListener(s.client.ServerAddr, s.client.Username, s.client.Password)
+	configListener.SetTransport(s.client.Transport())
 
 	// Define change handler
 	handler := func(dataID, grp, tenant string) error {
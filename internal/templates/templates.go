@@ -0,0 +1,175 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed builtin/*
+var builtinFS embed.FS
+
+// Template is a configuration scaffold. Variables are referenced as
+// {{ var "name" }} (required) or {{ var "name" "default" }}; a leading
+// {{/* comment */ -}} is shown as the description.
+type Template struct {
+	Name        string // file name without extension
+	Ext         string // file extension, e.g. ".yaml"
+	Description string
+	Source      string // "builtin" or the file path
+	text        string
+}
+
+// Var is a variable referenced by a template
+type Var struct {
+	Name     string
+	Default  string
+	Required bool
+}
+
+var descriptionPattern = regexp.MustCompile(`^\{\{-?\s*/\*\s*(.*?)\s*\*/\s*-?\}\}`)
+
+// List returns the built-in templates and those in userDir, sorted by name.
+// A user template replaces a built-in template with the same name.
+func List(userDir string) ([]Template, error) {
+	byName := make(map[string]Template)
+	entries, err := fs.ReadDir(builtinFS, "builtin")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		data, err := fs.ReadFile(builtinFS, "builtin/"+e.Name())
+		if err != nil {
+			return nil, err
+		}
+		t := newTemplate(e.Name(), "builtin", string(data))
+		byName[t.Name] = t
+	}
+
+	if userDir != "" {
+		entries, err := os.ReadDir(userDir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("read template directory: %w", err)
+		}
+		for _, e := range entries {
+			if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			path := filepath.Join(userDir, e.Name())
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			t := newTemplate(e.Name(), path, string(data))
+			byName[t.Name] = t
+		}
+	}
+
+	list := make([]Template, 0, len(byName))
+	for _, t := range byName {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Find returns the template with the given name
+func Find(userDir, name string) (*Template, error) {
+	list, err := List(userDir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for i := range list {
+		if list[i].Name == name {
+			return &list[i], nil
+		}
+		names = append(names, list[i].Name)
+	}
+	return nil, fmt.Errorf("template not found: %s (available: %s)", name, strings.Join(names, ", "))
+}
+
+func newTemplate(fileName, source, text string) Template {
+	ext := filepath.Ext(fileName)
+	t := Template{
+		Name:   strings.TrimSuffix(fileName, ext),
+		Ext:    ext,
+		Source: source,
+		text:   text,
+	}
+	if m := descriptionPattern.FindStringSubmatch(text); m != nil {
+		t.Description = m[1]
+	}
+	return t
+}
+
+// Vars lists the variables referenced by the template, in order of first use
+func (t *Template) Vars() ([]Var, error) {
+	var vars []Var
+	seen := make(map[string]bool)
+	_, err := t.execute(func(name string, def ...string) string {
+		if !seen[name] {
+			seen[name] = true
+			v := Var{Name: name, Required: len(def) == 0}
+			if !v.Required {
+				v.Default = def[0]
+			}
+			vars = append(vars, v)
+		}
+		return ""
+	})
+	return vars, err
+}
+
+// Render fills in the template. It fails on missing required variables and on
+// values given for variables the template does not use, which are usually typos.
+func (t *Template) Render(values map[string]string) (string, error) {
+	var missing []string
+	used := make(map[string]bool)
+	out, err := t.execute(func(name string, def ...string) string {
+		used[name] = true
+		if v, ok := values[name]; ok {
+			return v
+		}
+		if len(def) > 0 {
+			return def[0]
+		}
+		missing = append(missing, name)
+		return ""
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("template %s requires: %s (use --set name=value)", t.Name, strings.Join(missing, ", "))
+	}
+	var unknown []string
+	for name := range values {
+		if !used[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", fmt.Errorf("template %s has no variable(s): %s", t.Name, strings.Join(unknown, ", "))
+	}
+	return out, nil
+}
+
+func (t *Template) execute(lookup func(name string, def ...string) string) (string, error) {
+	tmpl, err := template.New(t.Name).Funcs(template.FuncMap{"var": lookup}).Parse(t.text)
+	if err != nil {
+		return "", fmt.Errorf("parse template %s: %w", t.Name, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, nil); err != nil {
+		return "", fmt.Errorf("render template %s: %w", t.Name, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,208 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/configtree"
+)
+
+// bookmark is a configuration kept at hand with bookmark add
+type bookmark struct {
+	Namespace string `json:"namespace"`
+	Group     string `json:"group"`
+	DataID    string `json:"dataId"`
+}
+
+// loadBookmarks reads the bookmarks of all profiles; a missing file is empty
+func (t *Terminal) loadBookmarks() (map[string][]bookmark, error) {
+	all := make(map[string][]bookmark)
+	data, err := os.ReadFile(t.BookmarksFile)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmarks: %w", err)
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", t.BookmarksFile, err)
+	}
+	return all, nil
+}
+
+func (t *Terminal) saveBookmarks(all map[string][]bookmark) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.BookmarksFile), 0755); err != nil {
+		return fmt.Errorf("failed to save bookmarks: %w", err)
+	}
+	if err := os.WriteFile(t.BookmarksFile, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to save bookmarks: %w", err)
+	}
+	return nil
+}
+
+// bookmarkCommand handles bookmark add and bookmark rm
+func (t *Terminal) bookmarkCommand(args []string) {
+	if t.BookmarksFile == "" {
+		fmt.Println("\033[31mError:\033[0m bookmarks are not available in this session")
+		return
+	}
+	if len(args) == 0 || (args[0] != "add" && args[0] != "rm") {
+		fmt.Println("\033[31mUsage:\033[0m bookmark add [<group>/<data-id> | <data-id> <group>] | bookmark rm <n>")
+		fmt.Println("\033[90mWithout a config, bookmark add keeps the one shown by config-show.\033[0m")
+		return
+	}
+	all, err := t.loadBookmarks()
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	scope := t.profileScope()
+	marks := all[scope]
+
+	switch args[0] {
+	case "add":
+		b, ok := t.bookmarkTarget(args[1:])
+		if !ok {
+			return
+		}
+		for _, m := range marks {
+			if m == b {
+				fmt.Printf("%s is already bookmarked\n", configtree.Key(b.Group, b.DataID))
+				return
+			}
+		}
+		all[scope] = append(marks, b)
+		if err := t.saveBookmarks(all); err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+		t.rememberConfig(b.DataID, b.Group)
+		fmt.Printf("Bookmarked %s as [%d] \033[90m(open %d shows it)\033[0m\n", configtree.Key(b.Group, b.DataID), len(marks)+1, len(marks)+1)
+	case "rm":
+		n, ok := bookmarkIndex(args[1:], len(marks), "bookmark rm")
+		if !ok {
+			return
+		}
+		b := marks[n-1]
+		all[scope] = append(marks[:n-1], marks[n:]...)
+		if len(all[scope]) == 0 {
+			delete(all, scope)
+		}
+		if err := t.saveBookmarks(all); err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+		fmt.Printf("Removed bookmark %s\n", configtree.Key(b.Group, b.DataID))
+	}
+}
+
+// bookmarkTarget resolves the configuration given to bookmark add
+func (t *Terminal) bookmarkTarget(args []string) (bookmark, bool) {
+	b := bookmark{Namespace: namespaceName(t.client.Namespace)}
+	switch {
+	case len(args) == 0 && t.selected != nil:
+		b.Group, b.DataID = t.selected.group, t.selected.dataID
+	case len(args) == 1 && strings.Contains(args[0], "/"):
+		b.Group, b.DataID = configtree.SplitKey(args[0])
+	case len(args) == 1 && t.Group != "":
+		b.Group, b.DataID = t.Group, args[0]
+	case len(args) == 2:
+		b.Group, b.DataID = args[1], args[0]
+	default:
+		fmt.Println("\033[31mUsage:\033[0m bookmark add [<group>/<data-id> | <data-id> <group>]")
+		return b, false
+	}
+	b.Group = t.resolveGroup(b.Group)
+	if b.Group == "" || b.DataID == "" {
+		fmt.Println("\033[31mError:\033[0m expected <group>/<data-id>")
+		return b, false
+	}
+	return b, true
+}
+
+// bookmarkIndex parses the bookmark number argument
+func bookmarkIndex(args []string, count int, command string) (int, bool) {
+	if len(args) != 1 {
+		fmt.Printf("\033[31mUsage:\033[0m %s <n>  (n as listed by bookmarks)\n", command)
+		return 0, false
+	}
+	if count == 0 {
+		fmt.Println("\033[33mNo bookmarks\033[0m \033[90m(use bookmark add <group>/<data-id>)\033[0m")
+		return 0, false
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > count {
+		fmt.Printf("\033[31mError:\033[0m bookmark must be between 1 and %d\n", count)
+		return 0, false
+	}
+	return n, true
+}
+
+// showBookmarks lists the bookmarks of the profile
+func (t *Terminal) showBookmarks() {
+	if t.BookmarksFile == "" {
+		fmt.Println("\033[31mError:\033[0m bookmarks are not available in this session")
+		return
+	}
+	all, err := t.loadBookmarks()
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	marks := all[t.profileScope()]
+	if len(marks) == 0 {
+		fmt.Println("\033[33mNo bookmarks\033[0m \033[90m(use bookmark add <group>/<data-id>)\033[0m")
+		return
+	}
+	fmt.Println("\033[1;36mBookmarks\033[0m \033[90m(open <n> shows one)\033[0m")
+	for i, b := range marks {
+		ns := ""
+		if b.Namespace != namespaceName(t.client.Namespace) {
+			ns = fmt.Sprintf(" \033[90m(namespace %s)\033[0m", b.Namespace)
+		}
+		fmt.Printf("  \033[33m[%d]\033[0m %s%s\n", i+1, configtree.Key(b.Group, b.DataID), ns)
+	}
+}
+
+// openBookmark shows a bookmarked configuration with config-show, switching
+// to its namespace first
+func (t *Terminal) openBookmark(args []string) {
+	if t.BookmarksFile == "" {
+		fmt.Println("\033[31mError:\033[0m bookmarks are not available in this session")
+		return
+	}
+	all, err := t.loadBookmarks()
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	marks := all[t.profileScope()]
+	n, ok := bookmarkIndex(args, len(marks), "open")
+	if !ok {
+		return
+	}
+	b := marks[n-1]
+	if b.Namespace != namespaceName(t.client.Namespace) {
+		t.useNamespace(b.Namespace)
+		if namespaceName(t.client.Namespace) != b.Namespace {
+			return
+		}
+	}
+	t.showConfig([]string{b.DataID, b.Group})
+}
+
+// namespaceName shows the default namespace as public
+func namespaceName(id string) string {
+	if id == "" {
+		return "public"
+	}
+	return id
+}
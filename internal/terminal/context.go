@@ -0,0 +1,153 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/skill"
+)
+
+// Profile is the session context built from a config file profile
+type Profile struct {
+	Name         string
+	Client       *client.NacosClient
+	Group        string // default group, may be empty
+	Prompt       string
+	PromptColor  string
+	DiffMode     string
+	ResolveGroup func(string) string                              // expands group aliases, may be nil
+	Guard        func(operation, namespace, command string) error // may be nil
+	KeepDeleted  func(dataID, group string) error                 // may be nil
+}
+
+// use shows or changes the active namespace, group or profile
+func (t *Terminal) use(args []string) {
+	if len(args) == 0 {
+		group := t.Group
+		if group == "" {
+			group = "(none, commands need a group)"
+		}
+		profile := t.Profile
+		if profile == "" {
+			profile = "(none)"
+		}
+		fmt.Printf("\033[33mProfile:\033[0m   %s\n", profile)
+		fmt.Printf("\033[33mNamespace:\033[0m %s\n", t.client.Namespace)
+		fmt.Printf("\033[33mGroup:\033[0m     %s\n", group)
+		return
+	}
+	if len(args) != 2 {
+		fmt.Println("\033[31mUsage:\033[0m use namespace <id> | use group <group|-> | use profile <name>")
+		return
+	}
+
+	switch args[0] {
+	case "namespace", "ns":
+		t.useNamespace(args[1])
+	case "group":
+		if args[1] == "-" {
+			t.Group = ""
+			fmt.Println("Cleared the active group")
+			return
+		}
+		t.Group = t.resolveGroup(args[1])
+		t.remember(kindGroup, t.Group)
+		fmt.Printf("Using group '%s'\n", t.Group)
+	case "profile":
+		t.useProfile(args[1])
+	default:
+		fmt.Printf("\033[31mUnknown context:\033[0m %s (expected namespace, group or profile)\n", args[0])
+	}
+}
+
+// useNamespace switches to a namespace after checking that it exists
+func (t *Terminal) useNamespace(id string) {
+	if t.inTransaction("switching the namespace") {
+		return
+	}
+	if id != "public" {
+		namespaces, err := t.client.ListNamespaces()
+		if err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+		var ids []string
+		found := false
+		for _, ns := range namespaces {
+			ids = append(ids, ns.Namespace)
+			found = found || ns.Namespace == id
+		}
+		if !found {
+			fmt.Printf("\033[31mError:\033[0m namespace '%s' does not exist (available: %s)\n", id, strings.Join(ids, ", "))
+			return
+		}
+	}
+	oldNs := t.client.Namespace
+	t.client.Namespace = id
+	t.remember(kindNamespace, id)
+	fmt.Printf("Switched namespace from '%s' to '%s'\n", oldNs, id)
+}
+
+// useProfile switches the session to another config file profile
+func (t *Terminal) useProfile(name string) {
+	if t.inTransaction("use profile") {
+		return
+	}
+	if t.SwitchProfile == nil {
+		fmt.Println("\033[31mError:\033[0m profiles are not available in this session")
+		return
+	}
+	p, err := t.SwitchProfile(name)
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	t.client = p.Client
+	t.skillService = skill.NewSkillService(p.Client)
+	t.Profile = p.Name
+	t.Prompt = p.Prompt
+	t.PromptColor = p.PromptColor
+	t.DiffMode = p.DiffMode
+	t.ResolveGroup = p.ResolveGroup
+	t.Guard = p.Guard
+	t.KeepDeleted = p.KeepDeleted
+	t.Group = p.Group
+	t.offline = false
+	fmt.Printf("Switched to profile '%s' (%s, namespace %s)\n", p.Name, p.Client.ServerAddr, p.Client.Namespace)
+}
+
+// guard returns an error when the profile does not allow the operation of a
+// command on the active namespace
+func (t *Terminal) guard(operation string, command ...string) error {
+	if t.Guard == nil {
+		return nil
+	}
+	return t.Guard(operation, t.client.Namespace, strings.Join(command, " "))
+}
+
+// deleteKept deletes a configuration after keeping a copy with KeepDeleted
+func (t *Terminal) deleteKept(dataID, group string) error {
+	if t.KeepDeleted != nil {
+		if err := t.KeepDeleted(dataID, group); err != nil {
+			return fmt.Errorf("keep %s (%s) before deleting it: %w", dataID, group, err)
+		}
+	}
+	return t.client.DeleteConfig(dataID, group)
+}
+
+// diffMode returns the mode of preview diffs
+func (t *Terminal) diffMode() string {
+	if t.DiffMode == "" {
+		return "line"
+	}
+	return t.DiffMode
+}
+
+// resolveGroup expands a group alias
+func (t *Terminal) resolveGroup(group string) string {
+	if t.ResolveGroup == nil {
+		return group
+	}
+	return t.ResolveGroup(group)
+}
@@ -0,0 +1,230 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/listener"
+)
+
+const (
+	showRevisions    = 10 // revisions listed by config-show
+	showContentLines = 40 // content lines before truncating
+)
+
+// selection is the configuration shown by config-show, which diff and
+// rollback refer to
+type selection struct {
+	dataID    string
+	group     string
+	detail    *client.ConfigDetail
+	revisions []client.ConfigHistory
+}
+
+// showConfig prints the content of a configuration next to its metadata and
+// recent revisions, and selects it for diff and rollback
+func (t *Terminal) showConfig(args []string) {
+	if len(args) < 1 || (len(args) < 2 && t.Group == "") {
+		fmt.Println("\033[31mUsage:\033[0m config-show <data-id> <group>")
+		return
+	}
+	dataID := args[0]
+	group := t.Group
+	if len(args) > 1 {
+		group = args[1]
+	}
+	group = t.resolveGroup(group)
+
+	detail, err := t.client.GetConfigDetail(dataID, group)
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	var revisions []client.ConfigHistory
+	page, err := t.client.ListConfigHistory(dataID, group, 1, showRevisions)
+	if err != nil {
+		fmt.Printf("\033[33mWarning:\033[0m history unavailable: %v\n", err)
+	} else {
+		revisions = page.PageItems
+	}
+	t.selected = &selection{dataID: dataID, group: group, detail: detail, revisions: revisions}
+	t.rememberConfig(dataID, group)
+
+	right := []string{
+		"\033[1;36mMetadata\033[0m",
+		"Data ID:  " + dataID,
+		"Group:    " + group,
+		"MD5:      " + detail.Md5,
+		"Modified: " + formatMillis(detail.ModifyTime),
+	}
+	if detail.Type != "" {
+		right = append(right, "Type:     "+detail.Type)
+	}
+	if tags := detail.Tags(); len(tags) > 0 {
+		right = append(right, "Tags:     "+strings.Join(tags, ","))
+	}
+	if detail.Desc != "" {
+		right = append(right, "Desc:     "+detail.Desc)
+	}
+	right = append(right, "", "\033[1;36mRecent revisions\033[0m")
+	if len(revisions) == 0 {
+		right = append(right, "\033[90m(none)\033[0m")
+	}
+	for i, rev := range revisions {
+		line := fmt.Sprintf("[%d] %s %s %s", i+1, formatMillis(rev.CreateTime), rev.OpType, rev.SrcUser)
+		if rev.Desc != "" {
+			line += " \033[90m" + rev.Desc + "\033[0m"
+		}
+		right = append(right, line)
+	}
+
+	left := strings.Split(strings.ReplaceAll(detail.Content, "\t", "    "), "\n")
+	if len(left) > showContentLines {
+		more := len(left) - showContentLines
+		left = append(left[:showContentLines], fmt.Sprintf("\033[90m... %d more line(s)\033[0m", more))
+	}
+
+	width := readline.GetScreenWidth()
+	if width < 60 {
+		width = 120
+	}
+	leftWidth := (width - 3) * 3 / 5
+	fmt.Println("\033[36m" + strings.Repeat("═", width) + "\033[0m")
+	for i := 0; i < len(left) || i < len(right); i++ {
+		l, r := "", ""
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		fmt.Printf("%s \033[36m│\033[0m %s\n", padVisible(l, leftWidth), r)
+	}
+	fmt.Println("\033[36m" + strings.Repeat("═", width) + "\033[0m")
+	if len(revisions) > 0 {
+		fmt.Println("\033[90mdiff <n> compares revision n with the current content, rollback <n> restores it\033[0m")
+	}
+}
+
+// diffRevision diffs a revision of the selected configuration against its current content
+func (t *Terminal) diffRevision(args []string) {
+	sel, rev, ok := t.selectedRevision(args, "diff")
+	if !ok {
+		return
+	}
+	out, err := diff.Diff(t.diffMode(),
+		diff.Document{Name: fmt.Sprintf("%s/%s (revision %d)", sel.group, sel.dataID, rev.ID), Content: rev.Content, Format: sel.dataID},
+		diff.Document{Name: fmt.Sprintf("%s/%s (current)", sel.group, sel.dataID), Content: sel.detail.Content, Format: sel.dataID},
+		diff.Options{Color: true})
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	if out == "" {
+		fmt.Println("\033[32mNo differences\033[0m")
+		return
+	}
+	fmt.Print(out)
+}
+
+// rollbackRevision publishes the content of a revision of the selected configuration
+func (t *Terminal) rollbackRevision(args []string) {
+	sel, rev, ok := t.selectedRevision(args, "rollback")
+	if !ok {
+		return
+	}
+	if rev.Content == sel.detail.Content {
+		fmt.Println("\033[33mThe revision has the current content, nothing to roll back\033[0m")
+		return
+	}
+	if err := t.guard("publish", "rollback", sel.dataID, sel.group, strconv.FormatInt(rev.ID, 10)); err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	if t.tx != nil {
+		t.tx.queue(change{operation: "publish", dataID: sel.dataID, group: sel.group, content: rev.Content})
+		return
+	}
+	if !t.confirm(fmt.Sprintf("Roll back %s (%s) to revision %d? [y/N]: ", sel.dataID, sel.group, rev.ID)) {
+		fmt.Println("\033[33mCancelled\033[0m")
+		return
+	}
+
+	opts := client.PublishOptions{CasMd5: listener.CalculateMD5(sel.detail.Content), Type: sel.detail.Type, Tags: sel.detail.Tags()}
+	if err := t.client.PublishConfigWithOptions(sel.dataID, sel.group, rev.Content, opts); err != nil {
+		if errors.Is(err, client.ErrCasMismatch) {
+			fmt.Println("\033[31mError:\033[0m the configuration changed since config-show, run it again")
+			return
+		}
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	fmt.Printf("\033[32mRolled back %s (%s) to revision %d\033[0m\n", sel.dataID, sel.group, rev.ID)
+	t.pushUndo(fmt.Sprintf("rollback %s (%s) to revision %d", sel.dataID, sel.group, rev.ID),
+		priorState{dataID: sel.dataID, group: sel.group, before: sel.detail, after: rev.Content})
+	t.selected = nil
+}
+
+// selectedRevision resolves the revision number argument against the selection
+func (t *Terminal) selectedRevision(args []string, command string) (*selection, *client.ConfigHistory, bool) {
+	if t.selected == nil {
+		fmt.Println("\033[31mError:\033[0m no configuration selected, use config-show first")
+		return nil, nil, false
+	}
+	if len(args) != 1 {
+		fmt.Printf("\033[31mUsage:\033[0m %s <n>  (n as listed by config-show)\n", command)
+		return nil, nil, false
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > len(t.selected.revisions) {
+		fmt.Printf("\033[31mError:\033[0m revision must be between 1 and %d\n", len(t.selected.revisions))
+		return nil, nil, false
+	}
+	rev, err := t.client.GetConfigHistory(t.selected.dataID, t.selected.group, t.selected.revisions[n-1].ID)
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return nil, nil, false
+	}
+	return t.selected, rev, true
+}
+
+// padVisible truncates or pads s to width runes, ignoring ANSI escapes
+func padVisible(s string, width int) string {
+	visible := []rune(stripANSI(s))
+	if len(visible) > width {
+		return string(visible[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(visible))
+}
+
+func stripANSI(s string) string {
+	var b strings.Builder
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case r == '\033':
+			inEscape = true
+		case inEscape:
+			// Colors end with m, cursor and erase sequences with another letter
+			if r != '[' && r >= '@' && r <= '~' {
+				inEscape = false
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func formatMillis(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,237 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/listener"
+)
+
+// recentChange is how long a change stays highlighted in the pins view
+const recentChange = time.Minute
+
+// pin is a configuration watched in the background
+type pin struct {
+	item      listener.ConfigItem
+	changedAt time.Time
+	changes   int
+	deleted   bool
+}
+
+// pinConfig adds a configuration to the watched pins
+func (t *Terminal) pinConfig(args []string) {
+	if len(args) < 1 || (len(args) < 2 && t.Group == "") {
+		fmt.Println("\033[31mUsage:\033[0m pin <data-id> <group>")
+		return
+	}
+	dataID := args[0]
+	group := t.Group
+	if len(args) > 1 {
+		group = args[1]
+	}
+	group = t.resolveGroup(group)
+	key := pinKey(t.client.Namespace, group, dataID)
+	if _, ok := t.pins[key]; ok {
+		fmt.Printf("%s is already pinned\n", key)
+		return
+	}
+
+	content, err := t.client.GetConfig(dataID, group)
+	if err != nil && !errors.Is(err, client.ErrConfigNotFound) {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	md5 := ""
+	if err == nil {
+		md5 = listener.CalculateMD5(content)
+	}
+	if t.pins == nil {
+		t.pins = make(map[string]*pin)
+		t.pinsWake = make(chan struct{}, 1)
+		go t.watchPins()
+	}
+	t.pins[key] = &pin{item: listener.ConfigItem{DataID: dataID, Group: group, Tenant: t.client.Namespace, MD5: md5}, deleted: md5 == ""}
+	t.wakePins()
+	t.rememberConfig(dataID, group)
+	fmt.Printf("Pinned %s, changes are shown as they happen\n", key)
+}
+
+// unpinConfig removes a configuration, or all with --all, from the pins
+func (t *Terminal) unpinConfig(args []string) {
+	if len(args) == 1 && args[0] == "--all" {
+		t.pins = make(map[string]*pin)
+		t.wakePins()
+		fmt.Println("Removed all pins")
+		return
+	}
+	if len(args) < 1 || (len(args) < 2 && t.Group == "") {
+		fmt.Println("\033[31mUsage:\033[0m unpin <data-id> <group> | unpin --all")
+		return
+	}
+	group := t.Group
+	if len(args) > 1 {
+		group = args[1]
+	}
+	key := pinKey(t.client.Namespace, t.resolveGroup(group), args[0])
+	if _, ok := t.pins[key]; !ok {
+		fmt.Printf("%s is not pinned\n", key)
+		return
+	}
+	delete(t.pins, key)
+	t.wakePins()
+	fmt.Printf("Unpinned %s\n", key)
+}
+
+// showPins prints the pinned configurations, highlighting recent changes
+func (t *Terminal) showPins() {
+	if len(t.pins) == 0 {
+		fmt.Println("\033[33mNothing pinned\033[0m \033[90m(use pin <data-id> <group>)\033[0m")
+		return
+	}
+	keys := make([]string, 0, len(t.pins))
+	for key := range t.pins {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Println("\033[1;36mPinned Configurations\033[0m")
+	fmt.Println("\033[36m═══════════════════════════════════════════════════════════════\033[0m")
+	fmt.Printf("\033[90m%-50s %-10s %-8s %s\033[0m\n", "Config", "Changed", "Changes", "MD5")
+	fmt.Println("\033[90m───────────────────────────────────────────────────────────────\033[0m")
+	for _, key := range keys {
+		p := t.pins[key]
+		changed := "-"
+		if !p.changedAt.IsZero() {
+			changed = p.changedAt.Format("15:04:05")
+		}
+		md5 := p.item.MD5
+		if p.deleted {
+			md5 = "(missing)"
+		} else if len(md5) > 8 {
+			md5 = md5[:8]
+		}
+		line := fmt.Sprintf("%-50s %-10s %-8d %s", key, changed, p.changes, md5)
+		if time.Since(p.changedAt) < recentChange {
+			line = "\033[1;33m" + line + "\033[0m"
+		}
+		fmt.Println(line)
+	}
+}
+
+// pinKey identifies a pin; pins keep the namespace they were made in
+func pinKey(namespace, group, dataID string) string {
+	return namespace + ":" + group + "/" + dataID
+}
+
+func (t *Terminal) wakePins() {
+	select {
+	case t.pinsWake <- struct{}{}:
+	default:
+	}
+}
+
+// watchPins long-polls the pinned configurations and prints each change above
+// the prompt. Changing the pins interrupts the current poll.
+func (t *Terminal) watchPins() {
+	for {
+		t.mu.Lock()
+		items := make([]listener.ConfigItem, 0, len(t.pins))
+		for _, p := range t.pins {
+			items = append(items, p.item)
+		}
+		addr, token, transport := t.client.ServerAddr, t.client.AccessToken(), t.client.Transport()
+		t.mu.Unlock()
+
+		if len(items) == 0 {
+			select {
+			case <-t.stop:
+				return
+			case <-t.pinsWake:
+				continue
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-t.stop:
+			case <-t.pinsWake:
+			case <-done:
+			}
+			cancel()
+		}()
+		configListener := listener.NewConfigListener(addr, "", "")
+		configListener.SetAccessToken(token)
+		configListener.SetTransport(transport)
+		changed, err := configListener.WaitChanged(ctx, items, 30*time.Second)
+		interrupted := ctx.Err() != nil
+		close(done)
+		cancel()
+
+		select {
+		case <-t.stop:
+			return
+		default:
+		}
+		if interrupted {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(t.rl.Stdout(), "\033[33mWarning:\033[0m watching pins: %v\n", err)
+			t.sleepOrStop(5 * time.Second)
+			continue
+		}
+		for _, item := range changed {
+			t.refreshPin(pinKey(item.Tenant, item.Group, item.DataID))
+		}
+		if len(changed) == 0 {
+			// Servers that answer without holding the request
+			t.sleepOrStop(time.Second)
+		}
+	}
+}
+
+// refreshPin fetches a pinned configuration and reports a change
+func (t *Terminal) refreshPin(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.pins[key]
+	if !ok {
+		return
+	}
+	content, err := t.client.WithNamespace(p.item.Tenant).GetConfig(p.item.DataID, p.item.Group)
+	md5 := ""
+	switch {
+	case errors.Is(err, client.ErrConfigNotFound):
+	case err != nil:
+		fmt.Fprintf(t.rl.Stdout(), "\033[33mWarning:\033[0m get %s: %v\n", key, err)
+		return
+	default:
+		md5 = listener.CalculateMD5(content)
+	}
+	if md5 == p.item.MD5 {
+		return
+	}
+	p.item.MD5 = md5
+	p.deleted = md5 == ""
+	p.changedAt = time.Now()
+	p.changes++
+
+	what := "changed"
+	if p.deleted {
+		what = "deleted"
+	}
+	fmt.Fprintf(t.rl.Stdout(), "\033[1;33m[%s] %s %s\033[0m\n", p.changedAt.Format("15:04:05"), key, what)
+}
+
+func (t *Terminal) sleepOrStop(d time.Duration) {
+	select {
+	case <-t.stop:
+	case <-time.After(d):
+	}
+}
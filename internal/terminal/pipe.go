@@ -0,0 +1,238 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pipeline is a command line split into the command, the filters its output
+// goes through and the file it is written to
+type pipeline struct {
+	command string
+	filters [][]string
+	file    string
+	append  bool
+}
+
+// parsePipeline splits `command | filter ... > file` at the | and > words
+func parsePipeline(input string) (pipeline, error) {
+	var p pipeline
+	var stages [][]string
+	stage := []string{}
+	words := strings.Fields(input)
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		switch {
+		case word == "|":
+			stages = append(stages, stage)
+			stage = []string{}
+		case strings.HasPrefix(word, ">"):
+			p.append = strings.HasPrefix(word, ">>")
+			p.file = strings.TrimLeft(word, ">")
+			if p.file == "" && i+1 < len(words) {
+				i++
+				p.file = words[i]
+			}
+			if p.file == "" || i != len(words)-1 {
+				return p, fmt.Errorf("expected one file after > at the end of the line")
+			}
+		default:
+			stage = append(stage, word)
+		}
+	}
+	stages = append(stages, stage)
+	for _, s := range stages {
+		if len(s) == 0 {
+			return p, fmt.Errorf("missing command around |")
+		}
+	}
+	p.command = strings.Join(stages[0], " ")
+	p.filters = stages[1:]
+	return p, nil
+}
+
+// run runs a command line, sending the output of the command through its
+// filters and into its file
+func (t *Terminal) run(line string) {
+	p, err := parsePipeline(line)
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	if len(p.filters) == 0 && p.file == "" {
+		t.handleCommand(line)
+		return
+	}
+	filters := make([]filter, len(p.filters))
+	for i, words := range p.filters {
+		if filters[i], err = parseFilter(words); err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+	}
+
+	t.piped = true
+	output, err := captureOutput(func() { t.handleCommand(p.command) })
+	t.piped = false
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	lines := outputLines(output)
+	for _, f := range filters {
+		lines = f(lines)
+	}
+
+	if p.file == "" {
+		for _, l := range lines {
+			fmt.Println(l)
+		}
+		return
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if p.append {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(p.file, flags, 0644)
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	defer file.Close()
+	for _, l := range lines {
+		if _, err := fmt.Fprintln(file, l); err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+	}
+	fmt.Printf("\033[90mWrote %d line(s) to %s\033[0m\n", len(lines), p.file)
+}
+
+// captureOutput returns what fn prints to stdout
+func captureOutput(fn func()) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", fmt.Errorf("failed to capture output: %w", err)
+	}
+	defer r.Close()
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	w.Close()
+	return <-done, nil
+}
+
+// outputLines turns terminal output into plain lines: without colors, without
+// progress messages overwritten on screen and without surrounding blank lines
+func outputLines(output string) []string {
+	lines := strings.Split(output, "\n")
+	for i, l := range lines {
+		// A carriage return and a clear line erase what was printed before
+		if k := strings.LastIndex(l, "\033[K"); k >= 0 && strings.Contains(l[:k], "\r") {
+			l = l[k+len("\033[K"):]
+		}
+		l = strings.TrimRight(stripANSI(l), "\r")
+		if j := strings.LastIndex(l, "\r"); j >= 0 {
+			l = l[j+1:]
+		}
+		lines[i] = l
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// filter is a built-in command output can be piped through
+type filter func(lines []string) []string
+
+// parseFilter parses grep [-i] [-v] <pattern>, head [-n <n>] and wc [-l]
+func parseFilter(words []string) (filter, error) {
+	name, args := words[0], words[1:]
+	switch name {
+	case "grep":
+		ignoreCase, invert := false, false
+		var pattern []string
+		for _, arg := range args {
+			switch arg {
+			case "-i":
+				ignoreCase = true
+			case "-v":
+				invert = true
+			case "-iv", "-vi":
+				ignoreCase, invert = true, true
+			default:
+				pattern = append(pattern, arg)
+			}
+		}
+		if len(pattern) != 1 {
+			return nil, fmt.Errorf("usage: grep [-i] [-v] <pattern>")
+		}
+		expr := pattern[0]
+		if ignoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grep pattern %q: %w", pattern[0], err)
+		}
+		return func(lines []string) []string {
+			var matched []string
+			for _, l := range lines {
+				if re.MatchString(l) != invert {
+					matched = append(matched, l)
+				}
+			}
+			return matched
+		}, nil
+	case "head":
+		n := 10
+		var err error
+		switch {
+		case len(args) == 0:
+		case len(args) == 2 && args[0] == "-n":
+			n, err = strconv.Atoi(args[1])
+		case len(args) == 1 && strings.HasPrefix(args[0], "-"):
+			n, err = strconv.Atoi(args[0][1:])
+		default:
+			err = fmt.Errorf("unexpected arguments")
+		}
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("usage: head [-n <lines>]")
+		}
+		return func(lines []string) []string {
+			return lines[:min(n, len(lines))]
+		}, nil
+	case "wc":
+		if len(args) > 1 || (len(args) == 1 && args[0] != "-l") {
+			return nil, fmt.Errorf("usage: wc [-l]")
+		}
+		countLines := len(args) == 1
+		return func(lines []string) []string {
+			if countLines {
+				return []string{strconv.Itoa(len(lines))}
+			}
+			words, chars := 0, 0
+			for _, l := range lines {
+				words += len(strings.Fields(l))
+				chars += len([]rune(l)) + 1
+			}
+			return []string{fmt.Sprintf("%7d %7d %7d", len(lines), words, chars)}
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown filter %q (grep, head and wc are built in)", name)
+}
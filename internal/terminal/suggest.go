@@ -0,0 +1,250 @@
+package terminal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chzyer/readline"
+)
+
+// Kinds of values the terminal remembers and suggests
+const (
+	kindDataID    = "dataId"
+	kindGroup     = "group"
+	kindNamespace = "namespace"
+)
+
+// maxRemembered is how many values of each kind are kept per profile
+const maxRemembered = 200
+
+// usage is how often and when a value was last used
+type usage struct {
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+// frecency ranks a value by how often and how recently it was used
+func (u usage) frecency(now time.Time) float64 {
+	weight := 0.25
+	switch age := now.Sub(u.Last); {
+	case age < time.Hour:
+		weight = 4
+	case age < 24*time.Hour:
+		weight = 2
+	case age < 7*24*time.Hour:
+		weight = 1
+	}
+	return float64(u.Count) * weight
+}
+
+// suggestions remembers the data IDs, groups and namespaces used in the
+// terminal, per profile, and suggests them as they are typed
+type suggestions struct {
+	path string
+	mu   sync.Mutex
+	data map[string]map[string]map[string]usage // profile -> kind -> value
+}
+
+// loadSuggestions reads the remembered values; a missing or unreadable file
+// starts empty
+func loadSuggestions(path string) *suggestions {
+	s := &suggestions{path: path, data: make(map[string]map[string]map[string]usage)}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &s.data)
+	}
+	return s
+}
+
+// remember records a use of a value and saves the file
+func (s *suggestions) remember(scope, kind, value string) {
+	if value == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data[scope] == nil {
+		s.data[scope] = make(map[string]map[string]usage)
+	}
+	values := s.data[scope][kind]
+	if values == nil {
+		values = make(map[string]usage)
+		s.data[scope][kind] = values
+	}
+	now := time.Now()
+	u := values[value]
+	values[value] = usage{Count: u.Count + 1, Last: now}
+	if len(values) > maxRemembered {
+		// Forget the lowest ranked
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return values[names[i]].frecency(now) > values[names[j]].frecency(now)
+		})
+		for _, name := range names[maxRemembered:] {
+			delete(values, name)
+		}
+	}
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err == nil {
+		_ = os.WriteFile(s.path, append(data, '\n'), 0600)
+	}
+}
+
+// suggest returns the rest of the highest ranked value starting with prefix
+func (s *suggestions) suggest(scope, kind, prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	best, bestRank := "", 0.0
+	for value, u := range s.data[scope][kind] {
+		if len(value) <= len(prefix) || !strings.HasPrefix(value, prefix) {
+			continue
+		}
+		if rank := u.frecency(now); best == "" || rank > bestRank || (rank == bestRank && value < best) {
+			best, bestRank = value, rank
+		}
+	}
+	return strings.TrimPrefix(best, prefix)
+}
+
+// argumentKind returns the kind of value the last word of a command line is,
+// "" when the terminal does not suggest one there
+func argumentKind(words []string) string {
+	if len(words) < 2 {
+		return ""
+	}
+	current, prev := words[len(words)-1], words[len(words)-2]
+	if strings.HasPrefix(current, "-") {
+		return ""
+	}
+	switch prev {
+	case "--data-id":
+		return kindDataID
+	case "--group":
+		return kindGroup
+	case "-f", "--file":
+		return ""
+	}
+	args := words[1 : len(words)-1]
+	switch words[0] {
+	case "ns":
+		if len(args) == 0 {
+			return kindNamespace
+		}
+	case "use":
+		if len(args) == 1 && (args[0] == "namespace" || args[0] == "ns") {
+			return kindNamespace
+		}
+		if len(args) == 1 && args[0] == "group" {
+			return kindGroup
+		}
+	case "config-get", "config-set", "config-delete", "config-show", "pin", "unpin":
+		positional := 0
+		for i := 0; i < len(args); i++ {
+			if args[i] == "-f" || args[i] == "--file" {
+				i++
+				continue
+			}
+			if !strings.HasPrefix(args[i], "-") {
+				positional++
+			}
+		}
+		switch positional {
+		case 0:
+			return kindDataID
+		case 1:
+			return kindGroup
+		}
+	}
+	return ""
+}
+
+// suggestion returns the suggested rest of the line being typed
+func (t *Terminal) suggestion(line []rune, pos int) string {
+	if t.suggestions == nil || pos != len(line) || len(line) == 0 {
+		return ""
+	}
+	text := string(line)
+	words := strings.Fields(text)
+	if strings.HasSuffix(text, " ") {
+		words = append(words, "")
+	}
+	kind := argumentKind(words)
+	if kind == "" {
+		return ""
+	}
+	return t.suggestions.suggest(t.profileScope(), kind, words[len(words)-1])
+}
+
+// profileScope keys what is remembered per profile: the profile, else the server
+func (t *Terminal) profileScope() string {
+	if t.Profile != "" {
+		return t.Profile
+	}
+	return t.client.ServerAddr
+}
+
+// remember records a value used by a command that succeeded
+func (t *Terminal) remember(kind, value string) {
+	if t.suggestions != nil {
+		t.suggestions.remember(t.profileScope(), kind, value)
+	}
+}
+
+// rememberConfig records the data ID and group of a configuration
+func (t *Terminal) rememberConfig(dataID, group string) {
+	t.remember(kindDataID, dataID)
+	t.remember(kindGroup, group)
+}
+
+// painterFunc adapts a function to readline.Painter
+type painterFunc func(line []rune, pos int) []rune
+
+func (f painterFunc) Paint(line []rune, pos int) []rune {
+	return f(line, pos)
+}
+
+// paintSuggestion shows the suggestion in gray after the cursor, like fish does
+func (t *Terminal) paintSuggestion(line []rune, pos int) []rune {
+	rest := t.suggestion(line, pos)
+	if rest == "" {
+		return line
+	}
+	// Stay on the line: readline does not know the suggestion is there
+	room := readline.GetScreenWidth() - len([]rune(stripANSI(t.prompt()))) - len(line) - 1
+	if room <= 0 {
+		return line
+	}
+	ghost := []rune(rest)
+	if len(ghost) > room {
+		ghost = ghost[:room]
+	}
+	painted := append([]rune{}, line...)
+	painted = append(painted, []rune("\033[90m"+string(ghost)+"\033[0m\033["+strconv.Itoa(len(ghost))+"D")...)
+	return painted
+}
+
+// acceptSuggestion completes the line with the suggestion on the right arrow,
+// Ctrl+F or Ctrl+E at the end of the line
+func (t *Terminal) acceptSuggestion(line []rune, pos int, key rune) ([]rune, int, bool) {
+	if key != readline.CharForward && key != readline.CharLineEnd {
+		return nil, 0, false
+	}
+	rest := t.suggestion(line, pos)
+	if rest == "" {
+		return nil, 0, false
+	}
+	accepted := append(append([]rune{}, line...), []rune(rest)...)
+	return accepted, len(accepted), true
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/chzyer/readline"
 	"github.com/nov11/nacos-cli/internal/client"
@@ -13,12 +15,66 @@ import (
 	"github.com/nov11/nacos-cli/internal/skill"
 )
 
+// keepAliveInterval is how often the terminal checks the connection in the background
+const keepAliveInterval = time.Minute
+
 // Terminal represents an interactive terminal
 type Terminal struct {
 	client       *client.NacosClient
 	skillService *skill.SkillService
 	rl           *readline.Instance
 	running      bool
+
+	// Prompt is the prompt template with {user}, {server}, {namespace},
+	// {group} and {profile} placeholders; PromptColor overrides the environment color
+	Prompt      string
+	PromptColor string
+	Profile     string
+
+	// SwitchProfile builds the context of another profile for "use profile";
+	// ResolveGroup expands group aliases. Both may be nil.
+	SwitchProfile func(name string) (*Profile, error)
+	ResolveGroup  func(group string) string
+
+	// Guard refuses the operations (publish) of a command line that the profile
+	// does not allow and records them in the audit log, may be nil
+	Guard func(operation, namespace, command string) error
+
+	// KeepDeleted keeps a copy of a configuration about to be deleted, e.g.
+	// in the recycle bin; a configuration that cannot be kept is not
+	// deleted. May be nil.
+	KeepDeleted func(dataID, group string) error
+
+	Group string // active group, changed by "use group"
+
+	// DiffMode is the mode of the diffs previewing changes: line (default),
+	// word or semantic
+	DiffMode string
+
+	// SuggestionsFile is where the data IDs, groups and namespaces used are
+	// remembered, per profile, to suggest them as they are typed; empty disables
+	SuggestionsFile string
+	suggestions     *suggestions
+
+	// BookmarksFile is where bookmark add keeps configurations, per profile;
+	// empty disables bookmarks
+	BookmarksFile string
+
+	selected *selection   // configuration shown by config-show
+	piped    bool         // output is captured for a filter or a file
+	tx       *transaction // changes queued between begin and commit
+
+	undoStack []undoEntry // mutations of the session, the last one first undone
+
+	pins     map[string]*pin // configurations watched in the background
+	pinsWake chan struct{}   // interrupts the pin long poll when pins change
+	stop     chan struct{}   // closed when the terminal exits
+
+	// mu serializes commands and background connection checks on the client
+	mu        sync.Mutex
+	offline   bool
+	lastCheck time.Time
+	lastErr   error
 }
 
 // NewTerminal creates a new interactive terminal
@@ -66,9 +122,35 @@ func completer() *readline.PrefixCompleter {
 			readline.PcItem("--file"),
 			readline.PcItem("-f"),
 		),
+		readline.PcItem("config-delete"),
+		readline.PcItem("config-show"),
+		readline.PcItem("diff"),
+		readline.PcItem("rollback"),
+		readline.PcItem("pin"),
+		readline.PcItem("unpin",
+			readline.PcItem("--all"),
+		),
+		readline.PcItem("pins"),
+		readline.PcItem("begin"),
+		readline.PcItem("commit"),
+		readline.PcItem("abort"),
+		readline.PcItem("undo",
+			readline.PcItem("--list"),
+		),
+		readline.PcItem("bookmark",
+			readline.PcItem("add"),
+			readline.PcItem("rm"),
+		),
+		readline.PcItem("bookmarks"),
+		readline.PcItem("open"),
 		readline.PcItem("clear"),
 		readline.PcItem("server"),
 		readline.PcItem("ns"),
+		readline.PcItem("use",
+			readline.PcItem("namespace"),
+			readline.PcItem("group"),
+			readline.PcItem("profile"),
+		),
 	)
 }
 
@@ -77,13 +159,19 @@ func (t *Terminal) Start() error {
 	// Configure readline
 	historyFile := filepath.Join(os.TempDir(), ".nacos-cli-history")
 
-	rl, err := readline.NewEx(&readline.Config{
-		Prompt:          "\033[32mnacos>\033[0m ",
+	rlConfig := &readline.Config{
+		Prompt:          t.prompt(),
 		HistoryFile:     historyFile,
 		AutoComplete:    completer(),
 		InterruptPrompt: "^C",
 		EOFPrompt:       "exit",
-	})
+	}
+	if t.SuggestionsFile != "" {
+		t.suggestions = loadSuggestions(t.SuggestionsFile)
+		rlConfig.Painter = painterFunc(t.paintSuggestion)
+		rlConfig.Listener = readline.FuncListener(t.acceptSuggestion)
+	}
+	rl, err := readline.NewEx(rlConfig)
 	if err != nil {
 		return err
 	}
@@ -93,6 +181,10 @@ func (t *Terminal) Start() error {
 
 	t.printWelcome()
 
+	t.stop = make(chan struct{})
+	defer close(t.stop)
+	go t.keepAlive(t.stop)
+
 	for t.running {
 		line, err := rl.Readline()
 		if err == readline.ErrInterrupt {
@@ -110,12 +202,106 @@ func (t *Terminal) Start() error {
 			continue
 		}
 
-		t.handleCommand(line)
+		t.mu.Lock()
+		t.run(line)
+		// Commands may switch the namespace shown in the prompt
+		rl.SetPrompt(t.prompt())
+		t.mu.Unlock()
 	}
 
 	return nil
 }
 
+// defaultPrompt is used when no prompt is configured
+const defaultPrompt = "nacos> "
+
+var promptColors = map[string]string{
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"none":    "",
+}
+
+// prompt renders the prompt template, colored red for production and marking
+// a lost connection
+func (t *Terminal) prompt() string {
+	text := t.Prompt
+	if text == "" {
+		text = defaultPrompt
+	}
+	text = strings.NewReplacer(
+		"{user}", t.client.Username,
+		"{server}", t.client.ServerAddr,
+		"{namespace}", t.client.Namespace,
+		"{group}", t.Group,
+		"{profile}", t.Profile,
+	).Replace(text)
+
+	color, ok := promptColors[strings.ToLower(t.PromptColor)]
+	if !ok {
+		color = "32"
+		if IsProduction(t.Profile) || IsProduction(t.client.Namespace) {
+			color = "31"
+		}
+	}
+	if color != "" {
+		// Keep the trailing space uncolored
+		trimmed := strings.TrimRight(text, " ")
+		text = "\033[" + color + "m" + trimmed + "\033[0m" + text[len(trimmed):]
+	}
+	if t.tx != nil {
+		text = fmt.Sprintf("\033[1;33m(tx %d)\033[0m ", len(t.tx.changes)) + text
+	}
+	if t.offline {
+		text = "\033[1;31m(offline)\033[0m " + text
+	}
+	return text
+}
+
+// IsProduction reports whether a profile or namespace name looks like production
+func IsProduction(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "prod") || strings.Contains(name, "prd")
+}
+
+// keepAlive checks the connection periodically, which also logs in again when
+// the token has expired or was rejected, and reports connection changes
+func (t *Terminal) keepAlive(stop <-chan struct{}) {
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		t.mu.Lock()
+		err := t.client.Ping()
+		addr := t.client.ServerAddr
+		wasOffline := t.offline
+		t.offline = err != nil
+		t.lastCheck = time.Now()
+		t.lastErr = err
+		t.mu.Unlock()
+
+		if t.offline != wasOffline {
+			if err != nil {
+				fmt.Fprintf(t.rl.Stdout(), "\033[31mConnection to %s lost:\033[0m %v\n", addr, err)
+			} else {
+				fmt.Fprintf(t.rl.Stdout(), "\033[32mConnection to %s restored\033[0m\n", addr)
+			}
+			t.mu.Lock()
+			t.rl.SetPrompt(t.prompt())
+			t.mu.Unlock()
+			t.rl.Refresh()
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // printWelcome prints welcome message
 func (t *Terminal) printWelcome() {
 	fmt.Println("\033[36m╔════════════════════════════════════════════════════════╗\033[0m")
@@ -128,6 +314,9 @@ func (t *Terminal) printWelcome() {
 	fmt.Println()
 	fmt.Println("\033[90mType '\033[0mhelp\033[90m' for available commands\033[0m")
 	fmt.Println("\033[90mPress '\033[0mTab\033[90m' for auto-completion\033[0m")
+	if t.suggestions != nil {
+		fmt.Println("\033[90mPress '\033[0m→\033[90m' to accept a suggested data ID, group or namespace\033[0m")
+	}
 	fmt.Println("\033[90mPress '\033[0mCtrl+C\033[90m' or type '\033[0mquit\033[90m' to quit\033[0m")
 	fmt.Println()
 }
@@ -146,7 +335,9 @@ func (t *Terminal) handleCommand(input string) {
 	case "help":
 		t.showHelp()
 	case "quit":
-		t.exit()
+		if !t.inTransaction("quit") {
+			t.exit()
+		}
 	case "skill-list":
 		if len(args) > 0 && (args[0] == "--help" || args[0] == "-h") {
 			t.showSkillListHelp()
@@ -190,12 +381,42 @@ func (t *Terminal) handleCommand(input string) {
 		} else {
 			t.setConfig(args)
 		}
+	case "config-delete":
+		t.deleteConfig(args)
+	case "begin":
+		t.begin()
+	case "commit":
+		t.commit()
+	case "abort":
+		t.abort()
+	case "undo":
+		t.undo(args)
+	case "bookmark":
+		t.bookmarkCommand(args)
+	case "bookmarks":
+		t.showBookmarks()
+	case "open":
+		t.openBookmark(args)
+	case "config-show":
+		t.showConfig(args)
+	case "diff":
+		t.diffRevision(args)
+	case "rollback":
+		t.rollbackRevision(args)
+	case "pin":
+		t.pinConfig(args)
+	case "unpin":
+		t.unpinConfig(args)
+	case "pins":
+		t.showPins()
 	case "clear":
 		t.clear()
 	case "server":
 		t.showServerInfo()
 	case "ns":
 		t.namespace(args)
+	case "use":
+		t.use(args)
 	default:
 		fmt.Printf("\033[31mUnknown command:\033[0m %s\n", cmd)
 		fmt.Println("\033[90mType '\033[0mhelp\033[90m' for available commands\033[0m")
@@ -226,6 +447,20 @@ func (t *Terminal) showHelp() {
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "", "Options: --data-id, --group, --page, --size", "")
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "config-get", "Get configuration content", "config-get <data-id> <group>")
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "config-set", "Publish config (-f file or type content)", "config-set <data-id> <group> [-f <file>]")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "config-delete", "Delete a configuration (asks first)", "config-delete <data-id> <group>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "config-show", "Content next to metadata and revisions", "config-show <data-id> <group>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "diff", "Diff a shown revision with the current", "diff <n>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "rollback", "Restore a shown revision", "rollback <n>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "pin", "Report changes as they happen", "pin <data-id> <group>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "unpin", "Stop watching a pinned config", "unpin <data-id> <group> | --all")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "pins", "Pinned configs and their last change", "pins")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "bookmark", "Keep a config at hand (per profile)", "bookmark add <group>/<data-id> | rm <n>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "bookmarks", "List the bookmarked configs", "bookmarks")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "open", "config-show a bookmarked config", "open <n>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "begin", "Queue config-set, config-delete, rollback", "begin")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "commit", "Show the queued changes, apply them", "commit")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "abort", "Discard the queued changes", "abort")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "undo", "Revert the last change of this session", "undo [--list]")
 	fmt.Println()
 
 	// System
@@ -233,6 +468,9 @@ func (t *Terminal) showHelp() {
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "server", "Show server information", "server")
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "ns", "Show current namespace", "ns")
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "ns <namespace>", "Switch to different namespace", "ns <namespace>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "use", "Show or change the active context", "use namespace|group|profile <name>")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "| grep/head/wc", "Filter the output of a command", "config-list | grep -i redis")
+	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "> file", "Write the output to a file (>> appends)", "config-get app.yaml > app.yaml")
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "clear", "Clear screen", "clear")
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "help", "Show this help message", "help")
 	fmt.Printf("\033[32m%-20s\033[0m %-40s %-30s\n", "quit", "Exit terminal", "quit")
@@ -260,6 +498,30 @@ func (t *Terminal) showServerInfo() {
 	fmt.Printf("  Server:    %s\n", t.client.ServerAddr)
 	fmt.Printf("  Username:  %s\n", t.client.Username)
 	fmt.Printf("  Namespace: %s\n", t.client.Namespace)
+	switch {
+	case t.lastCheck.IsZero():
+		fmt.Println("  Status:    not checked yet")
+	case t.lastErr != nil:
+		fmt.Printf("  Status:    \033[31moffline\033[0m (checked %s): %v\n", t.lastCheck.Format("15:04:05"), t.lastErr)
+	default:
+		fmt.Printf("  Status:    \033[32mconnected\033[0m (checked %s)\n", t.lastCheck.Format("15:04:05"))
+	}
+	if stats := t.client.NodeStats(); len(stats) > 0 {
+		fmt.Println("  Nodes (this session):")
+		preferred := t.client.PreferredNode()
+		for _, s := range stats {
+			health := "\033[32mhealthy\033[0m"
+			if !s.Healthy {
+				health = "\033[31mfailing\033[0m"
+			}
+			mark := " "
+			if s.Node == preferred && s.Healthy {
+				mark = "*"
+			}
+			fmt.Printf("   %s %-24s %s  %d requests, %.0f%% errors, %s\n", mark, s.Node, health,
+				s.Requests, s.ErrorRate()*100, s.Latency.Round(100*time.Microsecond))
+		}
+	}
 	fmt.Println("─────────────────────────────────────────────────────────")
 }
 
@@ -271,11 +533,7 @@ func (t *Terminal) namespace(args []string) {
 		return
 	}
 
-	// Switch namespace
-	oldNs := t.client.Namespace
-	t.client.Namespace = args[0]
-
-	fmt.Printf("Switched namespace from '%s' to '%s'\n", oldNs, t.client.Namespace)
+	t.useNamespace(args[0])
 }
 
 // listSkills lists all skills
@@ -381,6 +639,10 @@ func (t *Terminal) uploadSkill(args []string) {
 		fmt.Println("Usage: skill-upload <skillPath> or skill-upload --all <folder>")
 		return
 	}
+	if err := t.guard("publish", append([]string{"skill-upload"}, args...)...); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
 
 	// Check for --all flag
 	if args[0] == "--all" {
@@ -520,6 +782,11 @@ func (t *Terminal) listConfigs(args []string) {
 		}
 	}
 
+	if group == "" {
+		group = t.Group
+	}
+	group = t.resolveGroup(group)
+
 	fmt.Print("\033[90mFetching configurations...\033[0m\r")
 
 	configs, err := t.client.ListConfigs(dataID, group, "", page, size)
@@ -585,11 +852,19 @@ func (t *Terminal) setConfig(args []string) {
 		}
 	}
 
+	if group == "" {
+		group = t.Group
+	}
+	group = t.resolveGroup(group)
 	if dataID == "" || group == "" {
 		fmt.Println("\033[31mUsage:\033[0m config-set <data-id> <group> [-f <file>]")
 		fmt.Println("\033[90mWithout -f: enter content in next lines, empty line to finish.\033[0m")
 		return
 	}
+	if err := t.guard("publish", "config-set", dataID, group); err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
 
 	var content string
 	if filePath != "" {
@@ -630,24 +905,56 @@ func (t *Terminal) setConfig(args []string) {
 		fmt.Println("\033[31mError:\033[0m config content is empty (use -f <file> or type content)")
 		return
 	}
+	if t.tx != nil {
+		t.tx.queue(change{operation: "publish", dataID: dataID, group: group, content: content})
+		t.rememberConfig(dataID, group)
+		return
+	}
 
 	fmt.Printf("\033[90mPublishing config: \033[33m%s\033[90m (\033[33m%s\033[90m)...\033[0m\n", dataID, group)
+	before, err := t.captureState(dataID, group)
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
 	if err := t.client.PublishConfig(dataID, group, content); err != nil {
 		fmt.Printf("\033[31mError:\033[0m %v\n", err)
 		return
 	}
 	fmt.Println("\033[32mConfiguration published successfully\033[0m")
+	t.pushUndo(fmt.Sprintf("config-set %s (%s)", dataID, group),
+		priorState{dataID: dataID, group: group, before: before, after: content})
+	t.rememberConfig(dataID, group)
 }
 
 // getConfig gets configuration content
 func (t *Terminal) getConfig(args []string) {
-	if len(args) < 2 {
+	if len(args) < 1 || (len(args) < 2 && t.Group == "") {
 		fmt.Println("\033[31mUsage:\033[0m config-get <data-id> <group>")
 		return
 	}
 
 	dataID := args[0]
-	group := args[1]
+	group := t.Group
+	if len(args) > 1 {
+		group = args[1]
+	}
+	group = t.resolveGroup(group)
+
+	if t.piped {
+		// Only the content, like cat, so it can be written to a file as is
+		content, err := t.client.GetConfig(dataID, group)
+		if err == nil && content == "" {
+			err = fmt.Errorf("configuration not found")
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\033[31mError:\033[0m %v\n", err)
+			return
+		}
+		t.rememberConfig(dataID, group)
+		fmt.Println(content)
+		return
+	}
 
 	fmt.Printf("\033[90mFetching config: \033[33m%s\033[90m (\033[33m%s\033[90m)...\033[0m\n\n", dataID, group)
 
@@ -661,6 +968,7 @@ func (t *Terminal) getConfig(args []string) {
 		fmt.Println("\033[33mConfiguration not found\033[0m")
 		return
 	}
+	t.rememberConfig(dataID, group)
 
 	fmt.Println("\033[36m═══════════════════════════════════════\033[0m")
 	fmt.Printf("\033[33mData ID:\033[0m %s\n", dataID)
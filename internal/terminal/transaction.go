@@ -0,0 +1,243 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/listener"
+)
+
+// change is a publish or delete queued in a transaction
+type change struct {
+	operation string // publish or delete
+	dataID    string
+	group     string
+	content   string // published content
+}
+
+// transaction queues the changes made between begin and commit, so they are
+// reviewed together and applied at once instead of one by one
+type transaction struct {
+	namespace string
+	changes   []change
+}
+
+// queue adds a change; a later change of the same configuration replaces the
+// earlier one, as only the end result is applied
+func (tx *transaction) queue(c change) {
+	for i, queued := range tx.changes {
+		if queued.dataID == c.dataID && queued.group == c.group {
+			tx.changes = append(tx.changes[:i], tx.changes[i+1:]...)
+			break
+		}
+	}
+	tx.changes = append(tx.changes, c)
+	verb := "publish of"
+	if c.operation == "delete" {
+		verb = "delete of"
+	}
+	fmt.Printf("\033[90mQueued %s\033[0m %s (%s) \033[90m(%d change(s) in the transaction, commit to apply)\033[0m\n",
+		verb, c.dataID, c.group, len(tx.changes))
+}
+
+// begin starts queueing config-set, config-delete and rollback
+func (t *Terminal) begin() {
+	if t.tx != nil {
+		fmt.Printf("\033[31mError:\033[0m a transaction with %d change(s) is open, commit or abort it first\n", len(t.tx.changes))
+		return
+	}
+	t.tx = &transaction{namespace: t.client.Namespace}
+	fmt.Println("Started a transaction: config-set, config-delete and rollback are queued until commit (abort discards them)")
+}
+
+// abort discards the queued changes
+func (t *Terminal) abort() {
+	if t.tx == nil {
+		fmt.Println("\033[33mNo transaction open\033[0m")
+		return
+	}
+	fmt.Printf("Discarded %d queued change(s)\n", len(t.tx.changes))
+	t.tx = nil
+}
+
+// planned is a queued change next to the current state of its configuration
+type planned struct {
+	change
+	current *client.ConfigDetail // nil when the configuration does not exist
+}
+
+// commit shows the queued changes as one plan and applies them after
+// confirmation, stopping at the first that fails
+func (t *Terminal) commit() {
+	if t.tx == nil {
+		fmt.Println("\033[31mError:\033[0m no transaction open, use begin first")
+		return
+	}
+	if len(t.tx.changes) == 0 {
+		fmt.Println("Nothing queued, closed the transaction")
+		t.tx = nil
+		return
+	}
+
+	var plan []planned
+	for _, c := range t.tx.changes {
+		current, err := t.client.GetConfigDetail(c.dataID, c.group)
+		if errors.Is(err, client.ErrConfigNotFound) {
+			current, err = nil, nil
+		}
+		if err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+		if current == nil && c.operation == "delete" {
+			fmt.Printf("\033[90mSkipping delete of %s (%s): it does not exist\033[0m\n", c.dataID, c.group)
+			continue
+		}
+		if current != nil && c.operation == "publish" && current.Content == c.content {
+			fmt.Printf("\033[90mSkipping publish of %s (%s): the content is unchanged\033[0m\n", c.dataID, c.group)
+			continue
+		}
+		plan = append(plan, planned{change: c, current: current})
+	}
+	if len(plan) == 0 {
+		fmt.Println("\033[32mNothing to change, closed the transaction\033[0m")
+		t.tx = nil
+		return
+	}
+
+	fmt.Printf("\033[1;36mTransaction Plan\033[0m \033[90m(namespace %s, %d change(s))\033[0m\n", t.tx.namespace, len(plan))
+	fmt.Println("\033[36m═══════════════════════════════════════════════════════════════\033[0m")
+	for i, p := range plan {
+		action := "update"
+		before, after := "", p.content
+		if p.current != nil {
+			before = p.current.Content
+		}
+		switch {
+		case p.operation == "delete":
+			action, after = "delete", ""
+		case p.current == nil:
+			action = "create"
+		}
+		fmt.Printf("\033[33m%d. %s\033[0m %s (%s)\n", i+1, action, p.dataID, p.group)
+		out, err := diff.Diff(t.diffMode(),
+			diff.Document{Name: fmt.Sprintf("%s/%s (current)", p.group, p.dataID), Content: before, Format: p.dataID},
+			diff.Document{Name: fmt.Sprintf("%s/%s (%s)", p.group, p.dataID, action), Content: after, Format: p.dataID},
+			diff.Options{Color: true})
+		if err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+		fmt.Print(out)
+	}
+	fmt.Println()
+
+	if !t.confirm(fmt.Sprintf("Apply %d change(s)? [y/N]: ", len(plan))) {
+		fmt.Println("\033[33mNot applied, the transaction is still open (commit again or abort)\033[0m")
+		return
+	}
+
+	var applied []priorState
+	defer func() {
+		t.pushUndo(fmt.Sprintf("commit of %d change(s)", len(applied)), applied...)
+	}()
+	for i, p := range plan {
+		var err error
+		if p.operation == "delete" {
+			err = t.deleteKept(p.dataID, p.group)
+		} else {
+			// Apply what the plan showed: fail if the configuration changed since
+			opts := client.PublishOptions{}
+			if p.current != nil {
+				opts = client.PublishOptions{CasMd5: listener.CalculateMD5(p.current.Content), Type: p.current.Type, Tags: p.current.Tags()}
+			}
+			err = t.client.PublishConfigWithOptions(p.dataID, p.group, p.content, opts)
+		}
+		if err != nil {
+			if errors.Is(err, client.ErrCasMismatch) {
+				err = fmt.Errorf("%s (%s) changed since the plan was shown", p.dataID, p.group)
+			}
+			fmt.Printf("\033[31mError:\033[0m %d. %s %s (%s): %v\n", i+1, p.operation, p.dataID, p.group, err)
+			t.tx.changes = nil
+			for _, rest := range plan[i:] {
+				t.tx.changes = append(t.tx.changes, rest.change)
+			}
+			fmt.Printf("\033[33mApplied %d of %d change(s), the other %d are still queued (commit again or abort)\033[0m\n",
+				i, len(plan), len(plan)-i)
+			return
+		}
+		done := "published"
+		if p.operation == "delete" {
+			done = "deleted"
+		}
+		fmt.Printf("\033[32m%d. %s %s (%s)\033[0m\n", i+1, done, p.dataID, p.group)
+		applied = append(applied, priorState{dataID: p.dataID, group: p.group, before: p.current, after: p.content, deleted: p.operation == "delete"})
+	}
+	fmt.Printf("\033[32mCommitted %d change(s)\033[0m\n", len(plan))
+	t.tx = nil
+	t.selected = nil
+}
+
+// inTransaction refuses commands that would leave the transaction behind
+func (t *Terminal) inTransaction(command string) bool {
+	if t.tx == nil {
+		return false
+	}
+	fmt.Printf("\033[31mError:\033[0m %s is not allowed while a transaction with %d change(s) is open, commit or abort it first\n",
+		command, len(t.tx.changes))
+	return true
+}
+
+// deleteConfig deletes a configuration after confirmation, or queues the
+// delete in a transaction
+func (t *Terminal) deleteConfig(args []string) {
+	if len(args) < 1 || (len(args) < 2 && t.Group == "") {
+		fmt.Println("\033[31mUsage:\033[0m config-delete <data-id> <group>")
+		return
+	}
+	dataID := args[0]
+	group := t.Group
+	if len(args) > 1 {
+		group = args[1]
+	}
+	group = t.resolveGroup(group)
+	if err := t.guard("delete", "config-delete", dataID, group); err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	if t.tx != nil {
+		t.tx.queue(change{operation: "delete", dataID: dataID, group: group})
+		return
+	}
+	if !t.confirm(fmt.Sprintf("Delete %s (%s)? [y/N]: ", dataID, group)) {
+		fmt.Println("\033[33mCancelled\033[0m")
+		return
+	}
+	before, err := t.captureState(dataID, group)
+	if err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	if before == nil {
+		fmt.Println("\033[33mConfiguration not found\033[0m")
+		return
+	}
+	if err := t.deleteKept(dataID, group); err != nil {
+		fmt.Printf("\033[31mError:\033[0m %v\n", err)
+		return
+	}
+	fmt.Printf("\033[32mDeleted %s (%s)\033[0m\n", dataID, group)
+	t.pushUndo(fmt.Sprintf("config-delete %s (%s)", dataID, group),
+		priorState{dataID: dataID, group: group, before: before, deleted: true})
+}
+
+// confirm asks a yes/no question, no unless answered y
+func (t *Terminal) confirm(question string) bool {
+	t.rl.SetPrompt(question)
+	answer, err := t.rl.Readline()
+	t.rl.SetPrompt(t.prompt())
+	return err == nil && strings.EqualFold(strings.TrimSpace(answer), "y")
+}
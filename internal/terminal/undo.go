@@ -0,0 +1,203 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nov11/nacos-cli/internal/client"
+	"github.com/nov11/nacos-cli/internal/diff"
+	"github.com/nov11/nacos-cli/internal/listener"
+)
+
+// maxUndo is how many mutations of the session can be undone
+const maxUndo = 50
+
+// priorState is a configuration before and after a mutation of the session
+type priorState struct {
+	dataID  string
+	group   string
+	before  *client.ConfigDetail // nil when it did not exist
+	after   string               // content after the mutation
+	deleted bool                 // the mutation deleted it
+}
+
+// undoEntry is a mutation of the session with what it changed
+type undoEntry struct {
+	description string
+	server      string
+	namespace   string
+	states      []priorState
+}
+
+// captureState reads a configuration before a mutation, nil when it does not exist
+func (t *Terminal) captureState(dataID, group string) (*client.ConfigDetail, error) {
+	detail, err := t.client.GetConfigDetail(dataID, group)
+	if errors.Is(err, client.ErrConfigNotFound) {
+		return nil, nil
+	}
+	return detail, err
+}
+
+// pushUndo records a mutation so undo can revert it
+func (t *Terminal) pushUndo(description string, states ...priorState) {
+	if len(states) == 0 {
+		return
+	}
+	t.undoStack = append(t.undoStack, undoEntry{
+		description: description,
+		server:      t.client.ServerAddr,
+		namespace:   t.client.Namespace,
+		states:      states,
+	})
+	if len(t.undoStack) > maxUndo {
+		t.undoStack = t.undoStack[len(t.undoStack)-maxUndo:]
+	}
+}
+
+// undo reverts the last mutation of the session after confirmation, refusing
+// configurations changed since by anyone else
+func (t *Terminal) undo(args []string) {
+	if len(args) == 1 && (args[0] == "--list" || args[0] == "-l") {
+		t.listUndo()
+		return
+	}
+	if len(args) != 0 {
+		fmt.Println("\033[31mUsage:\033[0m undo [--list]")
+		return
+	}
+	if t.inTransaction("undo") {
+		return
+	}
+	if len(t.undoStack) == 0 {
+		fmt.Println("\033[33mNothing to undo in this session\033[0m")
+		return
+	}
+	entry := t.undoStack[len(t.undoStack)-1]
+	if entry.server != t.client.ServerAddr || entry.namespace != t.client.Namespace {
+		fmt.Printf("\033[31mError:\033[0m %s was made on %s in namespace %s, switch back to undo it\n",
+			entry.description, entry.server, entry.namespace)
+		return
+	}
+	for _, operation := range undoOperations(entry.states) {
+		if err := t.guard(operation, "undo", entry.description); err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+	}
+
+	fmt.Printf("\033[1;36mUndo\033[0m %s\n", entry.description)
+	for _, s := range entry.states {
+		before, after := "", s.after
+		if s.before != nil {
+			before = s.before.Content
+		}
+		if s.deleted {
+			after = ""
+		}
+		out, err := diff.Diff(t.diffMode(),
+			diff.Document{Name: fmt.Sprintf("%s/%s (now)", s.group, s.dataID), Content: after, Format: s.dataID},
+			diff.Document{Name: fmt.Sprintf("%s/%s (restored)", s.group, s.dataID), Content: before, Format: s.dataID},
+			diff.Options{Color: true})
+		if err != nil {
+			fmt.Printf("\033[31mError:\033[0m %v\n", err)
+			return
+		}
+		fmt.Print(out)
+	}
+	if !t.confirm(fmt.Sprintf("Revert %d configuration(s)? [y/N]: ", len(entry.states))) {
+		fmt.Println("\033[33mCancelled\033[0m")
+		return
+	}
+
+	// Revert the last change first
+	var failed []priorState
+	for i := len(entry.states) - 1; i >= 0; i-- {
+		s := entry.states[i]
+		if err := t.revert(s); err != nil {
+			fmt.Printf("\033[31mError:\033[0m %s (%s): %v\n", s.dataID, s.group, err)
+			failed = append([]priorState{s}, failed...)
+			continue
+		}
+		fmt.Printf("\033[32mReverted %s (%s)\033[0m\n", s.dataID, s.group)
+	}
+	t.undoStack = t.undoStack[:len(t.undoStack)-1]
+	t.selected = nil
+	if len(failed) > 0 {
+		// Keep what could not be reverted, e.g. to retry after checking it
+		entry.states = failed
+		t.undoStack = append(t.undoStack, entry)
+		fmt.Printf("\033[33m%d configuration(s) not reverted, still on the undo stack\033[0m\n", len(failed))
+	}
+}
+
+// revert restores the state of a configuration before a mutation, only if it
+// is still as the mutation left it
+func (t *Terminal) revert(s priorState) error {
+	current, err := t.captureState(s.dataID, s.group)
+	if err != nil {
+		return err
+	}
+	changed := fmt.Errorf("changed since, not reverted")
+	switch {
+	case s.before == nil:
+		// Created by the mutation
+		if current == nil {
+			return nil
+		}
+		err := t.client.DeleteConfigIfUnchanged(s.dataID, s.group, listener.CalculateMD5(s.after))
+		if errors.Is(err, client.ErrCasMismatch) {
+			return changed
+		}
+		return err
+	case s.deleted:
+		if current != nil {
+			return changed
+		}
+		opts := client.PublishOptions{Type: s.before.Type, Tags: s.before.Tags()}
+		return t.client.PublishConfigWithOptions(s.dataID, s.group, s.before.Content, opts)
+	default:
+		if current == nil {
+			return changed
+		}
+		opts := client.PublishOptions{CasMd5: listener.CalculateMD5(s.after), Type: s.before.Type, Tags: s.before.Tags()}
+		err := t.client.PublishConfigWithOptions(s.dataID, s.group, s.before.Content, opts)
+		if errors.Is(err, client.ErrCasMismatch) {
+			return changed
+		}
+		return err
+	}
+}
+
+// undoOperations returns what reverting the states does: configurations
+// created by the mutation are deleted, the others published
+func undoOperations(states []priorState) []string {
+	var publish, del bool
+	for _, s := range states {
+		if s.before == nil {
+			del = true
+		} else {
+			publish = true
+		}
+	}
+	var operations []string
+	if publish {
+		operations = append(operations, "publish")
+	}
+	if del {
+		operations = append(operations, "delete")
+	}
+	return operations
+}
+
+// listUndo prints the mutations undo can revert, the next one first
+func (t *Terminal) listUndo() {
+	if len(t.undoStack) == 0 {
+		fmt.Println("\033[33mNothing to undo in this session\033[0m")
+		return
+	}
+	fmt.Println("\033[1;36mUndo Stack\033[0m \033[90m(undo reverts the first)\033[0m")
+	for i := len(t.undoStack) - 1; i >= 0; i-- {
+		e := t.undoStack[i]
+		fmt.Printf("  %-3d %s \033[90m(%s, namespace %s)\033[0m\n", len(t.undoStack)-i, e.description, e.server, e.namespace)
+	}
+}
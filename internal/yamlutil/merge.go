@@ -0,0 +1,93 @@
+package yamlutil
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Parse decodes a YAML (or JSON) document into a node tree
+func Parse(content string) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		// Empty document
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	return &doc, nil
+}
+
+// Encode renders a node tree as YAML with two-space indentation
+func Encode(node *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MergeDocuments deep-merges YAML documents: mappings are merged key by key,
+// any other value (scalars, sequences) in a later document replaces the earlier one.
+// Key order follows the first document that defines each key.
+func MergeDocuments(contents []string) (string, error) {
+	var result *yaml.Node
+	for i, content := range contents {
+		doc, err := Parse(content)
+		if err != nil {
+			return "", fmt.Errorf("document %d: %w", i+1, err)
+		}
+		blockStyle(doc)
+		if result == nil {
+			result = doc
+			continue
+		}
+		result.Content[0] = Merge(result.Content[0], doc.Content[0])
+	}
+	if result == nil {
+		return "", nil
+	}
+	return Encode(result)
+}
+
+// Merge merges src into dst and returns the result
+func Merge(dst, src *yaml.Node) *yaml.Node {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return src
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		if j := findKey(dst, key.Value); j >= 0 {
+			dst.Content[j+1] = Merge(dst.Content[j+1], value)
+		} else {
+			dst.Content = append(dst.Content, key, value)
+		}
+	}
+	return dst
+}
+
+// findKey returns the index of key in a mapping node's content, or -1
+func findKey(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// blockStyle switches flow-style and double-quoted nodes (e.g. from JSON input)
+// to block style; the encoder still quotes strings that need it
+func blockStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,112 @@
+package yamlutil
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// patchDirective marks a list item of a strategic merge patch, as in
+// {name: legacy, $patch: delete}
+const patchDirective = "$patch"
+
+// MergePatch applies a JSON Merge Patch (RFC 7386) to a node tree and returns
+// the result: mappings are merged key by key, a null removes the key and
+// anything else replaces the value
+func MergePatch(dst, patch *yaml.Node) *yaml.Node {
+	blockStyle(patch)
+	return applyPatch(dst, patch, "")
+}
+
+// StrategicMergePatch applies a merge patch that also merges lists of mappings,
+// matching their items by mergeKey (e.g. name); a patch item with
+// "$patch: delete" removes the matching item. Other lists are replaced.
+func StrategicMergePatch(dst, patch *yaml.Node, mergeKey string) (*yaml.Node, error) {
+	if mergeKey == "" {
+		return nil, fmt.Errorf("strategic merge needs a merge key")
+	}
+	blockStyle(patch)
+	return applyPatch(dst, patch, mergeKey), nil
+}
+
+func applyPatch(dst, patch *yaml.Node, mergeKey string) *yaml.Node {
+	if dst != nil && dst.Kind == yaml.DocumentNode {
+		dst.Content[0] = applyPatch(dst.Content[0], patch, mergeKey)
+		return dst
+	}
+	if patch.Kind == yaml.DocumentNode {
+		patch = patch.Content[0]
+	}
+	switch {
+	case patch.Kind == yaml.MappingNode:
+		if dst == nil || dst.Kind != yaml.MappingNode {
+			// Patching a non-mapping starts from an empty one
+			dst = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for i := 0; i+1 < len(patch.Content); i += 2 {
+			key, value := patch.Content[i], patch.Content[i+1]
+			if mergeKey != "" && key.Value == patchDirective {
+				continue
+			}
+			j := findKey(dst, key.Value)
+			if isNull(value) {
+				if j >= 0 {
+					dst.Content = append(dst.Content[:j], dst.Content[j+2:]...)
+				}
+				continue
+			}
+			if j >= 0 {
+				dst.Content[j+1] = applyPatch(dst.Content[j+1], value, mergeKey)
+			} else {
+				dst.Content = append(dst.Content, key, applyPatch(nil, value, mergeKey))
+			}
+		}
+		return dst
+	case mergeKey != "" && patch.Kind == yaml.SequenceNode && dst != nil && dst.Kind == yaml.SequenceNode &&
+		keyedItems(dst, mergeKey) && keyedItems(patch, mergeKey):
+		return mergeList(dst, patch, mergeKey)
+	default:
+		return patch
+	}
+}
+
+// mergeList merges the items of two lists matched by mergeKey, keeping the
+// order of dst and appending new items
+func mergeList(dst, patch *yaml.Node, mergeKey string) *yaml.Node {
+	for _, item := range patch.Content {
+		name := item.Content[findKey(item, mergeKey)+1].Value
+		j := -1
+		for k, existing := range dst.Content {
+			if existing.Content[findKey(existing, mergeKey)+1].Value == name {
+				j = k
+				break
+			}
+		}
+		if d := findKey(item, patchDirective); d >= 0 && item.Content[d+1].Value == "delete" {
+			if j >= 0 {
+				dst.Content = append(dst.Content[:j], dst.Content[j+1:]...)
+			}
+			continue
+		}
+		if j >= 0 {
+			dst.Content[j] = applyPatch(dst.Content[j], item, mergeKey)
+		} else {
+			dst.Content = append(dst.Content, applyPatch(nil, item, mergeKey))
+		}
+	}
+	return dst
+}
+
+// keyedItems reports whether every item of a list is a mapping with mergeKey
+func keyedItems(list *yaml.Node, mergeKey string) bool {
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode || findKey(item, mergeKey) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
@@ -0,0 +1,282 @@
+package yamlutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SplitPath splits a path like spring.datasource.url or servers[0].host into
+// keys and sequence indexes; a leading $. as in JSONPath is ignored
+func SplitPath(path string) ([]string, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var parts []string
+	for _, segment := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+		if key == "" && rest == "" {
+			return nil, fmt.Errorf("invalid path %q: empty key", path)
+		}
+		if key != "" {
+			parts = append(parts, key)
+		}
+		if rest == "" {
+			continue
+		}
+		for _, i := range strings.Split(strings.TrimSuffix(rest, "]"), "][") {
+			if n, err := strconv.Atoi(i); err != nil || n < 0 || !strings.HasSuffix(rest, "]") {
+				return nil, fmt.Errorf("invalid path %q: expected [<index>]", path)
+			}
+			parts = append(parts, "["+i+"]")
+		}
+	}
+	return parts, nil
+}
+
+// index returns the sequence index of a path part, -1 for a key
+func index(part string) int {
+	if !strings.HasPrefix(part, "[") {
+		return -1
+	}
+	n, _ := strconv.Atoi(strings.Trim(part, "[]"))
+	return n
+}
+
+// Lookup returns the node at path in a document, nil when there is none
+func Lookup(doc *yaml.Node, path string) (*yaml.Node, error) {
+	parts, err := SplitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	node := doc
+	if node.Kind == yaml.DocumentNode {
+		node = node.Content[0]
+	}
+	for _, part := range parts {
+		switch i := index(part); {
+		case i >= 0 && node.Kind == yaml.SequenceNode:
+			if i >= len(node.Content) {
+				return nil, nil
+			}
+			node = node.Content[i]
+		case i < 0 && node.Kind == yaml.MappingNode:
+			j := findKey(node, part)
+			if j < 0 {
+				return nil, nil
+			}
+			node = node.Content[j+1]
+		default:
+			return nil, nil
+		}
+	}
+	return node, nil
+}
+
+// SetPath replaces the value at path, creating missing mappings on the way;
+// a sequence can grow by one, at the index after its last item
+func SetPath(doc *yaml.Node, path string, value *yaml.Node) error {
+	parts, err := SplitPath(path)
+	if err != nil {
+		return err
+	}
+	node := doc
+	if node.Kind == yaml.DocumentNode {
+		node = node.Content[0]
+	}
+	for n, part := range parts {
+		last := n == len(parts)-1
+		next := value
+		if !last {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if index(parts[n+1]) >= 0 {
+				next = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			}
+		}
+		switch i := index(part); {
+		case i >= 0 && node.Kind == yaml.SequenceNode:
+			switch {
+			case i < len(node.Content):
+				if last {
+					node.Content[i] = value
+				}
+			case i == len(node.Content):
+				node.Content = append(node.Content, next)
+			default:
+				return fmt.Errorf("%s: index %d is out of range (%d items)", path, i, len(node.Content))
+			}
+			node = node.Content[i]
+		case i < 0 && node.Kind == yaml.MappingNode:
+			j := findKey(node, part)
+			if j < 0 {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, next)
+				j = len(node.Content) - 2
+			} else if last {
+				// Keep the comment of the replaced value
+				value.LineComment = node.Content[j+1].LineComment
+				node.Content[j+1] = value
+			}
+			node = node.Content[j+1]
+		default:
+			kind := "mapping"
+			if index(part) >= 0 {
+				kind = "sequence"
+			}
+			return fmt.Errorf("%s: %s is not a %s", path, strings.Join(append([]string{"$"}, parts[:n]...), "."), kind)
+		}
+	}
+	return nil
+}
+
+// ParseValue parses a value given on the command line as YAML, so 50 is a
+// number, true a boolean and "50" a string
+func ParseValue(value string) *yaml.Node {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &doc); err != nil || doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	}
+	return doc.Content[0]
+}
+
+// EncodeJSON renders a node tree as indented JSON, keeping the key order
+func EncodeJSON(node *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, node); err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return "", err
+	}
+	return out.String() + "\n", nil
+}
+
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return writeJSON(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		var v interface{}
+		if err := node.Decode(&v); err != nil {
+			return err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		buf.Write(data)
+	}
+	return nil
+}
+
+// ReplaceScalar rewrites the text of a single-line scalar of content, parsed
+// into node, to value and leaves the rest of the text as it is, comments and
+// formatting included. It reports false when node or value is not a scalar
+// that can be rewritten in place, e.g. a block or multi-line scalar.
+func ReplaceScalar(content string, node, value *yaml.Node, isJSON bool) (string, bool) {
+	if node.Kind != yaml.ScalarNode || value.Kind != yaml.ScalarNode || node.Anchor != "" ||
+		node.Style&(yaml.TaggedStyle|yaml.LiteralStyle|yaml.FoldedStyle) != 0 || node.Line < 1 || node.Column < 1 {
+		return "", false
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if node.Line > len(lines) {
+		return "", false
+	}
+	start := 0
+	for _, line := range lines[:node.Line-1] {
+		start += len(line)
+	}
+	// Columns count characters
+	line := []rune(lines[node.Line-1])
+	if node.Column-1 > len(line) {
+		return "", false
+	}
+	start += len(string(line[:node.Column-1]))
+	end, ok := scalarEnd(content[start:], node)
+	if !ok {
+		return "", false
+	}
+
+	var text string
+	if isJSON {
+		var buf bytes.Buffer
+		if err := writeJSON(&buf, value); err != nil {
+			return "", false
+		}
+		text = buf.String()
+	} else {
+		out, err := yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Tag: value.Tag, Value: value.Value, Style: value.Style})
+		if err != nil {
+			return "", false
+		}
+		text = strings.TrimSuffix(string(out), "\n")
+	}
+	if strings.Contains(text, "\n") {
+		return "", false
+	}
+	return content[:start] + text + content[start+end:], true
+}
+
+// scalarEnd returns the length of the text of a single-line scalar at the
+// start of s
+func scalarEnd(s string, node *yaml.Node) (int, bool) {
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1, true
+			case '\n':
+				return 0, false
+			}
+		}
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for i := 1; i < len(s); i++ {
+			switch {
+			case s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+				i++
+			case s[i] == '\'':
+				return i + 1, true
+			case s[i] == '\n':
+				return 0, false
+			}
+		}
+	case strings.HasPrefix(s, node.Value):
+		return len(node.Value), true
+	}
+	return 0, false
+}